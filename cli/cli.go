@@ -0,0 +1,100 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Command-line interface for running the server and for scripting event administration without it.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"io"
+	"strings"
+)
+
+const (
+	DefaultDbPath   = "./event.db"
+	DefaultHttpPort = 8080
+)
+
+type command struct {
+	name        string
+	usage       string
+	description string
+	run         func(args []string, out io.Writer) error
+}
+
+var commands []command
+
+func init() {
+	// Initialized here rather than in the declaration to allow the usage command to refer to the list.
+	commands = []command{
+		{"serve", "[flags]", "Runs the event server (the default when no command is given).", runServe},
+		{"teams import", "[flags] <csv file>", "Adds the teams listed in a CSV file to the team list.", runTeamsImport},
+		{"schedule generate", "[flags]", "Generates and saves a practice or qualification schedule.",
+			runScheduleGenerate},
+		{"rankings recompute", "[flags]", "Recalculates the qualification rankings from the match results.",
+			runRankingsRecompute},
+		{"backup", "[flags] [file]", "Writes a copy of the database to the given file or the backups directory.",
+			runBackup},
+		{"restore", "[flags] <file>", "Replaces the database with the given backup file.", runRestore},
+		{"export", "[flags] [file]", "Exports the event data as JSON to the given file or standard output.",
+			runExport},
+		{"publish tba", "[flags]", "Publishes the event data to The Blue Alliance.", runPublishTba},
+		{"db check", "[flags]", "Checks the database for corruption and inconsistencies, and optionally repairs them.",
+			runDbCheck},
+		{"standby promote", "[flags]", "Promotes the standby server running on this machine to primary.",
+			runStandbyPromote},
+		{"help", "", "Shows this message.", runHelp},
+	}
+}
+
+// Runs the command given by the command-line arguments (excluding the program name), writing its output to the given
+// writer. Bare flags with no command are passed to the serve command for backwards compatibility.
+func Run(args []string, out io.Writer) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return runServe(args, out)
+	}
+	for _, command := range commands {
+		words := strings.Fields(command.name)
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == command.name {
+			return command.run(args[len(words):], out)
+		}
+	}
+	_ = runHelp(nil, out)
+	return fmt.Errorf("unknown command %q", strings.Join(args, " "))
+}
+
+func runHelp(args []string, out io.Writer) error {
+	fmt.Fprintln(out, "Usage: cheesy-arena <command> [flags] [arguments]")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Commands:")
+	for _, command := range commands {
+		fmt.Fprintf(out, "  %-36s %s\n", strings.TrimSpace(command.name+" "+command.usage), command.description)
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Run 'cheesy-arena <command> -h' for the flags of a command.")
+	return nil
+}
+
+// Returns a flag set for the given command that writes its usage to the given writer.
+func newFlagSet(name string, out io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(out)
+	return flags
+}
+
+// Adds the flag common to all commands for specifying the path of the event database.
+func addDbPathFlag(flags *flag.FlagSet) *string {
+	return flags.String("db", DefaultDbPath, "path of the event database")
+}
+
+// Opens the event database at the given path, with a clearer error for the common case of it being locked by a
+// running server.
+func openDatabase(dbPath string) (*model.Database, error) {
+	database, err := model.OpenDatabase(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open database %s (is the server running?): %v", dbPath, err)
+	}
+	return database, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+// Returns the path of a new event database in a temporary directory.
+func setupTestDbPath(t *testing.T) string {
+	model.BaseDir = ".."
+	dbPath := filepath.Join(t.TempDir(), "event.db")
+	database, err := model.OpenDatabase(dbPath)
+	assert.Nil(t, err)
+	database.Close()
+	return dbPath
+}
+
+func TestRunHelp(t *testing.T) {
+	var out bytes.Buffer
+	assert.Nil(t, Run([]string{"help"}, &out))
+	assert.Contains(t, out.String(), "teams import [flags] <csv file>")
+	assert.Contains(t, out.String(), "publish tba [flags]")
+}
+
+func TestRunUnknownCommand(t *testing.T) {
+	var out bytes.Buffer
+	err := Run([]string{"teams", "delete"}, &out)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "unknown command \"teams delete\"", err.Error())
+	}
+	assert.Contains(t, out.String(), "Usage: cheesy-arena <command>")
+}
+
+func TestRunCommandFlags(t *testing.T) {
+	var out bytes.Buffer
+	assert.Equal(t, flag.ErrHelp, Run([]string{"db", "check", "-h"}, &out))
+	assert.Contains(t, out.String(), "path of the event database")
+
+	out.Reset()
+	err := Run([]string{"db", "check", "-db", filepath.Join(t.TempDir(), "missing", "event.db")}, &out)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "could not open database")
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Commands for backing up, restoring, exporting, publishing and checking the event database.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Event data written by the export command.
+type eventExport struct {
+	EventName    string
+	TbaEventCode string
+	Teams        []model.Team
+	Matches      []matchExport
+	Rankings     game.Rankings
+	Alliances    []model.Alliance
+	Awards       []model.Award
+}
+
+type matchExport struct {
+	model.Match
+	Result *model.MatchResult `json:",omitempty"`
+}
+
+func runBackup(args []string, out io.Writer) error {
+	flags := newFlagSet("backup", out)
+	dbPath := addDbPathFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("expected at most one backup file path")
+	}
+
+	database, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if flags.NArg() == 0 {
+		eventSettings, err := database.GetEventSettings()
+		if err != nil {
+			return err
+		}
+		if err = database.Backup(eventSettings.Name, "manual"); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Backed up %s to the %s directory.\n", *dbPath, filepath.Join(model.BaseDir, "db/backups"))
+		return nil
+	}
+
+	file, err := os.Create(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err = database.WriteBackup(file); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Backed up %s to %s.\n", *dbPath, flags.Arg(0))
+	return nil
+}
+
+func runRestore(args []string, out io.Writer) error {
+	flags := newFlagSet("restore", out)
+	dbPath := addDbPathFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("expected the path of the backup file to restore")
+	}
+
+	// Copy the backup file to a temporary location next to the database and verify that it can be opened as one.
+	backupFile, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer backupFile.Close()
+	tempFile, err := os.CreateTemp(filepath.Dir(*dbPath), "restored-db-")
+	if err != nil {
+		return err
+	}
+	tempFilePath := tempFile.Name()
+	defer os.Remove(tempFilePath)
+	defer os.Remove(model.PacketLogPath(tempFilePath))
+	_, err = io.Copy(tempFile, backupFile)
+	tempFile.Close()
+	if err != nil {
+		return err
+	}
+	tempDb, err := model.OpenDatabase(tempFilePath)
+	if err != nil {
+		return fmt.Errorf("could not read %s; please verify that it is a valid database file", flags.Arg(0))
+	}
+	tempDb.Close()
+
+	// Back up the current database, if there is one.
+	if _, err = os.Stat(*dbPath); err == nil {
+		database, err := openDatabase(*dbPath)
+		if err != nil {
+			return err
+		}
+		eventSettings, err := database.GetEventSettings()
+		if err == nil {
+			err = database.Backup(eventSettings.Name, "pre_restore")
+		}
+		database.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = os.Rename(tempFilePath, *dbPath); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Restored %s from %s.\n", *dbPath, flags.Arg(0))
+	return nil
+}
+
+func runExport(args []string, out io.Writer) error {
+	flags := newFlagSet("export", out)
+	dbPath := addDbPathFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("expected at most one export file path")
+	}
+
+	database, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+	export, err := buildEventExport(database)
+	if err != nil {
+		return err
+	}
+
+	writer := out
+	if flags.NArg() == 1 {
+		file, err := os.Create(flags.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		writer = file
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(export)
+}
+
+// Gathers the event data to export. Settings are left out since they include passwords and other secrets.
+func buildEventExport(database *model.Database) (*eventExport, error) {
+	eventSettings, err := database.GetEventSettings()
+	if err != nil {
+		return nil, err
+	}
+	export := eventExport{EventName: eventSettings.Name, TbaEventCode: eventSettings.TbaEventCode}
+	if export.Teams, err = database.GetAllTeams(); err != nil {
+		return nil, err
+	}
+	for _, matchType := range []model.MatchType{model.Practice, model.Qualification, model.Playoff} {
+		matches, err := database.GetMatchesByType(matchType, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			matchResult, err := database.GetMatchResultForMatch(match.Id)
+			if err != nil {
+				return nil, err
+			}
+			export.Matches = append(export.Matches, matchExport{Match: match, Result: matchResult})
+		}
+	}
+	if export.Rankings, err = database.GetAllRankings(); err != nil {
+		return nil, err
+	}
+	if export.Alliances, err = database.GetAllAlliances(); err != nil {
+		return nil, err
+	}
+	if export.Awards, err = database.GetAllAwards(); err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+func runPublishTba(args []string, out io.Writer) error {
+	flags := newFlagSet("publish tba", out)
+	dbPath := addDbPathFlag(flags)
+	only := flags.String(
+		"only", "", "comma-separated subset of teams,matches,rankings,alliances,awards to publish; defaults to all",
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+	eventSettings, err := database.GetEventSettings()
+	if err != nil {
+		return err
+	}
+	if eventSettings.TbaEventCode == "" || eventSettings.TbaSecretId == "" || eventSettings.TbaSecret == "" {
+		return fmt.Errorf("the TBA event code and API credentials must first be set on the Settings page")
+	}
+	tbaClient := newTbaClient(eventSettings.TbaEventCode, eventSettings.TbaSecretId, eventSettings.TbaSecret)
+
+	publishers := []struct {
+		name    string
+		publish func() error
+	}{
+		{"teams", func() error { return tbaClient.PublishTeams(database) }},
+		{"matches", func() error {
+			if err := tbaClient.DeletePublishedMatches(); err != nil {
+				return err
+			}
+			return tbaClient.PublishMatches(database)
+		}},
+		{"rankings", func() error { return tbaClient.PublishRankings(database) }},
+		{"alliances", func() error { return tbaClient.PublishAlliances(database) }},
+		{"awards", func() error { return tbaClient.PublishAwards(database) }},
+	}
+	selected := make(map[string]bool)
+	if *only != "" {
+		for _, name := range strings.Split(*only, ",") {
+			selected[strings.TrimSpace(name)] = true
+		}
+		for name := range selected {
+			found := false
+			for _, publisher := range publishers {
+				found = found || publisher.name == name
+			}
+			if !found {
+				return fmt.Errorf("unknown data to publish %q", name)
+			}
+		}
+	}
+	for _, publisher := range publishers {
+		if len(selected) > 0 && !selected[publisher.name] {
+			continue
+		}
+		if err = publisher.publish(); err != nil {
+			return fmt.Errorf("failed to publish %s: %v", publisher.name, err)
+		}
+		fmt.Fprintf(out, "Published %s to TBA event %s.\n", publisher.name, eventSettings.TbaEventCode)
+	}
+	return nil
+}
+
+func runDbCheck(args []string, out io.Writer) error {
+	flags := newFlagSet("db check", out)
+	dbPath := addDbPathFlag(flags)
+	repair := flags.Bool(
+		"repair", false, "back up the database and then fix the inconsistencies that can be repaired automatically",
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	// Corruption of the file itself makes the record-level checks meaningless, so report it alone.
+	if errs := database.CheckConsistency(); len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Fprintf(out, "  %v\n", err)
+		}
+		return fmt.Errorf("%s is corrupted with %d error(s); restore it from a backup", *dbPath, len(errs))
+	}
+
+	if *repair {
+		eventSettings, err := database.GetEventSettings()
+		if err != nil {
+			return err
+		}
+		numRepaired, err := database.RepairIntegrity(eventSettings.Name)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Repaired %d problem(s).\n", numRepaired)
+	}
+
+	problems, err := database.CheckIntegrity()
+	if err != nil {
+		return err
+	}
+	numRepairable := 0
+	for _, problem := range problems {
+		repairability := "manual"
+		if problem.Repairable() {
+			repairability = "repairable"
+			numRepairable++
+		}
+		fmt.Fprintf(out, "  %s %d (%s): %s\n", problem.Table, problem.RecordId, repairability, problem.Description)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf(
+			"found %d problem(s) in %s, of which %d can be fixed with -repair",
+			len(problems),
+			*dbPath,
+			numRepairable,
+		)
+	}
+	fmt.Fprintf(out, "No problems found in %s.\n", *dbPath)
+	return nil
+}
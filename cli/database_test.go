@@ -0,0 +1,147 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	dbPath := setupTestDbPath(t)
+	database, _ := model.OpenDatabase(dbPath)
+	database.CreateTeam(&model.Team{Id: 254})
+	database.Close()
+
+	var out bytes.Buffer
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	assert.Nil(t, Run([]string{"backup", "-db", dbPath, backupPath}, &out))
+	assert.Contains(t, out.String(), "Backed up")
+
+	database, _ = model.OpenDatabase(dbPath)
+	database.CreateTeam(&model.Team{Id: 1114})
+	database.Close()
+	assert.Nil(t, Run([]string{"restore", "-db", dbPath, backupPath}, &out))
+	database, _ = model.OpenDatabase(dbPath)
+	teams, _ := database.GetAllTeams()
+	database.Close()
+	assert.Equal(t, []model.Team{{Id: 254}}, teams)
+
+	// Check that a file that isn't a database is rejected and leaves the current one alone.
+	invalidPath := filepath.Join(t.TempDir(), "invalid.db")
+	os.WriteFile(invalidPath, []byte("not a database"), 0644)
+	err := Run([]string{"restore", "-db", dbPath, invalidPath}, &out)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "valid database file")
+	}
+	database, _ = model.OpenDatabase(dbPath)
+	teams, _ = database.GetAllTeams()
+	database.Close()
+	assert.Equal(t, 1, len(teams))
+}
+
+func TestExport(t *testing.T) {
+	dbPath := setupTestDbPath(t)
+	database, _ := model.OpenDatabase(dbPath)
+	database.CreateTeam(&model.Team{Id: 254, Nickname: "The Cheesy Poofs"})
+	match := model.Match{Type: model.Qualification, TypeOrder: 1, ShortName: "Q1"}
+	database.CreateMatch(&match)
+	database.CreateMatch(&model.Match{Type: model.Qualification, TypeOrder: 2, ShortName: "Q2"})
+	matchResult := model.NewMatchResult()
+	matchResult.MatchId = match.Id
+	database.CreateMatchResult(matchResult)
+	database.Close()
+
+	var out bytes.Buffer
+	assert.Nil(t, Run([]string{"export", "-db", dbPath}, &out))
+	var export eventExport
+	assert.Nil(t, json.Unmarshal(out.Bytes(), &export))
+	assert.Equal(t, "Untitled Event", export.EventName)
+	assert.Equal(t, []model.Team{{Id: 254, Nickname: "The Cheesy Poofs"}}, export.Teams)
+	if assert.Equal(t, 2, len(export.Matches)) {
+		assert.Equal(t, "Q1", export.Matches[0].ShortName)
+		assert.NotNil(t, export.Matches[0].Result)
+		assert.Nil(t, export.Matches[1].Result)
+	}
+	assert.NotContains(t, out.String(), "AdminPassword")
+}
+
+func TestPublishTba(t *testing.T) {
+	dbPath := setupTestDbPath(t)
+	var out bytes.Buffer
+	err := Run([]string{"publish", "tba", "-db", dbPath}, &out)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "TBA event code and API credentials")
+	}
+
+	database, _ := model.OpenDatabase(dbPath)
+	eventSettings, _ := database.GetEventSettings()
+	eventSettings.TbaEventCode = "2025cc"
+	eventSettings.TbaSecretId = "secret_id"
+	eventSettings.TbaSecret = "secret"
+	database.UpdateEventSettings(eventSettings)
+	database.CreateTeam(&model.Team{Id: 254})
+	database.Close()
+
+	// Mock the TBA server.
+	var mutex sync.Mutex
+	var paths []string
+	tbaServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		paths = append(paths, strings.TrimPrefix(r.URL.Path, "/api/trusted/v1/event/2025cc/"))
+	}))
+	defer tbaServer.Close()
+	newTbaClient = func(eventCode, secretId, secret string) *partner.TbaClient {
+		client := partner.NewTbaClient(eventCode, secretId, secret)
+		client.BaseUrl = tbaServer.URL
+		return client
+	}
+	defer func() { newTbaClient = partner.NewTbaClient }()
+
+	assert.Nil(t, Run([]string{"publish", "tba", "-db", dbPath, "-only", "teams"}, &out))
+	assert.Equal(t, []string{"team_list/update"}, paths)
+	assert.Contains(t, out.String(), "Published teams to TBA event 2025cc.")
+
+	err = Run([]string{"publish", "tba", "-db", dbPath, "-only", "teams,photos"}, &out)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "unknown data to publish \"photos\"", err.Error())
+	}
+}
+
+func TestDbCheck(t *testing.T) {
+	dbPath := setupTestDbPath(t)
+	var out bytes.Buffer
+	assert.Nil(t, Run([]string{"db", "check", "-db", dbPath}, &out))
+	assert.Contains(t, out.String(), "No problems found")
+
+	database, _ := model.OpenDatabase(dbPath)
+	database.CreateRanking(&game.Ranking{TeamId: 254})
+	database.CreateAward(&model.Award{AwardName: "Winner", TeamId: 254})
+	database.Close()
+	out.Reset()
+	err := Run([]string{"db", "check", "-db", dbPath}, &out)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "found 2 problem(s) in "+dbPath+", of which 1 can be fixed with -repair", err.Error())
+	}
+	assert.Contains(t, out.String(), "Ranking 254 (repairable): Ranking is for team 254")
+
+	out.Reset()
+	err = Run([]string{"db", "check", "-db", dbPath, "-repair"}, &out)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "found 1 problem(s) in "+dbPath+", of which 0 can be fixed with -repair", err.Error())
+	}
+	assert.Contains(t, out.String(), "Repaired 1 problem(s).")
+	assert.Contains(t, out.String(), "Award 1 (manual)")
+}
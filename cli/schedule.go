@@ -0,0 +1,186 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Commands for generating the match schedule and recomputing the rankings.
+
+package cli
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/tournament"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const scheduleBlockTimeFormat = "2006-01-02 15:04"
+
+// Flag value that accumulates schedule blocks given as "<start time>,<number of matches>,<match spacing in seconds>".
+type scheduleBlocksFlag []model.ScheduleBlock
+
+func (blocks *scheduleBlocksFlag) String() string {
+	var values []string
+	for _, block := range *blocks {
+		values = append(
+			values,
+			fmt.Sprintf("%s,%d,%d", block.StartTime.Format(scheduleBlockTimeFormat), block.NumMatches,
+				block.MatchSpacingSec),
+		)
+	}
+	return strings.Join(values, " ")
+}
+
+func (blocks *scheduleBlocksFlag) Set(value string) error {
+	parts := strings.Split(value, ",")
+	if len(parts) != 3 {
+		return fmt.Errorf("expected <start time>,<number of matches>,<match spacing in seconds>")
+	}
+	var block model.ScheduleBlock
+	var err error
+	if block.StartTime, err = time.ParseInLocation(scheduleBlockTimeFormat, parts[0], time.Local); err != nil {
+		return fmt.Errorf("start time must be of the form %q", scheduleBlockTimeFormat)
+	}
+	if block.NumMatches, err = strconv.Atoi(parts[1]); err != nil || block.NumMatches <= 0 {
+		return fmt.Errorf("invalid number of matches %q", parts[1])
+	}
+	if block.MatchSpacingSec, err = strconv.Atoi(parts[2]); err != nil || block.MatchSpacingSec <= 0 {
+		return fmt.Errorf("invalid match spacing %q", parts[2])
+	}
+	*blocks = append(*blocks, block)
+	return nil
+}
+
+func runScheduleGenerate(args []string, out io.Writer) error {
+	flags := newFlagSet("schedule generate", out)
+	dbPath := addDbPathFlag(flags)
+	matchTypeString := flags.String("type", "qualification", "type of schedule to generate: practice or qualification")
+	var scheduleBlocks scheduleBlocksFlag
+	flags.Var(
+		&scheduleBlocks,
+		"block",
+		"schedule block as '<YYYY-MM-DD HH:MM>,<number of matches>,<match spacing in seconds>'; may be repeated, and "+
+			"defaults to the blocks last saved on the schedule page",
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	matchType, err := model.MatchTypeFromString(*matchTypeString)
+	if err != nil {
+		return err
+	}
+	if matchType != model.Practice && matchType != model.Qualification {
+		return fmt.Errorf("schedules can only be generated for practice or qualification matches")
+	}
+
+	database, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	existingMatches, err := database.GetMatchesByType(matchType, true)
+	if err != nil {
+		return err
+	}
+	if len(existingMatches) > 0 {
+		return fmt.Errorf(
+			"a schedule of %d %s matches already exists; clear it first on the Settings page",
+			len(existingMatches),
+			matchType,
+		)
+	}
+
+	if len(scheduleBlocks) > 0 {
+		// Save the blocks so that they show up on the schedule page as if entered there.
+		if err = database.DeleteScheduleBlocksByMatchType(matchType); err != nil {
+			return err
+		}
+		for _, block := range scheduleBlocks {
+			block.MatchType = matchType
+			if err = database.CreateScheduleBlock(&block); err != nil {
+				return err
+			}
+		}
+	} else if scheduleBlocks, err = database.GetScheduleBlocksByMatchType(matchType); err != nil {
+		return err
+	}
+	if len(scheduleBlocks) == 0 {
+		return fmt.Errorf("no schedule blocks were given with -block or previously saved on the schedule page")
+	}
+
+	teams, err := database.GetAllTeams()
+	if err != nil {
+		return err
+	}
+	if len(teams) < tournament.TeamsPerMatch {
+		return fmt.Errorf("there are only %d teams; there must be at least 6 teams to generate a schedule", len(teams))
+	}
+	matches, err := tournament.BuildRandomSchedule(teams, scheduleBlocks, matchType)
+	if err != nil {
+		return fmt.Errorf("error generating schedule: %v", err)
+	}
+	for _, match := range matches {
+		if err = database.CreateMatch(&match); err != nil {
+			return err
+		}
+	}
+
+	eventSettings, err := database.GetEventSettings()
+	if err != nil {
+		return err
+	}
+	if err = database.Backup(eventSettings.Name, "post_scheduling"); err != nil {
+		return err
+	}
+	fmt.Fprintf(
+		out,
+		"Generated %d %s matches for %d teams, from %s to %s.\n",
+		len(matches),
+		matchType,
+		len(teams),
+		matches[0].Time.Local().Format(scheduleBlockTimeFormat),
+		matches[len(matches)-1].Time.Local().Format(scheduleBlockTimeFormat),
+	)
+	return nil
+}
+
+func runRankingsRecompute(args []string, out io.Writer) error {
+	flags := newFlagSet("rankings recompute", out)
+	dbPath := addDbPathFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if err = tournament.CalculateTeamCards(database, model.Qualification); err != nil {
+		return err
+	}
+	rankings, err := tournament.CalculateRankings(database, false)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Recomputed rankings for %d teams.\n", len(rankings))
+	for i, ranking := range rankings {
+		if i == 8 {
+			break
+		}
+		fmt.Fprintf(
+			out,
+			"%3d. Team %d: %d RP, %d-%d-%d in %d played\n",
+			ranking.Rank,
+			ranking.TeamId,
+			ranking.RankingPoints,
+			ranking.Wins,
+			ranking.Losses,
+			ranking.Ties,
+			ranking.Played,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package cli
+
+import (
+	"bytes"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestScheduleBlocksFlag(t *testing.T) {
+	var blocks scheduleBlocksFlag
+	assert.Nil(t, blocks.Set("2025-04-18 09:00,10,360"))
+	assert.Nil(t, blocks.Set("2025-04-18 13:00,5,420"))
+	if assert.Equal(t, 2, len(blocks)) {
+		assert.Equal(t, time.Date(2025, 4, 18, 9, 0, 0, 0, time.Local), blocks[0].StartTime)
+		assert.Equal(t, 10, blocks[0].NumMatches)
+		assert.Equal(t, 420, blocks[1].MatchSpacingSec)
+	}
+	assert.Equal(t, "2025-04-18 09:00,10,360 2025-04-18 13:00,5,420", blocks.String())
+
+	assert.NotNil(t, blocks.Set("2025-04-18 09:00,10"))
+	assert.NotNil(t, blocks.Set("9am,10,360"))
+	assert.NotNil(t, blocks.Set("2025-04-18 09:00,0,360"))
+	assert.NotNil(t, blocks.Set("2025-04-18 09:00,10,fast"))
+}
+
+func TestScheduleGenerate(t *testing.T) {
+	dbPath := setupTestDbPath(t)
+	var out bytes.Buffer
+	args := []string{"schedule", "generate", "-db", dbPath, "-block", "2025-04-18 09:00,12,360"}
+	err := Run(args, &out)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "there are only 0 teams")
+	}
+
+	database, _ := model.OpenDatabase(dbPath)
+	for i := 1; i <= 18; i++ {
+		database.CreateTeam(&model.Team{Id: i + 100})
+	}
+	database.Close()
+	assert.Nil(t, Run(args, &out))
+	assert.Contains(t, out.String(), "Generated 12 Qualification matches for 18 teams")
+
+	database, _ = model.OpenDatabase(dbPath)
+	matches, _ := database.GetMatchesByType(model.Qualification, true)
+	assert.Equal(t, 12, len(matches))
+	scheduleBlocks, _ := database.GetScheduleBlocksByMatchType(model.Qualification)
+	assert.Equal(t, 1, len(scheduleBlocks))
+	database.Close()
+
+	// Check that an existing schedule isn't overwritten.
+	err = Run(args, &out)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "already exists")
+	}
+
+	// Check that the saved blocks are used if none are given.
+	practiceArgs := []string{"schedule", "generate", "-db", dbPath, "-type", "practice"}
+	err = Run(practiceArgs, &out)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "no schedule blocks")
+	}
+	database, _ = model.OpenDatabase(dbPath)
+	database.CreateScheduleBlock(
+		&model.ScheduleBlock{MatchType: model.Practice, StartTime: time.Now(), NumMatches: 6, MatchSpacingSec: 480},
+	)
+	database.Close()
+	out.Reset()
+	assert.Nil(t, Run(practiceArgs, &out))
+	assert.Contains(t, out.String(), "Generated 6 Practice matches for 18 teams")
+
+	err = Run([]string{"schedule", "generate", "-db", dbPath, "-type", "playoff"}, &out)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "only be generated for practice or qualification")
+	}
+}
+
+func TestRankingsRecompute(t *testing.T) {
+	dbPath := setupTestDbPath(t)
+	database, _ := model.OpenDatabase(dbPath)
+	match := model.Match{
+		Type:      model.Qualification,
+		TypeOrder: 1,
+		Red1:      1,
+		Red2:      2,
+		Red3:      3,
+		Blue1:     4,
+		Blue2:     5,
+		Blue3:     6,
+		Status:    game.RedWonMatch,
+	}
+	database.CreateMatch(&match)
+	matchResult := model.NewMatchResult()
+	matchResult.MatchId = match.Id
+	matchResult.MatchType = model.Qualification
+	database.CreateMatchResult(matchResult)
+	database.Close()
+
+	var out bytes.Buffer
+	assert.Nil(t, Run([]string{"rankings", "recompute", "-db", dbPath}, &out))
+	assert.Contains(t, out.String(), "Recomputed rankings for 6 teams.")
+}
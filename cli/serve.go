@@ -0,0 +1,129 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Command for running the event server, optionally as a hot standby of another, or the public display relay.
+
+package cli
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/relay"
+	"github.com/Team254/cheesy-arena/web"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func runServe(args []string, out io.Writer) error {
+	flags := newFlagSet("serve", out)
+	dbPath := addDbPathFlag(flags)
+	port := flags.Int("port", DefaultHttpPort, "port on which to serve the web interface of every field")
+	bindAddress := flags.String("bind", "", "address on which to listen for HTTP requests, or all addresses if blank")
+	relayMode := flags.Bool("relay", false, "run as a public relay for the displays of a venue server")
+	relayPort := flags.Int("relay-port", DefaultHttpPort, "port on which to serve the relay")
+	relayKey := flags.String(
+		"relay-key", os.Getenv("CHEESY_RELAY_KEY"), "key the venue server must present to connect to the relay",
+	)
+	viewerKey := flags.String(
+		"viewer-key", os.Getenv("CHEESY_VIEWER_KEY"), "key viewers must present to load relayed displays, if any",
+	)
+	tlsCertFile := flags.String("tls-cert", "", "certificate file with which to serve the relay over TLS")
+	tlsKeyFile := flags.String("tls-key", "", "private key file with which to serve the relay over TLS")
+	standbyOf := flags.String(
+		"standby-of",
+		"",
+		"base URL of a primary server, e.g. http://10.0.100.5:8080, whose database to replicate as a read-only standby",
+	)
+	replicationKey := flags.String(
+		"replication-key",
+		os.Getenv("CHEESY_REPLICATION_KEY"),
+		"replication key set on the primary's settings page, which the standby must present to connect",
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *standbyOf != "" {
+		if !strings.HasPrefix(*standbyOf, "http://") && !strings.HasPrefix(*standbyOf, "https://") {
+			return fmt.Errorf("the primary URL given with -standby-of must start with http:// or https://")
+		}
+		if *replicationKey == "" {
+			return fmt.Errorf(
+				"a replication key must be given with -replication-key or the CHEESY_REPLICATION_KEY environment " +
+					"variable",
+			)
+		}
+	}
+
+	if *relayMode {
+		return runRelay(*bindAddress, *relayPort, *relayKey, *viewerKey, *tlsCertFile, *tlsKeyFile)
+	}
+
+	arena, err := field.NewArena(*dbPath)
+	if err != nil {
+		return fmt.Errorf("error during startup: %v", err)
+	}
+	if *standbyOf == "" {
+		// Bring any match logs left as CSV files by an earlier version into the database; a standby gets them from
+		// the primary instead.
+		numImported, err := field.ImportMatchLogCsvs(arena.Database, filepath.Join(model.BaseDir, "static/logs"))
+		if err != nil {
+			return fmt.Errorf("error during startup: %v", err)
+		}
+		if numImported > 0 {
+			log.Printf("Imported %d CSV match logs into the database.", numImported)
+		}
+	}
+
+	// Create an arena sharing the same database for each additional field; all are served from the same port.
+	arenas := []*field.Arena{arena}
+	for fieldNumber := 2; fieldNumber <= arena.EventSettings.NumFields; fieldNumber++ {
+		fieldArena, err := field.NewFieldArena(arena.Database, fieldNumber)
+		if err != nil {
+			return fmt.Errorf("error during startup: %v", err)
+		}
+		arenas = append(arenas, fieldArena)
+	}
+	field.LinkFields(arenas...)
+	var webs []*web.Web
+	for _, fieldArena := range arenas {
+		webs = append(webs, web.NewWeb(fieldArena))
+	}
+	web.LinkFieldWebs(webs...)
+	if *standbyOf != "" {
+		if err = webs[0].StartStandby(*standbyOf, *replicationKey); err != nil {
+			return fmt.Errorf("error during startup: %v", err)
+		}
+	}
+
+	// Start the web server and the state machines of the additional arenas in separate goroutines.
+	go webs[0].ServeWebInterface(*bindAddress, *port)
+	for _, fieldArena := range arenas[1:] {
+		go fieldArena.Run()
+	}
+
+	// Run the state machine of the first arena in the main thread.
+	arena.Run()
+	return nil
+}
+
+// Serves the displays of whichever venue server connects with the given key. Does not return unless the server fails.
+func runRelay(bindAddress string, port int, relayKey, viewerKey, tlsCertFile, tlsKeyFile string) error {
+	if relayKey == "" {
+		return fmt.Errorf("a relay key must be given with -relay-key or the CHEESY_RELAY_KEY environment variable")
+	}
+	server := &http.Server{
+		Addr:    net.JoinHostPort(bindAddress, strconv.Itoa(port)),
+		Handler: relay.NewServer(relayKey, viewerKey).Handler(),
+	}
+	log.Printf("Serving display relay on port %d", port)
+	if tlsCertFile != "" {
+		return server.ListenAndServeTLS(tlsCertFile, tlsKeyFile)
+	}
+	return server.ListenAndServe()
+}
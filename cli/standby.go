@@ -0,0 +1,43 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Command for promoting a running hot standby server to primary.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func runStandbyPromote(args []string, out io.Writer) error {
+	flags := newFlagSet("standby promote", out)
+	url := flags.String(
+		"url", fmt.Sprintf("http://localhost:%d", DefaultHttpPort), "base URL of the standby server on this machine",
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// The server accepts promotion without a password only from the same machine, and redirects once it is done.
+	httpClient := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error { return http.ErrUseLastResponse },
+		Timeout:       30 * time.Second,
+	}
+	resp, err := httpClient.Post(strings.TrimSuffix(*url, "/")+"/setup/standby/promote", "text/plain", nil)
+	if err != nil {
+		return fmt.Errorf("could not reach the standby server at %s: %v", *url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusTemporaryRedirect {
+		return fmt.Errorf("the standby server requires logging in; promote it from its Hot Standby page instead")
+	}
+	if resp.StatusCode != http.StatusSeeOther {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("the standby server refused promotion: %s", strings.TrimSpace(string(body)))
+	}
+	fmt.Fprintf(out, "Promoted the standby server at %s to primary.\n", *url)
+	return nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package cli
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStandbyPromote(t *testing.T) {
+	promoted := false
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if assert.Equal(t, "POST", r.Method) && assert.Equal(t, "/setup/standby/promote", r.URL.Path) {
+				if promoted {
+					http.Error(w, "this server is not a standby", http.StatusConflict)
+					return
+				}
+				promoted = true
+				http.Redirect(w, r, "/setup/standby", http.StatusSeeOther)
+			}
+		}),
+	)
+	defer server.Close()
+
+	var out bytes.Buffer
+	assert.Nil(t, Run([]string{"standby", "promote", "-url", server.URL + "/"}, &out))
+	assert.True(t, promoted)
+	assert.Contains(t, out.String(), "Promoted the standby server at")
+
+	err := Run([]string{"standby", "promote", "-url", server.URL}, &out)
+	assert.EqualError(t, err, "the standby server refused promotion: this server is not a standby")
+}
+
+func TestServeStandbyFlags(t *testing.T) {
+	var out bytes.Buffer
+	err := Run([]string{"serve", "-standby-of", "10.0.100.5:8080", "-replication-key", "secret"}, &out)
+	assert.EqualError(t, err, "the primary URL given with -standby-of must start with http:// or https://")
+	t.Setenv("CHEESY_REPLICATION_KEY", "")
+	err = Run([]string{"serve", "-standby-of", "http://10.0.100.5:8080"}, &out)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "a replication key must be given")
+	}
+}
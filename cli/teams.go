@@ -0,0 +1,173 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Command for importing the team list from a CSV file.
+
+package cli
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Setters for the team fields that can be given as CSV columns, keyed by lowercase column header.
+var teamCsvColumns = map[string]func(team *model.Team, value string) error{
+	"name":       func(team *model.Team, value string) error { team.Name = value; return nil },
+	"nickname":   func(team *model.Team, value string) error { team.Nickname = value; return nil },
+	"city":       func(team *model.Team, value string) error { team.City = value; return nil },
+	"state_prov": func(team *model.Team, value string) error { team.StateProv = value; return nil },
+	"country":    func(team *model.Team, value string) error { team.Country = value; return nil },
+	"school":     func(team *model.Team, value string) error { team.SchoolName = value; return nil },
+	"robot_name": func(team *model.Team, value string) error { team.RobotName = value; return nil },
+	"wpa_key":    func(team *model.Team, value string) error { team.WpaKey = value; return nil },
+	"rookie_year": func(team *model.Team, value string) (err error) {
+		team.RookieYear, err = strconv.Atoi(value)
+		return
+	},
+}
+
+// Allows tests to point the client at a fake server.
+var newTbaClient = partner.NewTbaClient
+
+func runTeamsImport(args []string, out io.Writer) error {
+	flags := newFlagSet("teams import", out)
+	dbPath := addDbPathFlag(flags)
+	noTba := flags.Bool("no-tba", false, "skip downloading team details from TBA even if enabled in the settings")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("expected the path of a CSV file whose first column is the team number")
+	}
+
+	teams, err := readTeamsCsv(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	database, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+	eventSettings, err := database.GetEventSettings()
+	if err != nil {
+		return err
+	}
+	matches, err := database.GetMatchesByType(model.Qualification, true)
+	if err != nil {
+		return err
+	}
+	if len(matches) > 0 {
+		return fmt.Errorf("can't modify the team list once the qualification schedule has been generated")
+	}
+	tbaClient := newTbaClient(eventSettings.TbaEventCode, eventSettings.TbaSecretId, eventSettings.TbaSecret)
+
+	numCreated := 0
+	for _, team := range teams {
+		existingTeam, err := database.GetTeamById(team.Id)
+		if err != nil {
+			return err
+		}
+		if existingTeam != nil {
+			fmt.Fprintf(out, "Skipping team %d, which is already in the team list.\n", team.Id)
+			continue
+		}
+		if eventSettings.TbaDownloadEnabled && !*noTba {
+			// Download the official details, keeping any that were given in the file.
+			officialTeam := model.Team{Id: team.Id}
+			if err = tbaClient.PopulateTeamInfo(&officialTeam); err != nil {
+				return fmt.Errorf("error downloading details for team %d: %v", team.Id, err)
+			}
+			mergeTeamDetails(&officialTeam, &team)
+			team = officialTeam
+		}
+		if err = database.CreateTeam(&team); err != nil {
+			return err
+		}
+		numCreated++
+	}
+	fmt.Fprintf(out, "Imported %d team(s).\n", numCreated)
+	return nil
+}
+
+// Parses the teams from the given CSV file. If the first row is a header, the columns after the team number are
+// mapped by name onto the team details; otherwise only the first column is used.
+func readTeamsCsv(path string) ([]model.Team, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var header []string
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		if _, err = strconv.Atoi(rows[0][0]); err != nil {
+			header = rows[0]
+			rows = rows[1:]
+			for _, column := range header[1:] {
+				if _, ok := teamCsvColumns[strings.ToLower(column)]; !ok {
+					return nil, fmt.Errorf("unknown column %q", column)
+				}
+			}
+		}
+	}
+
+	firstLine := 1
+	if header != nil {
+		firstLine = 2
+	}
+	var teams []model.Team
+	for i, row := range rows {
+		if len(row) == 0 || row[0] == "" {
+			continue
+		}
+		teamId, err := strconv.Atoi(row[0])
+		if err != nil || teamId <= 0 {
+			return nil, fmt.Errorf("invalid team number %q on line %d", row[0], i+firstLine)
+		}
+		team := model.Team{Id: teamId}
+		for j := 1; j < len(row) && j < len(header); j++ {
+			if row[j] == "" {
+				continue
+			}
+			if err = teamCsvColumns[strings.ToLower(header[j])](&team, row[j]); err != nil {
+				return nil, fmt.Errorf("invalid %s %q for team %d", header[j], row[j], teamId)
+			}
+		}
+		teams = append(teams, team)
+	}
+	return teams, nil
+}
+
+// Overwrites the details of the given official team with any that are set in the given imported one.
+func mergeTeamDetails(officialTeam, importedTeam *model.Team) {
+	setIfPresent := func(official *string, imported string) {
+		if imported != "" {
+			*official = imported
+		}
+	}
+	setIfPresent(&officialTeam.Name, importedTeam.Name)
+	setIfPresent(&officialTeam.Nickname, importedTeam.Nickname)
+	setIfPresent(&officialTeam.City, importedTeam.City)
+	setIfPresent(&officialTeam.StateProv, importedTeam.StateProv)
+	setIfPresent(&officialTeam.Country, importedTeam.Country)
+	setIfPresent(&officialTeam.SchoolName, importedTeam.SchoolName)
+	setIfPresent(&officialTeam.RobotName, importedTeam.RobotName)
+	setIfPresent(&officialTeam.WpaKey, importedTeam.WpaKey)
+	if importedTeam.RookieYear != 0 {
+		officialTeam.RookieYear = importedTeam.RookieYear
+	}
+}
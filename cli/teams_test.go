@@ -0,0 +1,116 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package cli
+
+import (
+	"bytes"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestCsv(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "teams.csv")
+	assert.Nil(t, os.WriteFile(path, []byte(contents), 0644))
+	return path
+}
+
+func TestReadTeamsCsv(t *testing.T) {
+	teams, err := readTeamsCsv(writeTestCsv(t, "254\n1114\n\n1678\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, []model.Team{{Id: 254}, {Id: 1114}, {Id: 1678}}, teams)
+
+	teams, err = readTeamsCsv(
+		writeTestCsv(t, "team,nickname,Rookie_Year,wpa_key\n254,The Cheesy Poofs,1999,12345678\n1114,,,\n"),
+	)
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]model.Team{{Id: 254, Nickname: "The Cheesy Poofs", RookieYear: 1999, WpaKey: "12345678"}, {Id: 1114}},
+		teams,
+	)
+
+	_, err = readTeamsCsv(writeTestCsv(t, "team,mascot\n254,cheese\n"))
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "unknown column \"mascot\"", err.Error())
+	}
+	_, err = readTeamsCsv(writeTestCsv(t, "team,name\n254,Poofs\nabc,Robots\n"))
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "invalid team number \"abc\" on line 3", err.Error())
+	}
+	_, err = readTeamsCsv(writeTestCsv(t, "team,rookie_year\n254,long ago\n"))
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "invalid rookie_year \"long ago\" for team 254", err.Error())
+	}
+}
+
+func TestTeamsImport(t *testing.T) {
+	dbPath := setupTestDbPath(t)
+	database, _ := model.OpenDatabase(dbPath)
+	database.CreateTeam(&model.Team{Id: 1114, Nickname: "Simbotics"})
+	database.Close()
+
+	var out bytes.Buffer
+	csvPath := writeTestCsv(t, "team,nickname\n254,Poofs\n1114,\n1678\n")
+	assert.Nil(t, Run([]string{"teams", "import", "-db", dbPath, "-no-tba", csvPath}, &out))
+	assert.Contains(t, out.String(), "Skipping team 1114")
+	assert.Contains(t, out.String(), "Imported 2 team(s).")
+
+	database, _ = model.OpenDatabase(dbPath)
+	teams, _ := database.GetAllTeams()
+	if assert.Equal(t, 3, len(teams)) {
+		assert.Equal(t, model.Team{Id: 254, Nickname: "Poofs"}, teams[0])
+		assert.Equal(t, "Simbotics", teams[1].Nickname)
+		assert.Equal(t, 1678, teams[2].Id)
+	}
+
+	// Check that teams can't be imported once the schedule exists.
+	database.CreateMatch(&model.Match{Type: model.Qualification, TypeOrder: 1})
+	database.Close()
+	err := Run([]string{"teams", "import", "-db", dbPath, "-no-tba", csvPath}, &out)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "can't modify the team list")
+	}
+}
+
+func TestTeamsImportWithTbaDownload(t *testing.T) {
+	dbPath := setupTestDbPath(t)
+	database, _ := model.OpenDatabase(dbPath)
+	eventSettings, _ := database.GetEventSettings()
+	eventSettings.TbaDownloadEnabled = true
+	database.UpdateEventSettings(eventSettings)
+	database.Close()
+
+	// Mock the TBA server.
+	tbaServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/team/frc254") {
+			w.Write([]byte(`{"team_number":254,"nickname":"The Cheesy Poofs","city":"San Jose","rookie_year":1999}`))
+		} else {
+			w.Write([]byte("[]"))
+		}
+	}))
+	defer tbaServer.Close()
+	newTbaClient = func(eventCode, secretId, secret string) *partner.TbaClient {
+		client := partner.NewTbaClient(eventCode, secretId, secret)
+		client.BaseUrl = tbaServer.URL
+		return client
+	}
+	defer func() { newTbaClient = partner.NewTbaClient }()
+
+	var out bytes.Buffer
+	assert.Nil(t, Run([]string{"teams", "import", "-db", dbPath, writeTestCsv(t, "team,city\n254,Campbell\n")}, &out))
+	database, _ = model.OpenDatabase(dbPath)
+	defer database.Close()
+	team, _ := database.GetTeamById(254)
+	if assert.NotNil(t, team) {
+		assert.Equal(t, "The Cheesy Poofs", team.Nickname)
+		assert.Equal(t, "Campbell", team.City)
+		assert.Equal(t, 1999, team.RookieYear)
+	}
+}
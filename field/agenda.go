@@ -0,0 +1,96 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Logic for tracking the progress of the event's run-of-show against its plan.
+
+package field
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"time"
+)
+
+// Marks the given agenda segment as having started now, ending whichever segment was in progress before it.
+func (arena *Arena) StartAgendaSegment(segmentId int) error {
+	segment, err := arena.Database.GetAgendaSegmentById(segmentId)
+	if err != nil {
+		return err
+	}
+	if segment == nil {
+		return fmt.Errorf("agenda segment %d doesn't exist", segmentId)
+	}
+	if !segment.ActualStartTime.IsZero() {
+		return fmt.Errorf("agenda segment %s has already been started", segment.Title)
+	}
+
+	now := time.Now()
+	inProgressSegment, err := arena.getAgendaSegmentInProgress()
+	if err != nil {
+		return err
+	}
+	if inProgressSegment != nil {
+		inProgressSegment.ActualEndTime = now
+		if err = arena.Database.UpdateAgendaSegment(inProgressSegment); err != nil {
+			return err
+		}
+	}
+	segment.ActualStartTime = now
+	if err = arena.Database.UpdateAgendaSegment(segment); err != nil {
+		return err
+	}
+	arena.updateEarlyLateMessage()
+	return nil
+}
+
+// Marks the given agenda segment, which must be in progress, as having ended now.
+func (arena *Arena) EndAgendaSegment(segmentId int) error {
+	segment, err := arena.Database.GetAgendaSegmentById(segmentId)
+	if err != nil {
+		return err
+	}
+	if segment == nil {
+		return fmt.Errorf("agenda segment %d doesn't exist", segmentId)
+	}
+	if !segment.IsInProgress() {
+		return fmt.Errorf("agenda segment %s isn't in progress", segment.Title)
+	}
+
+	segment.ActualEndTime = time.Now()
+	if err = arena.Database.UpdateAgendaSegment(segment); err != nil {
+		return err
+	}
+	arena.updateEarlyLateMessage()
+	return nil
+}
+
+// Recalculates the parts of the event status that depend on the agenda, after it has been edited.
+func (arena *Arena) UpdateAgendaStatus() {
+	arena.updateEarlyLateMessage()
+}
+
+// Returns the agenda segment that has been started but not yet ended, or nil if there isn't one.
+func (arena *Arena) getAgendaSegmentInProgress() (*model.AgendaSegment, error) {
+	segments, err := arena.Database.GetAllAgendaSegments()
+	if err != nil {
+		return nil, err
+	}
+	for i := range segments {
+		if segments[i].IsInProgress() {
+			return &segments[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Returns the string that tells the audience and teams which agenda segment is up next, or an empty string if none
+// remain. Segments that were never started and whose planned end has already passed are assumed to have been skipped.
+func (arena *Arena) getAgendaMessage() string {
+	segments, _ := arena.Database.GetAllAgendaSegments()
+	now := time.Now()
+	for _, segment := range segments {
+		if segment.ActualStartTime.IsZero() && segment.PlannedEndTime.After(now) {
+			return fmt.Sprintf("Up next: %s at %s", segment.Title, segment.PlannedStartTime.Local().Format("3:04 PM"))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAgendaSegments(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.LoadTestMatch()
+	assert.Equal(t, "", arena.getAgendaMessage())
+
+	now := time.Now()
+	openingCeremonies := model.AgendaSegment{
+		Type:             model.CeremonySegment,
+		Title:            "Opening Ceremonies",
+		PlannedStartTime: now.Add(-10 * time.Minute),
+		PlannedEndTime:   now.Add(20 * time.Minute),
+	}
+	lunch := model.AgendaSegment{
+		Type:             model.MealSegment,
+		Title:            "Lunch",
+		PlannedStartTime: now.Add(30*time.Minute + 30*time.Second),
+		PlannedEndTime:   now.Add(90 * time.Minute),
+	}
+	arena.Database.CreateAgendaSegment(&openingCeremonies)
+	arena.Database.CreateAgendaSegment(&lunch)
+	openingCeremoniesTime := openingCeremonies.PlannedStartTime.Format("3:04 PM")
+	assert.Equal(t, "Up next: Opening Ceremonies at "+openingCeremoniesTime, arena.getAgendaMessage())
+
+	// Start the first segment late and check that the event status reflects it.
+	assert.Nil(t, arena.StartAgendaSegment(openingCeremonies.Id))
+	assert.Equal(t, "Event is running 10 minutes late", arena.EventStatus.EarlyLateMessage)
+	assert.Equal(t, "Up next: Lunch at "+lunch.PlannedStartTime.Format("3:04 PM"), arena.EventStatus.AgendaMessage)
+	err := arena.StartAgendaSegment(openingCeremonies.Id)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "agenda segment Opening Ceremonies has already been started", err.Error())
+	}
+
+	// Start the next segment and check that it ends the previous one.
+	assert.Nil(t, arena.StartAgendaSegment(lunch.Id))
+	segment, _ := arena.Database.GetAgendaSegmentById(openingCeremonies.Id)
+	assert.True(t, segment.IsComplete())
+	assert.Equal(t, "Event is running 30 minutes early", arena.EventStatus.EarlyLateMessage)
+	assert.Equal(t, "", arena.EventStatus.AgendaMessage)
+
+	// Check that lateness falls back to the match schedule once no segment is in progress.
+	assert.Nil(t, arena.EndAgendaSegment(lunch.Id))
+	assert.Equal(t, "", arena.EventStatus.EarlyLateMessage)
+	err = arena.EndAgendaSegment(lunch.Id)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "agenda segment Lunch isn't in progress", err.Error())
+	}
+	err = arena.StartAgendaSegment(12)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "agenda segment 12 doesn't exist", err.Error())
+	}
+}
+
+func TestAgendaSegmentsMatches(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.Database.CreateMatch(&model.Match{Type: model.Qualification, TypeOrder: 1, Time: time.Now()})
+	matches, _ := arena.Database.GetMatchesByType(model.Qualification, false)
+	arena.CurrentMatch = &matches[0]
+	arena.MatchState = PreMatch
+
+	// Check that a block of matches defers to the match schedule to determine lateness.
+	qualifications := model.AgendaSegment{
+		Type:             model.MatchesSegment,
+		Title:            "Qualification Matches",
+		PlannedStartTime: time.Now().Add(-time.Hour),
+		PlannedEndTime:   time.Now().Add(time.Hour),
+	}
+	arena.Database.CreateAgendaSegment(&qualifications)
+	assert.Nil(t, arena.StartAgendaSegment(qualifications.Id))
+	assert.Equal(t, "Event is running on schedule", arena.EventStatus.EarlyLateMessage)
+}
+
+func TestAgendaSegmentsSkipped(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.LoadTestMatch()
+
+	// Check that a segment that was never started is passed over once its planned end has gone by.
+	now := time.Now()
+	inspection := model.AgendaSegment{
+		Type:             model.InspectionSegment,
+		Title:            "Inspection",
+		PlannedStartTime: now.Add(-2 * time.Hour),
+		PlannedEndTime:   now.Add(-time.Hour),
+	}
+	lunch := model.AgendaSegment{
+		Type:             model.MealSegment,
+		Title:            "Lunch",
+		PlannedStartTime: now.Add(-30 * time.Minute),
+		PlannedEndTime:   now.Add(30 * time.Minute),
+	}
+	arena.Database.CreateAgendaSegment(&inspection)
+	arena.Database.CreateAgendaSegment(&lunch)
+	arena.runPeriodicTasks()
+	assert.Equal(t, "Up next: Lunch at "+lunch.PlannedStartTime.Format("3:04 PM"), arena.GetEventStatus().AgendaMessage)
+
+	// Check that the skipped segment can still be started late if it does happen.
+	assert.Nil(t, arena.StartAgendaSegment(inspection.Id))
+	assert.Equal(t, "Event is running 120 minutes late", arena.GetEventStatus().EarlyLateMessage)
+	assert.Equal(t, "Up next: Lunch at "+lunch.PlannedStartTime.Format("3:04 PM"), arena.GetEventStatus().AgendaMessage)
+}
+
+func TestAgendaStatusConcurrentUpdates(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.LoadTestMatch()
+	segment := model.AgendaSegment{
+		Title:            "Opening Ceremonies",
+		PlannedStartTime: time.Now().Add(time.Hour),
+		PlannedEndTime:   time.Now().Add(2 * time.Hour),
+	}
+	arena.Database.CreateAgendaSegment(&segment)
+
+	// Simulate the arena loop and a web handler updating the status at the same time; run with -race to verify.
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			arena.updateEarlyLateMessage()
+		}
+		close(done)
+	}()
+	for i := 0; i < 10; i++ {
+		arena.UpdateAgendaStatus()
+		_ = arena.GetEventStatus()
+	}
+	<-done
+	assert.Contains(t, arena.GetEventStatus().AgendaMessage, "Up next: Opening Ceremonies at ")
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Logic for applying the lineups submitted by playoff alliance captains to the matches they are for.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+)
+
+// Returns the teams for the given playoff match with the lineups that its alliances have submitted in place of their
+// default lineups, or nil if neither alliance has submitted one.
+func (arena *Arena) getSubmittedLineup(match *model.Match) (*[6]int, error) {
+	if match.Type != model.Playoff {
+		return nil, nil
+	}
+
+	lineup := [6]int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3}
+	isSubmitted := false
+	for i, allianceId := range []int{match.PlayoffRedAlliance, match.PlayoffBlueAlliance} {
+		if allianceId == 0 {
+			continue
+		}
+		lineupSubmission, err := arena.Database.GetLineupSubmission(match.Id, allianceId)
+		if err != nil {
+			return nil, err
+		}
+		if lineupSubmission != nil {
+			copy(lineup[3*i:3*i+3], lineupSubmission.TeamIds[:])
+			isSubmitted = true
+		}
+	}
+	if !isSubmitted {
+		return nil, nil
+	}
+	return &lineup, nil
+}
+
+// Returns true if the given match is loaded on this arena's field or is next in its queue behind the loaded match. Its
+// lineup is used to set up the field from that point on, so it can no longer be changed.
+func (arena *Arena) IsMatchQueued(match *model.Match) (bool, error) {
+	if arena.CurrentMatch.Id == match.Id {
+		return true, nil
+	}
+	nextMatch, err := arena.getNextMatch(true)
+	if err != nil {
+		return false, err
+	}
+	return nextMatch != nil && nextMatch.Id == match.Id, nil
+}
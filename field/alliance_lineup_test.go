@@ -0,0 +1,60 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLoadMatchWithSubmittedLineup(t *testing.T) {
+	arena := setupTestArena(t)
+	for _, teamId := range []int{101, 102, 103, 104, 201, 202, 203} {
+		assert.Nil(t, arena.Database.CreateTeam(&model.Team{Id: teamId}))
+	}
+	match := model.Match{
+		Type:                model.Playoff,
+		ShortName:           "M1",
+		PlayoffRedAlliance:  1,
+		PlayoffBlueAlliance: 2,
+		Red1:                102,
+		Red2:                101,
+		Red3:                103,
+		Blue1:               202,
+		Blue2:               201,
+		Blue3:               203,
+	}
+	assert.Nil(t, arena.Database.CreateMatch(&match))
+
+	// Check that the match is loaded as scheduled when no lineup has been submitted.
+	lineup, err := arena.getSubmittedLineup(&match)
+	assert.Nil(t, err)
+	assert.Nil(t, lineup)
+	assert.Nil(t, arena.LoadMatch(&match))
+	assert.Equal(t, 102, arena.AllianceStations["R1"].Team.Id)
+
+	// Check that a submitted lineup replaces only the lineup of the alliance that submitted it.
+	assert.Nil(
+		t,
+		arena.Database.CreateLineupSubmission(
+			&model.LineupSubmission{MatchId: match.Id, AllianceId: 1, TeamIds: [3]int{104, 103, 101}},
+		),
+	)
+	lineup, err = arena.getSubmittedLineup(&match)
+	assert.Nil(t, err)
+	assert.Equal(t, [6]int{104, 103, 101, 202, 201, 203}, *lineup)
+	assert.Nil(t, arena.LoadMatch(&match))
+	assert.Equal(t, 104, arena.AllianceStations["R1"].Team.Id)
+	assert.Equal(t, 103, arena.AllianceStations["R2"].Team.Id)
+	assert.Equal(t, 101, arena.AllianceStations["R3"].Team.Id)
+	assert.Equal(t, 202, arena.AllianceStations["B1"].Team.Id)
+	assert.Equal(t, 104, arena.CurrentMatch.Red1)
+	assert.Equal(t, 201, arena.CurrentMatch.Blue2)
+
+	// Check that lineups aren't applied to other types of matches.
+	match.Type = model.Practice
+	lineup, err = arena.getSubmittedLineup(&match)
+	assert.Nil(t, err)
+	assert.Nil(t, lineup)
+}
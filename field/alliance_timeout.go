@@ -0,0 +1,68 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Logic for timeouts called by a playoff alliance against its allowance.
+
+package field
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"time"
+)
+
+// Starts a timeout of the given duration on behalf of the given alliance, which must be playing in the current playoff
+// match and have timeouts remaining in its allowance.
+func (arena *Arena) StartAllianceTimeout(allianceId int, durationSec int) error {
+	match := arena.CurrentMatch
+	if match.Type != model.Playoff {
+		return fmt.Errorf("alliance timeouts can only be called before playoff matches")
+	}
+	if allianceId == 0 || (allianceId != match.PlayoffRedAlliance && allianceId != match.PlayoffBlueAlliance) {
+		return fmt.Errorf("alliance %d isn't playing in match %s", allianceId, match.ShortName)
+	}
+
+	// A field timeout already gives both alliances the time they need, so they can't extend it with one of their own.
+	if description, ok := arena.PlayoffTournament.FieldTimeoutBefore(match.TypeOrder); ok {
+		return fmt.Errorf(
+			"alliance timeouts can't be called before match %s since it follows a field timeout (%s)",
+			match.ShortName,
+			description,
+		)
+	}
+
+	timeoutsRemaining, err := arena.AllianceTimeoutsRemaining(allianceId)
+	if err != nil {
+		return err
+	}
+	if timeoutsRemaining <= 0 {
+		return fmt.Errorf("alliance %d has no timeouts remaining", allianceId)
+	}
+
+	// Only count the timeout against the alliance's allowance once it has actually started.
+	startedAt := time.Now()
+	if err = arena.StartTimeout(fmt.Sprintf("Alliance %d Timeout", allianceId), durationSec); err != nil {
+		return err
+	}
+	allianceTimeout := model.AllianceTimeout{
+		AllianceId:  allianceId,
+		MatchId:     match.Id,
+		StartedAt:   startedAt,
+		DurationSec: durationSec,
+	}
+	if err = arena.Database.CreateAllianceTimeout(&allianceTimeout); err != nil {
+		return err
+	}
+
+	// Update the timeouts remaining that were sent out when the timeout started.
+	arena.MatchLoadNotifier.Notify()
+	return nil
+}
+
+// Returns the number of timeouts that the given alliance has left to call in the playoff tournament.
+func (arena *Arena) AllianceTimeoutsRemaining(allianceId int) (int, error) {
+	allianceTimeouts, err := arena.Database.GetAllianceTimeoutsByAlliance(allianceId)
+	if err != nil {
+		return 0, err
+	}
+	return max(arena.EventSettings.PlayoffTimeoutsPerAlliance-len(allianceTimeouts), 0), nil
+}
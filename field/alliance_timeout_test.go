@@ -0,0 +1,81 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/tournament"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestStartAllianceTimeout(t *testing.T) {
+	arena := setupTestArena(t)
+
+	err := arena.StartAllianceTimeout(1, 300)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "alliance timeouts can only be called before playoff matches", err.Error())
+	}
+
+	tournament.CreateTestAlliances(arena.Database, 8)
+	assert.Nil(t, arena.CreatePlayoffMatches(time.Unix(1000, 0)))
+	matches, _ := arena.Database.GetMatchesByType(model.Playoff, false)
+	assert.Nil(t, arena.LoadMatch(&matches[0]))
+	err = arena.StartAllianceTimeout(2, 300)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "alliance 2 isn't playing in match M1", err.Error())
+	}
+
+	// Call a timeout for real and check that it is charged against the alliance's allowance.
+	timeoutsRemaining, err := arena.AllianceTimeoutsRemaining(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, timeoutsRemaining)
+	assert.Nil(t, arena.StartAllianceTimeout(1, 300))
+	assert.Equal(t, TimeoutActive, arena.MatchState)
+	assert.Equal(t, "Alliance 1 Timeout", arena.breakDescription)
+	timeoutsRemaining, _ = arena.AllianceTimeoutsRemaining(1)
+	assert.Equal(t, 0, timeoutsRemaining)
+	allianceTimeouts, _ := arena.Database.GetAllianceTimeoutsByAlliance(1)
+	if assert.Equal(t, 1, len(allianceTimeouts)) {
+		assert.Equal(t, matches[0].Id, allianceTimeouts[0].MatchId)
+		assert.Equal(t, 300, allianceTimeouts[0].DurationSec)
+	}
+
+	// Check that the opposing alliance can't stack a timeout on top of the running one, and isn't charged for trying.
+	err = arena.StartAllianceTimeout(8, 300)
+	if assert.NotNil(t, err) {
+		assert.Equal(
+			t, "cannot start timeout while there is a match still in progress or with results pending", err.Error(),
+		)
+	}
+	allianceTimeouts, _ = arena.Database.GetAllianceTimeoutsByAlliance(8)
+	assert.Empty(t, allianceTimeouts)
+	assert.Nil(t, arena.AbortMatch())
+	arena.Update()
+	arena.MatchStartTime = time.Now().Add(-time.Duration(300+postTimeoutSec) * time.Second)
+	arena.Update()
+	assert.Equal(t, PreMatch, arena.MatchState)
+
+	err = arena.StartAllianceTimeout(1, 300)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "alliance 1 has no timeouts remaining", err.Error())
+	}
+	arena.EventSettings.PlayoffTimeoutsPerAlliance = 2
+	timeoutsRemaining, _ = arena.AllianceTimeoutsRemaining(1)
+	assert.Equal(t, 1, timeoutsRemaining)
+
+	// Check that an alliance timeout can't be called before a match that already follows a scheduled field timeout.
+	arena.CurrentMatch = &model.Match{
+		Type: model.Playoff, TypeOrder: 11, ShortName: "M11", PlayoffRedAlliance: 8, PlayoffBlueAlliance: 2,
+	}
+	err = arena.StartAllianceTimeout(8, 300)
+	if assert.NotNil(t, err) {
+		assert.Equal(
+			t,
+			"alliance timeouts can't be called before match M11 since it follows a field timeout (Field Break)",
+			err.Error(),
+		)
+	}
+	assert.Equal(t, PreMatch, arena.MatchState)
+}
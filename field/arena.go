@@ -6,10 +6,13 @@
 package field
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Team254/cheesy-arena/game"
@@ -50,17 +53,20 @@ const (
 )
 
 type Arena struct {
-	Database         *model.Database
-	EventSettings    *model.EventSettings
-	accessPoint      network.AccessPoint
-	networkSwitch    *network.Switch
-	Plc              plc.Plc
-	TbaClient        *partner.TbaClient
-	NexusClient      *partner.NexusClient
-	BlackmagicClient *partner.BlackmagicClient
-	AllianceStations map[string]*AllianceStation
-	Displays         map[string]*Display
-	TeamSigns        *TeamSigns
+	FieldNumber       int
+	standby           atomic.Bool
+	Database          *model.Database
+	EventSettings     *model.EventSettings
+	accessPoint       network.AccessPoint
+	networkSwitch     *network.Switch
+	Plc               plc.Plc
+	TbaClient         *partner.TbaClient
+	NexusClient       *partner.NexusClient
+	BlackmagicClient  *partner.BlackmagicClient
+	AllianceStations  map[string]*AllianceStation
+	Displays          map[string]*Display
+	TeamSigns         *TeamSigns
+	TeamSignSimulator *TeamSignSimulator
 	ScoringPanelRegistry
 	ArenaNotifiers
 	MatchState
@@ -72,7 +78,12 @@ type Arena struct {
 	BlueRealtimeScore                 *RealtimeScore
 	lastDsPacketTime                  time.Time
 	lastPeriodicTaskTime              time.Time
+	lastChannelScanTime               time.Time
+	cancelChannelScan                 context.CancelFunc
+	channelScanAbortReason            string
+	channelScanMutex                  sync.Mutex
 	EventStatus                       EventStatus
+	eventStatusMutex                  sync.Mutex
 	FieldReset                        bool
 	AudienceDisplayMode               string
 	SavedMatch                        *model.Match
@@ -91,22 +102,52 @@ type Arena struct {
 	soundsPlayed                      map[*game.MatchSound]struct{}
 	breakDescription                  string
 	preloadedTeams                    *[6]*model.Team
+	teamNetworkDiagnostics            map[int]network.TeamNetworkDiagnostics
+	networkDiagnosticsMutex           sync.Mutex
+	networkDiagnosticsQueries         chan networkDiagnosticsQuery
+	networkDiagnosticsResults         chan networkDiagnosticsQuery
+	networkDiagnosticsPending         bool
+	lastNetworkDiagnosticsTime        time.Time
+	teamMatchLogs                     map[string]*TeamMatchLog
+	teamMatchLogWrites                sync.WaitGroup
+	teamWifiRecords                   map[string]*model.TeamWifiRecord
+	lastWifiSampleTime                time.Time
+	fields                            []*Arena
+	scoreCommitMutex                  *sync.Mutex
+	stateMutex                        *sync.RWMutex
+	hardwareLoopsOnce                 sync.Once
 }
 
 type AllianceStation struct {
-	DsConn     *DriverStationConnection
-	Ethernet   bool
-	AStop      bool
-	EStop      bool
-	Bypass     bool
-	Team       *model.Team
-	WifiStatus network.TeamWifiStatus
-	aStopReset bool
+	DsConn             *DriverStationConnection
+	Ethernet           bool
+	AStop              bool
+	EStop              bool
+	Bypass             bool
+	Team               *model.Team
+	WifiStatus         network.TeamWifiStatus
+	NetworkDiagnostics network.TeamNetworkDiagnostics
+	aStopReset         bool
 }
 
-// Creates the arena and sets it to its initial state.
+// Creates the arena for the first field and sets it to its initial state.
 func NewArena(dbPath string) (*Arena, error) {
-	arena := new(Arena)
+	database, err := model.OpenDatabase(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	return NewFieldArena(database, 1)
+}
+
+// Creates the arena for the given field using the given database, which is shared with the arenas of any other fields
+// at the event, and sets it to its initial state.
+func NewFieldArena(database *model.Database, fieldNumber int) (*Arena, error) {
+	arena := &Arena{
+		FieldNumber:      fieldNumber,
+		Database:         database,
+		scoreCommitMutex: new(sync.Mutex),
+		stateMutex:       new(sync.RWMutex),
+	}
 	arena.configureNotifiers()
 	arena.Plc = new(plc.ModbusPlc)
 
@@ -119,16 +160,14 @@ func NewArena(dbPath string) (*Arena, error) {
 	arena.AllianceStations["B3"] = new(AllianceStation)
 
 	arena.Displays = make(map[string]*Display)
+	arena.teamNetworkDiagnostics = make(map[int]network.TeamNetworkDiagnostics)
+	arena.networkDiagnosticsQueries = make(chan networkDiagnosticsQuery, 1)
+	arena.networkDiagnosticsResults = make(chan networkDiagnosticsQuery, 1)
 
 	arena.TeamSigns = NewTeamSigns()
+	arena.TeamSignSimulator = NewTeamSignSimulator(func() { arena.TeamSignsNotifier.Notify() })
 
-	var err error
-	arena.Database, err = model.OpenDatabase(dbPath)
-	if err != nil {
-		return nil, err
-	}
-	err = arena.LoadSettings()
-	if err != nil {
+	if err := arena.LoadSettings(); err != nil {
 		return nil, err
 	}
 
@@ -156,16 +195,29 @@ func (arena *Arena) LoadSettings() error {
 		return err
 	}
 	arena.EventSettings = settings
+	apAddress, apPassword, apChannel := settings.ApAddress, settings.ApPassword, settings.ApChannel
+	switchAddress, switchPassword, plcAddress := settings.SwitchAddress, settings.SwitchPassword, settings.PlcAddress
+	blackmagicAddresses := settings.BlackmagicAddresses
+	if arena.FieldNumber > 1 {
+		// Additional fields have their own network hardware and PLC, and leave the team signs and recorders, which are
+		// configured only once, to the first field.
+		fieldSettings, err := arena.Database.GetFieldSettings(arena.FieldNumber)
+		if err != nil {
+			return err
+		}
+		apAddress, apPassword, apChannel = fieldSettings.ApAddress, fieldSettings.ApPassword, fieldSettings.ApChannel
+		switchAddress, switchPassword = fieldSettings.SwitchAddress, fieldSettings.SwitchPassword
+		plcAddress = fieldSettings.PlcAddress
+		blackmagicAddresses = ""
+	}
+	if arena.IsStandby() {
+		// Keep the standby from contending with the primary for the field hardware.
+		apAddress, switchAddress, plcAddress, blackmagicAddresses = "", "", "", ""
+	} else if arena.FieldNumber == 1 {
+		arena.configureTeamSigns()
+	}
 
 	// Initialize the components that depend on settings.
-	arena.TeamSigns.Red1.SetId(settings.TeamSignRed1Id)
-	arena.TeamSigns.Red2.SetId(settings.TeamSignRed2Id)
-	arena.TeamSigns.Red3.SetId(settings.TeamSignRed3Id)
-	arena.TeamSigns.RedTimer.SetId(settings.TeamSignRedTimerId)
-	arena.TeamSigns.Blue1.SetId(settings.TeamSignBlue1Id)
-	arena.TeamSigns.Blue2.SetId(settings.TeamSignBlue2Id)
-	arena.TeamSigns.Blue3.SetId(settings.TeamSignBlue3Id)
-	arena.TeamSigns.BlueTimer.SetId(settings.TeamSignBlueTimerId)
 	accessPointWifiStatuses := [6]*network.TeamWifiStatus{
 		&arena.AllianceStations["R1"].WifiStatus,
 		&arena.AllianceStations["R2"].WifiStatus,
@@ -175,17 +227,20 @@ func (arena *Arena) LoadSettings() error {
 		&arena.AllianceStations["B3"].WifiStatus,
 	}
 	arena.accessPoint.SetSettings(
-		settings.ApAddress,
-		settings.ApPassword,
-		settings.ApChannel,
+		apAddress,
+		apPassword,
+		apChannel,
 		settings.NetworkSecurityEnabled,
 		accessPointWifiStatuses,
 	)
-	arena.networkSwitch = network.NewSwitch(settings.SwitchAddress, settings.SwitchPassword)
-	arena.Plc.SetAddress(settings.PlcAddress)
+	if err = arena.loadChannelScanHistory(); err != nil {
+		return err
+	}
+	arena.networkSwitch = network.NewSwitch(switchAddress, switchPassword)
+	arena.Plc.SetAddress(plcAddress)
 	arena.TbaClient = partner.NewTbaClient(settings.TbaEventCode, settings.TbaSecretId, settings.TbaSecret)
 	arena.NexusClient = partner.NewNexusClient(settings.TbaEventCode)
-	arena.BlackmagicClient = partner.NewBlackmagicClient(settings.BlackmagicAddresses)
+	arena.BlackmagicClient = partner.NewBlackmagicClient(blackmagicAddresses)
 
 	game.MatchTiming.WarmupDurationSec = settings.WarmupDurationSec
 	game.MatchTiming.AutoDurationSec = settings.AutoDurationSec
@@ -243,9 +298,10 @@ func (arena *Arena) LoadMatch(match *model.Match) error {
 		return fmt.Errorf("cannot load match while there is a match still in progress or with results pending")
 	}
 
+	arena.abortChannelScan("a match is being loaded")
 	arena.CurrentMatch = match
 
-	loadedByNexus := false
+	isLineupLoaded := false
 	if match.ShouldAllowNexusSubstitution() && arena.EventSettings.NexusEnabled {
 		// Attempt to get the match lineup from Nexus for FRC.
 		lineup, err := arena.NexusClient.GetLineup(match.TbaMatchKey)
@@ -259,12 +315,28 @@ func (arena *Arena) LoadMatch(match *model.Match) error {
 				log.Printf(
 					"Successfully loaded lineup for match %s from Nexus: %v", match.TbaMatchKey.String(), *lineup,
 				)
-				loadedByNexus = true
+				isLineupLoaded = true
+			}
+		}
+	}
+
+	if !isLineupLoaded {
+		// Fall back to any lineups submitted locally by the alliance captains.
+		lineup, err := arena.getSubmittedLineup(match)
+		if err != nil {
+			log.Printf("Failed to get submitted lineup: %s", err.Error())
+		} else if lineup != nil {
+			err = arena.SubstituteTeams(lineup[0], lineup[1], lineup[2], lineup[3], lineup[4], lineup[5])
+			if err != nil {
+				log.Printf("Failed to substitute teams using submitted lineup; loading match normally: %s", err.Error())
+			} else {
+				log.Printf("Successfully loaded submitted lineup for match %s: %v", match.ShortName, *lineup)
+				isLineupLoaded = true
 			}
 		}
 	}
 
-	if !loadedByNexus {
+	if !isLineupLoaded {
 		err := arena.assignTeam(match.Red1, "R1")
 		if err != nil {
 			return err
@@ -411,7 +483,24 @@ func (arena *Arena) SubstituteTeams(red1, red2, red3, blue1, blue2, blue3 int) e
 
 // Starts the match if all conditions are met.
 func (arena *Arena) StartMatch() error {
-	err := arena.checkCanStartMatch()
+	return arena.startMatch(false)
+}
+
+// Starts the match despite any failing readiness checks that the start match policy allows to be overridden, logging
+// the override.
+func (arena *Arena) StartMatchWithOverride() error {
+	return arena.startMatch(true)
+}
+
+func (arena *Arena) startMatch(override bool) error {
+	arena.abortChannelScan("a match is starting")
+	readiness := arena.GetMatchReadiness()
+	err := arena.checkCanStartMatchWithReadiness(readiness, override)
+	if err == nil && override && arena.EventSettings.StartMatchPolicy == model.OverridableStartMatchPolicy {
+		if overriddenChecks := readiness.getFailingOptionalChecks(); len(overriddenChecks) > 0 {
+			err = arena.logMatchStartOverride(overriddenChecks)
+		}
+	}
 	if err == nil {
 		// Save the match start time to the database for posterity.
 		arena.CurrentMatch.StartedAt = time.Now()
@@ -421,13 +510,8 @@ func (arena *Arena) StartMatch() error {
 		arena.updateCycleTime(arena.CurrentMatch.StartedAt)
 
 		// Save the missed packet count to subtract it from the running count.
+		arena.startTeamMatchLogs()
 		for _, allianceStation := range arena.AllianceStations {
-			if allianceStation.DsConn != nil {
-				err = allianceStation.DsConn.signalMatchStart(arena.CurrentMatch, &allianceStation.WifiStatus)
-				if err != nil {
-					log.Println(err)
-				}
-			}
 
 			// Save the teams that have successfully connected to the field.
 			if allianceStation.Team != nil && !allianceStation.Team.HasConnected && allianceStation.DsConn != nil &&
@@ -556,6 +640,7 @@ func (arena *Arena) Update() {
 		arena.AllianceStationDisplayMode = "match"
 		arena.AllianceStationDisplayModeNotifier.Notify()
 		go arena.BlackmagicClient.StartRecording()
+		arena.startTeamWifiRecords()
 		if game.MatchTiming.WarmupDurationSec > 0 {
 			arena.MatchState = WarmupPeriod
 			enabled = false
@@ -652,8 +737,14 @@ func (arena *Arena) Update() {
 			log.Printf("Warning: Long time since last driver station packet: %dms", msSinceLastDsPacket)
 		}
 		arena.sendDsPacket(auto, enabled)
+		arena.sampleTeamWifiRecords()
 		arena.ArenaStatusNotifier.Notify()
 	}
+	arena.handleNetworkDiagnostics()
+	if arena.MatchState == PostMatch && arena.lastMatchState != PostMatch {
+		arena.saveTeamMatchLogs()
+		arena.saveTeamWifiRecords()
+	}
 
 	arena.handleSounds(matchTimeSec)
 
@@ -669,15 +760,15 @@ func (arena *Arena) Update() {
 
 // Loops indefinitely to track and update the arena components.
 func (arena *Arena) Run() {
-	// Start other loops in goroutines.
-	go arena.listenForDriverStations()
-	go arena.listenForDsUdpPackets()
-	go arena.accessPoint.Run()
-	go arena.Plc.Run()
+	if !arena.IsStandby() {
+		arena.startHardwareLoops()
+	}
 
 	for {
 		loopStartTime := time.Now()
+		arena.RLockState()
 		arena.Update()
+		arena.RUnlockState()
 		if time.Since(arena.lastPeriodicTaskTime).Seconds() >= periodicTaskPeriodSec {
 			arena.lastPeriodicTaskTime = time.Now()
 			go arena.runPeriodicTasks()
@@ -690,6 +781,51 @@ func (arena *Arena) Run() {
 	}
 }
 
+// Starts the loops that communicate with the driver stations and field hardware in goroutines, if they haven't already
+// been started.
+func (arena *Arena) startHardwareLoops() {
+	arena.hardwareLoopsOnce.Do(func() {
+		// Driver stations always connect to the same address, so the first field accepts them on behalf of all fields.
+		if arena.IsPrimaryField() {
+			go arena.listenForDriverStations()
+			go arena.listenForDsUdpPackets()
+		}
+		go arena.accessPoint.Run()
+		go arena.runNetworkDiagnostics()
+		go arena.Plc.Run()
+	})
+}
+
+// Returns true while the server is a read-only standby replicating the database of a primary, in which case the field
+// hardware is left to the primary until the standby is promoted.
+func (arena *Arena) IsStandby() bool {
+	return arena.standby.Load()
+}
+
+// Puts the arena into standby mode, in which it serves read-only displays of a database replicated from a primary
+// server and leaves the field hardware alone. Must be called before Run.
+func (arena *Arena) EnterStandby() error {
+	arena.standby.Store(true)
+	arena.disconnectTeamSigns()
+	return arena.LoadSettings()
+}
+
+// Takes the arena out of standby mode so that it can run matches in place of the primary, connecting to the field
+// hardware configured in the replicated settings.
+func (arena *Arena) Promote() error {
+	arena.LockState()
+	defer arena.UnlockState()
+	if !arena.IsStandby() {
+		return fmt.Errorf("field %d is not a standby", arena.FieldNumber)
+	}
+	arena.standby.Store(false)
+	if err := arena.LoadSettings(); err != nil {
+		return err
+	}
+	arena.startHardwareLoops()
+	return nil
+}
+
 // Calculates the red alliance score summary for the given realtime snapshot.
 func (arena *Arena) RedScoreSummary() *game.ScoreSummary {
 	return arena.RedRealtimeScore.CurrentScore.Summarize(&arena.BlueRealtimeScore.CurrentScore)
@@ -757,13 +893,14 @@ func (arena *Arena) assignTeam(teamId int, station string) error {
 	return nil
 }
 
-// Returns the next match of the same type that is currently loaded, or nil if there are no more matches.
+// Returns the next match on this field of the same type that is currently loaded, or nil if there are no more
+// matches.
 func (arena *Arena) getNextMatch(excludeCurrent bool) (*model.Match, error) {
 	if arena.CurrentMatch.Type == model.Test {
 		return nil, nil
 	}
 
-	matches, err := arena.Database.GetMatchesByType(arena.CurrentMatch.Type, false)
+	matches, err := arena.GetFieldMatchesByType(arena.CurrentMatch.Type)
 	if err != nil {
 		return nil, err
 	}
@@ -793,6 +930,7 @@ func (arena *Arena) preLoadNextMatch() {
 	}
 
 	teamIds := [6]int{nextMatch.Red1, nextMatch.Red2, nextMatch.Red3, nextMatch.Blue1, nextMatch.Blue2, nextMatch.Blue3}
+	isLineupLoaded := false
 	if nextMatch.ShouldAllowNexusSubstitution() && arena.EventSettings.NexusEnabled {
 		// Attempt to get the match lineup from Nexus for FRC.
 		lineup, err := arena.NexusClient.GetLineup(nextMatch.TbaMatchKey)
@@ -800,6 +938,15 @@ func (arena *Arena) preLoadNextMatch() {
 			log.Printf("Failed to load lineup from Nexus: %s", err.Error())
 		} else {
 			teamIds = *lineup
+			isLineupLoaded = true
+		}
+	}
+	if !isLineupLoaded {
+		lineup, err := arena.getSubmittedLineup(nextMatch)
+		if err != nil {
+			log.Printf("Failed to get submitted lineup while pre-loading next match: %s", err.Error())
+		} else if lineup != nil {
+			teamIds = *lineup
 		}
 	}
 
@@ -843,44 +990,23 @@ func (arena *Arena) setupNetwork(teams [6]*model.Team, isPreload bool) {
 
 // Returns nil if the match can be started, and an error otherwise.
 func (arena *Arena) checkCanStartMatch() error {
+	return arena.checkCanStartMatchWithReadiness(arena.GetMatchReadiness(), false)
+}
+
+// Returns nil if the match can be started given the readiness report, and an error otherwise.
+func (arena *Arena) checkCanStartMatchWithReadiness(readiness *MatchReadiness, override bool) error {
 	if arena.MatchState != PreMatch {
 		return fmt.Errorf("cannot start match while there is a match still in progress or with results pending")
 	}
-
-	err := arena.checkAllianceStationsReady("R1", "R2", "R3", "B1", "B2", "B3")
-	if err != nil {
-		return err
-	}
-
-	if arena.Plc.IsEnabled() {
-		if !arena.Plc.IsHealthy() {
-			return fmt.Errorf("cannot start match while PLC is not healthy")
-		}
-		if arena.Plc.GetFieldEStop() {
-			return fmt.Errorf("cannot start match while field emergency stop is active")
-		}
-		for name, status := range arena.Plc.GetArmorBlockStatuses() {
-			if !status {
-				return fmt.Errorf("cannot start match while PLC ArmorBlock %q is not connected", name)
-			}
-		}
-	}
-
-	return nil
+	return readiness.checkCanStart(override)
 }
 
+// Returns nil if the given stations pass all their required readiness checks, and an error otherwise.
 func (arena *Arena) checkAllianceStationsReady(stations ...string) error {
 	for _, station := range stations {
-		allianceStation := arena.AllianceStations[station]
-		if allianceStation.EStop {
-			return fmt.Errorf("cannot start match while an emergency stop is active")
-		}
-		if !allianceStation.aStopReset {
-			return fmt.Errorf("cannot start match if an autonomous stop has not been reset since the previous match")
-		}
-		if !allianceStation.Bypass {
-			if allianceStation.DsConn == nil || !allianceStation.DsConn.RobotLinked {
-				return fmt.Errorf("cannot start match until all robots are connected or bypassed")
+		for _, check := range arena.getStationReadiness(station).Checks {
+			if !check.IsPassing && check.IsRequired {
+				return check.err
 			}
 		}
 	}
@@ -1128,6 +1254,10 @@ func (arena *Arena) alliancePostMatchScoreReady(alliance string) bool {
 
 // Performs any actions that need to run at the interval specified by periodicTaskPeriodSec.
 func (arena *Arena) runPeriodicTasks() {
+	arena.RLockState()
+	defer arena.RUnlockState()
 	arena.updateEarlyLateMessage()
 	arena.purgeDisconnectedDisplays()
+	arena.notifyDisplayDroppedMessages()
+	arena.scanChannelsIfIdle()
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/playoff"
 	"github.com/Team254/cheesy-arena/websocket"
+	"log"
 	"strconv"
 )
 
@@ -20,6 +21,7 @@ type ArenaNotifiers struct {
 	AudienceDisplayModeNotifier        *websocket.Notifier
 	DisplayConfigurationNotifier       *websocket.Notifier
 	EventStatusNotifier                *websocket.Notifier
+	InspectionNotifier                 *websocket.Notifier
 	LowerThirdNotifier                 *websocket.Notifier
 	MatchLoadNotifier                  *websocket.Notifier
 	MatchTimeNotifier                  *websocket.Notifier
@@ -29,6 +31,7 @@ type ArenaNotifiers struct {
 	ReloadDisplaysNotifier             *websocket.Notifier
 	ScorePostedNotifier                *websocket.Notifier
 	ScoringStatusNotifier              *websocket.Notifier
+	TeamSignsNotifier                  *websocket.Notifier
 }
 
 type MatchTimeMessage struct {
@@ -53,6 +56,7 @@ func (arena *Arena) configureNotifiers() {
 	arena.DisplayConfigurationNotifier = websocket.NewNotifier("displayConfiguration",
 		arena.generateDisplayConfigurationMessage)
 	arena.EventStatusNotifier = websocket.NewNotifier("eventStatus", arena.generateEventStatusMessage)
+	arena.InspectionNotifier = websocket.NewNotifier("inspection", arena.generateInspectionMessage)
 	arena.LowerThirdNotifier = websocket.NewNotifier("lowerThird", arena.generateLowerThirdMessage)
 	arena.MatchLoadNotifier = websocket.NewNotifier("matchLoad", arena.GenerateMatchLoadMessage)
 	arena.MatchTimeNotifier = websocket.NewNotifier("matchTime", arena.generateMatchTimeMessage)
@@ -62,6 +66,7 @@ func (arena *Arena) configureNotifiers() {
 	arena.ReloadDisplaysNotifier = websocket.NewNotifier("reload", nil)
 	arena.ScorePostedNotifier = websocket.NewNotifier("scorePosted", arena.GenerateScorePostedMessage)
 	arena.ScoringStatusNotifier = websocket.NewNotifier("scoringStatus", arena.generateScoringStatusMessage)
+	arena.TeamSignsNotifier = websocket.NewNotifier("teamSigns", arena.generateTeamSignsMessage)
 }
 
 func (arena *Arena) generateAllianceSelectionMessage() any {
@@ -83,11 +88,14 @@ func (arena *Arena) generateAllianceStationDisplayModeMessage() any {
 }
 
 func (arena *Arena) generateArenaStatusMessage() any {
+	readiness := arena.GetMatchReadiness()
 	return &struct {
 		MatchId          int
 		AllianceStations map[string]*AllianceStation
 		MatchState
 		CanStartMatch         bool
+		CanOverrideStartMatch bool
+		MatchReadiness        *MatchReadiness
 		AccessPointStatus     string
 		SwitchStatus          string
 		PlcIsHealthy          bool
@@ -97,7 +105,9 @@ func (arena *Arena) generateArenaStatusMessage() any {
 		arena.CurrentMatch.Id,
 		arena.AllianceStations,
 		arena.MatchState,
-		arena.checkCanStartMatch() == nil,
+		arena.checkCanStartMatchWithReadiness(readiness, false) == nil,
+		arena.MatchState == PreMatch && readiness.CanOverride,
+		readiness,
 		arena.accessPoint.Status,
 		arena.networkSwitch.Status,
 		arena.Plc.IsHealthy(),
@@ -116,13 +126,43 @@ func (arena *Arena) generateDisplayConfigurationMessage() any {
 	// serialized to JSON, outside the mutex lock.
 	displaysCopy := make(map[string]Display)
 	for displayId, display := range arena.Displays {
-		displaysCopy[displayId] = *display
+		displayCopy := *display
+		displayCopy.DroppedMessageCount = display.droppedMessages.Count()
+		displaysCopy[displayId] = displayCopy
 	}
 	return displaysCopy
 }
 
 func (arena *Arena) generateEventStatusMessage() any {
-	return arena.EventStatus
+	return arena.GetEventStatus()
+}
+
+// Generates the inspection status for the pit display, which is public and so only gets each team's pass/fail status
+// rather than the details of its inspections.
+func (arena *Arena) generateInspectionMessage() any {
+	type teamInspectionStatus struct {
+		TeamId      int
+		IsInspected bool
+		Passed      bool
+	}
+	message := struct {
+		NumPassed       int
+		NumFailed       int
+		NumNotInspected int
+		Teams           []teamInspectionStatus
+	}{Teams: []teamInspectionStatus{}}
+	summary, err := arena.GetInspectionSummary()
+	if err != nil {
+		log.Printf("Failed to get inspection summary: %s", err.Error())
+		return &message
+	}
+	message.NumPassed = summary.NumPassed
+	message.NumFailed = summary.NumFailed
+	message.NumNotInspected = summary.NumNotInspected
+	for _, team := range summary.Teams {
+		message.Teams = append(message.Teams, teamInspectionStatus{team.TeamId, team.IsInspected, team.Passed})
+	}
+	return &message
 }
 
 func (arena *Arena) generateLowerThirdMessage() any {
@@ -148,6 +188,7 @@ func (arena *Arena) GenerateMatchLoadMessage() any {
 	var matchup *playoff.Matchup
 	redOffFieldTeams := []*model.Team{}
 	blueOffFieldTeams := []*model.Team{}
+	var redTimeoutsRemaining, blueTimeoutsRemaining int
 	if arena.CurrentMatch.Type == model.Playoff {
 		matchGroup := arena.PlayoffTournament.MatchGroups()[arena.CurrentMatch.PlayoffMatchGroupId]
 		matchup, _ = matchGroup.(*playoff.Matchup)
@@ -162,6 +203,17 @@ func (arena *Arena) GenerateMatchLoadMessage() any {
 			blueOffFieldTeams = append(blueOffFieldTeams, team)
 			allTeamIds = append(allTeamIds, teamId)
 		}
+		if arena.CurrentMatch.PlayoffRedAlliance > 0 {
+			redTimeoutsRemaining, _ = arena.AllianceTimeoutsRemaining(arena.CurrentMatch.PlayoffRedAlliance)
+		}
+		if arena.CurrentMatch.PlayoffBlueAlliance > 0 {
+			blueTimeoutsRemaining, _ = arena.AllianceTimeoutsRemaining(arena.CurrentMatch.PlayoffBlueAlliance)
+		}
+	}
+
+	uninspectedTeamIds, err := arena.getUninspectedTeamIds()
+	if err != nil {
+		log.Printf("Failed to get uninspected teams: %s", err.Error())
 	}
 
 	rankings := make(map[string]int)
@@ -173,15 +225,18 @@ func (arena *Arena) GenerateMatchLoadMessage() any {
 	}
 
 	return &struct {
-		Match             *model.Match
-		AllowSubstitution bool
-		IsReplay          bool
-		Teams             map[string]*model.Team
-		Rankings          map[string]int
-		Matchup           *playoff.Matchup
-		RedOffFieldTeams  []*model.Team
-		BlueOffFieldTeams []*model.Team
-		BreakDescription  string
+		Match                 *model.Match
+		AllowSubstitution     bool
+		IsReplay              bool
+		Teams                 map[string]*model.Team
+		Rankings              map[string]int
+		Matchup               *playoff.Matchup
+		RedOffFieldTeams      []*model.Team
+		BlueOffFieldTeams     []*model.Team
+		RedTimeoutsRemaining  int
+		BlueTimeoutsRemaining int
+		BreakDescription      string
+		UninspectedTeamIds    []int
 	}{
 		arena.CurrentMatch,
 		arena.CurrentMatch.ShouldAllowSubstitution(),
@@ -191,7 +246,10 @@ func (arena *Arena) GenerateMatchLoadMessage() any {
 		matchup,
 		redOffFieldTeams,
 		blueOffFieldTeams,
+		redTimeoutsRemaining,
+		blueTimeoutsRemaining,
 		arena.breakDescription,
+		uninspectedTeamIds,
 	}
 }
 
@@ -326,6 +384,10 @@ func (arena *Arena) generateScoringStatusMessage() any {
 		arena.ScoringPanelRegistry.GetNumPanels("blue"), arena.ScoringPanelRegistry.GetNumScoreCommitted("blue")}
 }
 
+func (arena *Arena) generateTeamSignsMessage() any {
+	return arena.TeamSigns.SimulatedStates(arena.TeamSignSimulator)
+}
+
 // Constructs the data object for one alliance sent to the audience display for the realtime scoring overlay.
 func getAudienceAllianceScoreFields(allianceScore *RealtimeScore,
 	allianceScoreSummary *game.ScoreSummary) *audienceAllianceScoreFields {
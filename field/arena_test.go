@@ -1214,3 +1214,29 @@ func TestPlcMatchCycleGameSpecific(t *testing.T) {
 	assert.Equal(t, false, plc.speakerMotors)
 	assert.Equal(t, false, plc.postMatchSubwooferLights)
 }
+
+func TestArenaStandbyAndPromote(t *testing.T) {
+	teamSignSimulatorListenAddress = "127.0.0.1:0"
+	arena := setupTestArena(t)
+	defer arena.TeamSignSimulator.Close()
+	arena.EventSettings.PlcAddress = "10.0.100.40"
+	arena.EventSettings.TeamSignSimulatorEnabled = true
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.True(t, arena.Plc.IsEnabled())
+	assert.NotEqual(t, "", arena.TeamSignSimulator.LocalAddress())
+
+	// The standby should let go of the hardware while keeping the replicated settings.
+	assert.Nil(t, arena.EnterStandby())
+	assert.True(t, arena.IsStandby())
+	assert.False(t, arena.Plc.IsEnabled())
+	assert.Equal(t, "", arena.TeamSignSimulator.LocalAddress())
+	assert.Equal(t, "10.0.100.40", arena.EventSettings.PlcAddress)
+
+	DisableTestHardwareLoops(arena)
+	assert.Nil(t, arena.Promote())
+	assert.False(t, arena.IsStandby())
+	assert.True(t, arena.Plc.IsEnabled())
+	assert.NotEqual(t, "", arena.TeamSignSimulator.LocalAddress())
+	assert.EqualError(t, arena.Promote(), "field 1 is not a standby")
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Functions for periodically scanning the Wi-Fi spectrum between matches to recommend the least congested channel.
+
+package field
+
+import (
+	"context"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/network"
+	"log"
+	"time"
+)
+
+const channelScanPeriodMin = 5
+
+// Triggers a channel scan if no match is loaded or queued and enough time has elapsed since the last one.
+func (arena *Arena) scanChannelsIfIdle() {
+	arena.channelScanMutex.Lock()
+	lastChannelScanTime := arena.lastChannelScanTime
+	arena.channelScanMutex.Unlock()
+	if !arena.EventSettings.NetworkSecurityEnabled || !arena.channelScanIdle() ||
+		time.Since(lastChannelScanTime) < time.Minute*channelScanPeriodMin {
+		return
+	}
+	if err := arena.ScanAccessPointChannels(); err != nil {
+		log.Printf("Failed to scan access point channels: %v", err)
+	}
+}
+
+// Asks the access point to scan all channels and record their congestion. Returns an error if a match is in progress,
+// since the scan briefly takes the radio off-channel, or if another scan is already running. The scan is stopped if a
+// match is loaded or started before it completes.
+func (arena *Arena) ScanAccessPointChannels() error {
+	if !arena.channelScanAllowed() {
+		return fmt.Errorf("cannot scan access point channels while a match is in progress")
+	}
+
+	arena.channelScanMutex.Lock()
+	if arena.cancelChannelScan != nil {
+		arena.channelScanMutex.Unlock()
+		return fmt.Errorf("an access point channel scan is already in progress")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	arena.cancelChannelScan = cancel
+	arena.lastChannelScanTime = time.Now()
+	arena.channelScanMutex.Unlock()
+
+	samples, err := arena.accessPoint.ScanChannels(ctx)
+	if ctx.Err() != nil {
+		arena.channelScanMutex.Lock()
+		reason := arena.channelScanAbortReason
+		arena.channelScanMutex.Unlock()
+		return fmt.Errorf("access point channel scan was stopped because %s", reason)
+	}
+
+	arena.channelScanMutex.Lock()
+	arena.cancelChannelScan = nil
+	arena.channelScanMutex.Unlock()
+	cancel()
+	if err != nil {
+		return err
+	}
+	return arena.saveChannelScanSamples(samples)
+}
+
+// Stops any channel scan that is in progress so that the radio returns to its channel, giving the reason in the log.
+func (arena *Arena) abortChannelScan(reason string) {
+	arena.channelScanMutex.Lock()
+	cancelChannelScan := arena.cancelChannelScan
+	if cancelChannelScan != nil {
+		arena.channelScanAbortReason = reason
+		arena.cancelChannelScan = nil
+	}
+	arena.channelScanMutex.Unlock()
+	if cancelChannelScan == nil {
+		return
+	}
+
+	log.Printf("Stopping access point channel scan because %s.", reason)
+	cancelChannelScan()
+	// Abandoning the request doesn't stop the access point from scanning, so it needs to be told to stop as well.
+	if err := arena.accessPoint.CancelChannelScan(); err != nil {
+		log.Printf("Failed to stop access point channel scan: %v", err)
+	}
+}
+
+// Persists the given samples from the field's access point so that its scan history survives a restart, discarding the
+// oldest samples for each channel beyond what the access point keeps.
+func (arena *Arena) saveChannelScanSamples(samples []network.ChannelScanSample) error {
+	for _, sample := range samples {
+		err := arena.Database.CreateChannelScanSample(
+			&model.ChannelScanSample{
+				FieldNumber:        arena.FieldNumber,
+				Channel:            sample.Channel,
+				Time:               sample.Time,
+				UtilizationPercent: sample.UtilizationPercent,
+				NoiseDbm:           sample.NoiseDbm,
+				NetworkCount:       sample.NetworkCount,
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	savedSamples, err := arena.Database.GetChannelScanSamplesByField(arena.FieldNumber)
+	if err != nil {
+		return err
+	}
+	sampleCounts := make(map[int]int)
+	for _, sample := range savedSamples {
+		sampleCounts[sample.Channel]++
+	}
+	for _, sample := range savedSamples {
+		if sampleCounts[sample.Channel] > network.ChannelScanHistoryLength {
+			if err = arena.Database.DeleteChannelScanSample(sample.Id); err != nil {
+				return err
+			}
+			sampleCounts[sample.Channel]--
+		}
+	}
+	return nil
+}
+
+// Restores the field's access point scan history from the database.
+func (arena *Arena) loadChannelScanHistory() error {
+	savedSamples, err := arena.Database.GetChannelScanSamplesByField(arena.FieldNumber)
+	if err != nil {
+		return err
+	}
+	samples := make([]network.ChannelScanSample, len(savedSamples))
+	for i, sample := range savedSamples {
+		samples[i] = network.ChannelScanSample{
+			Time:               sample.Time,
+			Channel:            sample.Channel,
+			UtilizationPercent: sample.UtilizationPercent,
+			NoiseDbm:           sample.NoiseDbm,
+			NetworkCount:       sample.NetworkCount,
+		}
+	}
+	arena.accessPoint.SetChannelScanHistory(samples)
+	return nil
+}
+
+// Returns the allowed channel that the access point scans indicate is least congested, or nil if none of the allowed
+// channels have been scanned.
+func (arena *Arena) AccessPointChannelRecommendation() *network.ChannelRecommendation {
+	allowedChannels, err := network.ParseAllowedChannels(arena.EventSettings.ApAllowedChannels)
+	if err != nil {
+		log.Printf("Invalid allowed access point channels: %v", err)
+		return nil
+	}
+	return arena.accessPoint.RecommendChannel(allowedChannels)
+}
+
+// Returns the most recent scan sample for each channel, ordered by channel number.
+func (arena *Arena) AccessPointLatestChannelScans() []network.ChannelScanSample {
+	return arena.accessPoint.LatestChannelScans()
+}
+
+// Returns the most recent scan sample for the channel the access point is currently using, or nil if it has not been
+// scanned.
+func (arena *Arena) AccessPointChannelConditions() *network.ChannelScanSample {
+	return arena.accessPoint.CurrentChannelConditions()
+}
+
+// Returns true if the field is in a state where the Wi-Fi can be briefly disrupted without affecting a match.
+func (arena *Arena) channelScanAllowed() bool {
+	return arena.MatchState == PreMatch || arena.MatchState == TimeoutActive || arena.MatchState == PostTimeout
+}
+
+// Returns true if there is no match loaded or queued on the field, so that an automatic scan won't disrupt any teams
+// that are connecting for their next match.
+func (arena *Arena) channelScanIdle() bool {
+	if arena.MatchState != PreMatch || arena.CurrentMatch.Type != model.Test || arena.preloadedTeams != nil {
+		return false
+	}
+	for _, allianceStation := range arena.AllianceStations {
+		if allianceStation.Team != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/network"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArena_ScanAccessPointChannels(t *testing.T) {
+	arena := setupTestArena(t)
+
+	// Scanning is a no-op when network security is disabled.
+	assert.Nil(t, arena.ScanAccessPointChannels())
+	assert.Nil(t, arena.AccessPointChannelRecommendation())
+
+	arena.MatchState = TeleopPeriod
+	err := arena.ScanAccessPointChannels()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "while a match is in progress")
+	}
+
+	for _, matchState := range []MatchState{PreMatch, TimeoutActive, PostTimeout} {
+		arena.MatchState = matchState
+		assert.Nil(t, arena.ScanAccessPointChannels())
+	}
+}
+
+func TestArena_ChannelScanIdle(t *testing.T) {
+	arena := setupTestArena(t)
+	assert.True(t, arena.channelScanIdle())
+
+	// Shouldn't scan automatically while teams are assigned to a test match.
+	arena.AllianceStations["R2"].Team = &model.Team{Id: 254}
+	assert.False(t, arena.channelScanIdle())
+	arena.AllianceStations["R2"].Team = nil
+
+	// Shouldn't scan automatically while a real match is loaded.
+	arena.CurrentMatch = &model.Match{Type: model.Qualification}
+	assert.False(t, arena.channelScanIdle())
+	arena.CurrentMatch = &model.Match{Type: model.Test}
+
+	// Shouldn't scan automatically while the next match's teams have been queued on the access point.
+	arena.preloadedTeams = &[6]*model.Team{}
+	assert.False(t, arena.channelScanIdle())
+	arena.preloadedTeams = nil
+
+	arena.MatchState = TimeoutActive
+	assert.False(t, arena.channelScanIdle())
+}
+
+func TestArena_AbortChannelScan(t *testing.T) {
+	arena := setupTestArena(t)
+
+	// Aborting with no scan in progress should be a no-op.
+	arena.abortChannelScan("a match is being loaded")
+
+	// Mock an access point that scans until it is told to stop.
+	stopCount := 0
+	apServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/scan" && r.Method == "GET" {
+			<-r.Context().Done()
+		} else if r.URL.Path == "/scan" && r.Method == "DELETE" {
+			stopCount++
+		}
+	}))
+	defer apServer.Close()
+	arena.accessPoint.SetSettings(
+		strings.TrimPrefix(apServer.URL, "http://"), "", 36, true, [6]*network.TeamWifiStatus{},
+	)
+
+	scanErrors := make(chan error)
+	go func() {
+		scanErrors <- arena.ScanAccessPointChannels()
+	}()
+	assert.Eventually(
+		t,
+		func() bool {
+			arena.channelScanMutex.Lock()
+			defer arena.channelScanMutex.Unlock()
+			return arena.cancelChannelScan != nil
+		},
+		time.Second,
+		time.Millisecond,
+	)
+	err := arena.ScanAccessPointChannels()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "already in progress")
+	}
+
+	// Loading a match should stop the scan on the access point and give that as the reason.
+	assert.Nil(t, arena.LoadTestMatch())
+	if err = <-scanErrors; assert.NotNil(t, err) {
+		assert.Equal(t, "access point channel scan was stopped because a match is being loaded", err.Error())
+	}
+	assert.Equal(t, 1, stopCount)
+	assert.Nil(t, arena.cancelChannelScan)
+}
+
+func TestArena_ChannelScanPersistence(t *testing.T) {
+	arena := setupTestArena(t)
+
+	// Mock an access point that reports the same conditions on every scan.
+	apServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"channels": [{"channel": 36, "utilizationPercent": 60}, {"channel": 149, "noiseDbm": -90}]}`))
+	}))
+	defer apServer.Close()
+	arena.accessPoint.SetSettings(
+		strings.TrimPrefix(apServer.URL, "http://"), "", 36, true, [6]*network.TeamWifiStatus{},
+	)
+
+	// Should only keep as many samples for each channel as the access point does.
+	for i := 0; i < network.ChannelScanHistoryLength; i++ {
+		assert.Nil(
+			t,
+			arena.Database.CreateChannelScanSample(
+				&model.ChannelScanSample{FieldNumber: 1, Channel: 36, Time: time.Unix(int64(i), 0).UTC()},
+			),
+		)
+	}
+	assert.Nil(t, arena.Database.CreateChannelScanSample(&model.ChannelScanSample{FieldNumber: 2, Channel: 36}))
+	assert.Nil(t, arena.ScanAccessPointChannels())
+	samples, err := arena.Database.GetChannelScanSamplesByField(1)
+	assert.Nil(t, err)
+	if assert.Equal(t, network.ChannelScanHistoryLength+1, len(samples)) {
+		assert.Equal(t, time.Unix(1, 0).UTC(), samples[0].Time)
+	}
+	samples, err = arena.Database.GetChannelScanSamplesByField(2)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(samples))
+
+	// The scan history should be restored from the database when the settings are reloaded.
+	arena.accessPoint.SetChannelScanHistory(nil)
+	assert.Empty(t, arena.AccessPointLatestChannelScans())
+	assert.Nil(t, arena.LoadSettings())
+	latestScans := arena.AccessPointLatestChannelScans()
+	if assert.Equal(t, 2, len(latestScans)) {
+		assert.Equal(t, 60.0, latestScans[0].UtilizationPercent)
+		assert.Equal(t, -90, latestScans[1].NoiseDbm)
+	}
+	assert.Equal(t, network.ChannelScanHistoryLength, len(arena.accessPoint.GetChannelScanHistory()[36]))
+}
@@ -33,8 +33,10 @@ const (
 	BracketDisplay
 	FieldMonitorDisplay
 	LogoDisplay
+	PitDisplay
 	QueueingDisplay
 	RankingsDisplay
+	TeamSignsDisplay
 	TwitchStreamDisplay
 	WallDisplay
 	WebpageDisplay
@@ -48,8 +50,10 @@ var DisplayTypeNames = map[DisplayType]string{
 	BracketDisplay:         "Bracket",
 	FieldMonitorDisplay:    "Field Monitor",
 	LogoDisplay:            "Logo",
+	PitDisplay:             "Pit",
 	QueueingDisplay:        "Queueing",
 	RankingsDisplay:        "Rankings",
+	TeamSignsDisplay:       "Team Signs",
 	TwitchStreamDisplay:    "Twitch Stream",
 	WallDisplay:            "Wall",
 	WebpageDisplay:         "Web Page",
@@ -63,8 +67,10 @@ var displayTypePaths = map[DisplayType]string{
 	BracketDisplay:         "/displays/bracket",
 	FieldMonitorDisplay:    "/displays/field_monitor",
 	LogoDisplay:            "/displays/logo",
+	PitDisplay:             "/displays/pit",
 	QueueingDisplay:        "/displays/queueing",
 	RankingsDisplay:        "/displays/rankings",
+	TeamSignsDisplay:       "/displays/team_signs",
 	TwitchStreamDisplay:    "/displays/twitch",
 	WallDisplay:            "/displays/wall",
 	WebpageDisplay:         "/displays/webpage",
@@ -76,8 +82,11 @@ type Display struct {
 	DisplayConfiguration DisplayConfiguration
 	IpAddress            string
 	ConnectionCount      int
+	DroppedMessageCount  int64 // Only populated in the copies sent to the display configuration page.
 	Notifier             *websocket.Notifier
 	lastConnectedTime    time.Time
+	droppedMessages      *websocket.DropCounter // Persists across reconnections to identify displays that are lagging.
+	lastDroppedCount     int64
 }
 
 type DisplayConfiguration struct {
@@ -87,6 +96,11 @@ type DisplayConfiguration struct {
 	Configuration map[string]string
 }
 
+// Returns the counter to charge for notifier messages dropped on any of the display's websocket connections.
+func (display *Display) DropCounter() *websocket.DropCounter {
+	return display.droppedMessages
+}
+
 // Parses the given display URL path and query string to extract the configuration.
 func DisplayFromUrl(path string, query map[string][]string) (*DisplayConfiguration, error) {
 	if _, ok := query["displayId"]; !ok {
@@ -182,6 +196,7 @@ func (arena *Arena) RegisterDisplay(displayConfig *DisplayConfiguration, ipAddre
 	} else {
 		if !ok {
 			display = new(Display)
+			display.droppedMessages = new(websocket.DropCounter)
 			display.Notifier = websocket.NewNotifier("displayConfiguration",
 				display.generateDisplayConfigurationMessage)
 			arena.Displays[displayConfig.Id] = display
@@ -250,3 +265,21 @@ func (arena *Arena) purgeDisconnectedDisplays() {
 		arena.DisplayConfigurationNotifier.Notify()
 	}
 }
+
+// Sends an updated display list if any display has had notifier messages dropped since the last check, since the
+// counters are incremented without triggering a notification of their own.
+func (arena *Arena) notifyDisplayDroppedMessages() {
+	displayRegistryMutex.Lock()
+	defer displayRegistryMutex.Unlock()
+
+	changed := false
+	for _, display := range arena.Displays {
+		if count := display.droppedMessages.Count(); count != display.lastDroppedCount {
+			display.lastDroppedCount = count
+			changed = true
+		}
+	}
+	if changed {
+		arena.DisplayConfigurationNotifier.Notify()
+	}
+}
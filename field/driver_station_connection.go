@@ -91,11 +91,8 @@ func (arena *Arena) listenForDsUdpPackets() {
 		teamId := int(data[4])<<8 + int(data[5])
 
 		var dsConn *DriverStationConnection
-		for _, allianceStation := range arena.AllianceStations {
-			if allianceStation.Team != nil && allianceStation.Team.Id == teamId {
-				dsConn = allianceStation.DsConn
-				break
-			}
+		if fieldArena, station := arena.findAssignedAllianceStation(teamId); fieldArena != nil {
+			dsConn = fieldArena.AllianceStations[station].DsConn
 		}
 
 		if dsConn != nil {
@@ -135,9 +132,6 @@ func (dsConn *DriverStationConnection) update(arena *Arena) error {
 }
 
 func (dsConn *DriverStationConnection) close() {
-	if dsConn.log != nil {
-		dsConn.log.Close()
-	}
 	if dsConn.udpConn != nil {
 		dsConn.udpConn.Close()
 	}
@@ -146,13 +140,12 @@ func (dsConn *DriverStationConnection) close() {
 	}
 }
 
-// Called at the start of the match to allow for driver station initialization.
-func (dsConn *DriverStationConnection) signalMatchStart(match *model.Match, wifiStatus *network.TeamWifiStatus) error {
+// Called at the start of the match to allow for driver station initialization. The given log may be nil if the match
+// is not being logged.
+func (dsConn *DriverStationConnection) signalMatchStart(matchLog *TeamMatchLog) {
 	// Zero out missed packet count and begin logging.
 	dsConn.missedPacketOffset = dsConn.MissedPacketCount
-	var err error
-	dsConn.log, err = NewTeamMatchLog(dsConn.TeamId, match, wifiStatus)
-	return err
+	dsConn.log = matchLog
 }
 
 // Serializes the control information into a packet.
@@ -296,9 +289,9 @@ func (arena *Arena) listenForDriverStations() {
 		}
 		teamId := int(packet[3])<<8 + int(packet[4])
 
-		// Check to see if the team is supposed to be on the field, and notify the DS accordingly.
-		assignedStation := arena.getAssignedAllianceStation(teamId)
-		if assignedStation == "" {
+		// Check to see if the team is supposed to be on one of the fields, and notify the DS accordingly.
+		fieldArena, assignedStation := arena.findAssignedAllianceStation(teamId)
+		if fieldArena == nil {
 			log.Printf("Rejecting connection from Team %d, who is not in the current match, soon.", teamId)
 			go func() {
 				// Wait a second and then close it so it doesn't chew up bandwidth constantly trying to reconnect.
@@ -318,7 +311,7 @@ func (arena *Arena) listenForDriverStations() {
 		stationTeamId := teamDigit1*100 + teamDigit2
 		wrongAssignedStation := ""
 		if stationTeamId != teamId {
-			wrongAssignedStation = arena.getAssignedAllianceStation(stationTeamId)
+			wrongAssignedStation = fieldArena.getAssignedAllianceStation(stationTeamId)
 			if wrongAssignedStation != "" {
 				// The team is supposed to be in this match, but is plugged into the wrong station.
 				log.Printf("Team %d is in incorrect station %s.", teamId, wrongAssignedStation)
@@ -346,14 +339,14 @@ func (arena *Arena) listenForDriverStations() {
 			tcpConn.Close()
 			continue
 		}
-		arena.AllianceStations[assignedStation].DsConn = dsConn
+		fieldArena.AllianceStations[assignedStation].DsConn = dsConn
 
 		if wrongAssignedStation != "" {
 			dsConn.WrongStation = wrongAssignedStation
 		}
 
 		// Spin up a goroutine to handle further TCP communication with this driver station.
-		go dsConn.handleTcpConnection(arena)
+		go dsConn.handleTcpConnection(fieldArena)
 	}
 }
 
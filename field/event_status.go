@@ -17,12 +17,21 @@ const maxExpectedCycleTimeSec = 900
 type EventStatus struct {
 	CycleTime                   string
 	EarlyLateMessage            string
+	AgendaMessage               string
 	lastMatchStartTime          time.Time
 	lastMatchScheduledStartTime time.Time
 }
 
+// Returns a copy of the event status, which is safe to read outside of the arena loop.
+func (arena *Arena) GetEventStatus() EventStatus {
+	arena.eventStatusMutex.Lock()
+	defer arena.eventStatusMutex.Unlock()
+	return arena.EventStatus
+}
+
 // Calculates the last cycle time and publishes an update to the displays that show it.
 func (arena *Arena) updateCycleTime(matchStartTime time.Time) {
+	arena.eventStatusMutex.Lock()
 	expectedCycleTimeSec := arena.CurrentMatch.Time.Sub(arena.EventStatus.lastMatchScheduledStartTime).Seconds()
 	if arena.EventStatus.lastMatchStartTime.IsZero() || expectedCycleTimeSec > maxExpectedCycleTimeSec ||
 		arena.CurrentMatch.Type == model.Test {
@@ -53,20 +62,35 @@ func (arena *Arena) updateCycleTime(matchStartTime time.Time) {
 	}
 	arena.EventStatus.lastMatchStartTime = matchStartTime
 	arena.EventStatus.lastMatchScheduledStartTime = arena.CurrentMatch.Time
+	arena.eventStatusMutex.Unlock()
 	arena.EventStatusNotifier.Notify()
 }
 
-// Checks how early or late the event is running and publishes an update to the displays that show it.
+// Checks how early or late the event is running and what is up next on the agenda, and publishes an update to the
+// displays that show it. Called both from the arena loop and from web handlers that edit the agenda, so the status is
+// only modified while holding the lock.
 func (arena *Arena) updateEarlyLateMessage() {
 	newEarlyLateMessage := arena.getEarlyLateMessage()
-	if newEarlyLateMessage != arena.EventStatus.EarlyLateMessage {
-		arena.EventStatus.EarlyLateMessage = newEarlyLateMessage
+	newAgendaMessage := arena.getAgendaMessage()
+	arena.eventStatusMutex.Lock()
+	changed := newEarlyLateMessage != arena.EventStatus.EarlyLateMessage ||
+		newAgendaMessage != arena.EventStatus.AgendaMessage
+	arena.EventStatus.EarlyLateMessage = newEarlyLateMessage
+	arena.EventStatus.AgendaMessage = newAgendaMessage
+	arena.eventStatusMutex.Unlock()
+	if changed {
 		arena.EventStatusNotifier.Notify()
 	}
 }
 
 // Updates the string that indicates how early or late the event is running.
 func (arena *Arena) getEarlyLateMessage() string {
+	// While a segment of the agenda other than a block of matches is in progress, measure lateness against its plan.
+	inProgressSegment, _ := arena.getAgendaSegmentInProgress()
+	if inProgressSegment != nil && inProgressSegment.Type != model.MatchesSegment {
+		return formatEarlyLateMessage(inProgressSegment.MinutesLate(time.Now()))
+	}
+
 	currentMatch := arena.CurrentMatch
 	if currentMatch.Type == model.Test {
 		return ""
@@ -82,7 +106,7 @@ func (arena *Arena) getEarlyLateMessage() string {
 		minutesLate = currentMatch.StartedAt.Sub(currentMatch.Time).Minutes()
 	} else {
 		// We need to check the adjacent matches to accurately determine lateness.
-		matches, _ := arena.Database.GetMatchesByType(currentMatch.Type, false)
+		matches, _ := arena.GetFieldMatchesByType(currentMatch.Type)
 
 		previousMatchIndex := -1
 		nextMatchIndex := len(matches)
@@ -116,6 +140,11 @@ func (arena *Arena) getEarlyLateMessage() string {
 		}
 	}
 
+	return formatEarlyLateMessage(minutesLate)
+}
+
+// Returns the string that indicates how early or late the event is running, given its lateness in minutes.
+func formatEarlyLateMessage(minutesLate float64) string {
 	if minutesLate > earlyLateThresholdMin {
 		return fmt.Sprintf("Event is running %d minutes late", int(minutesLate))
 	} else if minutesLate < -earlyLateThresholdMin {
@@ -11,6 +11,7 @@ import (
 
 type FakePlc struct {
 	isEnabled                bool
+	isUnhealthy              bool
 	fieldEStop               bool
 	redEStops                [3]bool
 	blueEStops               [3]bool
@@ -44,7 +45,7 @@ func (plc *FakePlc) IsEnabled() bool {
 }
 
 func (plc *FakePlc) IsHealthy() bool {
-	return true
+	return !plc.isUnhealthy
 }
 
 func (plc *FakePlc) IoChangeNotifier() *websocket.Notifier {
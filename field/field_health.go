@@ -0,0 +1,27 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Logic for checking that the field's network and PLC hardware is ready to run matches.
+
+package field
+
+import "fmt"
+
+// Returns a description of each problem with the field hardware, or an empty slice if everything is healthy. The
+// network hardware is only checked when network security is enabled since it isn't configured otherwise.
+func (arena *Arena) GetFieldHardwareProblems() []string {
+	problems := []string{}
+	if arena.EventSettings.NetworkSecurityEnabled {
+		if arena.accessPoint.Status != "ACTIVE" {
+			problems = append(
+				problems, fmt.Sprintf("The access point isn't active (status %s).", arena.accessPoint.Status),
+			)
+		}
+		if arena.networkSwitch.Status != "ACTIVE" {
+			problems = append(problems, fmt.Sprintf("The switch isn't active (status %s).", arena.networkSwitch.Status))
+		}
+	}
+	if arena.Plc.IsEnabled() && !arena.Plc.IsHealthy() {
+		problems = append(problems, "The PLC isn't responding.")
+	}
+	return problems
+}
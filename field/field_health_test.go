@@ -0,0 +1,35 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetFieldHardwareProblems(t *testing.T) {
+	arena := setupTestArena(t)
+	var plc FakePlc
+	arena.Plc = &plc
+	assert.Empty(t, arena.GetFieldHardwareProblems())
+
+	arena.EventSettings.NetworkSecurityEnabled = true
+	arena.accessPoint.Status = "CONFIGURING"
+	arena.networkSwitch.Status = "ERROR"
+	plc.isEnabled = true
+	plc.isUnhealthy = true
+	assert.Equal(
+		t,
+		[]string{
+			"The access point isn't active (status CONFIGURING).",
+			"The switch isn't active (status ERROR).",
+			"The PLC isn't responding.",
+		},
+		arena.GetFieldHardwareProblems(),
+	)
+
+	arena.accessPoint.Status = "ACTIVE"
+	arena.networkSwitch.Status = "ACTIVE"
+	plc.isUnhealthy = false
+	assert.Empty(t, arena.GetFieldHardwareProblems())
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Functions for coordinating the arenas of a multi-field event, which share a database and schedule.
+
+package field
+
+import "github.com/Team254/cheesy-arena/model"
+
+// Links the given arenas, which must share a database and be given in order of field number, as the fields of a
+// single event.
+func LinkFields(arenas ...*Arena) {
+	for _, arena := range arenas {
+		arena.fields = arenas
+		arena.scoreCommitMutex = arenas[0].scoreCommitMutex
+		arena.stateMutex = arenas[0].stateMutex
+	}
+}
+
+// Returns the arenas of all the fields at the event in order of field number, including this one.
+func (arena *Arena) Fields() []*Arena {
+	if len(arena.fields) == 0 {
+		return []*Arena{arena}
+	}
+	return arena.fields
+}
+
+// Locks the mutex, shared by all the fields, that serializes the committing of match results and the recalculation of
+// the rankings and playoff bracket that depend on them.
+func (arena *Arena) LockScoreCommits() {
+	arena.scoreCommitMutex.Lock()
+}
+
+// Unlocks the mutex locked by LockScoreCommits.
+func (arena *Arena) UnlockScoreCommits() {
+	arena.scoreCommitMutex.Unlock()
+}
+
+// Takes the lock, shared by all the fields, that gives the caller exclusive access to their state, waiting for the
+// current iteration of each arena loop and any request holding the lock for reading to finish. Used to make changes
+// that would otherwise race with them, such as applying the changes replicated from a primary.
+func (arena *Arena) LockState() {
+	arena.stateMutex.Lock()
+}
+
+// Releases the lock taken by LockState.
+func (arena *Arena) UnlockState() {
+	arena.stateMutex.Unlock()
+}
+
+// Takes the lock shared by all the fields for reading, as each arena loop does for the duration of each iteration, so
+// that the state of the fields isn't changed through LockState in the meantime.
+func (arena *Arena) RLockState() {
+	arena.stateMutex.RLock()
+}
+
+// Releases the lock taken by RLockState.
+func (arena *Arena) RUnlockState() {
+	arena.stateMutex.RUnlock()
+}
+
+// Returns true if this arena is the first of the fields at the event, which owns the resources that are shared between
+// them, such as the driver station listeners.
+func (arena *Arena) IsPrimaryField() bool {
+	return arena.Fields()[0] == arena
+}
+
+// Returns true if the given match is to be played on this arena's field.
+func (arena *Arena) IsMatchOnField(match *model.Match) bool {
+	return match.AssignedField(len(arena.Fields())) == arena.FieldNumber
+}
+
+// Returns the arena and alliance station to which the given team is assigned across all fields, or nil if the team is
+// not in any of the currently loaded matches. A field waiting to start its match takes precedence in case the team is
+// still assigned to the finished match on another field.
+func (arena *Arena) findAssignedAllianceStation(teamId int) (*Arena, string) {
+	var assignedArena *Arena
+	var assignedStation string
+	for _, fieldArena := range arena.Fields() {
+		if station := fieldArena.getAssignedAllianceStation(teamId); station != "" {
+			if fieldArena.MatchState == PreMatch {
+				return fieldArena, station
+			}
+			if assignedArena == nil {
+				assignedArena, assignedStation = fieldArena, station
+			}
+		}
+	}
+	return assignedArena, assignedStation
+}
+
+// Returns the non-hidden matches of the given type that are to be played on this arena's field, in order.
+func (arena *Arena) GetFieldMatchesByType(matchType model.MatchType) ([]model.Match, error) {
+	matches, err := arena.Database.GetMatchesByType(matchType, false)
+	if err != nil {
+		return nil, err
+	}
+	var fieldMatches []model.Match
+	for _, match := range matches {
+		if arena.IsMatchOnField(&match) {
+			fieldMatches = append(fieldMatches, match)
+		}
+	}
+	return fieldMatches, nil
+}
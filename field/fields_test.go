@@ -0,0 +1,118 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// Sets up a second field sharing the database of the given arena and links the two.
+func setupTestSecondField(t *testing.T, arena *Arena) *Arena {
+	arena.EventSettings.NumFields = 2
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	arena2, err := NewFieldArena(arena.Database, 2)
+	assert.Nil(t, err)
+	LinkFields(arena, arena2)
+	return arena2
+}
+
+func TestFieldSettings(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.EventSettings.PlcAddress = "10.0.100.40"
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	arena2 := setupTestSecondField(t, arena)
+
+	if assert.Equal(t, 2, len(arena.Fields())) {
+		assert.Same(t, arena, arena.Fields()[0])
+		assert.Same(t, arena2, arena.Fields()[1])
+	}
+	assert.Same(t, arena, arena2.Fields()[0])
+	assert.Same(t, arena.scoreCommitMutex, arena2.scoreCommitMutex)
+	assert.True(t, arena.IsPrimaryField())
+	assert.False(t, arena2.IsPrimaryField())
+
+	// The second field should use its own PLC rather than that of the first.
+	assert.True(t, arena.Plc.IsEnabled())
+	assert.False(t, arena2.Plc.IsEnabled())
+	fieldSettings, err := arena.Database.GetFieldSettings(2)
+	assert.Nil(t, err)
+	fieldSettings.PlcAddress = "10.0.200.40"
+	assert.Nil(t, arena.Database.UpdateFieldSettings(fieldSettings))
+	assert.Nil(t, arena2.LoadSettings())
+	assert.True(t, arena2.Plc.IsEnabled())
+	assert.Equal(t, 2, arena2.EventSettings.NumFields)
+}
+
+func TestLoadNextMatchMultipleFields(t *testing.T) {
+	arena := setupTestArena(t)
+	arena2 := setupTestSecondField(t, arena)
+
+	for i := 1; i <= 4; i++ {
+		assert.Nil(t, arena.Database.CreateMatch(&model.Match{Type: model.Qualification, TypeOrder: i}))
+	}
+	match, _ := arena.Database.GetMatchByTypeOrder(model.Qualification, 4)
+	match.FieldNumber = 1
+	assert.Nil(t, arena.Database.UpdateMatch(match))
+
+	// Matches should alternate between the fields unless explicitly assigned.
+	matches, err := arena.GetFieldMatchesByType(model.Qualification)
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(matches)) {
+		assert.Equal(t, 1, matches[0].TypeOrder)
+		assert.Equal(t, 3, matches[1].TypeOrder)
+		assert.Equal(t, 4, matches[2].TypeOrder)
+	}
+	matches, err = arena2.GetFieldMatchesByType(model.Qualification)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(matches)) {
+		assert.Equal(t, 2, matches[0].TypeOrder)
+	}
+
+	match, _ = arena.Database.GetMatchByTypeOrder(model.Qualification, 1)
+	assert.Nil(t, arena.LoadMatch(match))
+	assert.Nil(t, arena2.LoadMatch(match))
+	assert.Nil(t, arena.LoadNextMatch(false))
+	assert.Equal(t, 1, arena.CurrentMatch.TypeOrder)
+	assert.Nil(t, arena2.LoadNextMatch(false))
+	assert.Equal(t, 2, arena2.CurrentMatch.TypeOrder)
+
+	arena.CurrentMatch.Status = game.RedWonMatch
+	assert.Nil(t, arena.Database.UpdateMatch(arena.CurrentMatch))
+	assert.Nil(t, arena.LoadNextMatch(false))
+	assert.Equal(t, 3, arena.CurrentMatch.TypeOrder)
+	arena2.CurrentMatch.Status = game.BlueWonMatch
+	assert.Nil(t, arena.Database.UpdateMatch(arena2.CurrentMatch))
+	assert.Nil(t, arena2.LoadNextMatch(false))
+	assert.Equal(t, model.Test, arena2.CurrentMatch.Type)
+}
+
+func TestFindAssignedAllianceStationMultipleFields(t *testing.T) {
+	arena := setupTestArena(t)
+	arena2 := setupTestSecondField(t, arena)
+	for _, teamId := range []int{254, 1114, 1678} {
+		assert.Nil(t, arena.Database.CreateTeam(&model.Team{Id: teamId}))
+	}
+
+	assert.Nil(t, arena.SubstituteTeams(254, 0, 0, 0, 0, 1114))
+	assert.Nil(t, arena2.SubstituteTeams(0, 0, 0, 0, 1678, 0))
+	fieldArena, station := arena2.findAssignedAllianceStation(254)
+	assert.Same(t, arena, fieldArena)
+	assert.Equal(t, "R1", station)
+	fieldArena, station = arena.findAssignedAllianceStation(1678)
+	assert.Same(t, arena2, fieldArena)
+	assert.Equal(t, "B2", station)
+	fieldArena, station = arena.findAssignedAllianceStation(148)
+	assert.Nil(t, fieldArena)
+	assert.Equal(t, "", station)
+
+	// A field waiting to start its match should take precedence over one whose match has finished.
+	assert.Nil(t, arena2.SubstituteTeams(0, 0, 0, 0, 1678, 1114))
+	arena.MatchState = PostMatch
+	fieldArena, station = arena.findAssignedAllianceStation(1114)
+	assert.Same(t, arena2, fieldArena)
+	assert.Equal(t, "B3", station)
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Functions for detecting robot problems in the driver station packets logged during a match.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/network"
+)
+
+const (
+	brownoutVoltageThreshold = 6.8
+	tripTimeSpikeThresholdMs = 50
+)
+
+// Scans the given packet rows for brownouts, comms drops while enabled, trip time spikes, and E-stop/A-stop events.
+func AnalyzeTeamMatchLog(rows []model.TeamMatchLogRow) model.TeamMatchLogAnalysis {
+	analysis := model.TeamMatchLogAnalysis{ChannelUtilizationPercent: -1, ChannelNoiseDbm: -1}
+	var inBrownout, inCommsDrop, inTripTimeSpike bool
+	for i, row := range rows {
+		intervalSec := 0.0
+		if i > 0 {
+			intervalSec = row.MatchTimeSec - rows[i-1].MatchTimeSec
+		}
+
+		// Battery voltage is only meaningful while the DS can talk to the robot.
+		if row.RobotLinked {
+			if analysis.MinBatteryVoltage == 0 || row.BatteryVoltage < analysis.MinBatteryVoltage {
+				analysis.MinBatteryVoltage = row.BatteryVoltage
+			}
+			brownout := row.BatteryVoltage > 0 && row.BatteryVoltage < brownoutVoltageThreshold
+			if brownout {
+				if !inBrownout {
+					analysis.BrownoutCount++
+				}
+				analysis.BrownoutSec += intervalSec
+			}
+			inBrownout = brownout
+		}
+
+		commsDrop := row.Enabled && !row.RobotLinked
+		if commsDrop {
+			if !inCommsDrop {
+				analysis.CommsDropCount++
+			}
+			analysis.CommsDropSec += intervalSec
+		}
+		inCommsDrop = commsDrop
+
+		if row.DsRobotTripTimeMs > analysis.MaxTripTimeMs {
+			analysis.MaxTripTimeMs = row.DsRobotTripTimeMs
+		}
+		tripTimeSpike := row.RobotLinked && row.DsRobotTripTimeMs >= tripTimeSpikeThresholdMs
+		if tripTimeSpike && !inTripTimeSpike {
+			analysis.TripTimeSpikeCount++
+		}
+		inTripTimeSpike = tripTimeSpike
+
+		if row.EmergencyStop && !analysis.EmergencyStopped {
+			analysis.EmergencyStopped = true
+			analysis.EmergencyStopTimeSec = row.MatchTimeSec
+		}
+		if row.AutonomousStop && !analysis.AutonomousStopped {
+			analysis.AutonomousStopped = true
+			analysis.AutonomousStopTimeSec = row.MatchTimeSec
+		}
+
+		if row.ChannelUtilizationPercent >= 0 {
+			analysis.ChannelUtilizationPercent = row.ChannelUtilizationPercent
+			analysis.ChannelNoiseDbm = row.ChannelNoiseDbm
+		}
+	}
+	if analysis.ChannelUtilizationPercent >= 0 {
+		analysis.ChannelCongested = network.ChannelIsCongested(
+			analysis.ChannelUtilizationPercent, analysis.ChannelNoiseDbm,
+		)
+	}
+	return analysis
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAnalyzeTeamMatchLog(t *testing.T) {
+	analysis := AnalyzeTeamMatchLog(nil)
+	assert.False(t, analysis.HasProblems())
+	assert.Equal(t, -1.0, analysis.ChannelUtilizationPercent)
+
+	linkedRow := func(matchTimeSec, batteryVoltage float64, tripTimeMs int) model.TeamMatchLogRow {
+		return model.TeamMatchLogRow{
+			MatchTimeSec: matchTimeSec, DsLinked: true, RadioLinked: true, RioLinked: true, RobotLinked: true,
+			Enabled: true, BatteryVoltage: batteryVoltage, DsRobotTripTimeMs: tripTimeMs,
+			ChannelUtilizationPercent: 65, ChannelNoiseDbm: -92,
+		}
+	}
+	rows := []model.TeamMatchLogRow{
+		linkedRow(0.5, 12.4, 5),
+		linkedRow(1.0, 6.5, 6),
+		linkedRow(1.5, 6.2, 7),
+		linkedRow(2.0, 11.9, 80),
+		linkedRow(2.5, 12.0, 90),
+		linkedRow(3.0, 12.0, 6),
+		{MatchTimeSec: 3.5, DsLinked: true, Enabled: true, ChannelUtilizationPercent: 65, ChannelNoiseDbm: -92},
+		{MatchTimeSec: 4.0, DsLinked: true, Enabled: true, ChannelUtilizationPercent: 65, ChannelNoiseDbm: -92},
+		linkedRow(4.5, 6.0, 5),
+		linkedRow(5.0, 12.1, 60),
+	}
+	rows[5].AutonomousStop = true
+	rows[9].EmergencyStop = true
+
+	analysis = AnalyzeTeamMatchLog(rows)
+	assert.Equal(t, 6.0, analysis.MinBatteryVoltage)
+	assert.Equal(t, 2, analysis.BrownoutCount)
+	assert.Equal(t, 1.5, analysis.BrownoutSec)
+	assert.Equal(t, 1, analysis.CommsDropCount)
+	assert.Equal(t, 1.0, analysis.CommsDropSec)
+	assert.Equal(t, 90, analysis.MaxTripTimeMs)
+	assert.Equal(t, 2, analysis.TripTimeSpikeCount)
+	assert.True(t, analysis.EmergencyStopped)
+	assert.Equal(t, 5.0, analysis.EmergencyStopTimeSec)
+	assert.True(t, analysis.AutonomousStopped)
+	assert.Equal(t, 3.0, analysis.AutonomousStopTimeSec)
+	assert.Equal(t, 65.0, analysis.ChannelUtilizationPercent)
+	assert.Equal(t, -92, analysis.ChannelNoiseDbm)
+	assert.True(t, analysis.ChannelCongested)
+
+	// A stop that is already active when the match starts should still be detected.
+	analysis = AnalyzeTeamMatchLog([]model.TeamMatchLogRow{{MatchTimeSec: 0, EmergencyStop: true}})
+	assert.True(t, analysis.EmergencyStopped)
+	assert.Equal(t, 0.0, analysis.EmergencyStopTimeSec)
+	assert.Contains(t, analysis.Problems(), "E-stopped")
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Functions for importing the CSV match logs written by earlier versions into the database.
+
+package field
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Subdirectory of the logs directory to which CSV files are moved once imported, so that they aren't imported again.
+const importedMatchLogsDir = "imported"
+
+// Matches the filenames of CSV match logs, e.g. "20240315103000_Qualification_Match_Q12_254.csv".
+var matchLogCsvFilenameRe = regexp.MustCompile(`^(\d{14})_([A-Za-z]+)_Match_(.+)_(\d+)\.csv$`)
+
+// Imports each CSV match log in the given directory into the database and moves it into the "imported" subdirectory.
+// Logs for test matches or for matches that no longer exist are left in place. Returns the number of logs imported.
+func ImportMatchLogCsvs(database *model.Database, logsDir string) (int, error) {
+	filenames, err := filepath.Glob(filepath.Join(logsDir, "*.csv"))
+	if err != nil {
+		return 0, err
+	}
+	if len(filenames) == 0 {
+		return 0, nil
+	}
+	if err = os.MkdirAll(filepath.Join(logsDir, importedMatchLogsDir), 0755); err != nil {
+		return 0, err
+	}
+
+	matchesByKey := make(map[string]*model.Match)
+	for _, matchType := range []model.MatchType{model.Practice, model.Qualification, model.Playoff} {
+		matches, err := database.GetMatchesByType(matchType, true)
+		if err != nil {
+			return 0, err
+		}
+		for i := range matches {
+			matchesByKey[fmt.Sprintf("%s_%s", matchType.String(), matches[i].ShortName)] = &matches[i]
+		}
+	}
+
+	numImported := 0
+	for _, filename := range filenames {
+		match := matchLogCsvFilenameRe.FindStringSubmatch(filepath.Base(filename))
+		if match == nil {
+			continue
+		}
+		startedAt, err := time.ParseInLocation("20060102150405", match[1], time.Local)
+		if err != nil {
+			continue
+		}
+		teamId, _ := strconv.Atoi(match[4])
+		matchRecord, ok := matchesByKey[fmt.Sprintf("%s_%s", match[2], match[3])]
+		if !ok {
+			log.Printf("Skipping import of match log %s since its match doesn't exist.", filename)
+			continue
+		}
+
+		allianceStation, rows, err := readMatchLogCsv(filename)
+		if err != nil {
+			return numImported, fmt.Errorf("failed to read match log %s: %v", filename, err)
+		}
+		matchLog := model.TeamMatchLog{
+			MatchId:         matchRecord.Id,
+			MatchType:       matchRecord.Type,
+			MatchShortName:  matchRecord.ShortName,
+			TeamId:          teamId,
+			AllianceStation: allianceStation,
+			StartedAt:       startedAt,
+			Analysis:        AnalyzeTeamMatchLog(rows),
+		}
+		if err = database.CreateTeamMatchLog(&matchLog, rows); err != nil {
+			return numImported, err
+		}
+		if err = os.Rename(
+			filename, filepath.Join(logsDir, importedMatchLogsDir, filepath.Base(filename)),
+		); err != nil {
+			return numImported, err
+		}
+		numImported++
+	}
+	return numImported, nil
+}
+
+// Parses the packet rows and alliance station from the given CSV match log. Logs written before the Wi-Fi and channel
+// columns were added have those fields set to -1.
+func readMatchLogCsv(filename string) (string, []model.TeamMatchLogRow, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return "", nil, err
+	}
+	if len(records) == 0 {
+		return "", nil, nil
+	}
+
+	headerMap := make(map[string]int)
+	for i, column := range records[0] {
+		headerMap[column] = i
+	}
+	getField := func(record []string, column string) string {
+		if index, ok := headerMap[column]; ok && index < len(record) {
+			return record[index]
+		}
+		return ""
+	}
+
+	allianceStation := ""
+	var rows []model.TeamMatchLogRow
+	for _, record := range records[1:] {
+		var row model.TeamMatchLogRow
+		row.MatchTimeSec, _ = strconv.ParseFloat(getField(record, "matchTimeSec"), 64)
+		row.PacketType, _ = strconv.Atoi(getField(record, "packetType"))
+		row.DsLinked, _ = strconv.ParseBool(getField(record, "dsLinked"))
+		row.RadioLinked, _ = strconv.ParseBool(getField(record, "radioLinked"))
+		row.RioLinked, _ = strconv.ParseBool(getField(record, "rioLinked"))
+		row.RobotLinked, _ = strconv.ParseBool(getField(record, "robotLinked"))
+		row.Auto, _ = strconv.ParseBool(getField(record, "auto"))
+		row.Enabled, _ = strconv.ParseBool(getField(record, "enabled"))
+		row.EmergencyStop, _ = strconv.ParseBool(getField(record, "emergencyStop"))
+		row.AutonomousStop, _ = strconv.ParseBool(getField(record, "autonomousStop"))
+		row.BatteryVoltage, _ = strconv.ParseFloat(getField(record, "batteryVoltage"), 64)
+		row.MissedPacketCount, _ = strconv.Atoi(getField(record, "missedPacketCount"))
+		row.DsRobotTripTimeMs, _ = strconv.Atoi(getField(record, "dsRobotTripTimeMs"))
+		if _, ok := headerMap["signalNoiseRatio"]; ok {
+			row.RxRate, _ = strconv.ParseFloat(getField(record, "rxRate"), 64)
+			row.TxRate, _ = strconv.ParseFloat(getField(record, "txRate"), 64)
+			row.SignalNoiseRatio, _ = strconv.Atoi(getField(record, "signalNoiseRatio"))
+		} else {
+			row.RxRate, row.TxRate, row.SignalNoiseRatio = -1, -1, -1
+		}
+		row.ChannelUtilizationPercent, row.ChannelNoiseDbm = -1, -1
+		if utilization := getField(record, "channelUtilizationPercent"); utilization != "" {
+			row.ChannelUtilizationPercent, _ = strconv.ParseFloat(utilization, 64)
+			row.ChannelNoiseDbm, _ = strconv.Atoi(getField(record, "channelNoiseDbm"))
+		}
+		if allianceStation == "" {
+			allianceStation = getField(record, "allianceStation")
+		}
+		rows = append(rows, row)
+	}
+	return allianceStation, rows, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestImportMatchLogCsvs(t *testing.T) {
+	arena := setupTestArena(t)
+	match := model.Match{Type: model.Qualification, ShortName: "Q12", Red2: 254}
+	assert.Nil(t, arena.Database.CreateMatch(&match))
+
+	logsDir := t.TempDir()
+	writeLog := func(filename, contents string) {
+		assert.Nil(t, os.WriteFile(filepath.Join(logsDir, filename), []byte(contents), 0644))
+	}
+	writeLog(
+		"20240315103000_Qualification_Match_Q12_254.csv",
+		"matchTimeSec,packetType,teamId,allianceStation,dsLinked,radioLinked,rioLinked,robotLinked,auto,enabled,"+
+			"emergencyStop,autonomousStop,batteryVoltage,missedPacketCount,dsRobotTripTimeMs,rxRate,txRate,"+
+			"signalNoiseRatio\n"+
+			"0.500000,22,254,R2,true,true,true,true,true,true,false,false,12.400000,0,5,43.300000,86.600000,41\n"+
+			"1.000000,22,254,R2,true,true,true,false,true,true,false,false,0.000000,3,0,0.000000,0.000000,0\n",
+	)
+	writeLog(
+		"20240315090000_Test_Match_T_254.csv",
+		"matchTimeSec,packetType,teamId,allianceStation\n0.500000,22,254,R1\n",
+	)
+	writeLog(
+		"20240315110000_Qualification_Match_Q13_1114.csv",
+		"matchTimeSec,packetType,teamId,allianceStation\n0.500000,22,1114,B1\n",
+	)
+
+	numImported, err := ImportMatchLogCsvs(arena.Database, logsDir)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numImported)
+	matchLogs, err := arena.Database.GetTeamMatchLogsByMatch(match.Id)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(matchLogs)) {
+		assert.Equal(t, 254, matchLogs[0].TeamId)
+		assert.Equal(t, "R2", matchLogs[0].AllianceStation)
+		assert.Equal(t, "Q12", matchLogs[0].MatchShortName)
+		assert.Equal(t, time.Date(2024, 3, 15, 10, 30, 0, 0, time.Local), matchLogs[0].StartedAt.Local())
+		assert.Equal(t, 1, matchLogs[0].Analysis.CommsDropCount)
+		rows, err := arena.Database.GetTeamMatchLogRows(matchLogs[0].Id)
+		assert.Nil(t, err)
+		if assert.Equal(t, 2, len(rows)) {
+			assert.Equal(t, 12.4, rows[0].BatteryVoltage)
+			assert.Equal(t, 41, rows[0].SignalNoiseRatio)
+			assert.Equal(t, -1.0, rows[0].ChannelUtilizationPercent)
+		}
+	}
+
+	// Imported logs should be moved aside, and the others left in place.
+	assert.FileExists(t, filepath.Join(logsDir, "imported", "20240315103000_Qualification_Match_Q12_254.csv"))
+	assert.NoFileExists(t, filepath.Join(logsDir, "20240315103000_Qualification_Match_Q12_254.csv"))
+	assert.FileExists(t, filepath.Join(logsDir, "20240315090000_Test_Match_T_254.csv"))
+	assert.FileExists(t, filepath.Join(logsDir, "20240315110000_Qualification_Match_Q13_1114.csv"))
+
+	// Running the import again shouldn't duplicate any logs.
+	numImported, err = ImportMatchLogCsvs(arena.Database, logsDir)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, numImported)
+	matchLogs, _ = arena.Database.GetTeamMatchLogsByMatch(match.Id)
+	assert.Equal(t, 1, len(matchLogs))
+}
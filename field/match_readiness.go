@@ -0,0 +1,294 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Logic for reporting whether each alliance station and the field as a whole are ready for the match to start.
+
+package field
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Represents a single condition that is checked before the match is started.
+type ReadinessCheck struct {
+	Name      string
+	IsPassing bool
+	// Required checks always block the match from starting; the rest are subject to the event's start match policy.
+	IsRequired bool
+	Detail     string
+	err        error
+}
+
+// Represents the readiness checks for a single alliance station.
+type StationReadiness struct {
+	Station string
+	TeamId  int
+	Bypass  bool
+	Checks  []ReadinessCheck
+}
+
+// Represents the readiness of every alliance station and of the field to start the match.
+type MatchReadiness struct {
+	Stations    []StationReadiness
+	FieldChecks []ReadinessCheck
+	CanStart    bool
+	CanOverride bool
+	policy      model.StartMatchPolicy
+}
+
+// Pairs a readiness check with a label that identifies which station, if any, it applies to.
+type labeledReadinessCheck struct {
+	label string
+	ReadinessCheck
+}
+
+// Returns the readiness report for the current state of the field and alliance stations.
+func (arena *Arena) GetMatchReadiness() *MatchReadiness {
+	readiness := &MatchReadiness{policy: arena.EventSettings.StartMatchPolicy}
+	for _, station := range []string{"R1", "R2", "R3", "B1", "B2", "B3"} {
+		readiness.Stations = append(readiness.Stations, arena.getStationReadiness(station))
+	}
+	readiness.FieldChecks = arena.getFieldReadinessChecks()
+	readiness.CanStart = readiness.checkCanStart(false) == nil
+	readiness.CanOverride = !readiness.CanStart && readiness.checkCanStart(true) == nil
+	return readiness
+}
+
+// Returns the readiness checks for the given alliance station. Only the stop buttons are checked for a bypassed
+// station since there is no robot expected to be connected.
+func (arena *Arena) getStationReadiness(station string) StationReadiness {
+	allianceStation := arena.AllianceStations[station]
+	stationReadiness := StationReadiness{Station: station, Bypass: allianceStation.Bypass}
+	if allianceStation.Team != nil {
+		stationReadiness.TeamId = allianceStation.Team.Id
+	}
+	dsConn := allianceStation.DsConn
+
+	stationReadiness.Checks = append(
+		stationReadiness.Checks,
+		requiredReadinessCheck(
+			"E-Stop Clear",
+			!allianceStation.EStop,
+			"The emergency stop is pressed.",
+			"cannot start match while an emergency stop is active",
+		),
+		requiredReadinessCheck(
+			"A-Stop Reset",
+			allianceStation.aStopReset,
+			"The autonomous stop hasn't been reset since the previous match.",
+			"cannot start match if an autonomous stop has not been reset since the previous match",
+		),
+	)
+	if allianceStation.Bypass {
+		return stationReadiness
+	}
+
+	stationReadiness.Checks = append(
+		stationReadiness.Checks,
+		optionalReadinessCheck("DS Linked", dsConn != nil && dsConn.DsLinked, "The driver station isn't connected."),
+		optionalReadinessCheck(
+			"Radio Linked", dsConn != nil && dsConn.RadioLinked, "The robot radio isn't connected.",
+		),
+		requiredReadinessCheck(
+			"Robot Linked",
+			dsConn != nil && dsConn.RobotLinked,
+			"The robot isn't connected.",
+			"cannot start match until all robots are connected or bypassed",
+		),
+	)
+	if arena.Plc.IsEnabled() {
+		stationReadiness.Checks = append(
+			stationReadiness.Checks,
+			optionalReadinessCheck(
+				"Ethernet Connected", allianceStation.Ethernet, "The driver station Ethernet isn't plugged in.",
+			),
+		)
+	}
+	correctStationCheck := optionalReadinessCheck(
+		"Correct Station", dsConn != nil && dsConn.WrongStation == "", "The driver station isn't connected.",
+	)
+	if dsConn != nil && dsConn.WrongStation != "" {
+		correctStationCheck.Detail = fmt.Sprintf("The driver station is plugged in at %s.", dsConn.WrongStation)
+	}
+	stationReadiness.Checks = append(stationReadiness.Checks, correctStationCheck)
+	if minVoltage := arena.EventSettings.MinRobotBatteryVoltage; minVoltage > 0 {
+		batteryCheck := optionalReadinessCheck(
+			"Battery Voltage",
+			dsConn != nil && dsConn.RobotLinked && dsConn.BatteryVoltage >= minVoltage,
+			"The robot isn't connected.",
+		)
+		if !batteryCheck.IsPassing && dsConn != nil && dsConn.RobotLinked {
+			batteryCheck.Detail = fmt.Sprintf(
+				"The battery is at %.1fV, below the %.1fV minimum.", dsConn.BatteryVoltage, minVoltage,
+			)
+		}
+		stationReadiness.Checks = append(stationReadiness.Checks, batteryCheck)
+	}
+	return stationReadiness
+}
+
+// Returns the readiness checks for the field hardware and scoring.
+func (arena *Arena) getFieldReadinessChecks() []ReadinessCheck {
+	var checks []ReadinessCheck
+	if arena.Plc.IsEnabled() {
+		checks = append(
+			checks,
+			requiredReadinessCheck(
+				"PLC Healthy",
+				arena.Plc.IsHealthy(),
+				"The PLC isn't responding.",
+				"cannot start match while PLC is not healthy",
+			),
+			requiredReadinessCheck(
+				"Field E-Stop Clear",
+				!arena.Plc.GetFieldEStop(),
+				"The field emergency stop is pressed.",
+				"cannot start match while field emergency stop is active",
+			),
+		)
+		armorBlockStatuses := arena.Plc.GetArmorBlockStatuses()
+		var armorBlockNames []string
+		for name := range armorBlockStatuses {
+			armorBlockNames = append(armorBlockNames, name)
+		}
+		sort.Strings(armorBlockNames)
+		for _, name := range armorBlockNames {
+			checks = append(
+				checks,
+				requiredReadinessCheck(
+					fmt.Sprintf("%s ArmorBlock Connected", name),
+					armorBlockStatuses[name],
+					fmt.Sprintf("The %s ArmorBlock isn't connected.", name),
+					fmt.Sprintf("cannot start match while PLC ArmorBlock %q is not connected", name),
+				),
+			)
+		}
+	}
+
+	if arena.EventSettings.NetworkSecurityEnabled {
+		apCheck := optionalReadinessCheck(
+			"Access Point Configured",
+			arena.accessPoint.Status == "ACTIVE",
+			fmt.Sprintf("The access point isn't active (status %s).", arena.accessPoint.Status),
+		)
+		if apCheck.IsPassing {
+			for _, station := range []string{"R1", "R2", "R3", "B1", "B2", "B3"} {
+				allianceStation := arena.AllianceStations[station]
+				expectedTeamId := 0
+				if allianceStation.Team != nil {
+					expectedTeamId = allianceStation.Team.Id
+				}
+				if allianceStation.WifiStatus.TeamId != expectedTeamId {
+					apCheck.IsPassing = false
+					apCheck.Detail = "The access point isn't configured for the teams in this match."
+					break
+				}
+			}
+		}
+		checks = append(
+			checks,
+			apCheck,
+			optionalReadinessCheck(
+				"Switch Active",
+				arena.networkSwitch.Status == "ACTIVE",
+				fmt.Sprintf("The switch isn't active (status %s).", arena.networkSwitch.Status),
+			),
+		)
+	}
+
+	redPanels := arena.ScoringPanelRegistry.GetNumPanels("red")
+	bluePanels := arena.ScoringPanelRegistry.GetNumPanels("blue")
+	checks = append(
+		checks,
+		optionalReadinessCheck(
+			"Scoring Panels Connected",
+			redPanels > 0 && bluePanels > 0,
+			fmt.Sprintf("%d red and %d blue scoring panels are connected.", redPanels, bluePanels),
+		),
+	)
+	return checks
+}
+
+// Returns nil if the report allows the match to start, and an error otherwise. Checks that aren't required only
+// block the match if the start match policy says so, and can be overridden if the policy allows it.
+func (readiness *MatchReadiness) checkCanStart(override bool) error {
+	checks := readiness.labeledChecks()
+	for _, check := range checks {
+		if !check.IsPassing && check.IsRequired {
+			return check.err
+		}
+	}
+
+	if readiness.policy == model.AdvisoryStartMatchPolicy ||
+		override && readiness.policy == model.OverridableStartMatchPolicy {
+		return nil
+	}
+	for _, check := range checks {
+		if !check.IsPassing {
+			return fmt.Errorf("cannot start match while the %s check is failing: %s", check.label, check.Detail)
+		}
+	}
+	return nil
+}
+
+// Returns the labels of the checks that aren't required and are currently failing.
+func (readiness *MatchReadiness) getFailingOptionalChecks() []string {
+	var labels []string
+	for _, check := range readiness.labeledChecks() {
+		if !check.IsPassing && !check.IsRequired {
+			labels = append(labels, check.label)
+		}
+	}
+	return labels
+}
+
+// Returns every check in the report, with each station's checks labeled by the station.
+func (readiness *MatchReadiness) labeledChecks() []labeledReadinessCheck {
+	var checks []labeledReadinessCheck
+	for _, station := range readiness.Stations {
+		for _, check := range station.Checks {
+			checks = append(checks, labeledReadinessCheck{fmt.Sprintf("%s %s", station.Station, check.Name), check})
+		}
+	}
+	for _, check := range readiness.FieldChecks {
+		checks = append(checks, labeledReadinessCheck{check.Name, check})
+	}
+	return checks
+}
+
+// Records that the match was started despite the given failing readiness checks.
+func (arena *Arena) logMatchStartOverride(checks []string) error {
+	log.Printf(
+		"Match %s was started with an override of the failing readiness checks: %s",
+		arena.CurrentMatch.ShortName,
+		strings.Join(checks, ", "),
+	)
+	if arena.CurrentMatch.Type == model.Test {
+		return nil
+	}
+	return arena.Database.CreateMatchStartOverride(
+		&model.MatchStartOverride{MatchId: arena.CurrentMatch.Id, OverriddenAt: time.Now(), Checks: checks},
+	)
+}
+
+func requiredReadinessCheck(name string, isPassing bool, detail string, errorMessage string) ReadinessCheck {
+	check := ReadinessCheck{Name: name, IsPassing: isPassing, IsRequired: true}
+	if !isPassing {
+		check.Detail = detail
+		check.err = errors.New(errorMessage)
+	}
+	return check
+}
+
+func optionalReadinessCheck(name string, isPassing bool, detail string) ReadinessCheck {
+	check := ReadinessCheck{Name: name, IsPassing: isPassing}
+	if !isPassing {
+		check.Detail = detail
+	}
+	return check
+}
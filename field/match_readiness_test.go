@@ -0,0 +1,130 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/websocket"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetMatchReadiness(t *testing.T) {
+	arena := setupTestArena(t)
+
+	readiness := arena.GetMatchReadiness()
+	assert.False(t, readiness.CanStart)
+	assert.False(t, readiness.CanOverride)
+	if assert.Equal(t, 6, len(readiness.Stations)) {
+		assert.Equal(t, "R1", readiness.Stations[0].Station)
+		var checkNames []string
+		for _, check := range readiness.Stations[0].Checks {
+			checkNames = append(checkNames, check.Name)
+		}
+		assert.Equal(
+			t,
+			[]string{
+				"E-Stop Clear",
+				"A-Stop Reset",
+				"DS Linked",
+				"Radio Linked",
+				"Robot Linked",
+				"Correct Station",
+				"Battery Voltage",
+			},
+			checkNames,
+		)
+	}
+	if assert.Equal(t, 1, len(readiness.FieldChecks)) {
+		assert.Equal(t, "Scoring Panels Connected", readiness.FieldChecks[0].Name)
+		assert.False(t, readiness.FieldChecks[0].IsPassing)
+		assert.Equal(t, "0 red and 0 blue scoring panels are connected.", readiness.FieldChecks[0].Detail)
+	}
+
+	// Check the details reported for a connected robot in the wrong station with a low battery.
+	arena.AllianceStations["R1"].DsConn = &DriverStationConnection{
+		DsLinked: true, RadioLinked: true, RobotLinked: true, BatteryVoltage: 11.5, WrongStation: "B2",
+	}
+	checks := arena.GetMatchReadiness().Stations[0].Checks
+	assert.True(t, checks[2].IsPassing)
+	assert.True(t, checks[4].IsPassing)
+	assert.False(t, checks[5].IsPassing)
+	assert.Equal(t, "The driver station is plugged in at B2.", checks[5].Detail)
+	assert.False(t, checks[6].IsPassing)
+	assert.Equal(t, "The battery is at 11.5V, below the 12.0V minimum.", checks[6].Detail)
+
+	// Check that a bypassed station only checks the stop buttons.
+	for _, allianceStation := range arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+	readiness = arena.GetMatchReadiness()
+	assert.Equal(t, 2, len(readiness.Stations[0].Checks))
+	assert.True(t, readiness.CanStart)
+	assert.False(t, readiness.CanOverride)
+
+	// Check the network checks.
+	arena.EventSettings.NetworkSecurityEnabled = true
+	arena.accessPoint.Status = "ACTIVE"
+	arena.networkSwitch.Status = "ERROR"
+	arena.AllianceStations["B3"].Team = &model.Team{Id: 254}
+	readiness = arena.GetMatchReadiness()
+	if assert.Equal(t, 3, len(readiness.FieldChecks)) {
+		assert.Equal(t, "Access Point Configured", readiness.FieldChecks[0].Name)
+		assert.False(t, readiness.FieldChecks[0].IsPassing)
+		assert.Equal(
+			t, "The access point isn't configured for the teams in this match.", readiness.FieldChecks[0].Detail,
+		)
+		assert.Equal(t, "Switch Active", readiness.FieldChecks[1].Name)
+		assert.False(t, readiness.FieldChecks[1].IsPassing)
+	}
+	arena.AllianceStations["B3"].WifiStatus.TeamId = 254
+	arena.networkSwitch.Status = "ACTIVE"
+	readiness = arena.GetMatchReadiness()
+	assert.True(t, readiness.FieldChecks[0].IsPassing)
+	assert.True(t, readiness.FieldChecks[1].IsPassing)
+}
+
+func TestStartMatchPolicy(t *testing.T) {
+	arena := setupTestArena(t)
+	match := model.Match{Type: model.Practice, ShortName: "P1"}
+	assert.Nil(t, arena.Database.CreateMatch(&match))
+	assert.Nil(t, arena.LoadMatch(&match))
+	for _, allianceStation := range arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+
+	// Failing checks that aren't required don't block the match under the advisory policy.
+	assert.Nil(t, arena.checkCanStartMatch())
+
+	arena.EventSettings.StartMatchPolicy = model.StrictStartMatchPolicy
+	err := arena.checkCanStartMatch()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Scoring Panels Connected")
+	}
+	assert.False(t, arena.GetMatchReadiness().CanOverride)
+	assert.NotNil(t, arena.StartMatchWithOverride())
+
+	arena.EventSettings.StartMatchPolicy = model.OverridableStartMatchPolicy
+	assert.NotNil(t, arena.StartMatch())
+	assert.True(t, arena.GetMatchReadiness().CanOverride)
+	assert.Nil(t, arena.StartMatchWithOverride())
+	assert.Equal(t, StartMatch, arena.MatchState)
+	matchStartOverrides, err := arena.Database.GetMatchStartOverridesByMatch(match.Id)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(matchStartOverrides)) {
+		assert.Equal(t, []string{"Scoring Panels Connected"}, matchStartOverrides[0].Checks)
+	}
+
+	// Required checks can't be overridden.
+	arena.MatchState = PreMatch
+	arena.AllianceStations["R2"].Bypass = false
+	arena.ScoringPanelRegistry.RegisterPanel("red", &websocket.Websocket{})
+	arena.ScoringPanelRegistry.RegisterPanel("blue", &websocket.Websocket{})
+	readiness := arena.GetMatchReadiness()
+	assert.False(t, readiness.CanStart)
+	assert.False(t, readiness.CanOverride)
+	err = arena.StartMatchWithOverride()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "cannot start match until all robots are connected or bypassed")
+	}
+}
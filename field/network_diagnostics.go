@@ -0,0 +1,122 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Functions for periodically gathering wired network diagnostics for the teams in each alliance station.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/network"
+	"log"
+	"sync"
+	"time"
+)
+
+const networkDiagnosticsPeriodSec = 5
+
+// A request from the arena loop for the diagnostics of the teams in each alliance station, which the diagnostics loop
+// fills in and hands back.
+type networkDiagnosticsQuery struct {
+	networkSwitch *network.Switch
+	teams         [6]*model.Team
+	diagnostics   [6]network.TeamNetworkDiagnostics
+}
+
+// Loops indefinitely to gather network diagnostics for the teams handed over by the arena loop. The slow switch and
+// ping queries are made here so as not to hold up the arena loop, which records the results.
+func (arena *Arena) runNetworkDiagnostics() {
+	var diagnostics [6]network.TeamNetworkDiagnostics
+	for query := range arena.networkDiagnosticsQueries {
+		diagnostics = queryNetworkDiagnostics(query.networkSwitch, query.teams, diagnostics)
+		query.diagnostics = diagnostics
+		arena.networkDiagnosticsResults <- query
+	}
+}
+
+// Records any diagnostics that have come back from the diagnostics loop and periodically hands it the teams in the
+// current match to query next. Called from the arena loop, which is the only place the alliance stations are touched.
+func (arena *Arena) handleNetworkDiagnostics() {
+	select {
+	case result := <-arena.networkDiagnosticsResults:
+		arena.networkDiagnosticsPending = false
+		arena.recordNetworkDiagnostics(result)
+	default:
+	}
+
+	if arena.networkDiagnosticsPending || !arena.EventSettings.NetworkSecurityEnabled ||
+		time.Since(arena.lastNetworkDiagnosticsTime).Seconds() < networkDiagnosticsPeriodSec {
+		return
+	}
+	query := networkDiagnosticsQuery{networkSwitch: arena.networkSwitch}
+	for i, station := range []string{"R1", "R2", "R3", "B1", "B2", "B3"} {
+		query.teams[i] = arena.AllianceStations[station].Team
+	}
+	arena.networkDiagnosticsQueries <- query
+	arena.networkDiagnosticsPending = true
+	arena.lastNetworkDiagnosticsTime = time.Now()
+}
+
+// Queries the switch and pings each team's roboRIO, starting from the previous diagnostics so that any counters the
+// switch doesn't report this time keep their last known values.
+func queryNetworkDiagnostics(
+	networkSwitch *network.Switch, teams [6]*model.Team, previousDiagnostics [6]network.TeamNetworkDiagnostics,
+) [6]network.TeamNetworkDiagnostics {
+	diagnostics := previousDiagnostics
+	var diagnosticsPointers [6]*network.TeamNetworkDiagnostics
+	for i := range diagnostics {
+		diagnosticsPointers[i] = &diagnostics[i]
+	}
+	if err := networkSwitch.UpdateTeamDiagnostics(teams, diagnosticsPointers); err != nil {
+		log.Printf("Failed to update network diagnostics: %v", err)
+	}
+
+	// Ping all the roboRIOs in parallel so that one unreachable team doesn't hold up the others.
+	var waitGroup sync.WaitGroup
+	for i, team := range teams {
+		if team == nil {
+			diagnostics[i].RioPingLatencyMs = -1
+			continue
+		}
+		waitGroup.Add(1)
+		go func(teamId int, teamDiagnostics *network.TeamNetworkDiagnostics) {
+			defer waitGroup.Done()
+			teamDiagnostics.RioPingLatencyMs = network.PingTeamRio(teamId)
+		}(team.Id, &diagnostics[i])
+	}
+	waitGroup.Wait()
+	return diagnostics
+}
+
+// Updates each alliance station with the latest diagnostics, unless the team in it has changed since they were queried,
+// and saves a copy for each team so that they remain available after the team leaves the field.
+func (arena *Arena) recordNetworkDiagnostics(result networkDiagnosticsQuery) {
+	arena.networkDiagnosticsMutex.Lock()
+	defer arena.networkDiagnosticsMutex.Unlock()
+	for i, station := range []string{"R1", "R2", "R3", "B1", "B2", "B3"} {
+		teamDiagnostics := result.diagnostics[i]
+		if teamDiagnostics.TeamId != 0 {
+			arena.teamNetworkDiagnostics[teamDiagnostics.TeamId] = teamDiagnostics
+		}
+		allianceStation := arena.AllianceStations[station]
+		if teamIdOrZero(allianceStation.Team) == teamIdOrZero(result.teams[i]) {
+			allianceStation.NetworkDiagnostics = teamDiagnostics
+		}
+	}
+}
+
+// Returns the most recent network diagnostics captured for the given team, or nil if none have been captured.
+func (arena *Arena) GetTeamNetworkDiagnostics(teamId int) *network.TeamNetworkDiagnostics {
+	arena.networkDiagnosticsMutex.Lock()
+	defer arena.networkDiagnosticsMutex.Unlock()
+	if teamDiagnostics, ok := arena.teamNetworkDiagnostics[teamId]; ok {
+		return &teamDiagnostics
+	}
+	return nil
+}
+
+func teamIdOrZero(team *model.Team) int {
+	if team == nil {
+		return 0
+	}
+	return team.Id
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/network"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestArena_RecordNetworkDiagnostics(t *testing.T) {
+	arena := setupTestArena(t)
+	assert.Nil(t, arena.GetTeamNetworkDiagnostics(254))
+	arena.AllianceStations["R1"].Team = &model.Team{Id: 254}
+	arena.AllianceStations["B1"].Team = &model.Team{Id: 1114}
+
+	query := networkDiagnosticsQuery{
+		teams: [6]*model.Team{{Id: 254}, nil, nil, {Id: 1114}, nil, nil},
+		diagnostics: [6]network.TeamNetworkDiagnostics{
+			{TeamId: 254, RioPingLatencyMs: 1.5}, {}, {}, {TeamId: 1114, SwitchPortCrcErrors: 12}, {}, {},
+		},
+	}
+	arena.recordNetworkDiagnostics(query)
+	assert.Equal(t, 254, arena.AllianceStations["R1"].NetworkDiagnostics.TeamId)
+	assert.Equal(t, 12, arena.AllianceStations["B1"].NetworkDiagnostics.SwitchPortCrcErrors)
+	if teamDiagnostics := arena.GetTeamNetworkDiagnostics(254); assert.NotNil(t, teamDiagnostics) {
+		assert.Equal(t, 1.5, teamDiagnostics.RioPingLatencyMs)
+	}
+	if teamDiagnostics := arena.GetTeamNetworkDiagnostics(1114); assert.NotNil(t, teamDiagnostics) {
+		assert.Equal(t, 12, teamDiagnostics.SwitchPortCrcErrors)
+	}
+
+	// Should retain the last known diagnostics for a team after it leaves the field.
+	arena.AllianceStations["R1"].Team = nil
+	query.teams[0] = nil
+	query.diagnostics[0] = network.TeamNetworkDiagnostics{RioPingLatencyMs: -1}
+	arena.recordNetworkDiagnostics(query)
+	assert.Equal(t, -1.0, arena.AllianceStations["R1"].NetworkDiagnostics.RioPingLatencyMs)
+	if teamDiagnostics := arena.GetTeamNetworkDiagnostics(254); assert.NotNil(t, teamDiagnostics) {
+		assert.Equal(t, 1.5, teamDiagnostics.RioPingLatencyMs)
+	}
+
+	// Should not attribute the diagnostics to a station whose team has changed since they were queried.
+	arena.AllianceStations["B1"].Team = &model.Team{Id: 148}
+	query.diagnostics[3].SwitchPortCrcErrors = 15
+	arena.recordNetworkDiagnostics(query)
+	assert.Equal(t, 12, arena.AllianceStations["B1"].NetworkDiagnostics.SwitchPortCrcErrors)
+	if teamDiagnostics := arena.GetTeamNetworkDiagnostics(1114); assert.NotNil(t, teamDiagnostics) {
+		assert.Equal(t, 15, teamDiagnostics.SwitchPortCrcErrors)
+	}
+}
+
+func TestArena_HandleNetworkDiagnostics(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.AllianceStations["R2"].Team = &model.Team{Id: 254}
+
+	// Should not query the network unless network security is enabled.
+	arena.handleNetworkDiagnostics()
+	assert.Empty(t, arena.networkDiagnosticsQueries)
+
+	// Should hand over the teams in the current match, and not again until the results have come back.
+	arena.EventSettings.NetworkSecurityEnabled = true
+	arena.handleNetworkDiagnostics()
+	if !assert.Equal(t, 1, len(arena.networkDiagnosticsQueries)) {
+		return
+	}
+	query := <-arena.networkDiagnosticsQueries
+	assert.Equal(t, [6]*model.Team{nil, {Id: 254}, nil, nil, nil, nil}, query.teams)
+	arena.lastNetworkDiagnosticsTime = time.Now().Add(-networkDiagnosticsPeriodSec * time.Second)
+	arena.handleNetworkDiagnostics()
+	assert.Empty(t, arena.networkDiagnosticsQueries)
+
+	// Should record the results once they come back and then query again.
+	query.diagnostics[1] = network.TeamNetworkDiagnostics{TeamId: 254, RioArpPresent: true}
+	arena.networkDiagnosticsResults <- query
+	arena.handleNetworkDiagnostics()
+	assert.True(t, arena.AllianceStations["R2"].NetworkDiagnostics.RioArpPresent)
+	assert.Equal(t, 1, len(arena.networkDiagnosticsQueries))
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Logic for summarizing the robot and safety inspection status of the teams at the event.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"sort"
+)
+
+// Represents the inspection status of a single team, based on its most recent inspection.
+type TeamInspectionStatus struct {
+	TeamId         int
+	Nickname       string
+	IsInspected    bool
+	Passed         bool
+	LastInspection *model.TeamInspection
+}
+
+// Represents the inspection status of every team at the event.
+type InspectionSummary struct {
+	NumPassed       int
+	NumFailed       int
+	NumNotInspected int
+	Teams           []TeamInspectionStatus
+}
+
+// Returns the inspection status of every team at the event, ordered by team number.
+func (arena *Arena) GetInspectionSummary() (*InspectionSummary, error) {
+	teams, err := arena.Database.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	latestInspections, err := arena.Database.GetLatestTeamInspections()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &InspectionSummary{Teams: []TeamInspectionStatus{}}
+	for _, team := range teams {
+		status := TeamInspectionStatus{TeamId: team.Id, Nickname: team.Nickname}
+		if inspection, ok := latestInspections[team.Id]; ok {
+			status.IsInspected = true
+			status.Passed = inspection.Passed
+			status.LastInspection = &inspection
+		}
+		if !status.IsInspected {
+			summary.NumNotInspected++
+		} else if status.Passed {
+			summary.NumPassed++
+		} else {
+			summary.NumFailed++
+		}
+		summary.Teams = append(summary.Teams, status)
+	}
+	return summary, nil
+}
+
+// Returns the numbers of the teams in the current match that haven't passed inspection, in ascending order.
+func (arena *Arena) getUninspectedTeamIds() ([]int, error) {
+	latestInspections, err := arena.Database.GetLatestTeamInspections()
+	if err != nil {
+		return nil, err
+	}
+	teamIds := []int{}
+	for _, allianceStation := range arena.AllianceStations {
+		if allianceStation.Team == nil {
+			continue
+		}
+		if inspection, ok := latestInspections[allianceStation.Team.Id]; !ok || !inspection.Passed {
+			teamIds = append(teamIds, allianceStation.Team.Id)
+		}
+	}
+	sort.Ints(teamIds)
+	return teamIds, nil
+}
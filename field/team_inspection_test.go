@@ -0,0 +1,54 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetInspectionSummary(t *testing.T) {
+	arena := setupTestArena(t)
+
+	summary, err := arena.GetInspectionSummary()
+	assert.Nil(t, err)
+	assert.Equal(t, InspectionSummary{Teams: []TeamInspectionStatus{}}, *summary)
+
+	arena.Database.CreateTeam(&model.Team{Id: 254, Nickname: "The Cheesy Poofs"})
+	arena.Database.CreateTeam(&model.Team{Id: 1114, Nickname: "Simbotics"})
+	arena.Database.CreateTeam(&model.Team{Id: 2056, Nickname: "OP Robotics"})
+	inspection1 := model.TeamInspection{TeamId: 254, Passed: false, InspectedAt: time.Unix(1000, 0).UTC()}
+	inspection2 := model.TeamInspection{TeamId: 254, Passed: true, InspectedAt: time.Unix(2000, 0).UTC()}
+	inspection3 := model.TeamInspection{TeamId: 2056, Passed: false, InspectedAt: time.Unix(3000, 0).UTC()}
+	assert.Nil(t, arena.Database.CreateTeamInspection(&inspection1))
+	assert.Nil(t, arena.Database.CreateTeamInspection(&inspection2))
+	assert.Nil(t, arena.Database.CreateTeamInspection(&inspection3))
+
+	summary, err = arena.GetInspectionSummary()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, summary.NumPassed)
+	assert.Equal(t, 1, summary.NumFailed)
+	assert.Equal(t, 1, summary.NumNotInspected)
+	assert.Equal(
+		t,
+		[]TeamInspectionStatus{
+			{TeamId: 254, Nickname: "The Cheesy Poofs", IsInspected: true, Passed: true, LastInspection: &inspection2},
+			{TeamId: 1114, Nickname: "Simbotics"},
+			{TeamId: 2056, Nickname: "OP Robotics", IsInspected: true, Passed: false, LastInspection: &inspection3},
+		},
+		summary.Teams,
+	)
+
+	// Check that the teams in the current match that haven't passed inspection are flagged.
+	teamIds, err := arena.getUninspectedTeamIds()
+	assert.Nil(t, err)
+	assert.Equal(t, []int{}, teamIds)
+	assert.Nil(t, arena.assignTeam(2056, "R1"))
+	assert.Nil(t, arena.assignTeam(254, "R2"))
+	assert.Nil(t, arena.assignTeam(1114, "B3"))
+	teamIds, err = arena.getUninspectedTeamIds()
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1114, 2056}, teamIds)
+}
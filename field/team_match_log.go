@@ -6,73 +6,128 @@
 package field
 
 import (
-	"fmt"
 	"log"
-	"os"
-	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/network"
 )
 
-const logsDir = "static/logs"
-
 type TeamMatchLog struct {
-	logger     *log.Logger
-	logFile    *os.File
-	wifiStatus *network.TeamWifiStatus
+	matchLog          model.TeamMatchLog
+	rows              []model.TeamMatchLogRow
+	wifiStatus        *network.TeamWifiStatus
+	channelConditions *network.ChannelScanSample
+	mutex             sync.Mutex
 }
 
-// Creates a file to log to for the given match and team.
-func NewTeamMatchLog(teamId int, match *model.Match, wifiStatus *network.TeamWifiStatus) (*TeamMatchLog, error) {
-	err := os.MkdirAll(filepath.Join(model.BaseDir, logsDir), 0755)
-	if err != nil {
-		return nil, err
+// Creates a log to accumulate packets for the given match and team until it is saved at the end of the match. The
+// channel conditions are those from the most recent access point scan, recorded alongside each packet so that link
+// drops can be correlated with congestion; they may be nil if no scan has been performed.
+func NewTeamMatchLog(
+	teamId int,
+	allianceStation string,
+	match *model.Match,
+	wifiStatus *network.TeamWifiStatus,
+	channelConditions *network.ChannelScanSample,
+) *TeamMatchLog {
+	return &TeamMatchLog{
+		matchLog: model.TeamMatchLog{
+			MatchId:         match.Id,
+			MatchType:       match.Type,
+			MatchShortName:  match.ShortName,
+			TeamId:          teamId,
+			AllianceStation: allianceStation,
+			StartedAt:       time.Now(),
+		},
+		wifiStatus:        wifiStatus,
+		channelConditions: channelConditions,
 	}
+}
 
-	filename := fmt.Sprintf("%s/%s_%s_Match_%s_%d.csv", filepath.Join(model.BaseDir, logsDir),
-		time.Now().Format("20060102150405"), match.Type.String(), match.ShortName, teamId)
-	logFile, err := os.Create(filename)
-	if err != nil {
-		return nil, err
+// Begins a new log for each connected driver station in the match that is about to start. Test matches are not logged.
+func (arena *Arena) startTeamMatchLogs() {
+	arena.teamMatchLogs = make(map[string]*TeamMatchLog)
+	channelConditions := arena.AccessPointChannelConditions()
+	for station, allianceStation := range arena.AllianceStations {
+		if allianceStation.DsConn == nil {
+			continue
+		}
+		var matchLog *TeamMatchLog
+		if arena.CurrentMatch.Type != model.Test {
+			matchLog = NewTeamMatchLog(
+				allianceStation.DsConn.TeamId,
+				station,
+				arena.CurrentMatch,
+				&allianceStation.WifiStatus,
+				channelConditions,
+			)
+			arena.teamMatchLogs[station] = matchLog
+		}
+		allianceStation.DsConn.signalMatchStart(matchLog)
 	}
+}
 
-	log := TeamMatchLog{log.New(logFile, "", 0), logFile, wifiStatus}
-	log.logger.Println(
-		"matchTimeSec,packetType,teamId,allianceStation,dsLinked,radioLinked,rioLinked,robotLinked,auto,enabled," +
-			"emergencyStop,autonomousStop,batteryVoltage,missedPacketCount,dsRobotTripTimeMs,rxRate,txRate," +
-			"signalNoiseRatio",
-	)
+// Persists the logs for the match that just ended, including those of any driver stations that disconnected during it.
+// The logs are analyzed and written in the background so as not to hold up the arena loop, under the state lock so that
+// the database can't be swapped out partway through.
+func (arena *Arena) saveTeamMatchLogs() {
+	matchLogs := arena.teamMatchLogs
+	arena.teamMatchLogs = nil
+	if len(matchLogs) == 0 {
+		return
+	}
 
-	return &log, nil
+	arena.teamMatchLogWrites.Add(1)
+	go func() {
+		defer arena.teamMatchLogWrites.Done()
+		arena.RLockState()
+		defer arena.RUnlockState()
+		for _, matchLog := range matchLogs {
+			if err := matchLog.Save(arena.Database); err != nil {
+				log.Printf("Failed to save match log for team %d: %v", matchLog.matchLog.TeamId, err)
+			}
+		}
+	}()
 }
 
-// Adds a line to the log when a packet is received.
+// Adds a row to the log when a packet is received.
 func (log *TeamMatchLog) LogDsPacket(matchTimeSec float64, packetType int, dsConn *DriverStationConnection) {
-	log.logger.Printf(
-		"%f,%d,%d,%s,%v,%v,%v,%v,%v,%v,%v,%v,%f,%d,%d,%f,%f,%d",
-		matchTimeSec,
-		packetType,
-		dsConn.TeamId,
-		dsConn.AllianceStation,
-		dsConn.DsLinked,
-		dsConn.RadioLinked,
-		dsConn.RioLinked,
-		dsConn.RobotLinked,
-		dsConn.Auto,
-		dsConn.Enabled,
-		dsConn.EStop,
-		dsConn.AStop,
-		dsConn.BatteryVoltage,
-		dsConn.MissedPacketCount,
-		dsConn.DsRobotTripTimeMs,
-		log.wifiStatus.RxRate,
-		log.wifiStatus.TxRate,
-		log.wifiStatus.SignalNoiseRatio,
-	)
+	row := model.TeamMatchLogRow{
+		MatchTimeSec:              matchTimeSec,
+		PacketType:                packetType,
+		DsLinked:                  dsConn.DsLinked,
+		RadioLinked:               dsConn.RadioLinked,
+		RioLinked:                 dsConn.RioLinked,
+		RobotLinked:               dsConn.RobotLinked,
+		Auto:                      dsConn.Auto,
+		Enabled:                   dsConn.Enabled,
+		EmergencyStop:             dsConn.EStop,
+		AutonomousStop:            dsConn.AStop,
+		BatteryVoltage:            dsConn.BatteryVoltage,
+		MissedPacketCount:         dsConn.MissedPacketCount,
+		DsRobotTripTimeMs:         dsConn.DsRobotTripTimeMs,
+		RxRate:                    log.wifiStatus.RxRate,
+		TxRate:                    log.wifiStatus.TxRate,
+		SignalNoiseRatio:          log.wifiStatus.SignalNoiseRatio,
+		ChannelUtilizationPercent: -1,
+		ChannelNoiseDbm:           -1,
+	}
+	if log.channelConditions != nil {
+		row.ChannelUtilizationPercent = log.channelConditions.UtilizationPercent
+		row.ChannelNoiseDbm = log.channelConditions.NoiseDbm
+	}
+
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+	log.rows = append(log.rows, row)
 }
 
-func (log *TeamMatchLog) Close() {
-	log.logFile.Close()
+// Analyzes the accumulated packets and persists them to the database.
+func (log *TeamMatchLog) Save(database *model.Database) error {
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+	log.matchLog.Analysis = AnalyzeTeamMatchLog(log.rows)
+	return database.CreateTeamMatchLog(&log.matchLog, log.rows)
 }
@@ -0,0 +1,74 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestArena_TeamMatchLogs(t *testing.T) {
+	arena := setupTestArena(t)
+
+	match := model.Match{Type: model.Practice, ShortName: "P3", Red2: 254, Blue1: 1114}
+	assert.Nil(t, arena.Database.CreateMatch(&match))
+	assert.Nil(t, arena.Database.CreateTeam(&model.Team{Id: 254}))
+	assert.Nil(t, arena.Database.CreateTeam(&model.Team{Id: 1114}))
+	assert.Nil(t, arena.LoadMatch(&match))
+	redDs := &DriverStationConnection{TeamId: 254, AllianceStation: "R2"}
+	blueDs := &DriverStationConnection{TeamId: 1114, AllianceStation: "B1"}
+	arena.AllianceStations["R2"].DsConn = redDs
+	arena.AllianceStations["B1"].DsConn = blueDs
+	arena.AllianceStations["R2"].WifiStatus.SignalNoiseRatio = 37
+
+	arena.startTeamMatchLogs()
+	assert.Equal(t, 2, len(arena.teamMatchLogs))
+	assert.Same(t, arena.teamMatchLogs["R2"], redDs.log)
+	redDs.RobotLinked = true
+	redDs.BatteryVoltage = 12.3
+	redDs.log.LogDsPacket(1.5, 22, redDs)
+	redDs.BatteryVoltage = 6.3
+	redDs.log.LogDsPacket(2.0, 22, redDs)
+
+	// The log should still be saved if the driver station disconnects partway through the match.
+	blueDs.Enabled = true
+	blueDs.log.LogDsPacket(1.5, 22, blueDs)
+	arena.AllianceStations["B1"].DsConn = nil
+
+	arena.saveTeamMatchLogs()
+	assert.Nil(t, arena.teamMatchLogs)
+	arena.teamMatchLogWrites.Wait()
+	matchLogs, err := arena.Database.GetTeamMatchLogsByMatch(match.Id)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(matchLogs)) {
+		for _, matchLog := range matchLogs {
+			rows, err := arena.Database.GetTeamMatchLogRows(matchLog.Id)
+			assert.Nil(t, err)
+			assert.Equal(t, "P3", matchLog.MatchShortName)
+			switch matchLog.TeamId {
+			case 254:
+				assert.Equal(t, "R2", matchLog.AllianceStation)
+				assert.Equal(t, 1, matchLog.Analysis.BrownoutCount)
+				if assert.Equal(t, 2, len(rows)) {
+					assert.Equal(t, 37, rows[0].SignalNoiseRatio)
+					assert.Equal(t, -1.0, rows[0].ChannelUtilizationPercent)
+				}
+			case 1114:
+				assert.Equal(t, "B1", matchLog.AllianceStation)
+				assert.Equal(t, 1, matchLog.Analysis.CommsDropCount)
+				assert.Equal(t, 1, len(rows))
+			default:
+				assert.Fail(t, "unexpected team in match log")
+			}
+		}
+	}
+
+	// Test matches should not be logged.
+	arena.MatchState = PreMatch
+	assert.Nil(t, arena.LoadTestMatch())
+	arena.AllianceStations["R2"].DsConn = redDs
+	arena.startTeamMatchLogs()
+	assert.Empty(t, arena.teamMatchLogs)
+	assert.Nil(t, redDs.log)
+}
@@ -1,7 +1,7 @@
 // Copyright 2024 Team 254. All Rights Reserved.
 // Author: pat@patfairbank.com (Patrick Fairbank)
 //
-// Models and logic for controlling a Cypress team number / timer sign.
+// Models and logic for generating the content of the team number / timer signs.
 
 package field
 
@@ -11,9 +11,6 @@ import (
 	"github.com/Team254/cheesy-arena/model"
 	"image/color"
 	"log"
-	"net"
-	"strconv"
-	"strings"
 	"time"
 )
 
@@ -32,18 +29,11 @@ type TeamSigns struct {
 // Represents a team number or timer sign.
 type TeamSign struct {
 	isTimer         bool
-	address         byte
 	nextMatchTeamId int
 	frontText       string
 	frontColor      color.RGBA
 	rearText        string
-	lastFrontText   string
-	lastFrontColor  color.RGBA
-	lastRearText    string
-	udpConn         net.Conn
-	packetData      [128]byte
-	packetIndex     int
-	lastPacketTime  time.Time
+	output          TeamSignOutput
 }
 
 const (
@@ -128,42 +118,121 @@ func (signs *TeamSigns) SetNextMatchTeams(match *model.Match) {
 	signs.Blue3.nextMatchTeamId = match.Blue3
 }
 
-// Sets the IP address of the sign.
-func (sign *TeamSign) SetId(id int) {
-	if sign.udpConn != nil {
-		_ = sign.udpConn.Close()
+// Returns the signs in the same order as their settings.
+func (signs *TeamSigns) positions() [8]*TeamSign {
+	return [8]*TeamSign{
+		&signs.Red1, &signs.Red2, &signs.Red3, &signs.RedTimer,
+		&signs.Blue1, &signs.Blue2, &signs.Blue3, &signs.BlueTimer,
 	}
-	sign.address = byte(id)
-	if id == 0 {
-		// The sign is not configured.
-		return
+}
+
+// Points each sign at the output configured for its position, or at the simulator if it is enabled.
+func (arena *Arena) configureTeamSigns() {
+	settings := arena.EventSettings
+	positions := arena.TeamSigns.positions()
+	ids := [8]int{
+		settings.TeamSignRed1Id,
+		settings.TeamSignRed2Id,
+		settings.TeamSignRed3Id,
+		settings.TeamSignRedTimerId,
+		settings.TeamSignBlue1Id,
+		settings.TeamSignBlue2Id,
+		settings.TeamSignBlue3Id,
+		settings.TeamSignBlueTimerId,
+	}
+	outputTypes := [8]model.TeamSignOutputType{
+		settings.TeamSignRed1OutputType,
+		settings.TeamSignRed2OutputType,
+		settings.TeamSignRed3OutputType,
+		settings.TeamSignRedTimerOutputType,
+		settings.TeamSignBlue1OutputType,
+		settings.TeamSignBlue2OutputType,
+		settings.TeamSignBlue3OutputType,
+		settings.TeamSignBlueTimerOutputType,
+	}
+	addresses := [8]string{
+		settings.TeamSignRed1Address,
+		settings.TeamSignRed2Address,
+		settings.TeamSignRed3Address,
+		settings.TeamSignRedTimerAddress,
+		settings.TeamSignBlue1Address,
+		settings.TeamSignBlue2Address,
+		settings.TeamSignBlue3Address,
+		settings.TeamSignBlueTimerAddress,
 	}
-	ipAddress := fmt.Sprintf("%s%d", teamSignAddressPrefix, id)
 
-	var err error
-	sign.udpConn, err = net.Dial("udp4", fmt.Sprintf("%s:%d", ipAddress, teamSignPort))
+	if settings.TeamSignSimulatorEnabled {
+		if err := arena.TeamSignSimulator.Listen(teamSignSimulatorListenAddress); err != nil {
+			log.Printf("Failed to start team sign simulator: %v", err)
+		} else {
+			simulatorAddress := arena.TeamSignSimulator.LocalAddress()
+			for i, id := range simulatedTeamSignIds(ids) {
+				positions[i].SetSimulatedId(id, simulatorAddress)
+			}
+			return
+		}
+	} else {
+		arena.TeamSignSimulator.Close()
+	}
+
+	matrix := ledMatrixLayout{
+		width:      settings.TeamSignMatrixWidth,
+		height:     settings.TeamSignMatrixHeight,
+		serpentine: settings.TeamSignMatrixSerpentine,
+	}
+	for i, sign := range positions {
+		output, err := newTeamSignOutput(
+			outputTypes[i], ids[i], addresses[i], matrix, func() { arena.TeamSignsNotifier.Notify() },
+		)
+		if err != nil {
+			log.Printf("Failed to configure team sign output: %v", err)
+		}
+		sign.SetOutput(output)
+	}
+}
+
+// Detaches every sign from its output and stops the simulator, leaving the signs to be driven by another server.
+func (arena *Arena) disconnectTeamSigns() {
+	arena.TeamSignSimulator.Close()
+	for _, sign := range arena.TeamSigns.positions() {
+		sign.SetOutput(nil)
+	}
+}
+
+// Sets the output that the sign's content is sent to, closing any previous output. A nil output leaves the position
+// unconfigured.
+func (sign *TeamSign) SetOutput(output TeamSignOutput) {
+	if sign.output != nil {
+		sign.output.Close()
+	}
+	sign.output = output
+}
+
+// Sets the IP address of the sign.
+func (sign *TeamSign) SetId(id int) {
+	output, err := newTeamSignOutput(model.CypressTeamSignOutput, id, "", ledMatrixLayout{}, nil)
 	if err != nil {
-		log.Printf("Failed to connect to team sign at %s: %v", ipAddress, err)
-		return
+		log.Printf("Failed to configure team sign: %v", err)
 	}
-	addressParts := strings.Split(ipAddress, ".")
-	if len(addressParts) != 4 {
-		log.Printf("Failed to configure team sign: invalid IP address: %s", ipAddress)
+	sign.SetOutput(output)
+}
+
+// Directs the sign's packets to the simulator at the given UDP address instead of to the physical sign.
+func (sign *TeamSign) SetSimulatedId(id int, simulatorAddress string) {
+	output, err := newCypressTeamSignOutput(id, simulatorAddress)
+	if err != nil {
+		log.Printf("Failed to connect to team sign simulator at %s: %v", simulatorAddress, err)
+		sign.SetOutput(nil)
 		return
 	}
-	address, _ := strconv.Atoi(addressParts[3])
-	sign.address = byte(address)
-
-	// Reset the sign's state to ensure that the next packet sent will update the sign.
-	sign.packetIndex = 0
-	sign.lastPacketTime = time.Time{}
+	sign.SetOutput(output)
 }
 
 // Updates the sign's internal state with the latest data and sends packets to the sign if anything has changed.
 func (sign *TeamSign) update(
 	arena *Arena, allianceStation *AllianceStation, isRed bool, countdown, inMatchRearText string,
 ) {
-	if sign.address == 0 {
+	if sign.output == nil {
 		// Don't do anything if there is no sign configured in this position.
 		return
 	}
@@ -176,8 +245,8 @@ func (sign *TeamSign) update(
 		)
 	}
 
-	if err := sign.sendPacket(); err != nil {
-		log.Printf("Failed to send team sign packet: %v", err)
+	if err := sign.output.Update(sign.frontText, sign.frontColor, sign.rearText); err != nil {
+		log.Printf("Failed to update team sign: %v", err)
 	}
 }
 
@@ -303,59 +372,6 @@ func (sign *TeamSign) generateTeamNumberTexts(
 	return frontText, frontColor, rearText
 }
 
-// Sends a UDP packet to the sign if its state has changed.
-func (sign *TeamSign) sendPacket() error {
-	if sign.packetIndex == 0 {
-		// Write the static packet header the first time this method is invoked.
-		sign.writePacketData([]byte(teamSignPacketMagicString))
-		sign.writePacketData([]byte{sign.address, teamSignCommandSetDisplay})
-	} else {
-		// Reset the write index to just after the header.
-		sign.packetIndex = teamSignPacketHeaderLength
-	}
-
-	isStale := time.Now().Sub(sign.lastPacketTime).Milliseconds() >= teamSignPacketPeriodMs
-
-	if sign.frontText != sign.lastFrontText || isStale {
-		sign.writePacketData([]byte{teamSignAddressSingle, sign.address, teamSignPacketTypeFrontText})
-		sign.writePacketData([]byte(sign.frontText))
-		sign.writePacketData([]byte{0, 0}) // Second byte is "show decimal point".
-		sign.lastFrontText = sign.frontText
-	}
-
-	if sign.frontColor != sign.lastFrontColor || isStale {
-		sign.writePacketData([]byte{teamSignAddressSingle, sign.address, teamSignPacketTypeColor})
-		sign.writePacketData([]byte{sign.frontColor.R, sign.frontColor.G, sign.frontColor.B})
-		sign.writePacketData([]byte{teamSignAddressSingle, sign.address, teamSignPacketTypeFrontIntensity})
-		sign.writePacketData([]byte{sign.frontColor.A})
-		sign.lastFrontColor = sign.frontColor
-	}
-
-	if sign.rearText != sign.lastRearText || isStale {
-		sign.writePacketData([]byte{teamSignAddressSingle, sign.address, teamSignPacketTypeRearText})
-		sign.writePacketData([]byte(sign.rearText))
-		sign.writePacketData([]byte{0})
-		sign.lastRearText = sign.rearText
-	}
-
-	if sign.packetIndex > teamSignPacketHeaderLength {
-		sign.lastPacketTime = time.Now()
-		if _, err := sign.udpConn.Write(sign.packetData[:sign.packetIndex]); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// Writes the given data to the packet buffer and advances the write index.
-func (sign *TeamSign) writePacketData(data []byte) {
-	for _, value := range data {
-		sign.packetData[sign.packetIndex] = value
-		sign.packetIndex++
-	}
-}
-
 // Periodically modifies the given color to zero brightness to create a blinking effect.
 func blinkColor(originalColor color.RGBA) color.RGBA {
 	if time.Now().UnixMilli()%teamSignBlinkPeriodMs < teamSignBlinkPeriodMs/2 {
@@ -0,0 +1,324 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Team sign outputs that render the front text onto generic LED matrix controllers using the DDP or E1.31 protocols.
+
+package field
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"image/color"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	defaultLedMatrixWidth      = 32
+	defaultLedMatrixHeight     = 8
+	ledMatrixGlyphWidth        = 5
+	ledMatrixGlyphHeight       = 7
+	ledMatrixRefreshPeriodMs   = 1000
+	ddpPort                    = 4048
+	ddpHeaderLength            = 10
+	ddpMaxDataLength           = 1440
+	ddpFlagsVersion1           = 0x40
+	ddpFlagsPush               = 0x01
+	ddpDataTypeRgb8            = 0x0b
+	ddpDestinationDefault      = 0x01
+	sacnPort                   = 5568
+	sacnHeaderLength           = 126
+	sacnChannelsPerUniverse    = 510
+	sacnPriority               = 100
+	sacnSourceName             = "Cheesy Arena"
+	sacnDefaultUniverse        = 1
+	sacnVectorRootData         = 0x00000004
+	sacnVectorFramingData      = 0x00000002
+	sacnVectorDmpSetProperty   = 0x02
+	sacnDmpAddressAndDataType  = 0xa1
+	sacnFlagsAndLengthTemplate = 0x7000
+)
+
+// Rows of each 5x7 glyph, with the most significant of the five bits being the leftmost column. Characters without a
+// glyph are rendered as blank space.
+var ledMatrixFont = map[rune][ledMatrixGlyphHeight]byte{
+	'0': {0b01110, 0b10001, 0b10011, 0b10101, 0b11001, 0b10001, 0b01110},
+	'1': {0b00100, 0b01100, 0b00100, 0b00100, 0b00100, 0b00100, 0b01110},
+	'2': {0b01110, 0b10001, 0b00001, 0b00010, 0b00100, 0b01000, 0b11111},
+	'3': {0b11111, 0b00010, 0b00100, 0b00010, 0b00001, 0b10001, 0b01110},
+	'4': {0b00010, 0b00110, 0b01010, 0b10010, 0b11111, 0b00010, 0b00010},
+	'5': {0b11111, 0b10000, 0b11110, 0b00001, 0b00001, 0b10001, 0b01110},
+	'6': {0b00110, 0b01000, 0b10000, 0b11110, 0b10001, 0b10001, 0b01110},
+	'7': {0b11111, 0b00001, 0b00010, 0b00100, 0b01000, 0b01000, 0b01000},
+	'8': {0b01110, 0b10001, 0b10001, 0b01110, 0b10001, 0b10001, 0b01110},
+	'9': {0b01110, 0b10001, 0b10001, 0b01111, 0b00001, 0b00010, 0b01100},
+	':': {0b00000, 0b01100, 0b01100, 0b00000, 0b01100, 0b01100, 0b00000},
+	'-': {0b00000, 0b00000, 0b00000, 0b11111, 0b00000, 0b00000, 0b00000},
+	'A': {0b01110, 0b10001, 0b10001, 0b11111, 0b10001, 0b10001, 0b10001},
+	'B': {0b11110, 0b10001, 0b10001, 0b11110, 0b10001, 0b10001, 0b11110},
+	'C': {0b01110, 0b10001, 0b10000, 0b10000, 0b10000, 0b10001, 0b01110},
+	'D': {0b11100, 0b10010, 0b10001, 0b10001, 0b10001, 0b10010, 0b11100},
+	'E': {0b11111, 0b10000, 0b10000, 0b11110, 0b10000, 0b10000, 0b11111},
+	'F': {0b11111, 0b10000, 0b10000, 0b11110, 0b10000, 0b10000, 0b10000},
+	'G': {0b01110, 0b10001, 0b10000, 0b10111, 0b10001, 0b10001, 0b01111},
+	'H': {0b10001, 0b10001, 0b10001, 0b11111, 0b10001, 0b10001, 0b10001},
+	'I': {0b01110, 0b00100, 0b00100, 0b00100, 0b00100, 0b00100, 0b01110},
+	'J': {0b00111, 0b00010, 0b00010, 0b00010, 0b00010, 0b10010, 0b01100},
+	'K': {0b10001, 0b10010, 0b10100, 0b11000, 0b10100, 0b10010, 0b10001},
+	'L': {0b10000, 0b10000, 0b10000, 0b10000, 0b10000, 0b10000, 0b11111},
+	'M': {0b10001, 0b11011, 0b10101, 0b10101, 0b10001, 0b10001, 0b10001},
+	'N': {0b10001, 0b10001, 0b11001, 0b10101, 0b10011, 0b10001, 0b10001},
+	'O': {0b01110, 0b10001, 0b10001, 0b10001, 0b10001, 0b10001, 0b01110},
+	'P': {0b11110, 0b10001, 0b10001, 0b11110, 0b10000, 0b10000, 0b10000},
+	'Q': {0b01110, 0b10001, 0b10001, 0b10001, 0b10101, 0b10010, 0b01101},
+	'R': {0b11110, 0b10001, 0b10001, 0b11110, 0b10100, 0b10010, 0b10001},
+	'S': {0b01111, 0b10000, 0b10000, 0b01110, 0b00001, 0b00001, 0b11110},
+	'T': {0b11111, 0b00100, 0b00100, 0b00100, 0b00100, 0b00100, 0b00100},
+	'U': {0b10001, 0b10001, 0b10001, 0b10001, 0b10001, 0b10001, 0b01110},
+	'V': {0b10001, 0b10001, 0b10001, 0b10001, 0b10001, 0b01010, 0b00100},
+	'W': {0b10001, 0b10001, 0b10001, 0b10101, 0b10101, 0b10101, 0b01010},
+	'X': {0b10001, 0b10001, 0b01010, 0b00100, 0b01010, 0b10001, 0b10001},
+	'Y': {0b10001, 0b10001, 0b10001, 0b01010, 0b00100, 0b00100, 0b00100},
+	'Z': {0b11111, 0b00001, 0b00010, 0b00100, 0b01000, 0b10000, 0b11111},
+}
+
+// Describes the dimensions and wiring of an LED matrix.
+type ledMatrixLayout struct {
+	width  int
+	height int
+
+	// Whether every other row is wired right-to-left, as is common for flexible LED panels.
+	serpentine bool
+}
+
+// Returns the layout with defaults substituted for any unset dimensions.
+func (layout ledMatrixLayout) withDefaults() ledMatrixLayout {
+	if layout.width <= 0 {
+		layout.width = defaultLedMatrixWidth
+	}
+	if layout.height <= 0 {
+		layout.height = defaultLedMatrixHeight
+	}
+	return layout
+}
+
+// Renders the given text centered on the matrix at the largest size that fits, returning the RGB value of each pixel
+// in wiring order. The "A" channel of the color is applied as the brightness.
+func (layout ledMatrixLayout) render(text string, textColor color.RGBA) []byte {
+	frame := make([]byte, layout.width*layout.height*3)
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) == 0 {
+		return frame
+	}
+
+	textWidth := len(runes)*(ledMatrixGlyphWidth+1) - 1
+	scale := max(min(layout.width/textWidth, layout.height/ledMatrixGlyphHeight), 1)
+	left := (layout.width - textWidth*scale) / 2
+	top := (layout.height - ledMatrixGlyphHeight*scale) / 2
+	pixel := []byte{
+		byte(int(textColor.R) * int(textColor.A) / 255),
+		byte(int(textColor.G) * int(textColor.A) / 255),
+		byte(int(textColor.B) * int(textColor.A) / 255),
+	}
+
+	for i, char := range runes {
+		glyph := ledMatrixFont[unicode.ToUpper(char)]
+		glyphLeft := left + i*(ledMatrixGlyphWidth+1)*scale
+		for row := 0; row < ledMatrixGlyphHeight; row++ {
+			for col := 0; col < ledMatrixGlyphWidth; col++ {
+				if glyph[row]&(1<<(ledMatrixGlyphWidth-1-col)) == 0 {
+					continue
+				}
+				for dy := 0; dy < scale; dy++ {
+					for dx := 0; dx < scale; dx++ {
+						layout.setPixel(frame, glyphLeft+col*scale+dx, top+row*scale+dy, pixel)
+					}
+				}
+			}
+		}
+	}
+	return frame
+}
+
+// Sets the given pixel in the frame, ignoring coordinates that fall outside the matrix.
+func (layout ledMatrixLayout) setPixel(frame []byte, x, y int, pixel []byte) {
+	if x < 0 || x >= layout.width || y < 0 || y >= layout.height {
+		return
+	}
+	if layout.serpentine && y%2 == 1 {
+		x = layout.width - 1 - x
+	}
+	copy(frame[(y*layout.width+x)*3:], pixel)
+}
+
+// Holds the state common to the LED matrix outputs, which only show the front text.
+type ledMatrixTeamSignOutput struct {
+	layout        ledMatrixLayout
+	udpConn       net.Conn
+	lastFrame     []byte
+	lastFrameTime time.Time
+}
+
+func newLedMatrixTeamSignOutput(udpAddress string, layout ledMatrixLayout) (ledMatrixTeamSignOutput, error) {
+	udpConn, err := net.Dial("udp4", udpAddress)
+	if err != nil {
+		return ledMatrixTeamSignOutput{}, err
+	}
+	return ledMatrixTeamSignOutput{layout: layout.withDefaults(), udpConn: udpConn}, nil
+}
+
+// Returns the frame to send for the given content, or nil if it is unchanged and the controller doesn't yet need a
+// refresh to keep it from timing out of realtime mode.
+func (output *ledMatrixTeamSignOutput) nextFrame(frontText string, frontColor color.RGBA) []byte {
+	frame := output.layout.render(frontText, frontColor)
+	isStale := time.Since(output.lastFrameTime).Milliseconds() >= ledMatrixRefreshPeriodMs
+	if !isStale && string(frame) == string(output.lastFrame) {
+		return nil
+	}
+	output.lastFrame = frame
+	output.lastFrameTime = time.Now()
+	return frame
+}
+
+func (output *ledMatrixTeamSignOutput) Close() {
+	_ = output.udpConn.Close()
+}
+
+// Sends content to an LED matrix controller (e.g. WLED) using the Distributed Display Protocol.
+type ddpTeamSignOutput struct {
+	ledMatrixTeamSignOutput
+	sequence byte
+}
+
+// Creates a DDP output for the controller at the given address, which may omit the port to use the default.
+func newDdpTeamSignOutput(address string, layout ledMatrixLayout) (*ddpTeamSignOutput, error) {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, strconv.Itoa(ddpPort))
+	}
+	matrixOutput, err := newLedMatrixTeamSignOutput(address, layout)
+	if err != nil {
+		return nil, err
+	}
+	return &ddpTeamSignOutput{ledMatrixTeamSignOutput: matrixOutput}, nil
+}
+
+func (output *ddpTeamSignOutput) Update(frontText string, frontColor color.RGBA, rearText string) error {
+	frame := output.nextFrame(frontText, frontColor)
+	if frame == nil {
+		return nil
+	}
+	for _, packet := range output.buildPackets(frame) {
+		if _, err := output.udpConn.Write(packet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Splits the frame into as many DDP packets as needed, setting the push flag on the last one so that the controller
+// displays the whole frame at once.
+func (output *ddpTeamSignOutput) buildPackets(frame []byte) [][]byte {
+	// Sequence numbers cycle from 1 to 15; zero means that the receiver should ignore them.
+	output.sequence = output.sequence%15 + 1
+
+	var packets [][]byte
+	for offset := 0; offset < len(frame); offset += ddpMaxDataLength {
+		data := frame[offset:min(offset+ddpMaxDataLength, len(frame))]
+		packet := make([]byte, ddpHeaderLength+len(data))
+		packet[0] = ddpFlagsVersion1
+		if offset+len(data) == len(frame) {
+			packet[0] |= ddpFlagsPush
+		}
+		packet[1] = output.sequence
+		packet[2] = ddpDataTypeRgb8
+		packet[3] = ddpDestinationDefault
+		binary.BigEndian.PutUint32(packet[4:8], uint32(offset))
+		binary.BigEndian.PutUint16(packet[8:10], uint16(len(data)))
+		copy(packet[ddpHeaderLength:], data)
+		packets = append(packets, packet)
+	}
+	return packets
+}
+
+// Sends content to an LED matrix controller using E1.31 (sACN) unicast, spanning as many consecutive universes as the
+// matrix requires.
+type sacnTeamSignOutput struct {
+	ledMatrixTeamSignOutput
+	startUniverse int
+	cid           [16]byte
+	sequence      byte
+}
+
+// Creates an sACN output for the controller at the given address, which may be suffixed with "/<universe>" to specify
+// the first universe.
+func newSacnTeamSignOutput(address string, layout ledMatrixLayout) (*sacnTeamSignOutput, error) {
+	host, universeString, hasUniverse := strings.Cut(address, "/")
+	startUniverse := sacnDefaultUniverse
+	if hasUniverse {
+		var err error
+		startUniverse, err = strconv.Atoi(universeString)
+		if err != nil || startUniverse < 1 || startUniverse > 63999 {
+			return nil, fmt.Errorf("invalid sACN universe %q", universeString)
+		}
+	}
+	matrixOutput, err := newLedMatrixTeamSignOutput(net.JoinHostPort(host, strconv.Itoa(sacnPort)), layout)
+	if err != nil {
+		return nil, err
+	}
+	output := &sacnTeamSignOutput{ledMatrixTeamSignOutput: matrixOutput, startUniverse: startUniverse}
+	_, _ = rand.Read(output.cid[:])
+	return output, nil
+}
+
+func (output *sacnTeamSignOutput) Update(frontText string, frontColor color.RGBA, rearText string) error {
+	frame := output.nextFrame(frontText, frontColor)
+	if frame == nil {
+		return nil
+	}
+	for _, packet := range output.buildPackets(frame) {
+		if _, err := output.udpConn.Write(packet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Splits the frame into one E1.31 data packet per universe.
+func (output *sacnTeamSignOutput) buildPackets(frame []byte) [][]byte {
+	var packets [][]byte
+	for offset := 0; offset < len(frame); offset += sacnChannelsPerUniverse {
+		data := frame[offset:min(offset+sacnChannelsPerUniverse, len(frame))]
+		universe := output.startUniverse + offset/sacnChannelsPerUniverse
+		packet := make([]byte, sacnHeaderLength+len(data))
+
+		// Root layer.
+		binary.BigEndian.PutUint16(packet[0:2], 0x0010)
+		copy(packet[4:16], "ASC-E1.17\x00\x00\x00")
+		binary.BigEndian.PutUint16(packet[16:18], uint16(sacnFlagsAndLengthTemplate|(len(packet)-16)))
+		binary.BigEndian.PutUint32(packet[18:22], sacnVectorRootData)
+		copy(packet[22:38], output.cid[:])
+
+		// Framing layer.
+		binary.BigEndian.PutUint16(packet[38:40], uint16(sacnFlagsAndLengthTemplate|(len(packet)-38)))
+		binary.BigEndian.PutUint32(packet[40:44], sacnVectorFramingData)
+		copy(packet[44:108], sacnSourceName)
+		packet[108] = sacnPriority
+		packet[111] = output.sequence
+		binary.BigEndian.PutUint16(packet[113:115], uint16(universe))
+
+		// DMP layer.
+		binary.BigEndian.PutUint16(packet[115:117], uint16(sacnFlagsAndLengthTemplate|(len(packet)-115)))
+		packet[117] = sacnVectorDmpSetProperty
+		packet[118] = sacnDmpAddressAndDataType
+		binary.BigEndian.PutUint16(packet[121:123], 0x0001)
+		binary.BigEndian.PutUint16(packet[123:125], uint16(len(data)+1))
+		copy(packet[sacnHeaderLength:], data)
+
+		output.sequence++
+		packets = append(packets, packet)
+	}
+	return packets
+}
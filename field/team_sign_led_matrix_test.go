@@ -0,0 +1,150 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/stretchr/testify/assert"
+	"image/color"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Returns the frame as rows of characters, with '#' for lit pixels and '.' for dark ones.
+func frameToStrings(layout ledMatrixLayout, frame []byte) []string {
+	var rows []string
+	for y := 0; y < layout.height; y++ {
+		var row strings.Builder
+		for x := 0; x < layout.width; x++ {
+			index := (y*layout.width + x) * 3
+			if frame[index] > 0 || frame[index+1] > 0 || frame[index+2] > 0 {
+				row.WriteByte('#')
+			} else {
+				row.WriteByte('.')
+			}
+		}
+		rows = append(rows, row.String())
+	}
+	return rows
+}
+
+func TestLedMatrixLayout_Render(t *testing.T) {
+	layout := ledMatrixLayout{width: 13, height: 9}
+	frame := layout.render(" 17 ", color.RGBA{200, 100, 0, 255})
+	assert.Equal(
+		t,
+		[]string{
+			".............",
+			"...#...#####.",
+			"..##.......#.",
+			"...#......#..",
+			"...#.....#...",
+			"...#....#....",
+			"...#....#....",
+			"..###...#....",
+			".............",
+		},
+		frameToStrings(layout, frame),
+	)
+	assert.Equal(t, []byte{200, 100, 0}, frame[(1*13+3)*3:(1*13+3)*3+3])
+
+	// Check that the intensity is applied to the color.
+	frame = layout.render("1", color.RGBA{200, 100, 0, 51})
+	assert.Equal(t, []byte{40, 20, 0}, frame[(1*13+6)*3:(1*13+6)*3+3])
+
+	// Check that the text is scaled up to fill a larger matrix.
+	layout = ledMatrixLayout{width: 12, height: 14}
+	rows := frameToStrings(layout, layout.render("1", whiteColor))
+	assert.Equal(t, ".....##.....", rows[0])
+	assert.Equal(t, ".....##.....", rows[1])
+	assert.Equal(t, "...####.....", rows[2])
+	assert.Equal(t, "...######...", rows[13])
+
+	// Check that serpentine wiring reverses every other row.
+	layout = ledMatrixLayout{width: 13, height: 9, serpentine: true}
+	rows = frameToStrings(layout, layout.render("17", whiteColor))
+	assert.Equal(t, ".#####...#...", rows[1])
+	assert.Equal(t, "..##.......#.", rows[2])
+
+	// Check that blank text and unknown characters render nothing.
+	assert.Equal(t, make([]byte, 13*9*3), layout.render("     ", whiteColor))
+	assert.Equal(t, make([]byte, 13*9*3), layout.render("?", whiteColor))
+}
+
+func TestDdpTeamSignOutput(t *testing.T) {
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	output, err := newDdpTeamSignOutput(conn.LocalAddr().String(), ledMatrixLayout{width: 32, height: 16})
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer output.Close()
+
+	// A 32x16 frame is too large for one packet, so it should be split in two with the push flag on the last.
+	packets := output.buildPackets(make([]byte, 32*16*3))
+	if assert.Equal(t, 2, len(packets)) {
+		assert.Equal(t, []byte{0x40, 1, 0x0b, 0x01, 0, 0, 0, 0, 0x05, 0xa0}, packets[0][:10])
+		assert.Equal(t, 10+1440, len(packets[0]))
+		assert.Equal(t, []byte{0x41, 1, 0x0b, 0x01, 0, 0, 0x05, 0xa0, 0, 0x60}, packets[1][:10])
+		assert.Equal(t, 10+96, len(packets[1]))
+	}
+
+	// Check that the frame is sent to the controller only when it changes or goes stale.
+	assert.Nil(t, output.Update("  254", redColor, "Rear Text"))
+	var buffer [2000]byte
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := conn.ReadFrom(buffer[:])
+	assert.Nil(t, err)
+	assert.Equal(t, 1450, n)
+	assert.Equal(t, byte(2), buffer[1])
+	n, _, err = conn.ReadFrom(buffer[:])
+	assert.Nil(t, err)
+	assert.Equal(t, 106, n)
+	assert.Nil(t, output.Update("  254", redColor, "Different Rear Text"))
+	assert.Equal(t, byte(2), output.sequence)
+	output.lastFrameTime = time.Now().Add(-ledMatrixRefreshPeriodMs * time.Millisecond)
+	assert.Nil(t, output.Update("  254", redColor, "Rear Text"))
+	assert.Equal(t, byte(3), output.sequence)
+}
+
+func TestSacnTeamSignOutput(t *testing.T) {
+	_, err := newSacnTeamSignOutput("127.0.0.1/0", ledMatrixLayout{})
+	assert.EqualError(t, err, "invalid sACN universe \"0\"")
+
+	output, err := newSacnTeamSignOutput("127.0.0.1/7", ledMatrixLayout{})
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer output.Close()
+	assert.Equal(t, "127.0.0.1:5568", output.udpConn.RemoteAddr().String())
+	assert.Equal(t, 32, output.layout.width)
+	assert.Equal(t, 8, output.layout.height)
+
+	// A 32x8 frame needs two universes.
+	frame := output.layout.render("12:34", whiteColor)
+	packets := output.buildPackets(frame)
+	if assert.Equal(t, 2, len(packets)) {
+		packet := packets[0]
+		assert.Equal(t, 126+510, len(packet))
+		assert.Equal(t, "ASC-E1.17\x00\x00\x00", string(packet[4:16]))
+		assert.Equal(t, []byte{0x72, 0x6c}, packet[16:18])
+		assert.Equal(t, output.cid[:], packet[22:38])
+		assert.Equal(t, []byte{0x72, 0x56}, packet[38:40])
+		assert.Equal(t, "Cheesy Arena", strings.TrimRight(string(packet[44:108]), "\x00"))
+		assert.Equal(t, byte(100), packet[108])
+		assert.Equal(t, byte(0), packet[111])
+		assert.Equal(t, []byte{0, 7}, packet[113:115])
+		assert.Equal(t, []byte{0x72, 0x09, 0x02, 0xa1, 0, 0, 0, 1, 0x01, 0xff, 0}, packet[115:126])
+		assert.Equal(t, frame[:510], packet[126:])
+
+		packet = packets[1]
+		assert.Equal(t, 126+258, len(packet))
+		assert.Equal(t, byte(1), packet[111])
+		assert.Equal(t, []byte{0, 8}, packet[113:115])
+		assert.Equal(t, []byte{0x01, 0x03}, packet[123:125])
+		assert.Equal(t, frame[510:], packet[126:])
+	}
+}
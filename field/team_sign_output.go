@@ -0,0 +1,165 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Pluggable transports for sending the content of a team sign position to different kinds of display hardware.
+
+package field
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"image/color"
+	"net"
+	"sync"
+	"time"
+)
+
+// Represents a transport that renders the content of a single sign position.
+type TeamSignOutput interface {
+	// Sends the given content to the display if it has changed or needs to be refreshed.
+	Update(frontText string, frontColor color.RGBA, rearText string) error
+
+	// Releases any resources held by the output.
+	Close()
+}
+
+// Creates an output of the given type, or returns nil if the position has not been given the ID or address it needs.
+// The callback is invoked whenever the content of a browser kiosk output changes.
+func newTeamSignOutput(
+	outputType model.TeamSignOutputType, id int, address string, matrix ledMatrixLayout, onKioskUpdate func(),
+) (TeamSignOutput, error) {
+	switch outputType {
+	case model.CypressTeamSignOutput:
+		if id == 0 {
+			return nil, nil
+		}
+		return newCypressTeamSignOutput(id, fmt.Sprintf("%s%d:%d", teamSignAddressPrefix, id, teamSignPort))
+	case model.DdpTeamSignOutput:
+		if address == "" {
+			return nil, nil
+		}
+		return newDdpTeamSignOutput(address, matrix)
+	case model.SacnTeamSignOutput:
+		if address == "" {
+			return nil, nil
+		}
+		return newSacnTeamSignOutput(address, matrix)
+	case model.KioskTeamSignOutput:
+		return &kioskTeamSignOutput{onUpdate: onKioskUpdate}, nil
+	default:
+		return nil, fmt.Errorf("invalid team sign output type %d", outputType)
+	}
+}
+
+// Sends content to a Cypress sign using its native UDP protocol, only including the fields that have changed.
+type cypressTeamSignOutput struct {
+	address        byte
+	udpConn        net.Conn
+	lastFrontText  string
+	lastFrontColor color.RGBA
+	lastRearText   string
+	packetData     [128]byte
+	packetIndex    int
+	lastPacketTime time.Time
+}
+
+func newCypressTeamSignOutput(id int, udpAddress string) (*cypressTeamSignOutput, error) {
+	udpConn, err := net.Dial("udp4", udpAddress)
+	if err != nil {
+		return nil, err
+	}
+	return &cypressTeamSignOutput{address: byte(id), udpConn: udpConn}, nil
+}
+
+// Sends a UDP packet to the sign if its state has changed.
+func (output *cypressTeamSignOutput) Update(frontText string, frontColor color.RGBA, rearText string) error {
+	if output.packetIndex == 0 {
+		// Write the static packet header the first time this method is invoked.
+		output.writePacketData([]byte(teamSignPacketMagicString))
+		output.writePacketData([]byte{output.address, teamSignCommandSetDisplay})
+	} else {
+		// Reset the write index to just after the header.
+		output.packetIndex = teamSignPacketHeaderLength
+	}
+
+	isStale := time.Now().Sub(output.lastPacketTime).Milliseconds() >= teamSignPacketPeriodMs
+
+	if frontText != output.lastFrontText || isStale {
+		output.writePacketData([]byte{teamSignAddressSingle, output.address, teamSignPacketTypeFrontText})
+		output.writePacketData([]byte(frontText))
+		output.writePacketData([]byte{0, 0}) // Second byte is "show decimal point".
+		output.lastFrontText = frontText
+	}
+
+	if frontColor != output.lastFrontColor || isStale {
+		output.writePacketData([]byte{teamSignAddressSingle, output.address, teamSignPacketTypeColor})
+		output.writePacketData([]byte{frontColor.R, frontColor.G, frontColor.B})
+		output.writePacketData([]byte{teamSignAddressSingle, output.address, teamSignPacketTypeFrontIntensity})
+		output.writePacketData([]byte{frontColor.A})
+		output.lastFrontColor = frontColor
+	}
+
+	if rearText != output.lastRearText || isStale {
+		output.writePacketData([]byte{teamSignAddressSingle, output.address, teamSignPacketTypeRearText})
+		output.writePacketData([]byte(rearText))
+		output.writePacketData([]byte{0})
+		output.lastRearText = rearText
+	}
+
+	if output.packetIndex > teamSignPacketHeaderLength {
+		output.lastPacketTime = time.Now()
+		if _, err := output.udpConn.Write(output.packetData[:output.packetIndex]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (output *cypressTeamSignOutput) Close() {
+	_ = output.udpConn.Close()
+}
+
+// Writes the given data to the packet buffer and advances the write index.
+func (output *cypressTeamSignOutput) writePacketData(data []byte) {
+	for _, value := range data {
+		output.packetData[output.packetIndex] = value
+		output.packetIndex++
+	}
+}
+
+// Holds the content of a sign position for display by a browser kiosk on the Team Signs display.
+type kioskTeamSignOutput struct {
+	sign     SimulatedTeamSign
+	onUpdate func()
+	mutex    sync.Mutex
+}
+
+func (output *kioskTeamSignOutput) Update(frontText string, frontColor color.RGBA, rearText string) error {
+	output.mutex.Lock()
+	changed := output.sign.LastPacketTime.IsZero() || frontText != output.sign.FrontText ||
+		frontColor != output.sign.FrontColor || rearText != output.sign.RearText
+	output.sign.FrontText = frontText
+	output.sign.FrontColor = frontColor
+	output.sign.RearText = rearText
+	output.sign.LastPacketTime = time.Now()
+	output.mutex.Unlock()
+
+	if changed && output.onUpdate != nil {
+		output.onUpdate()
+	}
+	return nil
+}
+
+func (output *kioskTeamSignOutput) Close() {
+}
+
+// Returns a copy of the content currently shown by the kiosk, or nil if it hasn't received any yet.
+func (output *kioskTeamSignOutput) getSign() *SimulatedTeamSign {
+	output.mutex.Lock()
+	defer output.mutex.Unlock()
+	if output.sign.LastPacketTime.IsZero() {
+		return nil
+	}
+	sign := output.sign
+	return &sign
+}
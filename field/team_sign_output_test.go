@@ -0,0 +1,106 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewTeamSignOutput(t *testing.T) {
+	// Positions missing the ID or address their output type needs should be left unconfigured.
+	output, err := newTeamSignOutput(model.CypressTeamSignOutput, 0, "10.0.100.99", ledMatrixLayout{}, nil)
+	assert.Nil(t, output)
+	assert.Nil(t, err)
+	output, err = newTeamSignOutput(model.DdpTeamSignOutput, 51, "", ledMatrixLayout{}, nil)
+	assert.Nil(t, output)
+	assert.Nil(t, err)
+	output, err = newTeamSignOutput(model.SacnTeamSignOutput, 51, "", ledMatrixLayout{}, nil)
+	assert.Nil(t, output)
+	assert.Nil(t, err)
+	output, err = newTeamSignOutput(17, 51, "", ledMatrixLayout{}, nil)
+	assert.Nil(t, output)
+	assert.EqualError(t, err, "invalid team sign output type 17")
+
+	output, err = newTeamSignOutput(model.CypressTeamSignOutput, 51, "", ledMatrixLayout{}, nil)
+	if assert.Nil(t, err) {
+		assert.Equal(t, "10.0.100.51:10011", output.(*cypressTeamSignOutput).udpConn.RemoteAddr().String())
+		output.Close()
+	}
+	output, err = newTeamSignOutput(model.DdpTeamSignOutput, 0, "127.0.0.1", ledMatrixLayout{}, nil)
+	if assert.Nil(t, err) {
+		assert.Equal(t, "127.0.0.1:4048", output.(*ddpTeamSignOutput).udpConn.RemoteAddr().String())
+		output.Close()
+	}
+	output, err = newTeamSignOutput(model.DdpTeamSignOutput, 0, "127.0.0.1:4049", ledMatrixLayout{}, nil)
+	if assert.Nil(t, err) {
+		assert.Equal(t, "127.0.0.1:4049", output.(*ddpTeamSignOutput).udpConn.RemoteAddr().String())
+		output.Close()
+	}
+}
+
+func TestArena_TeamSignOutputs(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.EventSettings.TeamSignRed1Id = 51
+	arena.EventSettings.TeamSignRed2OutputType = model.KioskTeamSignOutput
+	arena.EventSettings.TeamSignRed3OutputType = model.DdpTeamSignOutput
+	arena.EventSettings.TeamSignRed3Address = "127.0.0.1"
+	arena.EventSettings.TeamSignBlue1OutputType = model.SacnTeamSignOutput
+	arena.EventSettings.TeamSignBlue1Address = "127.0.0.1/3"
+	arena.EventSettings.TeamSignMatrixWidth = 64
+	arena.EventSettings.TeamSignMatrixHeight = 16
+	arena.EventSettings.TeamSignMatrixSerpentine = true
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+
+	assert.IsType(t, &cypressTeamSignOutput{}, arena.TeamSigns.Red1.output)
+	assert.IsType(t, &kioskTeamSignOutput{}, arena.TeamSigns.Red2.output)
+	if assert.IsType(t, &ddpTeamSignOutput{}, arena.TeamSigns.Red3.output) {
+		layout := arena.TeamSigns.Red3.output.(*ddpTeamSignOutput).layout
+		assert.Equal(t, ledMatrixLayout{width: 64, height: 16, serpentine: true}, layout)
+	}
+	if assert.IsType(t, &sacnTeamSignOutput{}, arena.TeamSigns.Blue1.output) {
+		assert.Equal(t, 3, arena.TeamSigns.Blue1.output.(*sacnTeamSignOutput).startUniverse)
+	}
+	assert.Nil(t, arena.TeamSigns.RedTimer.output)
+
+	// Only the kiosk output should be reflected in the simulated states when the simulator isn't running.
+	assert.Nil(t, arena.TeamSigns.SimulatedStates(arena.TeamSignSimulator).Red2)
+	arena.AllianceStations["R2"].Team = &model.Team{Id: 971}
+	arena.TeamSigns.Update(arena)
+	states := arena.TeamSigns.SimulatedStates(arena.TeamSignSimulator)
+	assert.Nil(t, states.Red1)
+	if assert.NotNil(t, states.Red2) {
+		assert.Equal(t, "  971", states.Red2.FrontText)
+		assert.Equal(t, redColor, states.Red2.FrontColor)
+		assert.Equal(t, "971       Connect PC", states.Red2.RearText)
+	}
+	assert.Nil(t, states.Red3)
+
+	// Reconfiguring a position should replace its output.
+	arena.EventSettings.TeamSignRed2OutputType = model.CypressTeamSignOutput
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.Nil(t, arena.TeamSigns.Red2.output)
+}
+
+func TestKioskTeamSignOutput(t *testing.T) {
+	updateCount := 0
+	output := &kioskTeamSignOutput{onUpdate: func() { updateCount++ }}
+	assert.Nil(t, output.getSign())
+
+	assert.Nil(t, output.Update("12:34", whiteColor, "Rear"))
+	assert.Equal(t, 1, updateCount)
+	if sign := output.getSign(); assert.NotNil(t, sign) {
+		assert.Equal(t, "12:34", sign.FrontText)
+		assert.Equal(t, whiteColor, sign.FrontColor)
+		assert.Equal(t, "Rear", sign.RearText)
+	}
+
+	// Unchanged content shouldn't trigger a notification.
+	assert.Nil(t, output.Update("12:34", whiteColor, "Rear"))
+	assert.Equal(t, 1, updateCount)
+	assert.Nil(t, output.Update("12:33", whiteColor, "Rear"))
+	assert.Equal(t, 2, updateCount)
+}
@@ -0,0 +1,258 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Simulator that decodes the Cypress team sign protocol so that sign output can be viewed without the hardware.
+
+package field
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"log"
+	"net"
+	"sync"
+	"time"
+)
+
+// Address on which the simulator listens for sign packets when enabled; overridden in tests.
+var teamSignSimulatorListenAddress = fmt.Sprintf("127.0.0.1:%d", teamSignPort)
+
+// Represents the state of a single simulated sign, as reconstructed from the packets sent to it.
+type SimulatedTeamSign struct {
+	Address        int
+	FrontText      string
+	FrontColor     color.RGBA // The "A" channel is the front intensity.
+	RearText       string
+	LastPacketTime time.Time
+}
+
+// Represents the simulated state of the full collection of signs, keyed by position.
+type SimulatedTeamSigns struct {
+	Red1      *SimulatedTeamSign
+	Red2      *SimulatedTeamSign
+	Red3      *SimulatedTeamSign
+	RedTimer  *SimulatedTeamSign
+	Blue1     *SimulatedTeamSign
+	Blue2     *SimulatedTeamSign
+	Blue3     *SimulatedTeamSign
+	BlueTimer *SimulatedTeamSign
+}
+
+type TeamSignSimulator struct {
+	signs    map[int]SimulatedTeamSign
+	conn     net.PacketConn
+	onUpdate func()
+	mutex    sync.Mutex
+}
+
+// Creates a new simulator which invokes the given callback whenever the displayed content of any sign changes.
+func NewTeamSignSimulator(onUpdate func()) *TeamSignSimulator {
+	return &TeamSignSimulator{signs: make(map[int]SimulatedTeamSign), onUpdate: onUpdate}
+}
+
+// Starts listening for sign packets on the given UDP address if not already doing so.
+func (simulator *TeamSignSimulator) Listen(address string) error {
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+	if simulator.conn != nil {
+		return nil
+	}
+	conn, err := net.ListenPacket("udp4", address)
+	if err != nil {
+		return err
+	}
+	simulator.conn = conn
+	log.Printf("Listening for team sign packets on UDP address %s", conn.LocalAddr())
+	go simulator.receivePackets(conn)
+	return nil
+}
+
+// Stops listening for sign packets and clears the simulated state.
+func (simulator *TeamSignSimulator) Close() {
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+	if simulator.conn != nil {
+		_ = simulator.conn.Close()
+		simulator.conn = nil
+	}
+	simulator.signs = make(map[int]SimulatedTeamSign)
+}
+
+// Returns the UDP address that the simulator is listening on, or the empty string if it is not running.
+func (simulator *TeamSignSimulator) LocalAddress() string {
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+	if simulator.conn == nil {
+		return ""
+	}
+	return simulator.conn.LocalAddr().String()
+}
+
+// Returns the current state of the sign with the given address, or nil if no packets have been received for it.
+func (simulator *TeamSignSimulator) GetSign(address int) *SimulatedTeamSign {
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+	if sign, ok := simulator.signs[address]; ok {
+		return &sign
+	}
+	return nil
+}
+
+// Loops indefinitely to read packets from the given connection until it is closed.
+func (simulator *TeamSignSimulator) receivePackets(conn net.PacketConn) {
+	var buffer [256]byte
+	for {
+		n, _, err := conn.ReadFrom(buffer[:])
+		if err != nil {
+			// The connection has been closed.
+			return
+		}
+		changed, err := simulator.handlePacket(buffer[:n])
+		if err != nil {
+			log.Printf("Invalid team sign packet: %v", err)
+			continue
+		}
+		if changed && simulator.onUpdate != nil {
+			simulator.onUpdate()
+		}
+	}
+}
+
+// Applies the given packet to the state of the sign it is addressed to. Returns true if the displayed content changed.
+func (simulator *TeamSignSimulator) handlePacket(data []byte) (bool, error) {
+	simulator.mutex.Lock()
+	address := 0
+	if len(data) > len(teamSignPacketMagicString) {
+		address = int(data[len(teamSignPacketMagicString)])
+	}
+	oldSign, ok := simulator.signs[address]
+	simulator.mutex.Unlock()
+
+	sign := oldSign
+	if !ok {
+		sign = SimulatedTeamSign{Address: address}
+	}
+	if err := DecodeTeamSignPacket(data, &sign); err != nil {
+		return false, err
+	}
+	sign.LastPacketTime = time.Now()
+
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+	simulator.signs[address] = sign
+	changed := !ok || sign.FrontText != oldSign.FrontText || sign.FrontColor != oldSign.FrontColor ||
+		sign.RearText != oldSign.RearText
+	return changed, nil
+}
+
+// Decodes the given Cypress sign packet and applies each of the updates it contains to the given sign state. The sign
+// state is left unmodified if the packet is malformed.
+func DecodeTeamSignPacket(data []byte, sign *SimulatedTeamSign) error {
+	if len(data) < teamSignPacketHeaderLength ||
+		string(data[:len(teamSignPacketMagicString)]) != teamSignPacketMagicString {
+		return fmt.Errorf("missing %s header", teamSignPacketMagicString)
+	}
+	address := int(data[len(teamSignPacketMagicString)])
+	if command := data[len(teamSignPacketMagicString)+1]; command != teamSignCommandSetDisplay {
+		return fmt.Errorf("unsupported command 0x%02x", command)
+	}
+
+	decoded := *sign
+	decoded.Address = address
+	index := teamSignPacketHeaderLength
+	for index < len(data) {
+		if len(data)-index < 3 {
+			return fmt.Errorf("truncated record at byte %d", index)
+		}
+		if data[index] != teamSignAddressSingle || int(data[index+1]) != address {
+			return fmt.Errorf("record at byte %d is not addressed to sign %d", index, address)
+		}
+		packetType := data[index+2]
+		index += 3
+
+		switch packetType {
+		case teamSignPacketTypeFrontText:
+			// The text is null-terminated and followed by a byte indicating whether to show the decimal point.
+			end := bytes.IndexByte(data[index:], 0)
+			if end < 0 || index+end+1 >= len(data) {
+				return fmt.Errorf("unterminated front text at byte %d", index)
+			}
+			decoded.FrontText = string(data[index : index+end])
+			index += end + 2
+		case teamSignPacketTypeRearText:
+			end := bytes.IndexByte(data[index:], 0)
+			if end < 0 {
+				return fmt.Errorf("unterminated rear text at byte %d", index)
+			}
+			decoded.RearText = string(data[index : index+end])
+			index += end + 1
+		case teamSignPacketTypeColor:
+			if len(data)-index < 3 {
+				return fmt.Errorf("truncated color at byte %d", index)
+			}
+			decoded.FrontColor.R, decoded.FrontColor.G, decoded.FrontColor.B = data[index], data[index+1], data[index+2]
+			index += 3
+		case teamSignPacketTypeFrontIntensity:
+			if len(data)-index < 1 {
+				return fmt.Errorf("truncated intensity at byte %d", index)
+			}
+			decoded.FrontColor.A = data[index]
+			index++
+		default:
+			return fmt.Errorf("unsupported packet type 0x%02x at byte %d", packetType, index-1)
+		}
+	}
+
+	*sign = decoded
+	return nil
+}
+
+// Returns the simulated state of each sign position, including those driven by browser kiosk outputs, or nil for
+// positions that are unconfigured or have not yet received any content.
+func (signs *TeamSigns) SimulatedStates(simulator *TeamSignSimulator) SimulatedTeamSigns {
+	simulatedSign := func(sign *TeamSign) *SimulatedTeamSign {
+		switch output := sign.output.(type) {
+		case *cypressTeamSignOutput:
+			return simulator.GetSign(int(output.address))
+		case *kioskTeamSignOutput:
+			return output.getSign()
+		}
+		return nil
+	}
+	return SimulatedTeamSigns{
+		Red1:      simulatedSign(&signs.Red1),
+		Red2:      simulatedSign(&signs.Red2),
+		Red3:      simulatedSign(&signs.Red3),
+		RedTimer:  simulatedSign(&signs.RedTimer),
+		Blue1:     simulatedSign(&signs.Blue1),
+		Blue2:     simulatedSign(&signs.Blue2),
+		Blue3:     simulatedSign(&signs.Blue3),
+		BlueTimer: simulatedSign(&signs.BlueTimer),
+	}
+}
+
+// Returns the sign IDs to use for each position when the simulator is enabled, in the order Red1, Red2, Red3, RedTimer,
+// Blue1, Blue2, Blue3, BlueTimer. Configured IDs are kept, and unconfigured positions are assigned the lowest unused
+// IDs so that every position can be simulated even when no signs have been rented.
+func simulatedTeamSignIds(configuredIds [8]int) [8]int {
+	usedIds := make(map[int]struct{})
+	for _, id := range configuredIds {
+		usedIds[id] = struct{}{}
+	}
+	ids := configuredIds
+	nextId := 1
+	for i := range ids {
+		if ids[i] != 0 {
+			continue
+		}
+		for {
+			if _, ok := usedIds[nextId]; !ok {
+				break
+			}
+			nextId++
+		}
+		ids[i] = nextId
+		usedIds[nextId] = struct{}{}
+	}
+	return ids
+}
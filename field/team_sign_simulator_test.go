@@ -0,0 +1,121 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"image/color"
+	"testing"
+	"time"
+)
+
+func TestDecodeTeamSignPacket(t *testing.T) {
+	simulator := NewTeamSignSimulator(nil)
+	assert.Nil(t, simulator.Listen("127.0.0.1:0"))
+	defer simulator.Close()
+	output, err := newCypressTeamSignOutput(56, simulator.LocalAddress())
+	assert.Nil(t, err)
+	assert.Nil(t, output.Update("12:34", orangeColor, "Rear Text"))
+
+	var simulatedSign SimulatedTeamSign
+	if assert.Nil(t, DecodeTeamSignPacket(output.packetData[:output.packetIndex], &simulatedSign)) {
+		assert.Equal(t, 56, simulatedSign.Address)
+		assert.Equal(t, "12:34", simulatedSign.FrontText)
+		assert.Equal(t, orangeColor, simulatedSign.FrontColor)
+		assert.Equal(t, "Rear Text", simulatedSign.RearText)
+	}
+
+	// A subsequent packet containing only the changed rear text should leave the rest of the state alone.
+	assert.Nil(t, output.Update("12:34", orangeColor, "Next Team Up: 254"))
+	assert.Equal(t, 28, output.packetIndex)
+	if assert.Nil(t, DecodeTeamSignPacket(output.packetData[:output.packetIndex], &simulatedSign)) {
+		assert.Equal(t, "12:34", simulatedSign.FrontText)
+		assert.Equal(t, orangeColor, simulatedSign.FrontColor)
+		assert.Equal(t, "Next Team Up: 254", simulatedSign.RearText)
+	}
+
+	// Check that malformed packets are rejected without modifying the state.
+	before := simulatedSign
+	err = DecodeTeamSignPacket([]byte("HELLO"), &simulatedSign)
+	assert.EqualError(t, err, "missing CYPRX header")
+	err = DecodeTeamSignPacket([]byte{'C', 'Y', 'P', 'R', 'X', 56, 0x07}, &simulatedSign)
+	assert.EqualError(t, err, "unsupported command 0x07")
+	err = DecodeTeamSignPacket([]byte{'C', 'Y', 'P', 'R', 'X', 56, 0x04, 0x01, 57, 0x01, 'A', 0, 0}, &simulatedSign)
+	assert.EqualError(t, err, "record at byte 7 is not addressed to sign 56")
+	err = DecodeTeamSignPacket([]byte{'C', 'Y', 'P', 'R', 'X', 56, 0x04, 0x01, 56, 0x02, 'A', 'B'}, &simulatedSign)
+	assert.EqualError(t, err, "unterminated rear text at byte 10")
+	err = DecodeTeamSignPacket([]byte{'C', 'Y', 'P', 'R', 'X', 56, 0x04, 0x01, 56, 0x04, 1, 2}, &simulatedSign)
+	assert.EqualError(t, err, "truncated color at byte 10")
+	err = DecodeTeamSignPacket([]byte{'C', 'Y', 'P', 'R', 'X', 56, 0x04, 0x01, 56, 0x09}, &simulatedSign)
+	assert.EqualError(t, err, "unsupported packet type 0x09 at byte 9")
+	assert.Equal(t, before, simulatedSign)
+}
+
+func TestSimulatedTeamSignIds(t *testing.T) {
+	assert.Equal(t, [8]int{1, 2, 3, 4, 5, 6, 7, 8}, simulatedTeamSignIds([8]int{}))
+	assert.Equal(
+		t, [8]int{51, 1, 2, 3, 4, 5, 6, 58}, simulatedTeamSignIds([8]int{51, 0, 0, 0, 0, 0, 0, 58}),
+	)
+	assert.Equal(t, [8]int{3, 1, 2, 4, 5, 6, 7, 8}, simulatedTeamSignIds([8]int{3, 1, 0, 0, 0, 0, 0, 0}))
+}
+
+func TestArena_TeamSignSimulator(t *testing.T) {
+	teamSignSimulatorListenAddress = "127.0.0.1:0"
+	arena := setupTestArena(t)
+	defer arena.TeamSignSimulator.Close()
+	assert.Equal(t, "", arena.TeamSignSimulator.LocalAddress())
+
+	arena.EventSettings.TeamSignRed1Id = 51
+	arena.EventSettings.TeamSignSimulatorEnabled = true
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.NotEqual(t, "", arena.TeamSignSimulator.LocalAddress())
+
+	arena.AllianceStations["R1"].Team = &model.Team{Id: 254}
+	arena.AllianceStations["B3"].Team = &model.Team{Id: 1678}
+	arena.TeamSigns.Update(arena)
+	assert.Eventually(
+		t,
+		func() bool {
+			states := arena.TeamSigns.SimulatedStates(arena.TeamSignSimulator)
+			return states.Red1 != nil && states.Blue3 != nil && states.BlueTimer != nil
+		},
+		time.Second,
+		10*time.Millisecond,
+	)
+	states := arena.TeamSigns.SimulatedStates(arena.TeamSignSimulator)
+	assert.Equal(t, 51, states.Red1.Address)
+	assert.Equal(t, "  254", states.Red1.FrontText)
+	assert.Equal(t, redColor, states.Red1.FrontColor)
+	assert.Equal(t, "254       Connect PC", states.Red1.RearText)
+	assert.Equal(t, " 1678", states.Blue3.FrontText)
+	assert.Equal(t, blueColor, states.Blue3.FrontColor)
+	assert.Equal(t, "00:15", states.BlueTimer.FrontText)
+	assert.Equal(t, whiteColor, states.BlueTimer.FrontColor)
+	assert.Equal(t, "     ", states.Red2.FrontText)
+
+	// Disabling the simulator should stop it and revert the signs to their physical addresses.
+	arena.EventSettings.TeamSignSimulatorEnabled = false
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.Equal(t, "", arena.TeamSignSimulator.LocalAddress())
+	assert.Equal(t, SimulatedTeamSigns{}, arena.TeamSigns.SimulatedStates(arena.TeamSignSimulator))
+}
+
+func TestTeamSignSimulator_HandlePacket(t *testing.T) {
+	simulator := NewTeamSignSimulator(nil)
+	packet := []byte{'C', 'Y', 'P', 'R', 'X', 12, 0x04, 0x01, 12, 0x03, 100}
+	changed, err := simulator.handlePacket(packet)
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	if sign := simulator.GetSign(12); assert.NotNil(t, sign) {
+		assert.Equal(t, color.RGBA{0, 0, 0, 100}, sign.FrontColor)
+	}
+
+	// A repeated packet shouldn't count as a change.
+	changed, err = simulator.handlePacket(packet)
+	assert.Nil(t, err)
+	assert.False(t, changed)
+	assert.Nil(t, simulator.GetSign(13))
+}
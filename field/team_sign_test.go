@@ -30,18 +30,19 @@ func TestTeamSign_Timer(t *testing.T) {
 
 	// Should do nothing if no address is set.
 	sign.update(arena, nil, true, "12:34", "Rear Text")
-	assert.Equal(t, [128]byte{}, sign.packetData)
+	assert.Equal(t, "", sign.frontText)
 
 	// Check some basics about the data but don't unit-test the whole packet.
 	sign.SetId(56)
+	output := sign.output.(*cypressTeamSignOutput)
 	sign.update(arena, nil, true, "12:34", "Rear Text")
-	assert.Equal(t, "CYPRX", string(sign.packetData[0:5]))
-	assert.Equal(t, 56, int(sign.packetData[5]))
-	assert.Equal(t, 0x04, int(sign.packetData[6]))
-	assert.Equal(t, "12:34", string(sign.packetData[10:15]))
-	assert.Equal(t, []byte{0, 0}, sign.packetData[15:17])
-	assert.Equal(t, "Rear Text", string(sign.packetData[30:39]))
-	assert.Equal(t, 40, sign.packetIndex)
+	assert.Equal(t, "CYPRX", string(output.packetData[0:5]))
+	assert.Equal(t, 56, int(output.packetData[5]))
+	assert.Equal(t, 0x04, int(output.packetData[6]))
+	assert.Equal(t, "12:34", string(output.packetData[10:15]))
+	assert.Equal(t, []byte{0, 0}, output.packetData[15:17])
+	assert.Equal(t, "Rear Text", string(output.packetData[30:39]))
+	assert.Equal(t, 40, output.packetIndex)
 
 	assertSign := func(expectedFrontText string, expectedFrontColor color.RGBA, expectedRearText string) {
 		frontText, frontColor, rearText := generateTimerTexts(arena, "23:45", "Rear Text")
@@ -84,19 +85,20 @@ func TestTeamSign_TeamNumber(t *testing.T) {
 
 	// Should do nothing if no address is set.
 	sign.update(arena, allianceStation, true, "12:34", "Rear Text")
-	assert.Equal(t, [128]byte{}, sign.packetData)
+	assert.Equal(t, "", sign.frontText)
 
 	// Check some basics about the data but don't unit-test the whole packet.
 	sign.SetId(53)
+	output := sign.output.(*cypressTeamSignOutput)
 	sign.update(arena, allianceStation, true, "12:34", "Rear Text")
-	assert.Equal(t, "CYPRX", string(sign.packetData[0:5]))
-	assert.Equal(t, 53, int(sign.packetData[5]))
-	assert.Equal(t, 0x04, int(sign.packetData[6]))
-	assert.Equal(t, []byte{0x01, 53, 0x01}, sign.packetData[7:10])
-	assert.Equal(t, "     ", string(sign.packetData[10:15]))
-	assert.Equal(t, []byte{0, 0}, sign.packetData[15:17])
-	assert.Equal(t, "No Team Assigned", string(sign.packetData[34:50]))
-	assert.Equal(t, 51, sign.packetIndex)
+	assert.Equal(t, "CYPRX", string(output.packetData[0:5]))
+	assert.Equal(t, 53, int(output.packetData[5]))
+	assert.Equal(t, 0x04, int(output.packetData[6]))
+	assert.Equal(t, []byte{0x01, 53, 0x01}, output.packetData[7:10])
+	assert.Equal(t, "     ", string(output.packetData[10:15]))
+	assert.Equal(t, []byte{0, 0}, output.packetData[15:17])
+	assert.Equal(t, "No Team Assigned", string(output.packetData[34:50]))
+	assert.Equal(t, 51, output.packetIndex)
 
 	assertSign := func(isRed bool, expectedFrontText string, expectedFrontColor color.RGBA, expectedRearText string) {
 		frontText, frontColor, rearText := sign.generateTeamNumberTexts(
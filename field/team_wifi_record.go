@@ -0,0 +1,86 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Functions for accumulating each team's Wi-Fi link quality over the course of a match and saving it to the database.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"log"
+	"time"
+)
+
+const (
+	wifiBandwidthCapMbits   = 4
+	maxWifiSampleIntervalMs = 1000
+)
+
+// Begins a new Wi-Fi record for each team in the match that is about to start.
+func (arena *Arena) startTeamWifiRecords() {
+	arena.teamWifiRecords = make(map[string]*model.TeamWifiRecord)
+	arena.lastWifiSampleTime = time.Now()
+	if arena.CurrentMatch.Type == model.Test {
+		return
+	}
+	for station, allianceStation := range arena.AllianceStations {
+		if allianceStation.Team == nil {
+			continue
+		}
+		arena.teamWifiRecords[station] = &model.TeamWifiRecord{
+			TeamId:          allianceStation.Team.Id,
+			MatchId:         arena.CurrentMatch.Id,
+			MatchType:       arena.CurrentMatch.Type,
+			MatchShortName:  arena.CurrentMatch.ShortName,
+			AllianceStation: station,
+			StartedAt:       time.Now(),
+		}
+	}
+}
+
+// Adds the current Wi-Fi status of each team to its record, attributing the time since the last sample to whatever
+// state the team is in now.
+func (arena *Arena) sampleTeamWifiRecords() {
+	if len(arena.teamWifiRecords) == 0 {
+		return
+	}
+	now := time.Now()
+	intervalSec := now.Sub(arena.lastWifiSampleTime).Seconds()
+	arena.lastWifiSampleTime = now
+	if intervalSec*1000 > maxWifiSampleIntervalMs {
+		// Avoid attributing a long stall in the arena loop to a single sample.
+		intervalSec = maxWifiSampleIntervalMs / 1000.0
+	}
+	if arena.MatchState != AutoPeriod && arena.MatchState != PausePeriod && arena.MatchState != TeleopPeriod {
+		return
+	}
+
+	for station, record := range arena.teamWifiRecords {
+		allianceStation := arena.AllianceStations[station]
+		wifiStatus := allianceStation.WifiStatus
+		if wifiStatus.RadioLinked && wifiStatus.SignalNoiseRatio > 0 {
+			record.SnrSamples = append(record.SnrSamples, wifiStatus.SignalNoiseRatio)
+		}
+		if wifiStatus.MBits > record.MaxMBits {
+			record.MaxMBits = wifiStatus.MBits
+		}
+		if wifiStatus.MBits > wifiBandwidthCapMbits {
+			record.OverBandwidthSec += intervalSec
+		}
+		if dsConn := allianceStation.DsConn; dsConn != nil && dsConn.Enabled {
+			record.EnabledSec += intervalSec
+			if !dsConn.RadioLinked {
+				record.RadioDownEnabledSec += intervalSec
+			}
+		}
+	}
+}
+
+// Persists the Wi-Fi records for the match that just ended.
+func (arena *Arena) saveTeamWifiRecords() {
+	for _, record := range arena.teamWifiRecords {
+		if err := arena.Database.CreateTeamWifiRecord(record); err != nil {
+			log.Printf("Failed to save Wi-Fi record for team %d: %v", record.TeamId, err)
+		}
+	}
+	arena.teamWifiRecords = nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/network"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestArena_TeamWifiRecords(t *testing.T) {
+	arena := setupTestArena(t)
+
+	match := model.Match{Type: model.Qualification, ShortName: "Q7", Red1: 254, Blue2: 1114}
+	assert.Nil(t, arena.Database.CreateMatch(&match))
+	assert.Nil(t, arena.Database.CreateTeam(&model.Team{Id: 254}))
+	assert.Nil(t, arena.Database.CreateTeam(&model.Team{Id: 1114}))
+	assert.Nil(t, arena.LoadMatch(&match))
+	arena.AllianceStations["R1"].DsConn = &DriverStationConnection{TeamId: 254}
+	arena.AllianceStations["B2"].DsConn = &DriverStationConnection{TeamId: 1114}
+
+	arena.startTeamWifiRecords()
+	assert.Equal(t, 2, len(arena.teamWifiRecords))
+
+	// Samples taken outside of the match periods should be ignored.
+	arena.MatchState = WarmupPeriod
+	arena.lastWifiSampleTime = time.Now().Add(-500 * time.Millisecond)
+	arena.sampleTeamWifiRecords()
+	assert.Equal(t, 0.0, arena.teamWifiRecords["R1"].EnabledSec)
+
+	arena.MatchState = AutoPeriod
+	arena.AllianceStations["R1"].WifiStatus = networkWifiStatus(254, true, 42, 2.5)
+	arena.AllianceStations["R1"].DsConn.Enabled = true
+	arena.AllianceStations["R1"].DsConn.RadioLinked = true
+	arena.AllianceStations["B2"].WifiStatus = networkWifiStatus(1114, false, 0, 5.2)
+	arena.AllianceStations["B2"].DsConn.Enabled = true
+	arena.lastWifiSampleTime = time.Now().Add(-500 * time.Millisecond)
+	arena.sampleTeamWifiRecords()
+
+	// A long gap between samples should be capped.
+	arena.lastWifiSampleTime = time.Now().Add(-10 * time.Second)
+	arena.sampleTeamWifiRecords()
+
+	arena.MatchState = PostMatch
+	arena.saveTeamWifiRecords()
+	assert.Nil(t, arena.teamWifiRecords)
+
+	records, err := arena.Database.GetTeamWifiRecordsByTeam(254)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(records)) {
+		assert.Equal(t, match.Id, records[0].MatchId)
+		assert.Equal(t, "Q7", records[0].MatchShortName)
+		assert.Equal(t, "R1", records[0].AllianceStation)
+		assert.Equal(t, []int{42, 42}, records[0].SnrSamples)
+		assert.InDelta(t, 1.5, records[0].EnabledSec, 0.05)
+		assert.Equal(t, 0.0, records[0].RadioDownEnabledSec)
+		assert.Equal(t, 0.0, records[0].OverBandwidthSec)
+		assert.Equal(t, 2.5, records[0].MaxMBits)
+	}
+	records, err = arena.Database.GetTeamWifiRecordsByTeam(1114)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(records)) {
+		assert.Empty(t, records[0].SnrSamples)
+		assert.InDelta(t, 1.5, records[0].RadioDownEnabledSec, 0.05)
+		assert.InDelta(t, 1.5, records[0].OverBandwidthSec, 0.05)
+		assert.Equal(t, 5.2, records[0].MaxMBits)
+	}
+
+	// Test matches should not be recorded.
+	arena.MatchState = PreMatch
+	assert.Nil(t, arena.LoadTestMatch())
+	arena.startTeamWifiRecords()
+	assert.Empty(t, arena.teamWifiRecords)
+}
+
+func networkWifiStatus(teamId int, radioLinked bool, snr int, mBits float64) network.TeamWifiStatus {
+	return network.TeamWifiStatus{TeamId: teamId, RadioLinked: radioLinked, SignalNoiseRatio: snr, MBits: mBits}
+}
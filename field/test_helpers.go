@@ -21,6 +21,7 @@ func SetupTestArena(t *testing.T, uniqueName string) *Arena {
 	model.BaseDir = ".."
 	dbPath := filepath.Join(model.BaseDir, fmt.Sprintf("%s_test.db", uniqueName))
 	os.Remove(dbPath)
+	os.Remove(model.PacketLogPath(dbPath))
 	arena, err := NewArena(dbPath)
 	assert.Nil(t, err)
 	return arena
@@ -31,3 +32,9 @@ func setupTestArena(t *testing.T) *Arena {
 	game.MatchTiming.PauseDurationSec = 2
 	return SetupTestArena(t, "field")
 }
+
+// Marks the arena's driver station and field hardware loops as already started, so that tests that promote a standby
+// don't bind to the real ports.
+func DisableTestHardwareLoops(arena *Arena) {
+	arena.hardwareLoopsOnce.Do(func() {})
+}
@@ -7,25 +7,18 @@
 package main
 
 import (
-	"github.com/Team254/cheesy-arena/field"
-	"github.com/Team254/cheesy-arena/web"
-	"log"
+	"flag"
+	"fmt"
+	"github.com/Team254/cheesy-arena/cli"
+	"os"
 )
 
-const eventDbPath = "./event.db"
-const httpPort = 8080
-
 // Main entry point for the application.
 func main() {
-	arena, err := field.NewArena(eventDbPath)
-	if err != nil {
-		log.Fatalln("Error during startup: ", err)
+	if err := cli.Run(os.Args[1:], os.Stdout); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(1)
 	}
-
-	// Start the web server in a separate goroutine.
-	web := web.NewWeb(arena)
-	go web.ServeWebInterface(httpPort)
-
-	// Run the arena state machine in the main thread.
-	arena.Run()
 }
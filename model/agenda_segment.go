@@ -0,0 +1,122 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for a segment of the event's run-of-show, such as a ceremony or a block of matches.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+type AgendaSegment struct {
+	Id               int `db:"id"`
+	Type             AgendaSegmentType
+	Title            string
+	PlannedStartTime time.Time
+	PlannedEndTime   time.Time
+	ActualStartTime  time.Time
+	ActualEndTime    time.Time
+}
+
+type AgendaSegmentType int
+
+const (
+	OtherSegment AgendaSegmentType = iota
+	CeremonySegment
+	InspectionSegment
+	MatchesSegment
+	AllianceSelectionSegment
+	AwardsSegment
+	MealSegment
+	BreakSegment
+)
+
+// The segment types in the order in which they are offered when configuring the agenda.
+var AgendaSegmentTypes = []AgendaSegmentType{
+	CeremonySegment,
+	InspectionSegment,
+	MatchesSegment,
+	AllianceSelectionSegment,
+	AwardsSegment,
+	MealSegment,
+	BreakSegment,
+	OtherSegment,
+}
+
+func (segmentType AgendaSegmentType) String() string {
+	switch segmentType {
+	case CeremonySegment:
+		return "Ceremony"
+	case InspectionSegment:
+		return "Inspection"
+	case MatchesSegment:
+		return "Matches"
+	case AllianceSelectionSegment:
+		return "Alliance Selection"
+	case AwardsSegment:
+		return "Awards"
+	case MealSegment:
+		return "Meal"
+	case BreakSegment:
+		return "Break"
+	default:
+		return "Other"
+	}
+}
+
+func (database *Database) CreateAgendaSegment(segment *AgendaSegment) error {
+	return database.agendaSegmentTable.create(segment)
+}
+
+func (database *Database) GetAgendaSegmentById(id int) (*AgendaSegment, error) {
+	return database.agendaSegmentTable.getById(id)
+}
+
+func (database *Database) UpdateAgendaSegment(segment *AgendaSegment) error {
+	return database.agendaSegmentTable.update(segment)
+}
+
+func (database *Database) DeleteAgendaSegment(id int) error {
+	return database.agendaSegmentTable.delete(id)
+}
+
+func (database *Database) TruncateAgendaSegments() error {
+	return database.agendaSegmentTable.truncate()
+}
+
+// Returns all the agenda segments in the order in which they are planned to start.
+func (database *Database) GetAllAgendaSegments() ([]AgendaSegment, error) {
+	segments, err := database.agendaSegmentTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].PlannedStartTime.Before(segments[j].PlannedStartTime)
+	})
+	return segments, nil
+}
+
+// Returns true if the segment has been started but not yet ended.
+func (segment *AgendaSegment) IsInProgress() bool {
+	return !segment.ActualStartTime.IsZero() && segment.ActualEndTime.IsZero()
+}
+
+// Returns true if the segment has been ended.
+func (segment *AgendaSegment) IsComplete() bool {
+	return !segment.ActualEndTime.IsZero()
+}
+
+// Returns how many minutes behind its plan the segment is running as of the given time, or a negative number if it is
+// ahead. A segment is behind if it started late or has run past its planned end.
+func (segment *AgendaSegment) MinutesLate(now time.Time) float64 {
+	minutesLate := segment.ActualStartTime.Sub(segment.PlannedStartTime).Minutes()
+	endTime := segment.ActualEndTime
+	if endTime.IsZero() {
+		endTime = now
+	}
+	if overrunMinutes := endTime.Sub(segment.PlannedEndTime).Minutes(); overrunMinutes > minutesLate {
+		minutesLate = overrunMinutes
+	}
+	return minutesLate
+}
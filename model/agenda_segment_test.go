@@ -0,0 +1,75 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAgendaSegmentCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	segment1 := AgendaSegment{
+		Type:             MealSegment,
+		Title:            "Lunch",
+		PlannedStartTime: time.Unix(3600, 0).UTC(),
+		PlannedEndTime:   time.Unix(7200, 0).UTC(),
+	}
+	segment2 := AgendaSegment{
+		Type:             CeremonySegment,
+		Title:            "Opening Ceremonies",
+		PlannedStartTime: time.Unix(0, 0).UTC(),
+		PlannedEndTime:   time.Unix(1800, 0).UTC(),
+	}
+	assert.Nil(t, db.CreateAgendaSegment(&segment1))
+	assert.Nil(t, db.CreateAgendaSegment(&segment2))
+	segment, err := db.GetAgendaSegmentById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, segment1, *segment)
+
+	segments, err := db.GetAllAgendaSegments()
+	assert.Nil(t, err)
+	assert.Equal(t, []AgendaSegment{segment2, segment1}, segments)
+
+	segment2.ActualStartTime = time.Unix(60, 0).UTC()
+	assert.Nil(t, db.UpdateAgendaSegment(&segment2))
+	segment, _ = db.GetAgendaSegmentById(2)
+	assert.Equal(t, segment2, *segment)
+
+	assert.Nil(t, db.DeleteAgendaSegment(segment1.Id))
+	segment, err = db.GetAgendaSegmentById(segment1.Id)
+	assert.Nil(t, err)
+	assert.Nil(t, segment)
+
+	assert.Nil(t, db.TruncateAgendaSegments())
+	segments, _ = db.GetAllAgendaSegments()
+	assert.Empty(t, segments)
+}
+
+func TestAgendaSegmentStatus(t *testing.T) {
+	segment := AgendaSegment{PlannedStartTime: time.Unix(1000, 0), PlannedEndTime: time.Unix(2800, 0)}
+	assert.False(t, segment.IsInProgress())
+	assert.False(t, segment.IsComplete())
+
+	// Check lateness when the segment starts late or early and while it is running.
+	segment.ActualStartTime = time.Unix(1300, 0)
+	assert.True(t, segment.IsInProgress())
+	assert.Equal(t, 5.0, segment.MinutesLate(time.Unix(2000, 0)))
+	assert.Equal(t, 10.0, segment.MinutesLate(time.Unix(3400, 0)))
+	segment.ActualStartTime = time.Unix(700, 0)
+	assert.Equal(t, -5.0, segment.MinutesLate(time.Unix(2000, 0)))
+
+	// Check lateness once the segment has ended.
+	segment.ActualEndTime = time.Unix(2500, 0)
+	assert.False(t, segment.IsInProgress())
+	assert.True(t, segment.IsComplete())
+	assert.Equal(t, -5.0, segment.MinutesLate(time.Unix(5000, 0)))
+	segment.ActualEndTime = time.Unix(3100, 0)
+	assert.Equal(t, 5.0, segment.MinutesLate(time.Unix(5000, 0)))
+
+	assert.Equal(t, "Alliance Selection", AllianceSelectionSegment.String())
+	assert.Equal(t, "Other", OtherSegment.String())
+}
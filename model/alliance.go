@@ -5,18 +5,28 @@
 
 package model
 
-import "sort"
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
 
 type Alliance struct {
 	Id      int `db:"id,manual"`
 	TeamIds []int
 	Lineup  [3]int
+	// Team called in from the backup pool during the playoffs, or zero if the alliance hasn't called one.
+	BackupTeamId int
+	// Team whose robot the backup replaced, which may not play for the alliance again.
+	ReplacedTeamId int
 }
 
 type AllianceSelectionRankedTeam struct {
 	Rank   int
 	TeamId int
 	Picked bool
+	// Whether the team has declined an invitation, after which it may still captain an alliance but can't be picked.
+	Declined bool
 }
 
 func (database *Database) CreateAlliance(alliance *Alliance) error {
@@ -83,6 +93,43 @@ func (database *Database) UpdateAllianceFromMatch(allianceId int, matchTeamIds [
 	return nil
 }
 
+// Adds the given backup team permanently to the alliance in place of the given member, which is also swapped out of
+// the lineup if it was in it. Only one backup may be called per alliance.
+func (database *Database) CallBackupTeam(allianceId, backupTeamId, replacedTeamId int) error {
+	alliances, err := database.GetAllAlliances()
+	if err != nil {
+		return err
+	}
+	var alliance *Alliance
+	for i := range alliances {
+		if alliances[i].Id == allianceId {
+			alliance = &alliances[i]
+		}
+		if slices.Contains(alliances[i].TeamIds, backupTeamId) {
+			return fmt.Errorf("team %d is already part of alliance %d", backupTeamId, alliances[i].Id)
+		}
+	}
+	if alliance == nil {
+		return fmt.Errorf("alliance %d doesn't exist", allianceId)
+	}
+	if alliance.BackupTeamId != 0 {
+		return fmt.Errorf("alliance %d has already called in backup team %d", allianceId, alliance.BackupTeamId)
+	}
+	if !slices.Contains(alliance.TeamIds, replacedTeamId) {
+		return fmt.Errorf("team %d isn't part of alliance %d", replacedTeamId, allianceId)
+	}
+
+	alliance.TeamIds = append(alliance.TeamIds, backupTeamId)
+	alliance.BackupTeamId = backupTeamId
+	alliance.ReplacedTeamId = replacedTeamId
+	for i, teamId := range alliance.Lineup {
+		if teamId == replacedTeamId {
+			alliance.Lineup[i] = backupTeamId
+		}
+	}
+	return database.UpdateAlliance(alliance)
+}
+
 // Returns two arrays containing the IDs of any teams for the red and blue alliances, respectively, who are part of the
 // playoff alliance but are not playing in the given match.
 // If the given match isn't a playoff match, empty arrays are returned.
@@ -115,6 +162,10 @@ func (database *Database) getOffFieldTeamIdsForAlliance(allianceId int, teamId1,
 	}
 	offFieldTeamIds := []int{}
 	for _, allianceTeamId := range alliance.TeamIds {
+		if allianceTeamId == alliance.ReplacedTeamId {
+			// A team that has been replaced by a backup can't be brought back in.
+			continue
+		}
 		if allianceTeamId != teamId1 && allianceTeamId != teamId2 && allianceTeamId != teamId3 {
 			offFieldTeamIds = append(offFieldTeamIds, allianceTeamId)
 		}
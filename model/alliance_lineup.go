@@ -0,0 +1,65 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for the match lineups that playoff alliance captains submit for themselves.
+
+package model
+
+import "time"
+
+// The PIN that an alliance's captain uses to submit lineups on the alliance's behalf. Kept apart from the alliance
+// itself since alliances are published to the API and to The Blue Alliance.
+type LineupPin struct {
+	AllianceId int `db:"id,manual"`
+	Pin        string
+}
+
+// Represents the robots that a playoff alliance has chosen to play in one of its matches.
+type LineupSubmission struct {
+	Id          int `db:"id"`
+	MatchId     int `db:"index"`
+	AllianceId  int
+	TeamIds     [3]int // Teams for stations 1 through 3 of the alliance's color.
+	SubmittedAt time.Time
+}
+
+func (database *Database) CreateLineupPin(lineupPin *LineupPin) error {
+	return database.lineupPinTable.create(lineupPin)
+}
+
+func (database *Database) GetLineupPinByAlliance(allianceId int) (*LineupPin, error) {
+	return database.lineupPinTable.getById(allianceId)
+}
+
+func (database *Database) UpdateLineupPin(lineupPin *LineupPin) error {
+	return database.lineupPinTable.update(lineupPin)
+}
+
+func (database *Database) CreateLineupSubmission(lineupSubmission *LineupSubmission) error {
+	return database.lineupSubmissionTable.create(lineupSubmission)
+}
+
+func (database *Database) UpdateLineupSubmission(lineupSubmission *LineupSubmission) error {
+	return database.lineupSubmissionTable.update(lineupSubmission)
+}
+
+// Returns the lineup that the given alliance has submitted for the given match, or nil if it hasn't submitted one.
+func (database *Database) GetLineupSubmission(matchId, allianceId int) (*LineupSubmission, error) {
+	lineupSubmissions, err := database.lineupSubmissionTable.getByIndex("MatchId", matchId)
+	if err != nil {
+		return nil, err
+	}
+	for _, lineupSubmission := range lineupSubmissions {
+		if lineupSubmission.AllianceId == allianceId {
+			return &lineupSubmission, nil
+		}
+	}
+	return nil, nil
+}
+
+// Deletes all the lineup PINs along with any lineups that were submitted using them.
+func (database *Database) TruncateLineups() error {
+	if err := database.lineupPinTable.truncate(); err != nil {
+		return err
+	}
+	return database.lineupSubmissionTable.truncate()
+}
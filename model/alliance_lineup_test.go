@@ -0,0 +1,65 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLineupPinCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	lineupPin, err := db.GetLineupPinByAlliance(2)
+	assert.Nil(t, err)
+	assert.Nil(t, lineupPin)
+
+	assert.Nil(t, db.CreateLineupPin(&LineupPin{AllianceId: 2, Pin: "0254"}))
+	lineupPin, err = db.GetLineupPinByAlliance(2)
+	assert.Nil(t, err)
+	assert.Equal(t, LineupPin{AllianceId: 2, Pin: "0254"}, *lineupPin)
+
+	lineupPin.Pin = "1114"
+	assert.Nil(t, db.UpdateLineupPin(lineupPin))
+	lineupPin, _ = db.GetLineupPinByAlliance(2)
+	assert.Equal(t, "1114", lineupPin.Pin)
+}
+
+func TestLineupSubmissionCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	lineupSubmission, err := db.GetLineupSubmission(12, 3)
+	assert.Nil(t, err)
+	assert.Nil(t, lineupSubmission)
+
+	lineupSubmission1 := LineupSubmission{
+		MatchId: 12, AllianceId: 3, TeamIds: [3]int{302, 301, 303}, SubmittedAt: time.Unix(1000, 0).UTC(),
+	}
+	lineupSubmission2 := LineupSubmission{
+		MatchId: 12, AllianceId: 6, TeamIds: [3]int{601, 604, 602}, SubmittedAt: time.Unix(2000, 0).UTC(),
+	}
+	assert.Nil(t, db.CreateLineupSubmission(&lineupSubmission1))
+	assert.Nil(t, db.CreateLineupSubmission(&lineupSubmission2))
+	lineupSubmission, err = db.GetLineupSubmission(12, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, lineupSubmission1, *lineupSubmission)
+	lineupSubmission, _ = db.GetLineupSubmission(12, 6)
+	assert.Equal(t, lineupSubmission2, *lineupSubmission)
+	lineupSubmission, _ = db.GetLineupSubmission(13, 3)
+	assert.Nil(t, lineupSubmission)
+
+	lineupSubmission1.TeamIds = [3]int{303, 301, 302}
+	assert.Nil(t, db.UpdateLineupSubmission(&lineupSubmission1))
+	lineupSubmission, _ = db.GetLineupSubmission(12, 3)
+	assert.Equal(t, lineupSubmission1, *lineupSubmission)
+
+	assert.Nil(t, db.CreateLineupPin(&LineupPin{AllianceId: 3, Pin: "1234"}))
+	assert.Nil(t, db.TruncateLineups())
+	lineupSubmission, _ = db.GetLineupSubmission(12, 3)
+	assert.Nil(t, lineupSubmission)
+	lineupPin, _ := db.GetLineupPinByAlliance(3)
+	assert.Nil(t, lineupPin)
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Model and datastore methods for the in-progress alliance selection and its history of picks.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// Snapshot of an alliance selection that is underway, persisted so that it can be resumed after a restart.
+type AllianceSelection struct {
+	Id           int `db:"id"`
+	Alliances    []Alliance
+	RankedTeams  []AllianceSelectionRankedTeam
+	ShowTimer    bool
+	TimerEndTime time.Time // Zero if the timer isn't counting down.
+}
+
+type AllianceSelectionEventType string
+
+const (
+	InviteEvent  AllianceSelectionEventType = "invite"
+	AcceptEvent  AllianceSelectionEventType = "accept"
+	DeclineEvent AllianceSelectionEventType = "decline"
+	AssignEvent  AllianceSelectionEventType = "assign" // A spot filled in or cleared directly by the operator.
+	UndoEvent    AllianceSelectionEventType = "undo"
+)
+
+// Represents one step of the alliance selection, recorded in order for the log.
+type AllianceSelectionEvent struct {
+	Id            int `db:"id"`
+	Time          time.Time
+	Type          AllianceSelectionEventType
+	AllianceId    int
+	Spot          int // Index into the alliance's team IDs; zero is the captain.
+	TeamId        int
+	UndoneEventId int // For undo events, the ID of the pick or decline that was undone.
+}
+
+// Returns the persisted alliance selection, or nil if there isn't one underway.
+func (database *Database) GetAllianceSelection() (*AllianceSelection, error) {
+	allianceSelections, err := database.allianceSelectionTable.getAll()
+	if err != nil || len(allianceSelections) == 0 {
+		return nil, err
+	}
+	return &allianceSelections[0], nil
+}
+
+// Persists the given alliance selection, replacing any that was previously saved.
+func (database *Database) SaveAllianceSelection(allianceSelection *AllianceSelection) error {
+	existingAllianceSelection, err := database.GetAllianceSelection()
+	if err != nil {
+		return err
+	}
+	if existingAllianceSelection == nil {
+		allianceSelection.Id = 0
+		return database.allianceSelectionTable.create(allianceSelection)
+	}
+	allianceSelection.Id = existingAllianceSelection.Id
+	return database.allianceSelectionTable.update(allianceSelection)
+}
+
+// Deletes the persisted alliance selection but keeps the history of its events.
+func (database *Database) DeleteAllianceSelection() error {
+	return database.allianceSelectionTable.truncate()
+}
+
+func (database *Database) CreateAllianceSelectionEvent(event *AllianceSelectionEvent) error {
+	return database.allianceSelectionEventTable.create(event)
+}
+
+// Returns all alliance selection events in the order in which they happened.
+func (database *Database) GetAllAllianceSelectionEvents() ([]AllianceSelectionEvent, error) {
+	events, err := database.allianceSelectionEventTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Id < events[j].Id
+	})
+	return events, nil
+}
+
+// Deletes the persisted alliance selection along with the history of its events.
+func (database *Database) TruncateAllianceSelection() error {
+	if err := database.allianceSelectionTable.truncate(); err != nil {
+		return err
+	}
+	return database.allianceSelectionEventTable.truncate()
+}
+
+// Returns the invitation that is still awaiting a response, or nil if there isn't one.
+func PendingAllianceSelectionInvite(events []AllianceSelectionEvent) *AllianceSelectionEvent {
+	var pendingInvite *AllianceSelectionEvent
+	for i, event := range events {
+		switch event.Type {
+		case InviteEvent:
+			pendingInvite = &events[i]
+		case AcceptEvent, DeclineEvent:
+			pendingInvite = nil
+		}
+	}
+	return pendingInvite
+}
+
+// Returns the most recent event that placed a team into an alliance or recorded a declined invitation and hasn't
+// already been undone, or nil if there isn't one.
+func LastUndoableAllianceSelectionEvent(events []AllianceSelectionEvent) *AllianceSelectionEvent {
+	undoneEventIds := make(map[int]bool)
+	for i := len(events) - 1; i >= 0; i-- {
+		event := &events[i]
+		if event.Type == UndoEvent {
+			undoneEventIds[event.UndoneEventId] = true
+		} else if (event.Type == AcceptEvent || event.Type == AssignEvent || event.Type == DeclineEvent) &&
+			event.TeamId != 0 && !undoneEventIds[event.Id] {
+			return event
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAllianceSelectionCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	allianceSelection, err := db.GetAllianceSelection()
+	assert.Nil(t, err)
+	assert.Nil(t, allianceSelection)
+
+	allianceSelection = &AllianceSelection{
+		Alliances:    []Alliance{{Id: 1, TeamIds: []int{254, 0, 0}}},
+		RankedTeams:  []AllianceSelectionRankedTeam{{Rank: 1, TeamId: 254, Picked: true}, {Rank: 2, TeamId: 1114}},
+		ShowTimer:    true,
+		TimerEndTime: time.Unix(1000, 0).UTC(),
+	}
+	assert.Nil(t, db.SaveAllianceSelection(allianceSelection))
+	allianceSelection2, err := db.GetAllianceSelection()
+	assert.Nil(t, err)
+	assert.Equal(t, allianceSelection, allianceSelection2)
+
+	// Saving a new record should replace the existing one rather than adding another.
+	allianceSelection3 := &AllianceSelection{Alliances: []Alliance{{Id: 1, TeamIds: []int{254, 1114, 0}}}}
+	assert.Nil(t, db.SaveAllianceSelection(allianceSelection3))
+	allianceSelection2, err = db.GetAllianceSelection()
+	assert.Nil(t, err)
+	assert.Equal(t, allianceSelection3, allianceSelection2)
+	assert.Equal(t, allianceSelection.Id, allianceSelection2.Id)
+
+	assert.Nil(t, db.CreateAllianceSelectionEvent(&AllianceSelectionEvent{Type: InviteEvent, AllianceId: 1}))
+	assert.Nil(t, db.DeleteAllianceSelection())
+	allianceSelection2, err = db.GetAllianceSelection()
+	assert.Nil(t, err)
+	assert.Nil(t, allianceSelection2)
+	events, _ := db.GetAllAllianceSelectionEvents()
+	assert.Equal(t, 1, len(events))
+
+	assert.Nil(t, db.SaveAllianceSelection(allianceSelection3))
+	assert.Nil(t, db.TruncateAllianceSelection())
+	allianceSelection2, _ = db.GetAllianceSelection()
+	assert.Nil(t, allianceSelection2)
+	events, _ = db.GetAllAllianceSelectionEvents()
+	assert.Empty(t, events)
+}
+
+func TestAllianceSelectionEvents(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	for _, event := range []AllianceSelectionEvent{
+		{Type: AssignEvent, AllianceId: 1, Spot: 0, TeamId: 254},
+		{Type: InviteEvent, AllianceId: 1, Spot: 1, TeamId: 1114},
+		{Type: DeclineEvent, AllianceId: 1, Spot: 1, TeamId: 1114},
+		{Type: InviteEvent, AllianceId: 1, Spot: 1, TeamId: 2056},
+	} {
+		assert.Nil(t, db.CreateAllianceSelectionEvent(&event))
+	}
+	events, err := db.GetAllAllianceSelectionEvents()
+	assert.Nil(t, err)
+	if assert.Equal(t, 4, len(events)) {
+		assert.Equal(t, DeclineEvent, events[2].Type)
+	}
+	if pendingInvite := PendingAllianceSelectionInvite(events); assert.NotNil(t, pendingInvite) {
+		assert.Equal(t, 2056, pendingInvite.TeamId)
+	}
+	if lastEvent := LastUndoableAllianceSelectionEvent(events); assert.NotNil(t, lastEvent) {
+		assert.Equal(t, 3, lastEvent.Id)
+	}
+
+	db.CreateAllianceSelectionEvent(&AllianceSelectionEvent{Type: AcceptEvent, AllianceId: 1, Spot: 1, TeamId: 2056})
+	db.CreateAllianceSelectionEvent(&AllianceSelectionEvent{Type: AssignEvent, AllianceId: 2, Spot: 0, TeamId: 0})
+	events, _ = db.GetAllAllianceSelectionEvents()
+	assert.Nil(t, PendingAllianceSelectionInvite(events))
+	if lastEvent := LastUndoableAllianceSelectionEvent(events); assert.NotNil(t, lastEvent) {
+		assert.Equal(t, 5, lastEvent.Id)
+	}
+
+	// Check that undone picks and declines are skipped over.
+	db.CreateAllianceSelectionEvent(&AllianceSelectionEvent{Type: UndoEvent, TeamId: 2056, UndoneEventId: 5})
+	events, _ = db.GetAllAllianceSelectionEvents()
+	if lastEvent := LastUndoableAllianceSelectionEvent(events); assert.NotNil(t, lastEvent) {
+		assert.Equal(t, 3, lastEvent.Id)
+	}
+	db.CreateAllianceSelectionEvent(&AllianceSelectionEvent{Type: UndoEvent, TeamId: 1114, UndoneEventId: 3})
+	events, _ = db.GetAllAllianceSelectionEvents()
+	if lastEvent := LastUndoableAllianceSelectionEvent(events); assert.NotNil(t, lastEvent) {
+		assert.Equal(t, 1, lastEvent.Id)
+	}
+	db.CreateAllianceSelectionEvent(&AllianceSelectionEvent{Type: UndoEvent, TeamId: 254, UndoneEventId: 1})
+	events, _ = db.GetAllAllianceSelectionEvents()
+	assert.Nil(t, LastUndoableAllianceSelectionEvent(events))
+}
@@ -133,3 +133,27 @@ func TestGetOffFieldTeamIds(t *testing.T) {
 	assert.Equal(t, []int{}, redOffFieldTeams)
 	assert.Equal(t, []int{254, 469}, blueOffFieldTeams)
 }
+
+func TestCallBackupTeam(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+	BuildTestAlliances(db)
+
+	assert.EqualError(t, db.CallBackupTeam(3, 1114, 1718), "alliance 3 doesn't exist")
+	assert.EqualError(t, db.CallBackupTeam(2, 74, 1718), "team 74 is already part of alliance 1")
+	assert.EqualError(t, db.CallBackupTeam(2, 1114, 254), "team 254 isn't part of alliance 2")
+
+	assert.Nil(t, db.CallBackupTeam(2, 1114, 2451))
+	alliance, _ := db.GetAllianceById(2)
+	assert.Equal(t, []int{1718, 2451, 1619, 1114}, alliance.TeamIds)
+	assert.Equal(t, 1114, alliance.BackupTeamId)
+	assert.Equal(t, 2451, alliance.ReplacedTeamId)
+	assert.Equal(t, [3]int{1114, 1718, 1619}, alliance.Lineup)
+	assert.EqualError(t, db.CallBackupTeam(2, 604, 1718), "alliance 2 has already called in backup team 1114")
+
+	// Check that the replaced team is no longer offered as a lineup choice.
+	match := &Match{PlayoffRedAlliance: 2, Red1: 1114, Red2: 1718, Red3: 1619}
+	redOffFieldTeams, _, err := db.GetOffFieldTeamIds(match)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{}, redOffFieldTeams)
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for a timeout called by a playoff alliance.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+type AllianceTimeout struct {
+	Id          int `db:"id"`
+	AllianceId  int `db:"index"`
+	MatchId     int
+	StartedAt   time.Time
+	DurationSec int
+}
+
+func (database *Database) CreateAllianceTimeout(allianceTimeout *AllianceTimeout) error {
+	return database.allianceTimeoutTable.create(allianceTimeout)
+}
+
+// Returns all the timeouts called by the given alliance, in the order that they were called.
+func (database *Database) GetAllianceTimeoutsByAlliance(allianceId int) ([]AllianceTimeout, error) {
+	allianceTimeouts, err := database.allianceTimeoutTable.getByIndex("AllianceId", allianceId)
+	if err != nil || len(allianceTimeouts) == 0 {
+		return nil, err
+	}
+	sort.Slice(allianceTimeouts, func(i, j int) bool {
+		return allianceTimeouts[i].Id < allianceTimeouts[j].Id
+	})
+	return allianceTimeouts, nil
+}
+
+func (database *Database) TruncateAllianceTimeouts() error {
+	return database.allianceTimeoutTable.truncate()
+}
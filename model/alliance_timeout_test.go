@@ -0,0 +1,38 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAllianceTimeoutCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	allianceTimeouts, err := db.GetAllianceTimeoutsByAlliance(3)
+	assert.Nil(t, err)
+	assert.Empty(t, allianceTimeouts)
+
+	allianceTimeout1 := AllianceTimeout{
+		AllianceId: 3, MatchId: 12, StartedAt: time.Unix(2000, 0).UTC(), DurationSec: 480,
+	}
+	allianceTimeout2 := AllianceTimeout{
+		AllianceId: 5, MatchId: 12, StartedAt: time.Unix(3000, 0).UTC(), DurationSec: 480,
+	}
+	allianceTimeout3 := AllianceTimeout{
+		AllianceId: 3, MatchId: 15, StartedAt: time.Unix(4000, 0).UTC(), DurationSec: 300,
+	}
+	assert.Nil(t, db.CreateAllianceTimeout(&allianceTimeout1))
+	assert.Nil(t, db.CreateAllianceTimeout(&allianceTimeout2))
+	assert.Nil(t, db.CreateAllianceTimeout(&allianceTimeout3))
+	allianceTimeouts, err = db.GetAllianceTimeoutsByAlliance(3)
+	assert.Nil(t, err)
+	assert.Equal(t, []AllianceTimeout{allianceTimeout1, allianceTimeout3}, allianceTimeouts)
+
+	assert.Nil(t, db.TruncateAllianceTimeouts())
+	allianceTimeouts, _ = db.GetAllianceTimeoutsByAlliance(5)
+	assert.Empty(t, allianceTimeouts)
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for the judged awards and the nominations that the judges deliberate over.
+
+package model
+
+import "sort"
+
+// Describes a judged award presented at the event, along with what the emcee reads when presenting it.
+type AwardDefinition struct {
+	Id            int `db:"id"`
+	Name          string
+	Script        string
+	CeremonyOrder int
+}
+
+// Represents a team that the judges have put forward for a judged award.
+type AwardNomination struct {
+	Id                int `db:"id"`
+	AwardDefinitionId int `db:"index"`
+	TeamId            int
+	PersonName        string
+	Notes             string // Kept private to the judges and never published.
+	IsWinner          bool
+	AwardId           int // The award generated for the nomination when it was last finalized, or zero if none.
+}
+
+func (database *Database) CreateAwardDefinition(awardDefinition *AwardDefinition) error {
+	return database.awardDefinitionTable.create(awardDefinition)
+}
+
+func (database *Database) GetAwardDefinitionById(id int) (*AwardDefinition, error) {
+	return database.awardDefinitionTable.getById(id)
+}
+
+func (database *Database) UpdateAwardDefinition(awardDefinition *AwardDefinition) error {
+	return database.awardDefinitionTable.update(awardDefinition)
+}
+
+func (database *Database) DeleteAwardDefinition(id int) error {
+	return database.awardDefinitionTable.delete(id)
+}
+
+// Returns all award definitions in the order in which they are presented at the ceremony.
+func (database *Database) GetAllAwardDefinitions() ([]AwardDefinition, error) {
+	awardDefinitions, err := database.awardDefinitionTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(awardDefinitions, func(i, j int) bool {
+		if awardDefinitions[i].CeremonyOrder == awardDefinitions[j].CeremonyOrder {
+			return awardDefinitions[i].Id < awardDefinitions[j].Id
+		}
+		return awardDefinitions[i].CeremonyOrder < awardDefinitions[j].CeremonyOrder
+	})
+	return awardDefinitions, nil
+}
+
+func (database *Database) CreateAwardNomination(awardNomination *AwardNomination) error {
+	return database.awardNominationTable.create(awardNomination)
+}
+
+func (database *Database) GetAwardNominationById(id int) (*AwardNomination, error) {
+	return database.awardNominationTable.getById(id)
+}
+
+func (database *Database) UpdateAwardNomination(awardNomination *AwardNomination) error {
+	return database.awardNominationTable.update(awardNomination)
+}
+
+func (database *Database) DeleteAwardNomination(id int) error {
+	return database.awardNominationTable.delete(id)
+}
+
+// Returns the nominations for the given award definition, in the order in which they were made.
+func (database *Database) GetAwardNominationsByDefinition(awardDefinitionId int) ([]AwardNomination, error) {
+	awardNominations, err := database.awardNominationTable.getByIndex("AwardDefinitionId", awardDefinitionId)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(awardNominations, func(i, j int) bool {
+		return awardNominations[i].Id < awardNominations[j].Id
+	})
+	return awardNominations, nil
+}
+
+// Deletes all award definitions along with their nominations.
+func (database *Database) TruncateAwardDefinitions() error {
+	if err := database.awardDefinitionTable.truncate(); err != nil {
+		return err
+	}
+	return database.awardNominationTable.truncate()
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAwardDefinitionCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	awardDefinition1 := AwardDefinition{Name: "Imagery Award", Script: "This award celebrates...", CeremonyOrder: 2}
+	awardDefinition2 := AwardDefinition{Name: "Safety Award", CeremonyOrder: 1}
+	awardDefinition3 := AwardDefinition{Name: "Judges' Award", CeremonyOrder: 2}
+	assert.Nil(t, db.CreateAwardDefinition(&awardDefinition1))
+	assert.Nil(t, db.CreateAwardDefinition(&awardDefinition2))
+	assert.Nil(t, db.CreateAwardDefinition(&awardDefinition3))
+	awardDefinition, err := db.GetAwardDefinitionById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, awardDefinition1, *awardDefinition)
+
+	awardDefinitions, err := db.GetAllAwardDefinitions()
+	assert.Nil(t, err)
+	assert.Equal(t, []AwardDefinition{awardDefinition2, awardDefinition1, awardDefinition3}, awardDefinitions)
+
+	awardDefinition1.CeremonyOrder = 3
+	assert.Nil(t, db.UpdateAwardDefinition(&awardDefinition1))
+	awardDefinitions, _ = db.GetAllAwardDefinitions()
+	assert.Equal(t, []AwardDefinition{awardDefinition2, awardDefinition3, awardDefinition1}, awardDefinitions)
+
+	assert.Nil(t, db.DeleteAwardDefinition(awardDefinition2.Id))
+	awardDefinition, err = db.GetAwardDefinitionById(awardDefinition2.Id)
+	assert.Nil(t, err)
+	assert.Nil(t, awardDefinition)
+}
+
+func TestAwardNominationCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	awardNomination1 := AwardNomination{AwardDefinitionId: 1, TeamId: 254, Notes: "Great pit"}
+	awardNomination2 := AwardNomination{AwardDefinitionId: 2, TeamId: 1114}
+	awardNomination3 := AwardNomination{AwardDefinitionId: 1, TeamId: 2056, PersonName: "Jane Doe", IsWinner: true}
+	assert.Nil(t, db.CreateAwardNomination(&awardNomination1))
+	assert.Nil(t, db.CreateAwardNomination(&awardNomination2))
+	assert.Nil(t, db.CreateAwardNomination(&awardNomination3))
+	awardNomination, err := db.GetAwardNominationById(3)
+	assert.Nil(t, err)
+	assert.Equal(t, awardNomination3, *awardNomination)
+
+	awardNominations, err := db.GetAwardNominationsByDefinition(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []AwardNomination{awardNomination1, awardNomination3}, awardNominations)
+
+	awardNomination1.IsWinner = true
+	awardNomination1.AwardId = 5
+	assert.Nil(t, db.UpdateAwardNomination(&awardNomination1))
+	awardNomination, _ = db.GetAwardNominationById(1)
+	assert.Equal(t, awardNomination1, *awardNomination)
+
+	assert.Nil(t, db.DeleteAwardNomination(awardNomination3.Id))
+	awardNominations, _ = db.GetAwardNominationsByDefinition(1)
+	assert.Equal(t, []AwardNomination{awardNomination1}, awardNominations)
+
+	assert.Nil(t, db.CreateAwardDefinition(&AwardDefinition{Name: "Safety Award"}))
+	assert.Nil(t, db.TruncateAwardDefinitions())
+	awardDefinitions, _ := db.GetAllAwardDefinitions()
+	assert.Empty(t, awardDefinitions)
+	awardNominations, _ = db.GetAwardNominationsByDefinition(2)
+	assert.Empty(t, awardNominations)
+}
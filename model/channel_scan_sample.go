@@ -0,0 +1,48 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for the conditions observed on a single Wi-Fi channel during a scan by the access
+// point of a given field.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+type ChannelScanSample struct {
+	Id                 int `db:"id"`
+	FieldNumber        int `db:"index"`
+	Channel            int
+	Time               time.Time
+	UtilizationPercent float64
+	NoiseDbm           int
+	NetworkCount       int
+}
+
+func (database *Database) CreateChannelScanSample(sample *ChannelScanSample) error {
+	return database.channelScanSampleTable.create(sample)
+}
+
+// Returns all the samples taken by the access point of the given field, oldest first.
+func (database *Database) GetChannelScanSamplesByField(fieldNumber int) ([]ChannelScanSample, error) {
+	samples, err := database.channelScanSampleTable.getByIndex("FieldNumber", fieldNumber)
+	if err != nil || len(samples) == 0 {
+		return nil, err
+	}
+	sort.SliceStable(samples, func(i, j int) bool {
+		if !samples[i].Time.Equal(samples[j].Time) {
+			return samples[i].Time.Before(samples[j].Time)
+		}
+		return samples[i].Id < samples[j].Id
+	})
+	return samples, nil
+}
+
+func (database *Database) DeleteChannelScanSample(id int) error {
+	return database.channelScanSampleTable.delete(id)
+}
+
+func (database *Database) TruncateChannelScanSamples() error {
+	return database.channelScanSampleTable.truncate()
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestChannelScanSampleCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	samples, err := db.GetChannelScanSamplesByField(1)
+	assert.Nil(t, err)
+	assert.Empty(t, samples)
+
+	sample1 := ChannelScanSample{
+		FieldNumber: 1, Channel: 36, Time: time.Unix(2000, 0).UTC(), UtilizationPercent: 60, NoiseDbm: -90,
+	}
+	sample2 := ChannelScanSample{
+		FieldNumber: 2, Channel: 149, Time: time.Unix(2000, 0).UTC(), UtilizationPercent: 10, NetworkCount: 3,
+	}
+	sample3 := ChannelScanSample{
+		FieldNumber: 1, Channel: 149, Time: time.Unix(1000, 0).UTC(), UtilizationPercent: 20, NoiseDbm: -95,
+	}
+	assert.Nil(t, db.CreateChannelScanSample(&sample1))
+	assert.Nil(t, db.CreateChannelScanSample(&sample2))
+	assert.Nil(t, db.CreateChannelScanSample(&sample3))
+
+	samples, err = db.GetChannelScanSamplesByField(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []ChannelScanSample{sample3, sample1}, samples)
+	samples, err = db.GetChannelScanSamplesByField(2)
+	assert.Nil(t, err)
+	assert.Equal(t, []ChannelScanSample{sample2}, samples)
+
+	assert.Nil(t, db.DeleteChannelScanSample(sample3.Id))
+	samples, err = db.GetChannelScanSamplesByField(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []ChannelScanSample{sample1}, samples)
+
+	assert.Nil(t, db.TruncateChannelScanSamples())
+	samples, err = db.GetChannelScanSamplesByField(2)
+	assert.Nil(t, err)
+	assert.Empty(t, samples)
+}
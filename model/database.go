@@ -21,41 +21,105 @@ const backupsDir = "db/backups"
 var BaseDir = "." // Mutable for testing
 
 type Database struct {
-	Path                string
-	bolt                *bbolt.DB
-	allianceTable       *table[Alliance]
-	awardTable          *table[Award]
-	eventSettingsTable  *table[EventSettings]
-	lowerThirdTable     *table[LowerThird]
-	matchTable          *table[Match]
-	matchResultTable    *table[MatchResult]
-	rankingTable        *table[game.Ranking]
-	scheduleBlockTable  *table[ScheduleBlock]
-	scheduledBreakTable *table[ScheduledBreak]
-	sponsorSlideTable   *table[SponsorSlide]
-	teamTable           *table[Team]
-	userSessionTable    *table[UserSession]
+	Path                           string
+	bolt                           *bbolt.DB
+	packetLogBolt                  *bbolt.DB
+	agendaSegmentTable             *table[AgendaSegment]
+	allianceTable                  *table[Alliance]
+	allianceSelectionTable         *table[AllianceSelection]
+	allianceSelectionEventTable    *table[AllianceSelectionEvent]
+	allianceTimeoutTable           *table[AllianceTimeout]
+	awardTable                     *table[Award]
+	awardDefinitionTable           *table[AwardDefinition]
+	awardNominationTable           *table[AwardNomination]
+	channelScanSampleTable         *table[ChannelScanSample]
+	eventSettingsTable             *table[EventSettings]
+	fieldSettingsTable             *table[FieldSettings]
+	lineupPinTable                 *table[LineupPin]
+	lineupSubmissionTable          *table[LineupSubmission]
+	lowerThirdTable                *table[LowerThird]
+	matchTable                     *table[Match]
+	matchResultTable               *table[MatchResult]
+	matchStartOverrideTable        *table[MatchStartOverride]
+	rankingTable                   *table[game.Ranking]
+	scheduleBlockTable             *table[ScheduleBlock]
+	scheduledBreakTable            *table[ScheduledBreak]
+	sponsorSlideTable              *table[SponsorSlide]
+	tbaPublishRecordTable          *table[TbaPublishRecord]
+	teamTable                      *table[Team]
+	teamInspectionTable            *table[TeamInspection]
+	teamMatchLogTable              *table[TeamMatchLog]
+	teamMatchLogDataTable          *table[TeamMatchLogData]
+	teamWifiRecordTable            *table[TeamWifiRecord]
+	userSessionTable               *table[UserSession]
+	wizardStepAcknowledgementTable *table[WizardStepAcknowledgement]
+	changeFeed                     *changeFeed
+}
+
+// Operations common to every table regardless of its record type, for tasks that span the whole database.
+type anyTable interface {
+	tableName() string
+	scanKeys() ([]int, []string, error)
+	checkIndexes() ([]IntegrityProblem, error)
+	applyChange(change *DatabaseChange) error
 }
 
 // Opens the Bolt database at the given path, creating it if it doesn't exist.
 func OpenDatabase(filename string) (*Database, error) {
-	database := Database{Path: filename}
+	database := Database{Path: filename, changeFeed: newChangeFeed()}
 	var err error
 	database.bolt, err = bbolt.Open(database.Path, 0644, &bbolt.Options{NoSync: true, Timeout: time.Second})
 	if err != nil {
 		return nil, err
 	}
+	database.packetLogBolt, err = bbolt.Open(
+		PacketLogPath(database.Path), 0644, &bbolt.Options{NoSync: true, Timeout: time.Second},
+	)
+	if err != nil {
+		database.bolt.Close()
+		return nil, err
+	}
 
 	// Register tables.
+	if database.agendaSegmentTable, err = newTable[AgendaSegment](&database); err != nil {
+		return nil, err
+	}
 	if database.allianceTable, err = newTable[Alliance](&database); err != nil {
 		return nil, err
 	}
+	if database.allianceSelectionTable, err = newTable[AllianceSelection](&database); err != nil {
+		return nil, err
+	}
+	if database.allianceSelectionEventTable, err = newTable[AllianceSelectionEvent](&database); err != nil {
+		return nil, err
+	}
+	if database.allianceTimeoutTable, err = newTable[AllianceTimeout](&database); err != nil {
+		return nil, err
+	}
 	if database.awardTable, err = newTable[Award](&database); err != nil {
 		return nil, err
 	}
+	if database.awardDefinitionTable, err = newTable[AwardDefinition](&database); err != nil {
+		return nil, err
+	}
+	if database.awardNominationTable, err = newTable[AwardNomination](&database); err != nil {
+		return nil, err
+	}
+	if database.channelScanSampleTable, err = newTable[ChannelScanSample](&database); err != nil {
+		return nil, err
+	}
 	if database.eventSettingsTable, err = newTable[EventSettings](&database); err != nil {
 		return nil, err
 	}
+	if database.fieldSettingsTable, err = newTable[FieldSettings](&database); err != nil {
+		return nil, err
+	}
+	if database.lineupPinTable, err = newTable[LineupPin](&database); err != nil {
+		return nil, err
+	}
+	if database.lineupSubmissionTable, err = newTable[LineupSubmission](&database); err != nil {
+		return nil, err
+	}
 	if database.lowerThirdTable, err = newTable[LowerThird](&database); err != nil {
 		return nil, err
 	}
@@ -65,6 +129,9 @@ func OpenDatabase(filename string) (*Database, error) {
 	if database.matchResultTable, err = newTable[MatchResult](&database); err != nil {
 		return nil, err
 	}
+	if database.matchStartOverrideTable, err = newTable[MatchStartOverride](&database); err != nil {
+		return nil, err
+	}
 	if database.rankingTable, err = newTable[game.Ranking](&database); err != nil {
 		return nil, err
 	}
@@ -77,18 +144,86 @@ func OpenDatabase(filename string) (*Database, error) {
 	if database.sponsorSlideTable, err = newTable[SponsorSlide](&database); err != nil {
 		return nil, err
 	}
+	if database.tbaPublishRecordTable, err = newTable[TbaPublishRecord](&database); err != nil {
+		return nil, err
+	}
 	if database.teamTable, err = newTable[Team](&database); err != nil {
 		return nil, err
 	}
+	if database.teamInspectionTable, err = newTable[TeamInspection](&database); err != nil {
+		return nil, err
+	}
+	if database.teamMatchLogTable, err = newTable[TeamMatchLog](&database); err != nil {
+		return nil, err
+	}
+	database.teamMatchLogDataTable, err = newTableInStore[TeamMatchLogData](database.packetLogBolt, nil)
+	if err != nil {
+		return nil, err
+	}
+	if database.teamWifiRecordTable, err = newTable[TeamWifiRecord](&database); err != nil {
+		return nil, err
+	}
 	if database.userSessionTable, err = newTable[UserSession](&database); err != nil {
 		return nil, err
 	}
+	if database.wizardStepAcknowledgementTable, err = newTable[WizardStepAcknowledgement](&database); err != nil {
+		return nil, err
+	}
 
 	return &database, nil
 }
 
+// Returns the path of the file alongside the given event database in which the packet data of the team match logs is
+// kept. It is stored apart from the rest so that its bulk doesn't weigh down backups, replication and the change feed.
+func PacketLogPath(dbPath string) string {
+	return strings.TrimSuffix(dbPath, filepath.Ext(dbPath)) + "_packets.db"
+}
+
+// Returns every table in the event database, which are those that are backed up and replicated.
+func (database *Database) tables() []anyTable {
+	return []anyTable{
+		database.agendaSegmentTable,
+		database.allianceTable,
+		database.allianceSelectionTable,
+		database.allianceSelectionEventTable,
+		database.allianceTimeoutTable,
+		database.awardTable,
+		database.awardDefinitionTable,
+		database.awardNominationTable,
+		database.channelScanSampleTable,
+		database.eventSettingsTable,
+		database.fieldSettingsTable,
+		database.lineupPinTable,
+		database.lineupSubmissionTable,
+		database.lowerThirdTable,
+		database.matchTable,
+		database.matchResultTable,
+		database.matchStartOverrideTable,
+		database.rankingTable,
+		database.scheduleBlockTable,
+		database.scheduledBreakTable,
+		database.sponsorSlideTable,
+		database.tbaPublishRecordTable,
+		database.teamTable,
+		database.teamInspectionTable,
+		database.teamMatchLogTable,
+		database.teamWifiRecordTable,
+		database.userSessionTable,
+		database.wizardStepAcknowledgementTable,
+	}
+}
+
+// Returns every table, including those kept outside the event database.
+func (database *Database) allTables() []anyTable {
+	return append(database.tables(), database.teamMatchLogDataTable)
+}
+
 func (database *Database) Close() error {
-	return database.bolt.Close()
+	packetLogErr := database.packetLogBolt.Close()
+	if err := database.bolt.Close(); err != nil {
+		return err
+	}
+	return packetLogErr
 }
 
 // Creates a copy of the current database and saves it to the backups directory.
@@ -120,3 +255,17 @@ func (database *Database) WriteBackup(writer io.Writer) error {
 		return err
 	})
 }
+
+// Verifies the consistency of the pages and free list of the Bolt database file, returning any errors found.
+func (database *Database) CheckConsistency() []error {
+	var errs []error
+	for _, bolt := range []*bbolt.DB{database.bolt, database.packetLogBolt} {
+		_ = bolt.View(func(tx *bbolt.Tx) error {
+			for err := range tx.Check() {
+				errs = append(errs, err)
+			}
+			return nil
+		})
+	}
+	return errs
+}
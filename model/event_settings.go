@@ -14,6 +14,34 @@ const (
 	SingleEliminationPlayoff
 )
 
+// Determines how the readiness checks that aren't strictly required affect whether a match can be started.
+type StartMatchPolicy int
+
+const (
+	// The checks are only shown to the scorekeeper.
+	AdvisoryStartMatchPolicy StartMatchPolicy = iota
+	// Failing checks block the match from starting until the scorekeeper overrides them.
+	OverridableStartMatchPolicy
+	// Failing checks block the match from starting with no override possible.
+	StrictStartMatchPolicy
+)
+
+type TeamSignOutputType int
+
+const (
+	CypressTeamSignOutput TeamSignOutputType = iota
+	DdpTeamSignOutput
+	SacnTeamSignOutput
+	KioskTeamSignOutput
+)
+
+var TeamSignOutputTypeNames = map[TeamSignOutputType]string{
+	CypressTeamSignOutput: "Cypress Sign",
+	DdpTeamSignOutput:     "LED Matrix (WLED/DDP)",
+	SacnTeamSignOutput:    "LED Matrix (E1.31 sACN)",
+	KioskTeamSignOutput:   "Browser Kiosk",
+}
+
 type EventSettings struct {
 	Id                              int `db:"id"`
 	Name                            string
@@ -22,20 +50,29 @@ type EventSettings struct {
 	SelectionRound2Order            string
 	SelectionRound3Order            string
 	SelectionShowUnpickedTeams      bool
+	PlayoffTimeoutsPerAlliance      int
 	TbaDownloadEnabled              bool
 	TbaPublishingEnabled            bool
 	TbaEventCode                    string
 	TbaSecretId                     string
 	TbaSecret                       string
 	NexusEnabled                    bool
+	NumFields                       int
 	NetworkSecurityEnabled          bool
 	ApAddress                       string
 	ApPassword                      string
 	ApChannel                       int
+	ApAllowedChannels               string
 	SwitchAddress                   string
 	SwitchPassword                  string
 	PlcAddress                      string
+	StartMatchPolicy                StartMatchPolicy
+	MinRobotBatteryVoltage          float64
 	AdminPassword                   string
+	RelayEnabled                    bool
+	RelayUrl                        string
+	RelayKey                        string
+	ReplicationKey                  string
 	TeamSignRed1Id                  int
 	TeamSignRed2Id                  int
 	TeamSignRed3Id                  int
@@ -44,6 +81,26 @@ type EventSettings struct {
 	TeamSignBlue2Id                 int
 	TeamSignBlue3Id                 int
 	TeamSignBlueTimerId             int
+	TeamSignRed1OutputType          TeamSignOutputType
+	TeamSignRed2OutputType          TeamSignOutputType
+	TeamSignRed3OutputType          TeamSignOutputType
+	TeamSignRedTimerOutputType      TeamSignOutputType
+	TeamSignBlue1OutputType         TeamSignOutputType
+	TeamSignBlue2OutputType         TeamSignOutputType
+	TeamSignBlue3OutputType         TeamSignOutputType
+	TeamSignBlueTimerOutputType     TeamSignOutputType
+	TeamSignRed1Address             string
+	TeamSignRed2Address             string
+	TeamSignRed3Address             string
+	TeamSignRedTimerAddress         string
+	TeamSignBlue1Address            string
+	TeamSignBlue2Address            string
+	TeamSignBlue3Address            string
+	TeamSignBlueTimerAddress        string
+	TeamSignMatrixWidth             int
+	TeamSignMatrixHeight            int
+	TeamSignMatrixSerpentine        bool
+	TeamSignSimulatorEnabled        bool
 	BlackmagicAddresses             string
 	WarmupDurationSec               int
 	AutoDurationSec                 int
@@ -73,8 +130,13 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 		SelectionRound2Order:            "L",
 		SelectionRound3Order:            "",
 		SelectionShowUnpickedTeams:      true,
+		PlayoffTimeoutsPerAlliance:      1,
 		TbaDownloadEnabled:              true,
+		NumFields:                       1,
 		ApChannel:                       36,
+		MinRobotBatteryVoltage:          12,
+		TeamSignMatrixWidth:             32,
+		TeamSignMatrixHeight:            8,
 		WarmupDurationSec:               game.MatchTiming.WarmupDurationSec,
 		AutoDurationSec:                 game.MatchTiming.AutoDurationSec,
 		PauseDurationSec:                game.MatchTiming.PauseDurationSec,
@@ -24,8 +24,13 @@ func TestEventSettingsReadWrite(t *testing.T) {
 			SelectionRound2Order:            "L",
 			SelectionRound3Order:            "",
 			SelectionShowUnpickedTeams:      true,
+			PlayoffTimeoutsPerAlliance:      1,
 			TbaDownloadEnabled:              true,
+			NumFields:                       1,
 			ApChannel:                       36,
+			MinRobotBatteryVoltage:          12,
+			TeamSignMatrixWidth:             32,
+			TeamSignMatrixHeight:            8,
 			WarmupDurationSec:               0,
 			AutoDurationSec:                 15,
 			PauseDurationSec:                3,
@@ -0,0 +1,53 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Model and datastore read/write methods for the hardware configuration of each additional field at a multi-field
+// event. The first field is configured through the event settings.
+
+package model
+
+import "sort"
+
+type FieldSettings struct {
+	Id             int `db:"id"`
+	FieldNumber    int
+	ApAddress      string
+	ApPassword     string
+	ApChannel      int
+	SwitchAddress  string
+	SwitchPassword string
+	PlcAddress     string
+}
+
+// Returns the settings for the given field, creating them with default values if they don't exist yet.
+func (database *Database) GetFieldSettings(fieldNumber int) (*FieldSettings, error) {
+	allFieldSettings, err := database.fieldSettingsTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, fieldSettings := range allFieldSettings {
+		if fieldSettings.FieldNumber == fieldNumber {
+			return &fieldSettings, nil
+		}
+	}
+
+	fieldSettings := FieldSettings{FieldNumber: fieldNumber, ApChannel: 36}
+	if err = database.fieldSettingsTable.create(&fieldSettings); err != nil {
+		return nil, err
+	}
+	return &fieldSettings, nil
+}
+
+func (database *Database) GetAllFieldSettings() ([]FieldSettings, error) {
+	allFieldSettings, err := database.fieldSettingsTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(allFieldSettings, func(i, j int) bool {
+		return allFieldSettings[i].FieldNumber < allFieldSettings[j].FieldNumber
+	})
+	return allFieldSettings, nil
+}
+
+func (database *Database) UpdateFieldSettings(fieldSettings *FieldSettings) error {
+	return database.fieldSettingsTable.update(fieldSettings)
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFieldSettingsReadWrite(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	fieldSettings, err := db.GetFieldSettings(2)
+	assert.Nil(t, err)
+	assert.Equal(t, FieldSettings{Id: 1, FieldNumber: 2, ApChannel: 36}, *fieldSettings)
+
+	fieldSettings.ApAddress = "10.0.200.10"
+	fieldSettings.SwitchAddress = "10.0.200.1"
+	fieldSettings.PlcAddress = "10.0.200.20"
+	assert.Nil(t, db.UpdateFieldSettings(fieldSettings))
+	fieldSettings2, err := db.GetFieldSettings(2)
+	assert.Nil(t, err)
+	assert.Equal(t, fieldSettings, fieldSettings2)
+
+	fieldSettings3, err := db.GetFieldSettings(3)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, fieldSettings3.FieldNumber)
+	allFieldSettings, err := db.GetAllFieldSettings()
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(allFieldSettings)) {
+		assert.Equal(t, *fieldSettings, allFieldSettings[0])
+		assert.Equal(t, *fieldSettings3, allFieldSettings[1])
+	}
+}
@@ -0,0 +1,679 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Cross-table integrity checks for dangling references and inconsistencies between records, with repairs for those
+// that can be fixed without losing meaningful data.
+
+package model
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Describes an inconsistency found in the database.
+type IntegrityProblem struct {
+	Table       string
+	RecordId    int
+	Description string
+	repair      func(database *Database) error
+}
+
+// Returns whether the problem can be fixed automatically; the rest need a manual fix or a restore from backup.
+func (problem *IntegrityProblem) Repairable() bool {
+	return problem.repair != nil
+}
+
+// Checks every table for records that can't be read, references to records that don't exist, duplicated or
+// contradictory records, and index entries that don't agree with the records. Returns the problems found in a stable
+// order.
+func (database *Database) CheckIntegrity() ([]IntegrityProblem, error) {
+	// Records that can't be decoded break the bulk reads that the other checks rely on, so stop if there are any.
+	problems, err := database.checkUndecodableRecords()
+	if err != nil || len(problems) > 0 {
+		return problems, err
+	}
+
+	teams, err := database.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	teamIds := make(map[int]bool, len(teams))
+	for _, team := range teams {
+		teamIds[team.Id] = true
+	}
+	matches, err := database.matchTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	matchesById := make(map[int]Match, len(matches))
+	for _, match := range matches {
+		matchesById[match.Id] = match
+	}
+
+	for _, check := range []func(map[int]bool, map[int]Match) ([]IntegrityProblem, error){
+		database.checkMatches,
+		database.checkMatchResults,
+		database.checkTeamMatchLogs,
+		database.checkRankings,
+		database.checkAlliances,
+		database.checkAwards,
+		database.checkTeamInspections,
+		database.checkAwardNominations,
+		database.checkLineups,
+		database.checkAllianceTimeouts,
+		database.checkMatchStartOverrides,
+	} {
+		tableProblems, err := check(teamIds, matchesById)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, tableProblems...)
+	}
+
+	for _, table := range database.allTables() {
+		indexProblems, err := table.checkIndexes()
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, indexProblems...)
+	}
+	return problems, nil
+}
+
+// Backs up the database and then repairs every repairable problem found, returning the number repaired. Does nothing
+// (including the backup) if there is nothing to repair.
+func (database *Database) RepairIntegrity(eventName string) (int, error) {
+	problems, err := database.CheckIntegrity()
+	if err != nil {
+		return 0, err
+	}
+	var repairableProblems []IntegrityProblem
+	for _, problem := range problems {
+		if problem.Repairable() {
+			repairableProblems = append(repairableProblems, problem)
+		}
+	}
+	if len(repairableProblems) == 0 {
+		return 0, nil
+	}
+
+	if err = database.Backup(eventName, "pre_repair"); err != nil {
+		return 0, err
+	}
+	for i, problem := range repairableProblems {
+		if err = problem.repair(database); err != nil {
+			return i, fmt.Errorf("error repairing %s %d: %v", problem.Table, problem.RecordId, err)
+		}
+	}
+	return len(repairableProblems), nil
+}
+
+func (database *Database) checkUndecodableRecords() ([]IntegrityProblem, error) {
+	var problems []IntegrityProblem
+	for _, table := range database.allTables() {
+		_, invalidKeys, err := table.scanKeys()
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range invalidKeys {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table: table.tableName(), Description: fmt.Sprintf("Record with key %q can't be read.", key),
+				},
+			)
+		}
+	}
+	return problems, nil
+}
+
+// Checks for matches with the same position in the schedule or with teams that aren't in the team list.
+func (database *Database) checkMatches(teamIds map[int]bool, matchesById map[int]Match) ([]IntegrityProblem, error) {
+	var problems []IntegrityProblem
+	matchIdsByPosition := make(map[string]int)
+	for _, matchId := range sortedMatchIds(matchesById) {
+		match := matchesById[matchId]
+		if match.Type == Test {
+			continue
+		}
+		position := fmt.Sprintf("%s %d", match.Type, match.TypeOrder)
+		if otherMatchId, ok := matchIdsByPosition[position]; ok {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:    database.matchTable.name,
+					RecordId: match.Id,
+					Description: fmt.Sprintf(
+						"Match %s is number %d of the %s matches, the same as match %d.",
+						match.ShortName,
+						match.TypeOrder,
+						match.Type,
+						otherMatchId,
+					),
+				},
+			)
+		} else {
+			matchIdsByPosition[position] = match.Id
+		}
+		for _, teamId := range []int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3} {
+			if teamId != 0 && !teamIds[teamId] {
+				problems = append(
+					problems,
+					IntegrityProblem{
+						Table:    database.matchTable.name,
+						RecordId: match.Id,
+						Description: fmt.Sprintf(
+							"Match %s includes team %d, which isn't in the team list.", match.ShortName, teamId,
+						),
+					},
+				)
+			}
+		}
+	}
+	return problems, nil
+}
+
+// Checks for results of matches that don't exist, results whose type disagrees with their match, and multiple results
+// for the same play of a match. Of the duplicates, the most recently created one is kept as the latest edit.
+func (database *Database) checkMatchResults(_ map[int]bool, matchesById map[int]Match) ([]IntegrityProblem, error) {
+	matchResults, err := database.matchResultTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(matchResults, func(i, j int) bool {
+		return matchResults[i].Id > matchResults[j].Id
+	})
+
+	var problems []IntegrityProblem
+	keptResultIds := make(map[string]int)
+	for _, matchResult := range matchResults {
+		deleteResult := func(database *Database) error {
+			return database.DeleteMatchResult(matchResult.Id)
+		}
+		match, ok := matchesById[matchResult.MatchId]
+		if !ok {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:       database.matchResultTable.name,
+					RecordId:    matchResult.Id,
+					Description: fmt.Sprintf("Result is for match %d, which doesn't exist.", matchResult.MatchId),
+					repair:      deleteResult,
+				},
+			)
+			continue
+		}
+
+		play := fmt.Sprintf("%d-%d", matchResult.MatchId, matchResult.PlayNumber)
+		if keptResultId, ok := keptResultIds[play]; ok {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:    database.matchResultTable.name,
+					RecordId: matchResult.Id,
+					Description: fmt.Sprintf(
+						"Result is a duplicate of result %d for play %d of match %s.",
+						keptResultId,
+						matchResult.PlayNumber,
+						match.ShortName,
+					),
+					repair: deleteResult,
+				},
+			)
+			continue
+		}
+		keptResultIds[play] = matchResult.Id
+
+		if matchResult.MatchType != match.Type {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:    database.matchResultTable.name,
+					RecordId: matchResult.Id,
+					Description: fmt.Sprintf(
+						"Result has type %s but match %s is of type %s.",
+						matchResult.MatchType,
+						match.ShortName,
+						match.Type,
+					),
+					repair: func(database *Database) error {
+						matchResult.MatchType = match.Type
+						return database.UpdateMatchResult(&matchResult)
+					},
+				},
+			)
+		}
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].RecordId < problems[j].RecordId
+	})
+	return problems, nil
+}
+
+// Checks for match logs of matches that don't exist, and for log packet data that has no corresponding log.
+func (database *Database) checkTeamMatchLogs(_ map[int]bool, matchesById map[int]Match) ([]IntegrityProblem, error) {
+	matchLogs, err := database.teamMatchLogTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	dataIds, _, err := database.teamMatchLogDataTable.scanKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []IntegrityProblem
+	matchLogIds := make(map[int]bool, len(matchLogs))
+	for _, matchLog := range matchLogs {
+		matchLogIds[matchLog.Id] = true
+		if _, ok := matchesById[matchLog.MatchId]; !ok {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:    database.teamMatchLogTable.name,
+					RecordId: matchLog.Id,
+					Description: fmt.Sprintf(
+						"Log for team %d is for match %d, which doesn't exist.", matchLog.TeamId, matchLog.MatchId,
+					),
+					repair: func(database *Database) error {
+						return database.deleteTeamMatchLogRecords(matchLog.Id)
+					},
+				},
+			)
+		}
+	}
+	for _, dataId := range dataIds {
+		if !matchLogIds[dataId] {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:       database.teamMatchLogDataTable.name,
+					RecordId:    dataId,
+					Description: "Packet data has no corresponding match log.",
+					repair: func(database *Database) error {
+						return database.teamMatchLogDataTable.delete(dataId)
+					},
+				},
+			)
+		}
+	}
+	return problems, nil
+}
+
+// Checks for rankings of teams that aren't in the team list. They would be dropped on the next ranking calculation
+// anyway, so deleting them is safe.
+func (database *Database) checkRankings(teamIds map[int]bool, _ map[int]Match) ([]IntegrityProblem, error) {
+	rankings, err := database.rankingTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	var problems []IntegrityProblem
+	for _, ranking := range rankings {
+		if !teamIds[ranking.TeamId] {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:       database.rankingTable.name,
+					RecordId:    ranking.TeamId,
+					Description: fmt.Sprintf("Ranking is for team %d, which isn't in the team list.", ranking.TeamId),
+					repair: func(database *Database) error {
+						return database.DeleteRanking(ranking.TeamId)
+					},
+				},
+			)
+		}
+	}
+	return problems, nil
+}
+
+// Checks for alliances including teams that aren't in the team list or that are also on another alliance, and for
+// playoff matches between alliances that don't exist.
+func (database *Database) checkAlliances(teamIds map[int]bool, matchesById map[int]Match) ([]IntegrityProblem, error) {
+	alliances, err := database.GetAllAlliances()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []IntegrityProblem
+	allianceIds := make(map[int]bool, len(alliances))
+	allianceIdsByTeam := make(map[int]int)
+	for _, alliance := range alliances {
+		allianceIds[alliance.Id] = true
+		for _, teamId := range alliance.TeamIds {
+			if teamId == 0 {
+				continue
+			}
+			if !teamIds[teamId] {
+				problems = append(
+					problems,
+					IntegrityProblem{
+						Table:       database.allianceTable.name,
+						RecordId:    alliance.Id,
+						Description: fmt.Sprintf("Alliance includes team %d, which isn't in the team list.", teamId),
+					},
+				)
+			}
+			if otherAllianceId, ok := allianceIdsByTeam[teamId]; ok {
+				problems = append(
+					problems,
+					IntegrityProblem{
+						Table:    database.allianceTable.name,
+						RecordId: alliance.Id,
+						Description: fmt.Sprintf(
+							"Alliance includes team %d, which is also on alliance %d.", teamId, otherAllianceId,
+						),
+					},
+				)
+			} else {
+				allianceIdsByTeam[teamId] = alliance.Id
+			}
+		}
+	}
+
+	for _, matchId := range sortedMatchIds(matchesById) {
+		match := matchesById[matchId]
+		if match.Type != Playoff {
+			continue
+		}
+		for _, allianceId := range []int{match.PlayoffRedAlliance, match.PlayoffBlueAlliance} {
+			if allianceId != 0 && !allianceIds[allianceId] {
+				problems = append(
+					problems,
+					IntegrityProblem{
+						Table:    database.matchTable.name,
+						RecordId: match.Id,
+						Description: fmt.Sprintf(
+							"Playoff match %s is for alliance %d, which doesn't exist.", match.ShortName, allianceId,
+						),
+					},
+				)
+			}
+		}
+	}
+	return problems, nil
+}
+
+// Checks for awards given to teams that aren't in the team list.
+func (database *Database) checkAwards(teamIds map[int]bool, _ map[int]Match) ([]IntegrityProblem, error) {
+	awards, err := database.GetAllAwards()
+	if err != nil {
+		return nil, err
+	}
+	var problems []IntegrityProblem
+	for _, award := range awards {
+		if award.TeamId != 0 && !teamIds[award.TeamId] {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:    database.awardTable.name,
+					RecordId: award.Id,
+					Description: fmt.Sprintf(
+						"Award %q is given to team %d, which isn't in the team list.", award.AwardName, award.TeamId,
+					),
+				},
+			)
+		}
+	}
+	return problems, nil
+}
+
+// Checks for inspections of teams that aren't in the team list.
+func (database *Database) checkTeamInspections(teamIds map[int]bool, _ map[int]Match) ([]IntegrityProblem, error) {
+	teamInspections, err := database.GetAllTeamInspections()
+	if err != nil {
+		return nil, err
+	}
+	var problems []IntegrityProblem
+	for _, teamInspection := range teamInspections {
+		if !teamIds[teamInspection.TeamId] {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:    database.teamInspectionTable.name,
+					RecordId: teamInspection.Id,
+					Description: fmt.Sprintf(
+						"Inspection is of team %d, which isn't in the team list.", teamInspection.TeamId,
+					),
+				},
+			)
+		}
+	}
+	return problems, nil
+}
+
+// Checks for nominations for award definitions that don't exist, of teams that aren't in the team list, or linked to
+// generated awards that don't exist. Nominations left behind by a deleted definition can't be shown and so are safe to
+// delete, and a missing generated award is simply created again on the next finalization.
+func (database *Database) checkAwardNominations(teamIds map[int]bool, _ map[int]Match) ([]IntegrityProblem, error) {
+	awardNominations, err := database.awardNominationTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	awardDefinitionIds, _, err := database.awardDefinitionTable.scanKeys()
+	if err != nil {
+		return nil, err
+	}
+	awardIds, _, err := database.awardTable.scanKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []IntegrityProblem
+	for _, awardNomination := range awardNominations {
+		if !slices.Contains(awardDefinitionIds, awardNomination.AwardDefinitionId) {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:    database.awardNominationTable.name,
+					RecordId: awardNomination.Id,
+					Description: fmt.Sprintf(
+						"Nomination is for award definition %d, which doesn't exist.",
+						awardNomination.AwardDefinitionId,
+					),
+					repair: func(database *Database) error {
+						return database.DeleteAwardNomination(awardNomination.Id)
+					},
+				},
+			)
+			continue
+		}
+		if awardNomination.TeamId != 0 && !teamIds[awardNomination.TeamId] {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:    database.awardNominationTable.name,
+					RecordId: awardNomination.Id,
+					Description: fmt.Sprintf(
+						"Nomination is of team %d, which isn't in the team list.", awardNomination.TeamId,
+					),
+				},
+			)
+		}
+		if awardNomination.AwardId != 0 && !slices.Contains(awardIds, awardNomination.AwardId) {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:    database.awardNominationTable.name,
+					RecordId: awardNomination.Id,
+					Description: fmt.Sprintf(
+						"Nomination generated award %d, which doesn't exist.", awardNomination.AwardId,
+					),
+					repair: func(database *Database) error {
+						awardNomination.AwardId = 0
+						return database.UpdateAwardNomination(&awardNomination)
+					},
+				},
+			)
+		}
+	}
+	return problems, nil
+}
+
+// Checks for lineup PINs of alliances that don't exist, and for lineups submitted for matches or by alliances that
+// don't exist or including teams that aren't in the team list. Lineups that can no longer be used are safe to delete.
+func (database *Database) checkLineups(teamIds map[int]bool, matchesById map[int]Match) ([]IntegrityProblem, error) {
+	allianceIds, _, err := database.allianceTable.scanKeys()
+	if err != nil {
+		return nil, err
+	}
+	lineupPins, err := database.lineupPinTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	lineupSubmissions, err := database.lineupSubmissionTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []IntegrityProblem
+	for _, lineupPin := range lineupPins {
+		if !slices.Contains(allianceIds, lineupPin.AllianceId) {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:       database.lineupPinTable.name,
+					RecordId:    lineupPin.AllianceId,
+					Description: fmt.Sprintf("PIN is for alliance %d, which doesn't exist.", lineupPin.AllianceId),
+					repair: func(database *Database) error {
+						return database.lineupPinTable.delete(lineupPin.AllianceId)
+					},
+				},
+			)
+		}
+	}
+	for _, lineupSubmission := range lineupSubmissions {
+		deleteSubmission := func(database *Database) error {
+			return database.lineupSubmissionTable.delete(lineupSubmission.Id)
+		}
+		if _, ok := matchesById[lineupSubmission.MatchId]; !ok {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:    database.lineupSubmissionTable.name,
+					RecordId: lineupSubmission.Id,
+					Description: fmt.Sprintf(
+						"Lineup is for match %d, which doesn't exist.", lineupSubmission.MatchId,
+					),
+					repair: deleteSubmission,
+				},
+			)
+			continue
+		}
+		if !slices.Contains(allianceIds, lineupSubmission.AllianceId) {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:    database.lineupSubmissionTable.name,
+					RecordId: lineupSubmission.Id,
+					Description: fmt.Sprintf(
+						"Lineup is for alliance %d, which doesn't exist.", lineupSubmission.AllianceId,
+					),
+					repair: deleteSubmission,
+				},
+			)
+			continue
+		}
+		for _, teamId := range lineupSubmission.TeamIds {
+			if teamId != 0 && !teamIds[teamId] {
+				problems = append(
+					problems,
+					IntegrityProblem{
+						Table:       database.lineupSubmissionTable.name,
+						RecordId:    lineupSubmission.Id,
+						Description: fmt.Sprintf("Lineup includes team %d, which isn't in the team list.", teamId),
+					},
+				)
+			}
+		}
+	}
+	return problems, nil
+}
+
+// Checks for timeouts called by alliances that don't exist or during matches that don't exist. Both are kept as a
+// record of what happened, so they are only reported.
+func (database *Database) checkAllianceTimeouts(_ map[int]bool, matchesById map[int]Match) ([]IntegrityProblem, error) {
+	allianceIds, _, err := database.allianceTable.scanKeys()
+	if err != nil {
+		return nil, err
+	}
+	allianceTimeouts, err := database.allianceTimeoutTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []IntegrityProblem
+	for _, allianceTimeout := range allianceTimeouts {
+		if !slices.Contains(allianceIds, allianceTimeout.AllianceId) {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:    database.allianceTimeoutTable.name,
+					RecordId: allianceTimeout.Id,
+					Description: fmt.Sprintf(
+						"Timeout was called by alliance %d, which doesn't exist.", allianceTimeout.AllianceId,
+					),
+				},
+			)
+		}
+		if _, ok := matchesById[allianceTimeout.MatchId]; allianceTimeout.MatchId != 0 && !ok {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:    database.allianceTimeoutTable.name,
+					RecordId: allianceTimeout.Id,
+					Description: fmt.Sprintf(
+						"Timeout was called before match %d, which doesn't exist.", allianceTimeout.MatchId,
+					),
+				},
+			)
+		}
+	}
+	return problems, nil
+}
+
+// Checks for start overrides of matches that don't exist. They are kept as an audit trail, so they are only reported.
+func (database *Database) checkMatchStartOverrides(
+	_ map[int]bool, matchesById map[int]Match,
+) ([]IntegrityProblem, error) {
+	matchStartOverrides, err := database.matchStartOverrideTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	var problems []IntegrityProblem
+	for _, matchStartOverride := range matchStartOverrides {
+		if _, ok := matchesById[matchStartOverride.MatchId]; matchStartOverride.MatchId != 0 && !ok {
+			problems = append(
+				problems,
+				IntegrityProblem{
+					Table:    database.matchStartOverrideTable.name,
+					RecordId: matchStartOverride.Id,
+					Description: fmt.Sprintf(
+						"Start override is for match %d, which doesn't exist.", matchStartOverride.MatchId,
+					),
+				},
+			)
+		}
+	}
+	return problems, nil
+}
+
+// Deletes whichever of the metadata and packet data records exist for the given match log.
+func (database *Database) deleteTeamMatchLogRecords(id int) error {
+	if data, err := database.teamMatchLogDataTable.getById(id); err != nil {
+		return err
+	} else if data != nil {
+		if err = database.teamMatchLogDataTable.delete(id); err != nil {
+			return err
+		}
+	}
+	return database.teamMatchLogTable.delete(id)
+}
+
+func sortedMatchIds(matchesById map[int]Match) []int {
+	ids := make([]int, 0, len(matchesById))
+	for id := range matchesById {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
@@ -0,0 +1,313 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckIntegrityWithNoProblems(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	db.CreateTeam(&Team{Id: 254})
+	db.CreateTeam(&Team{Id: 1114})
+	match := Match{Type: Qualification, TypeOrder: 1, Red1: 254, Blue1: 1114}
+	db.CreateMatch(&match)
+	db.CreateMatchResult(BuildTestMatchResult(match.Id, 1))
+	db.CreateMatchResult(BuildTestMatchResult(match.Id, 2))
+	db.CreateRanking(&game.Ranking{TeamId: 254, Rank: 1})
+	db.CreateTeamMatchLog(&TeamMatchLog{MatchId: match.Id, TeamId: 254}, nil)
+	db.CreateAlliance(&Alliance{Id: 1, TeamIds: []int{254, 1114}})
+	db.CreateMatch(&Match{Type: Playoff, TypeOrder: 1, PlayoffRedAlliance: 1})
+	award := Award{AwardName: "Winner", TeamId: 254}
+	db.CreateAward(&award)
+	db.CreateTeamInspection(&TeamInspection{TeamId: 254, Passed: true})
+	awardDefinition := AwardDefinition{Name: "Safety Award"}
+	db.CreateAwardDefinition(&awardDefinition)
+	db.CreateAwardNomination(
+		&AwardNomination{AwardDefinitionId: awardDefinition.Id, TeamId: 1114, IsWinner: true, AwardId: award.Id},
+	)
+	db.CreateLineupPin(&LineupPin{AllianceId: 1, Pin: "12345678"})
+	db.CreateLineupSubmission(&LineupSubmission{MatchId: match.Id, AllianceId: 1, TeamIds: [3]int{254, 1114, 0}})
+	db.CreateAllianceTimeout(&AllianceTimeout{AllianceId: 1, MatchId: match.Id})
+	db.CreateMatchStartOverride(&MatchStartOverride{MatchId: match.Id})
+	db.CreateMatchStartOverride(&MatchStartOverride{MatchId: 0})
+
+	problems, err := db.CheckIntegrity()
+	assert.Nil(t, err)
+	assert.Empty(t, problems)
+	repaired, err := db.RepairIntegrity("Test Event")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, repaired)
+}
+
+func TestCheckAndRepairIntegrity(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	db.CreateTeam(&Team{Id: 254})
+	match := Match{Type: Qualification, TypeOrder: 1, ShortName: "Q1", Red1: 254, Blue1: 1114}
+	db.CreateMatch(&match)
+	db.CreateMatch(&Match{Type: Qualification, TypeOrder: 1, ShortName: "Q1"})
+	orphanedResult := BuildTestMatchResult(1000, 1)
+	db.CreateMatchResult(orphanedResult)
+	duplicateResult := BuildTestMatchResult(match.Id, 1)
+	db.CreateMatchResult(duplicateResult)
+	keptResult := BuildTestMatchResult(match.Id, 1)
+	keptResult.MatchType = Practice
+	db.CreateMatchResult(keptResult)
+	orphanedLog := TeamMatchLog{MatchId: 1000, TeamId: 254}
+	db.CreateTeamMatchLog(&orphanedLog, []TeamMatchLogRow{{MatchTimeSec: 1}})
+	db.teamMatchLogDataTable.create(&TeamMatchLogData{Id: 500})
+	db.CreateRanking(&game.Ranking{TeamId: 254, Rank: 1})
+	db.CreateRanking(&game.Ranking{TeamId: 1114, Rank: 2})
+	db.CreateAlliance(&Alliance{Id: 1, TeamIds: []int{254, 1678}})
+	db.CreateAlliance(&Alliance{Id: 2, TeamIds: []int{254}})
+	playoffMatch := Match{Type: Playoff, TypeOrder: 1, ShortName: "F1", PlayoffRedAlliance: 1, PlayoffBlueAlliance: 3}
+	db.CreateMatch(&playoffMatch)
+	award := Award{AwardName: "Winner", TeamId: 1678}
+	db.CreateAward(&award)
+
+	problems, err := db.CheckIntegrity()
+	assert.Nil(t, err)
+	type problemSummary struct {
+		Table       string
+		RecordId    int
+		Description string
+		Repairable  bool
+	}
+	var summaries []problemSummary
+	for _, problem := range problems {
+		summaries = append(
+			summaries,
+			problemSummary{problem.Table, problem.RecordId, problem.Description, problem.Repairable()},
+		)
+	}
+	assert.Equal(
+		t,
+		[]problemSummary{
+			{"Match", match.Id, "Match Q1 includes team 1114, which isn't in the team list.", false},
+			{"Match", 2, "Match Q1 is number 1 of the Qualification matches, the same as match 1.", false},
+			{"MatchResult", orphanedResult.Id, "Result is for match 1000, which doesn't exist.", true},
+			{"MatchResult", duplicateResult.Id, "Result is a duplicate of result 3 for play 1 of match Q1.", true},
+			{"MatchResult", keptResult.Id, "Result has type Practice but match Q1 is of type Qualification.", true},
+			{"TeamMatchLog", orphanedLog.Id, "Log for team 254 is for match 1000, which doesn't exist.", true},
+			{"TeamMatchLogData", 500, "Packet data has no corresponding match log.", true},
+			{"Ranking", 1114, "Ranking is for team 1114, which isn't in the team list.", true},
+			{"Alliance", 1, "Alliance includes team 1678, which isn't in the team list.", false},
+			{"Alliance", 2, "Alliance includes team 254, which is also on alliance 1.", false},
+			{"Match", playoffMatch.Id, "Playoff match F1 is for alliance 3, which doesn't exist.", false},
+			{"Award", award.Id, "Award \"Winner\" is given to team 1678, which isn't in the team list.", false},
+		},
+		summaries,
+	)
+
+	repaired, err := db.RepairIntegrity("Test Event")
+	assert.Nil(t, err)
+	assert.Equal(t, 6, repaired)
+	problems, err = db.CheckIntegrity()
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(problems))
+	for _, problem := range problems {
+		assert.False(t, problem.Repairable())
+	}
+
+	matchResult, _ := db.GetMatchResultForMatch(match.Id)
+	if assert.NotNil(t, matchResult) {
+		assert.Equal(t, keptResult.Id, matchResult.Id)
+		assert.Equal(t, Qualification, matchResult.MatchType)
+	}
+	matchLog, _ := db.GetTeamMatchLogById(orphanedLog.Id)
+	assert.Nil(t, matchLog)
+	rows, _ := db.GetTeamMatchLogRows(orphanedLog.Id)
+	assert.Nil(t, rows)
+	rankings, _ := db.GetAllRankings()
+	assert.Equal(t, 1, len(rankings))
+
+	// Check that the database was backed up before the repair.
+	backups, _ := filepath.Glob(filepath.Join(BaseDir, backupsDir, "Test_Event_*_pre_repair.db"))
+	assert.NotEmpty(t, backups)
+	for _, backup := range backups {
+		os.Remove(backup)
+	}
+}
+
+func TestCheckAndRepairIntegrityOfEventRecords(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	db.CreateTeam(&Team{Id: 254})
+	match := Match{Type: Playoff, TypeOrder: 1, ShortName: "F1", PlayoffRedAlliance: 1}
+	db.CreateMatch(&match)
+	db.CreateAlliance(&Alliance{Id: 1, TeamIds: []int{254}})
+	teamInspection := TeamInspection{TeamId: 1114}
+	db.CreateTeamInspection(&teamInspection)
+	awardDefinition := AwardDefinition{Name: "Safety Award"}
+	db.CreateAwardDefinition(&awardDefinition)
+	orphanedNomination := AwardNomination{AwardDefinitionId: 100, TeamId: 254}
+	db.CreateAwardNomination(&orphanedNomination)
+	nomination := AwardNomination{AwardDefinitionId: awardDefinition.Id, TeamId: 1114, AwardId: 200}
+	db.CreateAwardNomination(&nomination)
+	db.CreateLineupPin(&LineupPin{AllianceId: 1, Pin: "12345678"})
+	db.CreateLineupPin(&LineupPin{AllianceId: 2, Pin: "87654321"})
+	orphanedLineup := LineupSubmission{MatchId: 1000, AllianceId: 1}
+	db.CreateLineupSubmission(&orphanedLineup)
+	lineup := LineupSubmission{MatchId: match.Id, AllianceId: 2}
+	db.CreateLineupSubmission(&lineup)
+	invalidTeamLineup := LineupSubmission{MatchId: match.Id, AllianceId: 1, TeamIds: [3]int{254, 1678, 0}}
+	db.CreateLineupSubmission(&invalidTeamLineup)
+	allianceTimeout := AllianceTimeout{AllianceId: 3, MatchId: 1000}
+	db.CreateAllianceTimeout(&allianceTimeout)
+	matchStartOverride := MatchStartOverride{MatchId: 1000}
+	db.CreateMatchStartOverride(&matchStartOverride)
+
+	problems, err := db.CheckIntegrity()
+	assert.Nil(t, err)
+	var descriptions []string
+	var numRepairable int
+	for _, problem := range problems {
+		descriptions = append(
+			descriptions, fmt.Sprintf("%s %d: %s", problem.Table, problem.RecordId, problem.Description),
+		)
+		if problem.Repairable() {
+			numRepairable++
+		}
+	}
+	assert.Equal(
+		t,
+		[]string{
+			fmt.Sprintf(
+				"TeamInspection %d: Inspection is of team 1114, which isn't in the team list.", teamInspection.Id,
+			),
+			fmt.Sprintf(
+				"AwardNomination %d: Nomination is for award definition 100, which doesn't exist.",
+				orphanedNomination.Id,
+			),
+			fmt.Sprintf("AwardNomination %d: Nomination is of team 1114, which isn't in the team list.", nomination.Id),
+			fmt.Sprintf("AwardNomination %d: Nomination generated award 200, which doesn't exist.", nomination.Id),
+			"LineupPin 2: PIN is for alliance 2, which doesn't exist.",
+			fmt.Sprintf("LineupSubmission %d: Lineup is for match 1000, which doesn't exist.", orphanedLineup.Id),
+			fmt.Sprintf("LineupSubmission %d: Lineup is for alliance 2, which doesn't exist.", lineup.Id),
+			fmt.Sprintf(
+				"LineupSubmission %d: Lineup includes team 1678, which isn't in the team list.", invalidTeamLineup.Id,
+			),
+			fmt.Sprintf(
+				"AllianceTimeout %d: Timeout was called by alliance 3, which doesn't exist.", allianceTimeout.Id,
+			),
+			fmt.Sprintf(
+				"AllianceTimeout %d: Timeout was called before match 1000, which doesn't exist.", allianceTimeout.Id,
+			),
+			fmt.Sprintf(
+				"MatchStartOverride %d: Start override is for match 1000, which doesn't exist.", matchStartOverride.Id,
+			),
+		},
+		descriptions,
+	)
+	assert.Equal(t, 5, numRepairable)
+
+	repaired, err := db.RepairIntegrity("Test Event")
+	assert.Nil(t, err)
+	assert.Equal(t, 5, repaired)
+	problems, err = db.CheckIntegrity()
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(problems))
+
+	awardNomination, _ := db.GetAwardNominationById(orphanedNomination.Id)
+	assert.Nil(t, awardNomination)
+	awardNomination, _ = db.GetAwardNominationById(nomination.Id)
+	if assert.NotNil(t, awardNomination) {
+		assert.Equal(t, 0, awardNomination.AwardId)
+	}
+	lineupPin, _ := db.GetLineupPinByAlliance(2)
+	assert.Nil(t, lineupPin)
+	lineupSubmission, _ := db.GetLineupSubmission(match.Id, 2)
+	assert.Nil(t, lineupSubmission)
+	lineupSubmission, _ = db.GetLineupSubmission(match.Id, 1)
+	assert.NotNil(t, lineupSubmission)
+
+	backups, _ := filepath.Glob(filepath.Join(BaseDir, backupsDir, "Test_Event_*_pre_repair.db"))
+	for _, backup := range backups {
+		os.Remove(backup)
+	}
+}
+
+func TestCheckAndRepairIntegrityOfIndexes(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	db.CreateTeam(&Team{Id: 254})
+	match1 := Match{Type: Qualification, TypeOrder: 1, ShortName: "Q1"}
+	db.CreateMatch(&match1)
+	match2 := Match{Type: Qualification, TypeOrder: 2, ShortName: "Q2"}
+	db.CreateMatch(&match2)
+	db.bolt.Update(func(tx *bbolt.Tx) error {
+		indexBucket := tx.Bucket([]byte("Match.Type"))
+		indexBucket.Delete([]byte(fmt.Sprintf("%d\x00%d", Qualification, match1.Id)))
+		indexBucket.Put([]byte(fmt.Sprintf("%d\x00%d", Playoff, match2.Id)), []byte{})
+		indexBucket.Put([]byte(fmt.Sprintf("%d\x00100", Qualification)), []byte{})
+		return nil
+	})
+
+	problems, err := db.CheckIntegrity()
+	assert.Nil(t, err)
+	var descriptions []string
+	for _, problem := range problems {
+		assert.Equal(t, "Match", problem.Table)
+		assert.True(t, problem.Repairable())
+		descriptions = append(descriptions, fmt.Sprintf("%d: %s", problem.RecordId, problem.Description))
+	}
+	assert.Equal(
+		t,
+		[]string{
+			fmt.Sprintf(
+				"100: Index on Type has an entry for value \"%d\", but the record doesn't exist.", Qualification,
+			),
+			fmt.Sprintf(
+				"%d: Index on Type has an entry for value \"%d\", which doesn't match the record.", match2.Id, Playoff,
+			),
+			fmt.Sprintf("%d: Index on Type is missing the entry for the record.", match1.Id),
+		},
+		descriptions,
+	)
+
+	repaired, err := db.RepairIntegrity("Test Event")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, repaired)
+	problems, err = db.CheckIntegrity()
+	assert.Nil(t, err)
+	assert.Empty(t, problems)
+	matches, _ := db.GetMatchesByType(Qualification, true)
+	assert.Equal(t, 2, len(matches))
+	matches, _ = db.GetMatchesByType(Playoff, true)
+	assert.Empty(t, matches)
+
+	backups, _ := filepath.Glob(filepath.Join(BaseDir, backupsDir, "Test_Event_*_pre_repair.db"))
+	for _, backup := range backups {
+		os.Remove(backup)
+	}
+}
+
+func TestCheckIntegrityWithUndecodableRecord(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	db.CreateTeam(&Team{Id: 254})
+	db.bolt.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("Team")).Put([]byte("1114"), []byte("{not json"))
+	})
+
+	problems, err := db.CheckIntegrity()
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(problems)) {
+		assert.Equal(t, "Team", problems[0].Table)
+		assert.Equal(t, "Record with key \"1114\" can't be read.", problems[0].Description)
+		assert.False(t, problems[0].Repairable())
+	}
+}
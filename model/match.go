@@ -28,8 +28,8 @@ func (t MatchType) Get() MatchType {
 }
 
 type Match struct {
-	Id                  int `db:"id"`
-	Type                MatchType
+	Id                  int       `db:"id"`
+	Type                MatchType `db:"index"`
 	TypeOrder           int
 	Time                time.Time
 	LongName            string
@@ -56,6 +56,7 @@ type Match struct {
 	Status              game.MatchStatus
 	UseTiebreakCriteria bool
 	TbaMatchKey         TbaMatchKey
+	FieldNumber         int // Field explicitly assigned to play the match on, or zero to alternate between fields.
 }
 
 type TbaMatchKey struct {
@@ -99,14 +100,14 @@ func (database *Database) GetMatchByTypeOrder(matchType MatchType, typeOrder int
 }
 
 func (database *Database) GetMatchesByType(matchType MatchType, includeHidden bool) ([]Match, error) {
-	matches, err := database.matchTable.getAll()
+	matches, err := database.matchTable.getByIndex("Type", matchType)
 	if err != nil {
 		return nil, err
 	}
 
 	var matchingMatches []Match
 	for _, match := range matches {
-		if match.Type == matchType && (includeHidden || match.Status != game.MatchHidden) {
+		if includeHidden || match.Status != game.MatchHidden {
 			matchingMatches = append(matchingMatches, match)
 		}
 	}
@@ -117,6 +118,21 @@ func (database *Database) GetMatchesByType(matchType MatchType, includeHidden bo
 	return matchingMatches, nil
 }
 
+// Returns the number of the field on which the match is to be played, given the number of fields at the event. Matches
+// without an explicit assignment alternate between the fields in order.
+func (match *Match) AssignedField(numFields int) int {
+	if numFields <= 1 {
+		return 1
+	}
+	if match.FieldNumber > 0 && match.FieldNumber <= numFields {
+		return match.FieldNumber
+	}
+	if match.TypeOrder < 1 {
+		return 1
+	}
+	return (match.TypeOrder-1)%numFields + 1
+}
+
 func (match *Match) IsComplete() bool {
 	return match.Status == game.RedWonMatch || match.Status == game.BlueWonMatch || match.Status == game.TieMatch
 }
@@ -11,7 +11,7 @@ import (
 
 type MatchResult struct {
 	Id         int `db:"id"`
-	MatchId    int
+	MatchId    int `db:"index"`
 	PlayNumber int
 	MatchType  MatchType
 	RedScore   *game.Score
@@ -35,15 +35,14 @@ func (database *Database) CreateMatchResult(matchResult *MatchResult) error {
 }
 
 func (database *Database) GetMatchResultForMatch(matchId int) (*MatchResult, error) {
-	matchResults, err := database.matchResultTable.getAll()
+	matchResults, err := database.matchResultTable.getByIndex("MatchId", matchId)
 	if err != nil {
 		return nil, err
 	}
 
 	var mostRecentMatchResult *MatchResult
 	for i, matchResult := range matchResults {
-		if matchResult.MatchId == matchId &&
-			(mostRecentMatchResult == nil || matchResult.PlayNumber > mostRecentMatchResult.PlayNumber) {
+		if mostRecentMatchResult == nil || matchResult.PlayNumber > mostRecentMatchResult.PlayNumber {
 			mostRecentMatchResult = &matchResults[i]
 		}
 	}
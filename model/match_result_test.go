@@ -69,3 +69,32 @@ func TestGetMatchResultForMatch(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, matchResult2, matchResult4)
 }
+
+// Compares the indexed lookup of a match's result against the full table scan it replaced, over an event's worth of
+// results.
+func BenchmarkGetMatchResultForMatch(b *testing.B) {
+	db := SetupTestDb(b, "model_benchmark")
+	defer db.Close()
+	for matchId := 1; matchId <= 100; matchId++ {
+		db.CreateMatchResult(BuildTestMatchResult(matchId, 1))
+		db.CreateMatchResult(BuildTestMatchResult(matchId, 2))
+	}
+
+	b.Run("Index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			db.GetMatchResultForMatch(i%100 + 1)
+		}
+	})
+	b.Run("Scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			matchResults, _ := db.matchResultTable.getAll()
+			var mostRecentMatchResult *MatchResult
+			for j, matchResult := range matchResults {
+				if matchResult.MatchId == i%100+1 &&
+					(mostRecentMatchResult == nil || matchResult.PlayNumber > mostRecentMatchResult.PlayNumber) {
+					mostRecentMatchResult = &matchResults[j]
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for the record of a match that was started despite failing readiness checks.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+type MatchStartOverride struct {
+	Id           int `db:"id"`
+	MatchId      int `db:"index"`
+	OverriddenAt time.Time
+	Checks       []string
+}
+
+func (database *Database) CreateMatchStartOverride(matchStartOverride *MatchStartOverride) error {
+	return database.matchStartOverrideTable.create(matchStartOverride)
+}
+
+// Returns all the overrides used to start the given match, in the order that they happened.
+func (database *Database) GetMatchStartOverridesByMatch(matchId int) ([]MatchStartOverride, error) {
+	matchStartOverrides, err := database.matchStartOverrideTable.getByIndex("MatchId", matchId)
+	if err != nil || len(matchStartOverrides) == 0 {
+		return nil, err
+	}
+	sort.Slice(matchStartOverrides, func(i, j int) bool {
+		return matchStartOverrides[i].Id < matchStartOverrides[j].Id
+	})
+	return matchStartOverrides, nil
+}
+
+func (database *Database) DeleteMatchStartOverride(id int) error {
+	return database.matchStartOverrideTable.delete(id)
+}
+
+func (database *Database) TruncateMatchStartOverrides() error {
+	return database.matchStartOverrideTable.truncate()
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMatchStartOverrideCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	matchStartOverrides, err := db.GetMatchStartOverridesByMatch(12)
+	assert.Nil(t, err)
+	assert.Empty(t, matchStartOverrides)
+
+	matchStartOverride1 := MatchStartOverride{
+		MatchId: 12, OverriddenAt: time.Unix(2000, 0).UTC(), Checks: []string{"R1 Radio Linked"},
+	}
+	matchStartOverride2 := MatchStartOverride{
+		MatchId: 15, OverriddenAt: time.Unix(3000, 0).UTC(), Checks: []string{"Scoring Panels Connected"},
+	}
+	matchStartOverride3 := MatchStartOverride{
+		MatchId:      12,
+		OverriddenAt: time.Unix(4000, 0).UTC(),
+		Checks:       []string{"B2 Battery Voltage", "B3 Ethernet Connected"},
+	}
+	assert.Nil(t, db.CreateMatchStartOverride(&matchStartOverride1))
+	assert.Nil(t, db.CreateMatchStartOverride(&matchStartOverride2))
+	assert.Nil(t, db.CreateMatchStartOverride(&matchStartOverride3))
+	matchStartOverrides, err = db.GetMatchStartOverridesByMatch(12)
+	assert.Nil(t, err)
+	assert.Equal(t, []MatchStartOverride{matchStartOverride1, matchStartOverride3}, matchStartOverrides)
+
+	assert.Nil(t, db.DeleteMatchStartOverride(matchStartOverride1.Id))
+	matchStartOverrides, _ = db.GetMatchStartOverridesByMatch(12)
+	assert.Equal(t, []MatchStartOverride{matchStartOverride3}, matchStartOverrides)
+
+	assert.Nil(t, db.TruncateMatchStartOverrides())
+	matchStartOverrides, _ = db.GetMatchStartOverridesByMatch(15)
+	assert.Empty(t, matchStartOverrides)
+}
@@ -211,3 +211,55 @@ func TestTbaMatchKeyString(t *testing.T) {
 	key = TbaMatchKey{CompLevel: "f", SetNumber: 1, MatchNumber: 4}
 	assert.Equal(t, "f1m4", key.String())
 }
+
+func TestMatchAssignedField(t *testing.T) {
+	match := Match{Type: Qualification, TypeOrder: 1}
+	assert.Equal(t, 1, match.AssignedField(0))
+	assert.Equal(t, 1, match.AssignedField(1))
+	assert.Equal(t, 1, match.AssignedField(2))
+	match.TypeOrder = 2
+	assert.Equal(t, 1, match.AssignedField(1))
+	assert.Equal(t, 2, match.AssignedField(2))
+	match.TypeOrder = 6
+	assert.Equal(t, 2, match.AssignedField(2))
+	assert.Equal(t, 3, match.AssignedField(3))
+
+	// Explicit assignments should override the alternation as long as the field exists.
+	match.FieldNumber = 1
+	assert.Equal(t, 1, match.AssignedField(2))
+	match.FieldNumber = 3
+	assert.Equal(t, 2, match.AssignedField(2))
+	assert.Equal(t, 3, match.AssignedField(3))
+
+	testMatch := Match{Type: Test}
+	assert.Equal(t, 1, testMatch.AssignedField(2))
+}
+
+// Compares the indexed lookup of the matches of one type against the full table scan it replaced, over an event's
+// worth of matches.
+func BenchmarkGetMatchesByType(b *testing.B) {
+	db := SetupTestDb(b, "model_benchmark")
+	defer db.Close()
+	for matchType, numMatches := range map[MatchType]int{Practice: 60, Qualification: 100, Playoff: 16} {
+		for typeOrder := 1; typeOrder <= numMatches; typeOrder++ {
+			db.CreateMatch(&Match{Type: matchType, TypeOrder: typeOrder, Red1: 254, Blue1: 1114})
+		}
+	}
+
+	b.Run("Index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			db.GetMatchesByType(Playoff, false)
+		}
+	})
+	b.Run("Scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			matches, _ := db.matchTable.getAll()
+			var matchingMatches []Match
+			for _, match := range matches {
+				if match.Type == Playoff && match.Status != game.MatchHidden {
+					matchingMatches = append(matchingMatches, match)
+				}
+			}
+		}
+	})
+}
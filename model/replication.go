@@ -0,0 +1,108 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Feed of the changes made to the database, for replicating it to a standby server.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Number of changes that can be queued for a subscriber before it is considered to have fallen behind.
+const changeSubscriptionBufferSize = 1000
+
+type ChangeOp string
+
+const (
+	PutChange      ChangeOp = "put"
+	DeleteChange   ChangeOp = "delete"
+	TruncateChange ChangeOp = "truncate"
+)
+
+// Represents a single committed write to one of the tables.
+type DatabaseChange struct {
+	// Position of the change in the sequence of changes made since the database was opened, starting from 1.
+	Number   int
+	Table    string
+	Op       ChangeOp
+	Id       int             `json:",omitempty"`
+	Record   json.RawMessage `json:",omitempty"`
+	Sequence uint64          // Value of the table's ID sequence once the change is made.
+}
+
+type changeFeed struct {
+	mutex         sync.Mutex
+	lastNumber    int
+	subscriptions map[*ChangeSubscription]struct{}
+}
+
+// Receives the changes made to the database after a given snapshot of it.
+type ChangeSubscription struct {
+	// Number of the last change included in the snapshot; the first change received will be the one after it.
+	SnapshotChangeNumber int
+	// Delivers the changes in order. Closed if the subscription is closed or the subscriber falls too far behind.
+	Changes <-chan DatabaseChange
+	changes chan DatabaseChange
+	feed    *changeFeed
+}
+
+func newChangeFeed() *changeFeed {
+	return &changeFeed{subscriptions: make(map[*ChangeSubscription]struct{})}
+}
+
+// Numbers the given change and sends it to each subscriber. Must be called with the mutex held.
+func (feed *changeFeed) publish(change *DatabaseChange) {
+	feed.lastNumber++
+	change.Number = feed.lastNumber
+	for subscription := range feed.subscriptions {
+		select {
+		case subscription.changes <- *change:
+		default:
+			// Drop the subscriber rather than block writes on it; the gap tells it to start over from a new snapshot.
+			delete(feed.subscriptions, subscription)
+			close(subscription.changes)
+		}
+	}
+}
+
+// Writes a snapshot of the database to the given writer and returns a subscription to all changes made after it.
+func (database *Database) SubscribeToChanges(snapshotWriter io.Writer) (*ChangeSubscription, error) {
+	feed := database.changeFeed
+	feed.mutex.Lock()
+	defer feed.mutex.Unlock()
+
+	if err := database.WriteBackup(snapshotWriter); err != nil {
+		return nil, err
+	}
+	changes := make(chan DatabaseChange, changeSubscriptionBufferSize)
+	subscription := ChangeSubscription{
+		SnapshotChangeNumber: feed.lastNumber, Changes: changes, changes: changes, feed: feed,
+	}
+	feed.subscriptions[&subscription] = struct{}{}
+	return &subscription, nil
+}
+
+// Stops delivery of changes to the subscription and closes its channel.
+func (subscription *ChangeSubscription) Close() {
+	feed := subscription.feed
+	feed.mutex.Lock()
+	defer feed.mutex.Unlock()
+	if _, ok := feed.subscriptions[subscription]; ok {
+		delete(feed.subscriptions, subscription)
+		close(subscription.changes)
+	}
+}
+
+// Applies a change received from another database to this one. The change is renumbered and republished to this
+// database's own subscribers.
+func (database *Database) ApplyChange(change *DatabaseChange) error {
+	for _, table := range database.tables() {
+		if table.tableName() == change.Table {
+			return table.applyChange(change)
+		}
+	}
+	return fmt.Errorf("unknown table %s", change.Table)
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReplicateChanges(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+	db.CreateTeam(&Team{Id: 254})
+	db.CreateMatch(&Match{Type: Qualification, TypeOrder: 1})
+
+	var snapshot bytes.Buffer
+	subscription, err := db.SubscribeToChanges(&snapshot)
+	assert.Nil(t, err)
+	defer subscription.Close()
+	assert.Equal(t, 2, subscription.SnapshotChangeNumber)
+	replicaPath := filepath.Join(BaseDir, "replica_test.db")
+	assert.Nil(t, os.WriteFile(replicaPath, snapshot.Bytes(), 0644))
+	defer os.Remove(replicaPath)
+	replica, err := OpenDatabase(replicaPath)
+	assert.Nil(t, err)
+	defer replica.Close()
+
+	match := Match{Type: Playoff, TypeOrder: 1}
+	db.CreateMatch(&match)
+	match.Type = Practice
+	db.UpdateMatch(&match)
+	db.CreateMatchResult(BuildTestMatchResult(match.Id, 1))
+	db.DeleteTeam(254)
+	db.CreateTeam(&Team{Id: 1114})
+	db.TruncateMatches()
+	db.CreateMatch(&Match{Type: Qualification, TypeOrder: 2})
+	assert.Equal(t, 7, len(subscription.Changes))
+
+	for i := 0; i < 7; i++ {
+		change := <-subscription.Changes
+		assert.Equal(t, subscription.SnapshotChangeNumber+i+1, change.Number)
+		assert.Nil(t, replica.ApplyChange(&change))
+	}
+
+	teams, _ := replica.GetAllTeams()
+	if assert.Equal(t, 1, len(teams)) {
+		assert.Equal(t, 1114, teams[0].Id)
+	}
+	matches, _ := replica.GetMatchesByType(Qualification, true)
+	if assert.Equal(t, 1, len(matches)) {
+		assert.Equal(t, 1, matches[0].Id)
+		assert.Equal(t, 2, matches[0].TypeOrder)
+	}
+	matches, _ = replica.GetMatchesByType(Practice, true)
+	assert.Empty(t, matches)
+	matchResult, _ := replica.GetMatchResultForMatch(match.Id)
+	assert.NotNil(t, matchResult)
+
+	// Check that records created on the replica after promotion continue the primary's ID sequences.
+	newMatch := Match{Type: Practice, TypeOrder: 1}
+	assert.Nil(t, replica.CreateMatch(&newMatch))
+	assert.Equal(t, 2, newMatch.Id)
+
+	err = replica.ApplyChange(&DatabaseChange{Table: "Blorpy", Op: PutChange})
+	assert.EqualError(t, err, "unknown table Blorpy")
+}
+
+func TestChangeSubscriptionFallingBehind(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	var snapshot bytes.Buffer
+	subscription, err := db.SubscribeToChanges(&snapshot)
+	assert.Nil(t, err)
+	for i := 1; i <= changeSubscriptionBufferSize+1; i++ {
+		db.CreateTeam(&Team{Id: i})
+	}
+
+	numReceived := 0
+	for range subscription.Changes {
+		numReceived++
+	}
+	assert.Equal(t, changeSubscriptionBufferSize, numReceived)
+
+	// Closing an already dropped subscription should be a no-op.
+	subscription.Close()
+}
@@ -11,8 +11,8 @@ import (
 )
 
 type ScheduleBlock struct {
-	Id              int `db:"id"`
-	MatchType       MatchType
+	Id              int       `db:"id"`
+	MatchType       MatchType `db:"index"`
 	StartTime       time.Time
 	NumMatches      int
 	MatchSpacingSec int
@@ -23,18 +23,11 @@ func (database *Database) CreateScheduleBlock(block *ScheduleBlock) error {
 }
 
 func (database *Database) GetScheduleBlocksByMatchType(matchType MatchType) ([]ScheduleBlock, error) {
-	scheduleBlocks, err := database.scheduleBlockTable.getAll()
-	if err != nil {
+	matchingScheduleBlocks, err := database.scheduleBlockTable.getByIndex("MatchType", matchType)
+	if err != nil || len(matchingScheduleBlocks) == 0 {
 		return nil, err
 	}
 
-	var matchingScheduleBlocks []ScheduleBlock
-	for _, scheduleBlock := range scheduleBlocks {
-		if scheduleBlock.MatchType == matchType {
-			matchingScheduleBlocks = append(matchingScheduleBlocks, scheduleBlock)
-		}
-	}
-
 	sort.Slice(matchingScheduleBlocks, func(i, j int) bool {
 		return matchingScheduleBlocks[i].StartTime.Before(matchingScheduleBlocks[j].StartTime)
 	})
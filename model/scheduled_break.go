@@ -11,8 +11,8 @@ import (
 )
 
 type ScheduledBreak struct {
-	Id              int `db:"id"`
-	MatchType       MatchType
+	Id              int       `db:"id"`
+	MatchType       MatchType `db:"index"`
 	TypeOrderBefore int
 	Time            time.Time
 	DurationSec     int
@@ -32,18 +32,11 @@ func (database *Database) UpdateScheduledBreak(scheduledBreak *ScheduledBreak) e
 }
 
 func (database *Database) GetScheduledBreaksByMatchType(matchType MatchType) ([]ScheduledBreak, error) {
-	scheduledBreaks, err := database.scheduledBreakTable.getAll()
-	if err != nil {
+	matchingScheduledBreaks, err := database.scheduledBreakTable.getByIndex("MatchType", matchType)
+	if err != nil || len(matchingScheduledBreaks) == 0 {
 		return nil, err
 	}
 
-	var matchingScheduledBreaks []ScheduledBreak
-	for _, scheduledBreak := range scheduledBreaks {
-		if scheduledBreak.MatchType == matchType {
-			matchingScheduledBreaks = append(matchingScheduledBreaks, scheduledBreak)
-		}
-	}
-
 	sort.Slice(matchingScheduledBreaks, func(i, j int) bool {
 		return matchingScheduledBreaks[i].TypeOrderBefore < matchingScheduledBreaks[j].TypeOrderBefore
 	})
@@ -6,10 +6,12 @@
 package model
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"go.etcd.io/bbolt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -22,10 +24,26 @@ type table[R any] struct {
 	bucketKey    []byte
 	idFieldIndex *int
 	manualId     bool
+	indexes      []tableIndex
+	feed         *changeFeed
 }
 
-// Registers a new table for a struct.
+// Secondary index over a field tagged with 'index', stored in its own Bolt bucket with one empty-valued key per record
+// consisting of the field value and the record ID separated by a null byte. Looking up the records having a given value
+// is then a prefix scan over the index followed by direct reads, rather than a decode of every record in the table.
+type tableIndex struct {
+	fieldName  string
+	fieldIndex int
+	bucketKey  []byte
+}
+
+// Registers a new table for a struct in the event database.
 func newTable[R any](database *Database) (*table[R], error) {
+	return newTableInStore[R](database.bolt, database.changeFeed)
+}
+
+// Registers a new table for a struct in the given Bolt store, publishing its changes to the given feed if there is one.
+func newTableInStore[R any](bolt *bbolt.DB, feed *changeFeed) (*table[R], error) {
 	var recordType R
 	recordTypeValue := reflect.ValueOf(recordType)
 	if recordTypeValue.Kind() != reflect.Struct {
@@ -33,12 +51,14 @@ func newTable[R any](database *Database) (*table[R], error) {
 	}
 
 	var table table[R]
-	table.bolt = database.bolt
+	table.bolt = bolt
+	table.feed = feed
 	table.recordType = reflect.TypeOf(recordType)
 	table.name = table.recordType.Name()
 	table.bucketKey = []byte(table.name)
 
-	// Determine which field in the struct is tagged as the ID and cache its index.
+	// Determine which field in the struct is tagged as the ID and cache its index, along with those of any fields
+	// tagged for secondary indexing.
 	idFound := false
 	for i := 0; i < recordTypeValue.Type().NumField(); i++ {
 		field := recordTypeValue.Type().Field(i)
@@ -46,7 +66,16 @@ func newTable[R any](database *Database) (*table[R], error) {
 		for _, tag := range strings.Split(field.Tag.Get("db"), ",") {
 			tags[tag] = struct{}{}
 		}
-		if _, ok := tags["id"]; ok {
+		if _, ok := tags["index"]; ok {
+			if _, err := indexKeyPrefix(reflect.Zero(field.Type)); err != nil {
+				return nil, fmt.Errorf("field %s in struct %s can't be indexed: %v", field.Name, table.name, err)
+			}
+			table.indexes = append(
+				table.indexes,
+				tableIndex{fieldName: field.Name, fieldIndex: i, bucketKey: []byte(table.name + "." + field.Name)},
+			)
+		}
+		if _, ok := tags["id"]; ok && !idFound {
 			if field.Type.Kind() != reflect.Int {
 				return nil,
 					fmt.Errorf(
@@ -57,17 +86,31 @@ func newTable[R any](database *Database) (*table[R], error) {
 			*table.idFieldIndex = i
 			idFound = true
 			_, table.manualId = tags["manual"]
-			break
 		}
 	}
 	if !idFound {
 		return nil, fmt.Errorf("struct %s has no field tagged as the id", table.name)
 	}
 
-	// Create the Bolt bucket corresponding to the struct.
+	// Create the Bolt bucket corresponding to the struct, and those of any indexes that don't yet exist, populating
+	// them from the existing records in case the index was added after the database was created.
 	err := table.bolt.Update(func(tx *bbolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists(table.bucketKey)
-		return err
+		bucket, err := tx.CreateBucketIfNotExists(table.bucketKey)
+		if err != nil {
+			return err
+		}
+		for _, index := range table.indexes {
+			if tx.Bucket(index.bucketKey) != nil {
+				continue
+			}
+			if _, err = tx.CreateBucket(index.bucketKey); err != nil {
+				return err
+			}
+			if err = table.addAllIndexEntries(tx, bucket); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
 	if err != nil {
 		return nil, err
@@ -118,6 +161,81 @@ func (table *table[R]) getAll() ([]R, error) {
 	return records, err
 }
 
+// Returns the records whose field having the given name, which must be tagged for indexing, has the given value. They
+// are ordered by string representation of ID, the same as getAll().
+func (table *table[R]) getByIndex(fieldName string, value any) ([]R, error) {
+	var index *tableIndex
+	for i := range table.indexes {
+		if table.indexes[i].fieldName == fieldName {
+			index = &table.indexes[i]
+		}
+	}
+	if index == nil {
+		return nil, fmt.Errorf("%s has no index on field %s", table.name, fieldName)
+	}
+	prefix, err := indexKeyPrefix(reflect.ValueOf(value))
+	if err != nil {
+		return nil, err
+	}
+
+	records := []R{}
+	err = table.bolt.View(func(tx *bbolt.Tx) error {
+		bucket, err := table.getBucket(tx)
+		if err != nil {
+			return err
+		}
+		indexBucket := tx.Bucket(index.bucketKey)
+		if indexBucket == nil {
+			return fmt.Errorf("unknown index %s", index.bucketKey)
+		}
+
+		cursor := indexBucket.Cursor()
+		for indexKey, _ := cursor.Seek(prefix); bytes.HasPrefix(indexKey, prefix); indexKey, _ = cursor.Next() {
+			// Skip keys for longer string values that happen to contain the prefix, whose remainder won't be an ID.
+			key := indexKey[len(prefix):]
+			if id, err := strconv.Atoi(string(key)); err != nil || !bytes.Equal(idToKey(id), key) {
+				continue
+			}
+			recordJson := bucket.Get(key)
+			if recordJson == nil {
+				return fmt.Errorf("index %s refers to non-existent %s with ID %s", index.bucketKey, table.name, key)
+			}
+			var record R
+			if err := json.Unmarshal(recordJson, &record); err != nil {
+				return err
+			}
+			records = append(records, record)
+		}
+		return nil
+	})
+	return records, err
+}
+
+// Returns the IDs of the records in the table that can be decoded, and the keys of those that can't, without
+// retaining the records themselves.
+func (table *table[R]) scanKeys() ([]int, []string, error) {
+	var ids []int
+	var invalidKeys []string
+	err := table.bolt.View(func(tx *bbolt.Tx) error {
+		bucket, err := table.getBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		return bucket.ForEach(func(key, value []byte) error {
+			var record R
+			id, err := strconv.Atoi(string(key))
+			if err != nil || json.Unmarshal(value, &record) != nil {
+				invalidKeys = append(invalidKeys, string(key))
+			} else {
+				ids = append(ids, id)
+			}
+			return nil
+		})
+	})
+	return ids, invalidKeys, err
+}
+
 // Persists the given record as a new row in the table.
 func (table *table[R]) create(record *R) error {
 	// Validate that the record has its ID set to zero or not as expected, depending on whether it is configured for
@@ -132,7 +250,7 @@ func (table *table[R]) create(record *R) error {
 		)
 	}
 
-	return table.bolt.Update(func(tx *bbolt.Tx) error {
+	return table.updateAndPublish(func(tx *bbolt.Tx, change *DatabaseChange) error {
 		bucket, err := table.getBucket(tx)
 		if err != nil {
 			return err
@@ -159,7 +277,11 @@ func (table *table[R]) create(record *R) error {
 		if err != nil {
 			return err
 		}
-		return bucket.Put(key, recordJson)
+		if err = bucket.Put(key, recordJson); err != nil {
+			return err
+		}
+		change.Op, change.Id, change.Record = PutChange, id, recordJson
+		return table.addIndexEntries(tx, record)
 	})
 }
 
@@ -173,7 +295,7 @@ func (table *table[R]) update(record *R) error {
 		return fmt.Errorf("can't update %s with zero ID", table.name)
 	}
 
-	return table.bolt.Update(func(tx *bbolt.Tx) error {
+	return table.updateAndPublish(func(tx *bbolt.Tx, change *DatabaseChange) error {
 		bucket, err := table.getBucket(tx)
 		if err != nil {
 			return err
@@ -190,13 +312,20 @@ func (table *table[R]) update(record *R) error {
 		if err != nil {
 			return err
 		}
-		return bucket.Put(key, recordJson)
+		if err = table.removeIndexEntries(tx, key, oldRecord); err != nil {
+			return err
+		}
+		if err = bucket.Put(key, recordJson); err != nil {
+			return err
+		}
+		change.Op, change.Id, change.Record = PutChange, id, recordJson
+		return table.addIndexEntries(tx, record)
 	})
 }
 
 // Deletes the record having the given ID from the table. Returns an error if the record does not exist.
 func (table *table[R]) delete(id int) error {
-	return table.bolt.Update(func(tx *bbolt.Tx) error {
+	return table.updateAndPublish(func(tx *bbolt.Tx, change *DatabaseChange) error {
 		bucket, err := table.getBucket(tx)
 		if err != nil {
 			return err
@@ -209,28 +338,299 @@ func (table *table[R]) delete(id int) error {
 			return fmt.Errorf("can't delete non-existent %s with ID %d", table.name, id)
 		}
 
+		if err = table.removeIndexEntries(tx, key, oldRecord); err != nil {
+			return err
+		}
+		change.Op, change.Id = DeleteChange, id
 		return bucket.Delete(key)
 	})
 }
 
 // Deletes all records from the table.
 func (table *table[R]) truncate() error {
-	return table.bolt.Update(func(tx *bbolt.Tx) error {
-		_, err := table.getBucket(tx)
+	return table.updateAndPublish(func(tx *bbolt.Tx, change *DatabaseChange) error {
+		change.Op = TruncateChange
+		return table.truncateBuckets(tx)
+	})
+}
+
+// Deletes and recreates the table's bucket and those of its indexes within the given transaction.
+func (table *table[R]) truncateBuckets(tx *bbolt.Tx) error {
+	_, err := table.getBucket(tx)
+	if err != nil {
+		return err
+	}
+
+	// Carry out the truncation by way of deleting the whole bucket and then recreate it.
+	err = tx.DeleteBucket(table.bucketKey)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.CreateBucket(table.bucketKey); err != nil {
+		return err
+	}
+	for _, index := range table.indexes {
+		if err = tx.DeleteBucket(index.bucketKey); err != nil && err != bbolt.ErrBucketNotFound {
+			return err
+		}
+		if _, err = tx.CreateBucket(index.bucketKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Applies the given change, received from another database, to the table such that the two end up identical,
+// including the sequence from which new IDs are generated.
+func (table *table[R]) applyChange(change *DatabaseChange) error {
+	return table.updateAndPublish(func(tx *bbolt.Tx, appliedChange *DatabaseChange) error {
+		*appliedChange = *change
+		if change.Op == TruncateChange {
+			if err := table.truncateBuckets(tx); err != nil {
+				return err
+			}
+		}
+		bucket, err := table.getBucket(tx)
 		if err != nil {
 			return err
 		}
 
-		// Carry out the truncation by way of deleting the whole bucket and then recreate it.
-		err = tx.DeleteBucket(table.bucketKey)
+		key := idToKey(change.Id)
+		switch change.Op {
+		case PutChange:
+			var record R
+			if err = json.Unmarshal(change.Record, &record); err != nil {
+				return err
+			}
+			if oldRecord := bucket.Get(key); oldRecord != nil {
+				if err = table.removeIndexEntries(tx, key, oldRecord); err != nil {
+					return err
+				}
+			}
+			if err = bucket.Put(key, change.Record); err != nil {
+				return err
+			}
+			if err = table.addIndexEntries(tx, &record); err != nil {
+				return err
+			}
+		case DeleteChange:
+			if oldRecord := bucket.Get(key); oldRecord != nil {
+				if err = table.removeIndexEntries(tx, key, oldRecord); err != nil {
+					return err
+				}
+				if err = bucket.Delete(key); err != nil {
+					return err
+				}
+			}
+		case TruncateChange:
+		default:
+			return fmt.Errorf("unknown change operation %q", change.Op)
+		}
+		return bucket.SetSequence(change.Sequence)
+	})
+}
+
+// Runs the given function within a read-write transaction and, once the transaction is committed, publishes the change
+// that the function describes to any subscribers. Holding the feed lock throughout ensures that changes are numbered
+// and published in the same order in which they are committed.
+func (table *table[R]) updateAndPublish(update func(tx *bbolt.Tx, change *DatabaseChange) error) error {
+	if table.feed == nil {
+		// The table isn't replicated, so there is nothing to publish.
+		return table.bolt.Update(func(tx *bbolt.Tx) error {
+			return update(tx, new(DatabaseChange))
+		})
+	}
+	table.feed.mutex.Lock()
+	defer table.feed.mutex.Unlock()
+
+	var change DatabaseChange
+	err := table.bolt.Update(func(tx *bbolt.Tx) error {
+		if err := update(tx, &change); err != nil {
+			return err
+		}
+		bucket, err := table.getBucket(tx)
 		if err != nil {
 			return err
 		}
-		_, err = tx.CreateBucket(table.bucketKey)
+		change.Table = table.name
+		change.Sequence = bucket.Sequence()
+		return nil
+	})
+	if err != nil {
 		return err
+	}
+	table.feed.publish(&change)
+	return nil
+}
+
+func (table *table[R]) tableName() string {
+	return table.name
+}
+
+// Adds the entries for the given record to each of the table's indexes.
+func (table *table[R]) addIndexEntries(tx *bbolt.Tx, record *R) error {
+	value := reflect.ValueOf(record).Elem()
+	key := idToKey(int(value.Field(*table.idFieldIndex).Int()))
+	for _, index := range table.indexes {
+		prefix, err := indexKeyPrefix(value.Field(index.fieldIndex))
+		if err != nil {
+			return err
+		}
+		if err = tx.Bucket(index.bucketKey).Put(append(prefix, key...), []byte{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Adds the entries for every record in the given bucket to each of the table's indexes.
+func (table *table[R]) addAllIndexEntries(tx *bbolt.Tx, bucket *bbolt.Bucket) error {
+	return bucket.ForEach(func(key, value []byte) error {
+		var record R
+		if err := json.Unmarshal(value, &record); err != nil {
+			// Leave undecodable records for the integrity checker to report rather than failing.
+			return nil
+		}
+		return table.addIndexEntries(tx, &record)
+	})
+}
+
+// Compares each of the table's indexes with its records, returning a problem for each record that is missing its entry
+// and for each entry that doesn't match the record it refers to. All are repaired by rebuilding the indexes.
+func (table *table[R]) checkIndexes() ([]IntegrityProblem, error) {
+	var problems []IntegrityProblem
+	addProblem := func(id int, description string) {
+		problems = append(
+			problems,
+			IntegrityProblem{Table: table.name, RecordId: id, Description: description, repair: table.rebuildIndexes},
+		)
+	}
+	err := table.bolt.View(func(tx *bbolt.Tx) error {
+		bucket, err := table.getBucket(tx)
+		if err != nil {
+			return err
+		}
+		for _, index := range table.indexes {
+			expectedEntries := make(map[string]int)
+			err = bucket.ForEach(func(key, value []byte) error {
+				var record R
+				id, err := strconv.Atoi(string(key))
+				if err != nil || json.Unmarshal(value, &record) != nil {
+					return nil
+				}
+				prefix, err := indexKeyPrefix(reflect.ValueOf(record).Field(index.fieldIndex))
+				if err != nil {
+					return err
+				}
+				expectedEntries[string(append(prefix, key...))] = id
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			if indexBucket := tx.Bucket(index.bucketKey); indexBucket != nil {
+				_ = indexBucket.ForEach(func(indexKey, _ []byte) error {
+					if _, ok := expectedEntries[string(indexKey)]; ok {
+						delete(expectedEntries, string(indexKey))
+						return nil
+					}
+					value, key, _ := bytes.Cut(indexKey, []byte{0})
+					id, _ := strconv.Atoi(string(key))
+					if bucket.Get(key) == nil {
+						addProblem(
+							id,
+							fmt.Sprintf(
+								"Index on %s has an entry for value %q, but the record doesn't exist.",
+								index.fieldName,
+								value,
+							),
+						)
+					} else {
+						addProblem(
+							id,
+							fmt.Sprintf(
+								"Index on %s has an entry for value %q, which doesn't match the record.",
+								index.fieldName,
+								value,
+							),
+						)
+					}
+					return nil
+				})
+			}
+			var missingIds []int
+			for _, id := range expectedEntries {
+				missingIds = append(missingIds, id)
+			}
+			sort.Ints(missingIds)
+			for _, id := range missingIds {
+				addProblem(id, fmt.Sprintf("Index on %s is missing the entry for the record.", index.fieldName))
+			}
+		}
+		return nil
+	})
+	return problems, err
+}
+
+// Discards the table's indexes and regenerates them from its records.
+func (table *table[R]) rebuildIndexes(*Database) error {
+	return table.bolt.Update(func(tx *bbolt.Tx) error {
+		bucket, err := table.getBucket(tx)
+		if err != nil {
+			return err
+		}
+		for _, index := range table.indexes {
+			if err = tx.DeleteBucket(index.bucketKey); err != nil && err != bbolt.ErrBucketNotFound {
+				return err
+			}
+			if _, err = tx.CreateBucket(index.bucketKey); err != nil {
+				return err
+			}
+		}
+		return table.addAllIndexEntries(tx, bucket)
 	})
 }
 
+// Removes the entries for the record having the given key and previously stored JSON from each of the table's indexes.
+// If the JSON can't be decoded, the indexes are instead searched for the entries belonging to the key.
+func (table *table[R]) removeIndexEntries(tx *bbolt.Tx, key, oldRecordJson []byte) error {
+	if len(table.indexes) == 0 {
+		return nil
+	}
+	var oldRecord R
+	decodeErr := json.Unmarshal(oldRecordJson, &oldRecord)
+	oldValue := reflect.ValueOf(oldRecord)
+	for _, index := range table.indexes {
+		indexBucket := tx.Bucket(index.bucketKey)
+		if decodeErr == nil {
+			prefix, err := indexKeyPrefix(oldValue.Field(index.fieldIndex))
+			if err != nil {
+				return err
+			}
+			if err = indexBucket.Delete(append(prefix, key...)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var staleKeys [][]byte
+		suffix := append([]byte{0}, key...)
+		_ = indexBucket.ForEach(func(indexKey, _ []byte) error {
+			if bytes.HasSuffix(indexKey, suffix) {
+				staleKeys = append(staleKeys, bytes.Clone(indexKey))
+			}
+			return nil
+		})
+		for _, staleKey := range staleKeys {
+			if err := indexBucket.Delete(staleKey); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Obtains the Bolt bucket belonging to the table.
 func (table *table[R]) getBucket(tx *bbolt.Tx) (*bbolt.Bucket, error) {
 	bucket := tx.Bucket(table.bucketKey)
@@ -240,6 +640,23 @@ func (table *table[R]) getBucket(tx *bbolt.Tx) (*bbolt.Bucket, error) {
 	return bucket, nil
 }
 
+// Serializes the given indexed field value to the prefix of its index entries: its string representation followed by a
+// null byte to separate it from the record ID.
+func indexKeyPrefix(value reflect.Value) ([]byte, error) {
+	var valueString string
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		valueString = strconv.FormatInt(value.Int(), 10)
+	case reflect.String:
+		valueString = value.String()
+	case reflect.Bool:
+		valueString = strconv.FormatBool(value.Bool())
+	default:
+		return nil, fmt.Errorf("indexed values must be integers, strings, or booleans; got %v", value.Kind())
+	}
+	return append([]byte(valueString), 0), nil
+}
+
 // Serializes the given integer ID to a byte array containing its Base-10 string representation.
 func idToKey(id int) []byte {
 	return []byte(strconv.Itoa(id))
@@ -204,3 +204,112 @@ func TestTableCrudErrors(t *testing.T) {
 		assert.Equal(t, "can't delete non-existent validRecord with ID 12345", err.Error())
 	}
 }
+
+type indexedRecord struct {
+	Id         int    `db:"id"`
+	IntData    int    `db:"index"`
+	StringData string `db:"index"`
+	BoolData   bool   `db:"index"`
+}
+
+func TestTableIndexes(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	table, err := newTable[indexedRecord](db)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, 3, len(table.indexes))
+
+	record1 := indexedRecord{IntData: 254, StringData: "Poofs"}
+	record2 := indexedRecord{IntData: 1114, StringData: "Poofs", BoolData: true}
+	record3 := indexedRecord{IntData: 254, StringData: "Poofs\x00Cheesy"}
+	assert.Nil(t, table.create(&record1))
+	assert.Nil(t, table.create(&record2))
+	assert.Nil(t, table.create(&record3))
+
+	records, err := table.getByIndex("IntData", 254)
+	assert.Nil(t, err)
+	assert.Equal(t, []indexedRecord{record1, record3}, records)
+	records, err = table.getByIndex("StringData", "Poofs")
+	assert.Nil(t, err)
+	assert.Equal(t, []indexedRecord{record1, record2}, records)
+	records, err = table.getByIndex("BoolData", true)
+	assert.Nil(t, err)
+	assert.Equal(t, []indexedRecord{record2}, records)
+	records, err = table.getByIndex("IntData", 1678)
+	assert.Nil(t, err)
+	assert.Empty(t, records)
+
+	// Check that updates and deletes are reflected in the indexes.
+	record1.IntData = 1678
+	assert.Nil(t, table.update(&record1))
+	records, _ = table.getByIndex("IntData", 254)
+	assert.Equal(t, []indexedRecord{record3}, records)
+	records, _ = table.getByIndex("IntData", 1678)
+	assert.Equal(t, []indexedRecord{record1}, records)
+	assert.Nil(t, table.delete(record2.Id))
+	records, _ = table.getByIndex("StringData", "Poofs")
+	assert.Equal(t, []indexedRecord{record1}, records)
+
+	assert.Nil(t, table.truncate())
+	records, _ = table.getByIndex("IntData", 1678)
+	assert.Empty(t, records)
+
+	_, err = table.getByIndex("Id", 1)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "indexedRecord has no index on field Id", err.Error())
+	}
+	_, err = table.getByIndex("IntData", 1.5)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "indexed values must be integers, strings, or booleans; got float64", err.Error())
+	}
+}
+
+func TestTableIndexAddedToExistingTable(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	{
+		type backfilledRecord struct {
+			Id      int `db:"id"`
+			IntData int
+		}
+		table, _ := newTable[backfilledRecord](db)
+		table.create(&backfilledRecord{IntData: 254})
+		table.create(&backfilledRecord{IntData: 1114})
+		table.create(&backfilledRecord{IntData: 254})
+	}
+
+	// Registering the table again with an index should populate it from the existing records.
+	type backfilledRecord struct {
+		Id      int `db:"id"`
+		IntData int `db:"index"`
+	}
+	table, err := newTable[backfilledRecord](db)
+	assert.Nil(t, err)
+	records, err := table.getByIndex("IntData", 254)
+	assert.Nil(t, err)
+	assert.Equal(t, []backfilledRecord{{1, 254}, {3, 254}}, records)
+}
+
+func TestTableIndexErrors(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	type recordWithUnindexableField struct {
+		Id   int       `db:"id"`
+		Data []float64 `db:"index"`
+	}
+	table, err := newTable[recordWithUnindexableField](db)
+	assert.Nil(t, table)
+	if assert.NotNil(t, err) {
+		assert.Equal(
+			t,
+			"field Data in struct recordWithUnindexableField can't be indexed: indexed values must be integers, "+
+				"strings, or booleans; got slice",
+			err.Error(),
+		)
+	}
+}
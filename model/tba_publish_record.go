@@ -0,0 +1,49 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for the record of when each kind of event data was last published to The Blue
+// Alliance.
+
+package model
+
+import "time"
+
+type TbaPublishRecord struct {
+	Id          int    `db:"id"`
+	Kind        string `db:"index"`
+	PublishedAt time.Time
+}
+
+// The kinds of event data that are published to The Blue Alliance.
+const (
+	TbaPublishTeams     = "teams"
+	TbaPublishMatches   = "matches"
+	TbaPublishRankings  = "rankings"
+	TbaPublishAlliances = "alliances"
+	TbaPublishAwards    = "awards"
+)
+
+// Records that the given kind of event data has just been published, replacing any earlier record of it.
+func (database *Database) RecordTbaPublish(kind string) error {
+	record, err := database.GetTbaPublishRecord(kind)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return database.tbaPublishRecordTable.create(&TbaPublishRecord{Kind: kind, PublishedAt: time.Now()})
+	}
+	record.PublishedAt = time.Now()
+	return database.tbaPublishRecordTable.update(record)
+}
+
+// Returns the record of when the given kind of event data was last published, or nil if it never has been.
+func (database *Database) GetTbaPublishRecord(kind string) (*TbaPublishRecord, error) {
+	records, err := database.tbaPublishRecordTable.getByIndex("Kind", kind)
+	if err != nil || len(records) == 0 {
+		return nil, err
+	}
+	return &records[0], nil
+}
+
+func (database *Database) TruncateTbaPublishRecords() error {
+	return database.tbaPublishRecordTable.truncate()
+}
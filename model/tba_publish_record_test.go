@@ -0,0 +1,37 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTbaPublishRecord(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	record, err := db.GetTbaPublishRecord(TbaPublishTeams)
+	assert.Nil(t, err)
+	assert.Nil(t, record)
+
+	assert.Nil(t, db.RecordTbaPublish(TbaPublishTeams))
+	record, err = db.GetTbaPublishRecord(TbaPublishTeams)
+	assert.Nil(t, err)
+	if assert.NotNil(t, record) {
+		assert.Equal(t, TbaPublishTeams, record.Kind)
+		assert.WithinDuration(t, time.Now(), record.PublishedAt, time.Second)
+	}
+	record, _ = db.GetTbaPublishRecord(TbaPublishAwards)
+	assert.Nil(t, record)
+
+	// Check that publishing again updates the existing record rather than adding another.
+	assert.Nil(t, db.RecordTbaPublish(TbaPublishTeams))
+	records, _ := db.tbaPublishRecordTable.getAll()
+	assert.Equal(t, 1, len(records))
+
+	assert.Nil(t, db.TruncateTbaPublishRecords())
+	record, _ = db.GetTbaPublishRecord(TbaPublishTeams)
+	assert.Nil(t, record)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for the record of a robot and safety inspection of a team.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+type TeamInspection struct {
+	Id          int `db:"id"`
+	TeamId      int `db:"index"`
+	Inspector   string
+	Passed      bool
+	WeightLbs   float64
+	BumpersOk   bool
+	Notes       string
+	InspectedAt time.Time
+}
+
+func (database *Database) CreateTeamInspection(teamInspection *TeamInspection) error {
+	return database.teamInspectionTable.create(teamInspection)
+}
+
+// Returns the history of inspections of the given team, from oldest to newest.
+func (database *Database) GetTeamInspectionsByTeam(teamId int) ([]TeamInspection, error) {
+	teamInspections, err := database.teamInspectionTable.getByIndex("TeamId", teamId)
+	if err != nil {
+		return nil, err
+	}
+	sortTeamInspections(teamInspections)
+	return teamInspections, nil
+}
+
+// Returns every inspection of every team, ordered by team and then from oldest to newest.
+func (database *Database) GetAllTeamInspections() ([]TeamInspection, error) {
+	teamInspections, err := database.teamInspectionTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sortTeamInspections(teamInspections)
+	return teamInspections, nil
+}
+
+// Returns the most recent inspection of each team that has been inspected, keyed by team ID.
+func (database *Database) GetLatestTeamInspections() (map[int]TeamInspection, error) {
+	teamInspections, err := database.GetAllTeamInspections()
+	if err != nil {
+		return nil, err
+	}
+	latestTeamInspections := make(map[int]TeamInspection)
+	for _, teamInspection := range teamInspections {
+		latestTeamInspections[teamInspection.TeamId] = teamInspection
+	}
+	return latestTeamInspections, nil
+}
+
+func (database *Database) DeleteTeamInspection(id int) error {
+	return database.teamInspectionTable.delete(id)
+}
+
+func (database *Database) TruncateTeamInspections() error {
+	return database.teamInspectionTable.truncate()
+}
+
+func sortTeamInspections(teamInspections []TeamInspection) {
+	sort.Slice(teamInspections, func(i, j int) bool {
+		if teamInspections[i].TeamId != teamInspections[j].TeamId {
+			return teamInspections[i].TeamId < teamInspections[j].TeamId
+		}
+		if !teamInspections[i].InspectedAt.Equal(teamInspections[j].InspectedAt) {
+			return teamInspections[i].InspectedAt.Before(teamInspections[j].InspectedAt)
+		}
+		return teamInspections[i].Id < teamInspections[j].Id
+	})
+}
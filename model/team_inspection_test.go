@@ -0,0 +1,65 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTeamInspectionCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	teamInspections, err := db.GetTeamInspectionsByTeam(254)
+	assert.Nil(t, err)
+	assert.Empty(t, teamInspections)
+
+	teamInspection1 := TeamInspection{
+		TeamId:      254,
+		Inspector:   "Alice",
+		Passed:      false,
+		WeightLbs:   127.5,
+		BumpersOk:   false,
+		Notes:       "Bumper numbers missing",
+		InspectedAt: time.Unix(3000, 0).UTC(),
+	}
+	teamInspection2 := TeamInspection{
+		TeamId:      1114,
+		Inspector:   "Bob",
+		Passed:      true,
+		WeightLbs:   120,
+		BumpersOk:   true,
+		InspectedAt: time.Unix(2000, 0).UTC(),
+	}
+	teamInspection3 := TeamInspection{
+		TeamId:      254,
+		Inspector:   "Bob",
+		Passed:      true,
+		WeightLbs:   124,
+		BumpersOk:   true,
+		InspectedAt: time.Unix(4000, 0).UTC(),
+	}
+	assert.Nil(t, db.CreateTeamInspection(&teamInspection3))
+	assert.Nil(t, db.CreateTeamInspection(&teamInspection1))
+	assert.Nil(t, db.CreateTeamInspection(&teamInspection2))
+
+	teamInspections, err = db.GetTeamInspectionsByTeam(254)
+	assert.Nil(t, err)
+	assert.Equal(t, []TeamInspection{teamInspection1, teamInspection3}, teamInspections)
+	teamInspections, err = db.GetAllTeamInspections()
+	assert.Nil(t, err)
+	assert.Equal(t, []TeamInspection{teamInspection1, teamInspection3, teamInspection2}, teamInspections)
+	latestTeamInspections, err := db.GetLatestTeamInspections()
+	assert.Nil(t, err)
+	assert.Equal(t, map[int]TeamInspection{254: teamInspection3, 1114: teamInspection2}, latestTeamInspections)
+
+	assert.Nil(t, db.DeleteTeamInspection(teamInspection3.Id))
+	latestTeamInspections, _ = db.GetLatestTeamInspections()
+	assert.Equal(t, teamInspection1, latestTeamInspections[254])
+
+	assert.Nil(t, db.TruncateTeamInspections())
+	teamInspections, _ = db.GetAllTeamInspections()
+	assert.Empty(t, teamInspections)
+}
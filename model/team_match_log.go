@@ -0,0 +1,166 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for the driver station packet log of a single team during a single match.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// Holds the metadata and analysis results for a team's match log. The packet rows are stored separately in a
+// TeamMatchLogData record with the same ID, kept outside the event database so that listing logs doesn't require
+// loading every packet and so that the packets aren't carried along in backups and replication.
+type TeamMatchLog struct {
+	Id              int `db:"id"`
+	MatchId         int `db:"index"`
+	MatchType       MatchType
+	MatchShortName  string
+	TeamId          int
+	AllianceStation string
+	StartedAt       time.Time
+	Analysis        TeamMatchLogAnalysis
+}
+
+type TeamMatchLogData struct {
+	Id   int `db:"id,manual"`
+	Rows []TeamMatchLogRow
+}
+
+type TeamMatchLogRow struct {
+	MatchTimeSec              float64
+	PacketType                int
+	DsLinked                  bool
+	RadioLinked               bool
+	RioLinked                 bool
+	RobotLinked               bool
+	Auto                      bool
+	Enabled                   bool
+	EmergencyStop             bool
+	AutonomousStop            bool
+	BatteryVoltage            float64
+	MissedPacketCount         int
+	DsRobotTripTimeMs         int
+	RxRate                    float64
+	TxRate                    float64
+	SignalNoiseRatio          int
+	ChannelUtilizationPercent float64
+	ChannelNoiseDbm           int
+}
+
+// Summarizes the robot problems detected in a team's match log.
+type TeamMatchLogAnalysis struct {
+	MinBatteryVoltage         float64
+	BrownoutCount             int
+	BrownoutSec               float64
+	CommsDropCount            int
+	CommsDropSec              float64
+	MaxTripTimeMs             int
+	TripTimeSpikeCount        int
+	EmergencyStopped          bool
+	EmergencyStopTimeSec      float64
+	AutonomousStopped         bool
+	AutonomousStopTimeSec     float64
+	ChannelUtilizationPercent float64
+	ChannelNoiseDbm           int
+	ChannelCongested          bool
+}
+
+// Creates the given log and its packet rows, assigning the log an ID.
+func (database *Database) CreateTeamMatchLog(matchLog *TeamMatchLog, rows []TeamMatchLogRow) error {
+	if err := database.teamMatchLogTable.create(matchLog); err != nil {
+		return err
+	}
+
+	// Restoring the event database leaves the packet data in place, so clear out any left from a log with the same ID.
+	if data, err := database.teamMatchLogDataTable.getById(matchLog.Id); err != nil {
+		return err
+	} else if data != nil {
+		if err = database.teamMatchLogDataTable.delete(matchLog.Id); err != nil {
+			return err
+		}
+	}
+	return database.teamMatchLogDataTable.create(&TeamMatchLogData{Id: matchLog.Id, Rows: rows})
+}
+
+func (database *Database) GetTeamMatchLogById(id int) (*TeamMatchLog, error) {
+	return database.teamMatchLogTable.getById(id)
+}
+
+// Returns the packet rows for the log with the given ID, or nil if there are none.
+func (database *Database) GetTeamMatchLogRows(id int) ([]TeamMatchLogRow, error) {
+	data, err := database.teamMatchLogDataTable.getById(id)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	return data.Rows, nil
+}
+
+// Returns all logs for the given match, in the order that they were started.
+func (database *Database) GetTeamMatchLogsByMatch(matchId int) ([]TeamMatchLog, error) {
+	matchingLogs, err := database.teamMatchLogTable.getByIndex("MatchId", matchId)
+	if err != nil || len(matchingLogs) == 0 {
+		return nil, err
+	}
+	sort.Slice(matchingLogs, func(i, j int) bool {
+		return matchingLogs[i].StartedAt.Before(matchingLogs[j].StartedAt)
+	})
+	return matchingLogs, nil
+}
+
+// Returns all logs grouped by match ID, for building summaries across many matches without repeated table scans.
+func (database *Database) GetTeamMatchLogsByMatchId() (map[int][]TeamMatchLog, error) {
+	matchLogs, err := database.teamMatchLogTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+
+	matchLogsByMatchId := make(map[int][]TeamMatchLog)
+	for _, matchLog := range matchLogs {
+		matchLogsByMatchId[matchLog.MatchId] = append(matchLogsByMatchId[matchLog.MatchId], matchLog)
+	}
+	for _, matchLogs := range matchLogsByMatchId {
+		sort.Slice(matchLogs, func(i, j int) bool {
+			return matchLogs[i].StartedAt.Before(matchLogs[j].StartedAt)
+		})
+	}
+	return matchLogsByMatchId, nil
+}
+
+func (database *Database) DeleteTeamMatchLog(id int) error {
+	return database.deleteTeamMatchLogRecords(id)
+}
+
+func (database *Database) TruncateTeamMatchLogs() error {
+	if err := database.teamMatchLogDataTable.truncate(); err != nil {
+		return err
+	}
+	return database.teamMatchLogTable.truncate()
+}
+
+// Returns true if any problem was detected in the log.
+func (analysis *TeamMatchLogAnalysis) HasProblems() bool {
+	return len(analysis.Problems()) > 0
+}
+
+// Returns a short human-readable description of each problem detected in the log.
+func (analysis *TeamMatchLogAnalysis) Problems() []string {
+	var problems []string
+	if analysis.EmergencyStopped {
+		problems = append(problems, "E-stopped")
+	}
+	if analysis.AutonomousStopped {
+		problems = append(problems, "A-stopped")
+	}
+	if analysis.BrownoutCount > 0 {
+		problems = append(problems, "brownout")
+	}
+	if analysis.CommsDropCount > 0 {
+		problems = append(problems, "comms drop")
+	}
+	if analysis.TripTimeSpikeCount > 0 {
+		problems = append(problems, "trip time spike")
+	}
+	return problems
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetNonexistentTeamMatchLog(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	matchLog, err := db.GetTeamMatchLogById(1114)
+	assert.Nil(t, err)
+	assert.Nil(t, matchLog)
+	rows, err := db.GetTeamMatchLogRows(1114)
+	assert.Nil(t, err)
+	assert.Nil(t, rows)
+}
+
+func TestTeamMatchLogCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	matchLog1 := TeamMatchLog{MatchId: 3, MatchType: Qualification, MatchShortName: "Q3", TeamId: 254,
+		AllianceStation: "R1", StartedAt: time.Unix(3000, 0).UTC(), Analysis: TeamMatchLogAnalysis{BrownoutCount: 2}}
+	rows1 := []TeamMatchLogRow{{MatchTimeSec: 0.5, BatteryVoltage: 12.5}, {MatchTimeSec: 1, BatteryVoltage: 6.1}}
+	matchLog2 := TeamMatchLog{MatchId: 3, MatchType: Qualification, MatchShortName: "Q3", TeamId: 1114,
+		AllianceStation: "B2", StartedAt: time.Unix(2000, 0).UTC()}
+	matchLog3 := TeamMatchLog{MatchId: 4, MatchType: Qualification, MatchShortName: "Q4", TeamId: 254,
+		AllianceStation: "B1", StartedAt: time.Unix(4000, 0).UTC()}
+	assert.Nil(t, db.CreateTeamMatchLog(&matchLog1, rows1))
+	assert.Nil(t, db.CreateTeamMatchLog(&matchLog2, nil))
+	assert.Nil(t, db.CreateTeamMatchLog(&matchLog3, []TeamMatchLogRow{{MatchTimeSec: 2}}))
+	assert.Equal(t, 1, matchLog1.Id)
+
+	matchLog, err := db.GetTeamMatchLogById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, matchLog1, *matchLog)
+	rows, err := db.GetTeamMatchLogRows(1)
+	assert.Nil(t, err)
+	assert.Equal(t, rows1, rows)
+
+	matchLogs, err := db.GetTeamMatchLogsByMatch(3)
+	assert.Nil(t, err)
+	assert.Equal(t, []TeamMatchLog{matchLog2, matchLog1}, matchLogs)
+	matchLogsByMatchId, err := db.GetTeamMatchLogsByMatchId()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(matchLogsByMatchId))
+	assert.Equal(t, []TeamMatchLog{matchLog2, matchLog1}, matchLogsByMatchId[3])
+	assert.Equal(t, []TeamMatchLog{matchLog3}, matchLogsByMatchId[4])
+
+	assert.Nil(t, db.DeleteTeamMatchLog(1))
+	matchLog, err = db.GetTeamMatchLogById(1)
+	assert.Nil(t, err)
+	assert.Nil(t, matchLog)
+	rows, err = db.GetTeamMatchLogRows(1)
+	assert.Nil(t, err)
+	assert.Nil(t, rows)
+
+	assert.Nil(t, db.TruncateTeamMatchLogs())
+	matchLogs, err = db.GetTeamMatchLogsByMatch(4)
+	assert.Nil(t, err)
+	assert.Empty(t, matchLogs)
+}
+
+func TestTeamMatchLogPacketsKeptOutOfEventDatabase(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	var snapshot bytes.Buffer
+	subscription, err := db.SubscribeToChanges(&snapshot)
+	assert.Nil(t, err)
+	defer subscription.Close()
+	matchLog := TeamMatchLog{MatchId: 3, TeamId: 254}
+	assert.Nil(t, db.CreateTeamMatchLog(&matchLog, []TeamMatchLogRow{{MatchTimeSec: 0.5}}))
+
+	// Only the log itself should be replicated and backed up, leaving the packets behind.
+	if assert.Equal(t, 1, len(subscription.Changes)) {
+		change := <-subscription.Changes
+		assert.Equal(t, "TeamMatchLog", change.Table)
+	}
+	backupPath := filepath.Join(BaseDir, "backup_test.db")
+	os.Remove(PacketLogPath(backupPath))
+	defer os.Remove(PacketLogPath(backupPath))
+	var backup bytes.Buffer
+	assert.Nil(t, db.WriteBackup(&backup))
+	assert.Nil(t, os.WriteFile(backupPath, backup.Bytes(), 0644))
+	defer os.Remove(backupPath)
+	backupDb, err := OpenDatabase(backupPath)
+	if assert.Nil(t, err) {
+		defer backupDb.Close()
+		restoredLog, err := backupDb.GetTeamMatchLogById(matchLog.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, matchLog, *restoredLog)
+		rows, err := backupDb.GetTeamMatchLogRows(matchLog.Id)
+		assert.Nil(t, err)
+		assert.Nil(t, rows)
+	}
+
+	// Packets left over from before a restore should give way to those of a new log with the same ID.
+	assert.Nil(t, db.teamMatchLogTable.delete(matchLog.Id))
+	matchLog.Id = 0
+	assert.Nil(t, db.teamMatchLogTable.bolt.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(db.teamMatchLogTable.bucketKey).SetSequence(0)
+	}))
+	assert.Nil(t, db.CreateTeamMatchLog(&matchLog, []TeamMatchLogRow{{MatchTimeSec: 1.5}}))
+	assert.Equal(t, 1, matchLog.Id)
+	rows, err := db.GetTeamMatchLogRows(matchLog.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, []TeamMatchLogRow{{MatchTimeSec: 1.5}}, rows)
+}
+
+func TestTeamMatchLogAnalysisProblems(t *testing.T) {
+	analysis := TeamMatchLogAnalysis{MinBatteryVoltage: 11.2, MaxTripTimeMs: 8}
+	assert.False(t, analysis.HasProblems())
+	assert.Empty(t, analysis.Problems())
+
+	analysis = TeamMatchLogAnalysis{
+		BrownoutCount: 1, CommsDropCount: 2, TripTimeSpikeCount: 3, EmergencyStopped: true,
+		EmergencyStopTimeSec: 80.5, AutonomousStopped: true, AutonomousStopTimeSec: 0,
+	}
+	assert.True(t, analysis.HasProblems())
+	assert.Equal(
+		t, []string{"E-stopped", "A-stopped", "brownout", "comms drop", "trip time spike"}, analysis.Problems(),
+	)
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for the Wi-Fi link quality of a single team during a single match.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+type TeamWifiRecord struct {
+	Id                  int `db:"id"`
+	TeamId              int `db:"index"`
+	MatchId             int
+	MatchType           MatchType
+	MatchShortName      string
+	AllianceStation     string
+	StartedAt           time.Time
+	SnrSamples          []int
+	EnabledSec          float64
+	RadioDownEnabledSec float64
+	OverBandwidthSec    float64
+	MaxMBits            float64
+}
+
+// Represents the Wi-Fi link quality of a team aggregated across all the matches it has played.
+type TeamWifiSummary struct {
+	MatchCount          int
+	MedianSnr           int
+	WorstSnr            int
+	EnabledSec          float64
+	RadioDownEnabledSec float64
+	OverBandwidthSec    float64
+	MaxMBits            float64
+}
+
+func (database *Database) CreateTeamWifiRecord(record *TeamWifiRecord) error {
+	return database.teamWifiRecordTable.create(record)
+}
+
+func (database *Database) GetTeamWifiRecordById(id int) (*TeamWifiRecord, error) {
+	return database.teamWifiRecordTable.getById(id)
+}
+
+// Returns all the Wi-Fi records for the given team, in the order that the matches were played.
+func (database *Database) GetTeamWifiRecordsByTeam(teamId int) ([]TeamWifiRecord, error) {
+	matchingRecords, err := database.teamWifiRecordTable.getByIndex("TeamId", teamId)
+	if err != nil || len(matchingRecords) == 0 {
+		return nil, err
+	}
+	sort.Slice(matchingRecords, func(i, j int) bool {
+		return matchingRecords[i].StartedAt.Before(matchingRecords[j].StartedAt)
+	})
+	return matchingRecords, nil
+}
+
+func (database *Database) TruncateTeamWifiRecords() error {
+	return database.teamWifiRecordTable.truncate()
+}
+
+// Returns the median SNR of the samples in the given record, or zero if there are none.
+func (record *TeamWifiRecord) MedianSnr() int {
+	return medianInt(record.SnrSamples)
+}
+
+// Returns the lowest SNR in the given record, or zero if there are no samples.
+func (record *TeamWifiRecord) WorstSnr() int {
+	if len(record.SnrSamples) == 0 {
+		return 0
+	}
+	worstSnr := record.SnrSamples[0]
+	for _, snr := range record.SnrSamples {
+		if snr < worstSnr {
+			worstSnr = snr
+		}
+	}
+	return worstSnr
+}
+
+// Aggregates the given per-match records into a single summary for the team.
+func SummarizeTeamWifiRecords(records []TeamWifiRecord) TeamWifiSummary {
+	summary := TeamWifiSummary{MatchCount: len(records)}
+	var allSnrSamples []int
+	for _, record := range records {
+		allSnrSamples = append(allSnrSamples, record.SnrSamples...)
+		summary.EnabledSec += record.EnabledSec
+		summary.RadioDownEnabledSec += record.RadioDownEnabledSec
+		summary.OverBandwidthSec += record.OverBandwidthSec
+		if record.MaxMBits > summary.MaxMBits {
+			summary.MaxMBits = record.MaxMBits
+		}
+	}
+	combinedRecord := TeamWifiRecord{SnrSamples: allSnrSamples}
+	summary.MedianSnr = combinedRecord.MedianSnr()
+	summary.WorstSnr = combinedRecord.WorstSnr()
+	return summary
+}
+
+// Returns the median of the given values, rounding down between the two middle values if there is an even number.
+func medianInt(values []int) int {
+	if len(values) == 0 {
+		return 0
+	}
+	sortedValues := append([]int(nil), values...)
+	sort.Ints(sortedValues)
+	middle := len(sortedValues) / 2
+	if len(sortedValues)%2 == 0 {
+		return (sortedValues[middle-1] + sortedValues[middle]) / 2
+	}
+	return sortedValues[middle]
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetNonexistentTeamWifiRecord(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	record, err := db.GetTeamWifiRecordById(1114)
+	assert.Nil(t, err)
+	assert.Nil(t, record)
+}
+
+func TestTeamWifiRecordCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	record1 := TeamWifiRecord{TeamId: 254, MatchId: 2, MatchType: Qualification, MatchShortName: "Q2",
+		AllianceStation: "R1", StartedAt: time.Unix(2000, 0).UTC(), SnrSamples: []int{40, 35, 38}, EnabledSec: 150}
+	record2 := TeamWifiRecord{TeamId: 1114, MatchId: 1, MatchType: Qualification, MatchShortName: "Q1",
+		AllianceStation: "B3", StartedAt: time.Unix(1000, 0).UTC(), SnrSamples: []int{20}, RadioDownEnabledSec: 3.5}
+	record3 := TeamWifiRecord{TeamId: 254, MatchId: 1, MatchType: Qualification, MatchShortName: "Q1",
+		AllianceStation: "B1", StartedAt: time.Unix(1000, 0).UTC(), OverBandwidthSec: 12, MaxMBits: 5.5}
+	assert.Nil(t, db.CreateTeamWifiRecord(&record1))
+	assert.Nil(t, db.CreateTeamWifiRecord(&record2))
+	assert.Nil(t, db.CreateTeamWifiRecord(&record3))
+
+	record, err := db.GetTeamWifiRecordById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, record1, *record)
+
+	records, err := db.GetTeamWifiRecordsByTeam(254)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(records)) {
+		assert.Equal(t, record3, records[0])
+		assert.Equal(t, record1, records[1])
+	}
+	records, err = db.GetTeamWifiRecordsByTeam(1503)
+	assert.Nil(t, err)
+	assert.Empty(t, records)
+
+	assert.Nil(t, db.TruncateTeamWifiRecords())
+	records, err = db.GetTeamWifiRecordsByTeam(254)
+	assert.Nil(t, err)
+	assert.Empty(t, records)
+}
+
+func TestSummarizeTeamWifiRecords(t *testing.T) {
+	assert.Equal(t, TeamWifiSummary{}, SummarizeTeamWifiRecords(nil))
+
+	records := []TeamWifiRecord{
+		{SnrSamples: []int{40, 30, 50}, EnabledSec: 150, RadioDownEnabledSec: 0.5, MaxMBits: 3.2},
+		{SnrSamples: []int{12, 45}, EnabledSec: 148, RadioDownEnabledSec: 4, OverBandwidthSec: 2.5, MaxMBits: 4.7},
+		{EnabledSec: 0},
+	}
+	assert.Equal(t, 40, records[0].MedianSnr())
+	assert.Equal(t, 30, records[0].WorstSnr())
+	assert.Equal(t, 28, records[1].MedianSnr())
+	assert.Equal(t, 0, records[2].MedianSnr())
+	assert.Equal(t, 0, records[2].WorstSnr())
+
+	summary := SummarizeTeamWifiRecords(records)
+	assert.Equal(t, 3, summary.MatchCount)
+	assert.Equal(t, 40, summary.MedianSnr)
+	assert.Equal(t, 12, summary.WorstSnr)
+	assert.Equal(t, 298.0, summary.EnabledSec)
+	assert.Equal(t, 4.5, summary.RadioDownEnabledSec)
+	assert.Equal(t, 2.5, summary.OverBandwidthSec)
+	assert.Equal(t, 4.7, summary.MaxMBits)
+}
@@ -14,10 +14,11 @@ import (
 	"testing"
 )
 
-func SetupTestDb(t *testing.T, uniqueName string) *Database {
+func SetupTestDb(t testing.TB, uniqueName string) *Database {
 	BaseDir = ".."
 	dbPath := filepath.Join(BaseDir, fmt.Sprintf("%s_test.db", uniqueName))
 	os.Remove(dbPath)
+	os.Remove(PacketLogPath(dbPath))
 	database, err := OpenDatabase(dbPath)
 	assert.Nil(t, err)
 	return database
@@ -8,8 +8,8 @@ package model
 import "time"
 
 type UserSession struct {
-	Id        int `db:"id"`
-	Token     string
+	Id        int    `db:"id"`
+	Token     string `db:"index"`
 	Username  string
 	CreatedAt time.Time
 }
@@ -19,17 +19,11 @@ func (database *Database) CreateUserSession(session *UserSession) error {
 }
 
 func (database *Database) GetUserSessionByToken(token string) (*UserSession, error) {
-	userSessions, err := database.userSessionTable.getAll()
-	if err != nil {
+	userSessions, err := database.userSessionTable.getByIndex("Token", token)
+	if err != nil || len(userSessions) == 0 {
 		return nil, err
 	}
-
-	for _, userSession := range userSessions {
-		if userSession.Token == token {
-			return &userSession, nil
-		}
-	}
-	return nil, nil
+	return &userSessions[0], nil
 }
 
 func (database *Database) DeleteUserSession(id int) error {
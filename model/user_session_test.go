@@ -4,6 +4,7 @@
 package model
 
 import (
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -48,3 +49,29 @@ func TestTruncateUserSessions(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Nil(t, session2)
 }
+
+// Compares the indexed lookup of a session by token, done on every authenticated request, against the full table scan
+// it replaced.
+func BenchmarkGetUserSessionByToken(b *testing.B) {
+	db := SetupTestDb(b, "model_benchmark")
+	defer db.Close()
+	for i := 0; i < 200; i++ {
+		db.CreateUserSession(&UserSession{Token: fmt.Sprintf("token%d", i), Username: "admin", CreatedAt: time.Now()})
+	}
+
+	b.Run("Index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			db.GetUserSessionByToken("token150")
+		}
+	})
+	b.Run("Scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			userSessions, _ := db.userSessionTable.getAll()
+			for _, userSession := range userSessions {
+				if userSession.Token == "token150" {
+					break
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for the scorekeeper's sign-off on an advisory step of the event wizard.
+
+package model
+
+import "time"
+
+type WizardStepAcknowledgement struct {
+	Id             int    `db:"id"`
+	StepKey        string `db:"index"`
+	Problems       []string
+	AcknowledgedAt time.Time
+}
+
+// Records that the given step has been marked done despite the given outstanding problems, replacing any earlier
+// acknowledgement of it.
+func (database *Database) AcknowledgeWizardStep(stepKey string, problems []string) error {
+	acknowledgement, err := database.GetWizardStepAcknowledgement(stepKey)
+	if err != nil {
+		return err
+	}
+	if acknowledgement == nil {
+		return database.wizardStepAcknowledgementTable.create(
+			&WizardStepAcknowledgement{StepKey: stepKey, Problems: problems, AcknowledgedAt: time.Now()},
+		)
+	}
+	acknowledgement.Problems = problems
+	acknowledgement.AcknowledgedAt = time.Now()
+	return database.wizardStepAcknowledgementTable.update(acknowledgement)
+}
+
+// Returns the acknowledgement of the given step, or nil if it hasn't been marked done.
+func (database *Database) GetWizardStepAcknowledgement(stepKey string) (*WizardStepAcknowledgement, error) {
+	acknowledgements, err := database.wizardStepAcknowledgementTable.getByIndex("StepKey", stepKey)
+	if err != nil || len(acknowledgements) == 0 {
+		return nil, err
+	}
+	return &acknowledgements[0], nil
+}
+
+// Removes the acknowledgement of the given step, if there is one.
+func (database *Database) DeleteWizardStepAcknowledgement(stepKey string) error {
+	acknowledgement, err := database.GetWizardStepAcknowledgement(stepKey)
+	if err != nil || acknowledgement == nil {
+		return err
+	}
+	return database.wizardStepAcknowledgementTable.delete(acknowledgement.Id)
+}
+
+func (database *Database) TruncateWizardStepAcknowledgements() error {
+	return database.wizardStepAcknowledgementTable.truncate()
+}
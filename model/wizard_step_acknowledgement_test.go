@@ -0,0 +1,45 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestWizardStepAcknowledgement(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	acknowledgement, err := db.GetWizardStepAcknowledgement("field_hardware")
+	assert.Nil(t, err)
+	assert.Nil(t, acknowledgement)
+
+	assert.Nil(t, db.AcknowledgeWizardStep("field_hardware", []string{"The PLC isn't responding."}))
+	acknowledgement, err = db.GetWizardStepAcknowledgement("field_hardware")
+	assert.Nil(t, err)
+	if assert.NotNil(t, acknowledgement) {
+		assert.Equal(t, []string{"The PLC isn't responding."}, acknowledgement.Problems)
+		assert.WithinDuration(t, time.Now(), acknowledgement.AcknowledgedAt, time.Second)
+	}
+	acknowledgement, _ = db.GetWizardStepAcknowledgement("settings")
+	assert.Nil(t, acknowledgement)
+
+	// Check that acknowledging again updates the existing record rather than adding another.
+	assert.Nil(t, db.AcknowledgeWizardStep("field_hardware", nil))
+	acknowledgements, _ := db.wizardStepAcknowledgementTable.getAll()
+	if assert.Equal(t, 1, len(acknowledgements)) {
+		assert.Empty(t, acknowledgements[0].Problems)
+	}
+
+	assert.Nil(t, db.DeleteWizardStepAcknowledgement("field_hardware"))
+	assert.Nil(t, db.DeleteWizardStepAcknowledgement("field_hardware"))
+	acknowledgement, _ = db.GetWizardStepAcknowledgement("field_hardware")
+	assert.Nil(t, acknowledgement)
+
+	assert.Nil(t, db.AcknowledgeWizardStep("settings", nil))
+	assert.Nil(t, db.TruncateWizardStepAcknowledgements())
+	acknowledgement, _ = db.GetWizardStepAcknowledgement("settings")
+	assert.Nil(t, acknowledgement)
+}
@@ -15,6 +15,7 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
@@ -33,6 +34,8 @@ type AccessPoint struct {
 	Status                 string
 	TeamWifiStatuses       [6]*TeamWifiStatus
 	lastConfiguredTeams    [6]*model.Team
+	channelScanHistory     map[int][]ChannelScanSample
+	channelScanMutex       sync.Mutex
 }
 
 type TeamWifiStatus struct {
@@ -0,0 +1,305 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Methods for requesting a spectrum scan from a Vivid-Hosting VH-109 access point and recommending a channel based on
+// the accumulated results.
+
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ChannelScanHistoryLength    = 60
+	channelScanTimeoutSec       = 30
+	channelScanCancelTimeoutSec = 2
+	noiseFloorDbm               = -95
+	congestedUtilization        = 50
+	congestedNoiseDbm           = -85
+)
+
+// Channels that the access point can be configured to use, matching the options offered in the settings. The 6 GHz
+// channels 149 and 157 share their numbers with 5 GHz channels and so are only listed once.
+var ApChannels = []int{
+	36, 40, 44, 48, 149, 153, 157, 161, 5, 13, 21, 29, 37, 45, 53, 61, 69, 77, 85, 93, 101, 109, 117, 125, 133, 141,
+	165, 173, 181, 189, 197, 205, 213, 221, 229,
+}
+
+// Represents the conditions observed on a single channel during a single spectrum scan.
+type ChannelScanSample struct {
+	Time               time.Time
+	Channel            int
+	UtilizationPercent float64
+	NoiseDbm           int
+	NetworkCount       int
+}
+
+// Represents the channel that the access point should be set to based on historical scan results.
+type ChannelRecommendation struct {
+	Channel                   int
+	AverageUtilizationPercent float64
+	AverageNoiseDbm           float64
+	SampleCount               int
+	Score                     float64
+}
+
+type channelScanResponse struct {
+	Channels []channelScanResult `json:"channels"`
+}
+
+type channelScanResult struct {
+	Channel            int     `json:"channel"`
+	UtilizationPercent float64 `json:"utilizationPercent"`
+	NoiseDbm           int     `json:"noiseDbm"`
+	NetworkCount       int     `json:"networkCount"`
+}
+
+// Calls the access point's API to perform a scan of all channels and records the utilization and noise on each one,
+// returning the new samples. This takes the radio off-channel briefly, so it should not be called while a match is in
+// progress; the given context can be cancelled to abandon the request, after which CancelChannelScan should be called
+// to stop the scan on the access point itself.
+func (ap *AccessPoint) ScanChannels(ctx context.Context) ([]ChannelScanSample, error) {
+	if !ap.networkSecurityEnabled {
+		return nil, nil
+	}
+
+	url := ap.apiUrl + "/scan"
+	httpRequest, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if ap.password != "" {
+		httpRequest.Header.Add("Authorization", fmt.Sprintf("Bearer %s", ap.password))
+	}
+	httpClient := http.Client{Timeout: time.Second * channelScanTimeoutSec}
+	httpResponse, err := httpClient.Do(httpRequest)
+	if err != nil {
+		ap.checkAndLogApiError(err)
+		return nil, fmt.Errorf("failed to request access point channel scan: %v", err)
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(httpResponse.Body)
+		return nil, fmt.Errorf("access point returned status %d: %s", httpResponse.StatusCode, string(body))
+	}
+
+	var scanResponse channelScanResponse
+	if err = json.NewDecoder(httpResponse.Body).Decode(&scanResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse access point channel scan: %v", err)
+	}
+	samples := ap.recordChannelScan(scanResponse, time.Now())
+	log.Printf("Access point scanned %d channels.", len(scanResponse.Channels))
+	return samples, nil
+}
+
+// Asks the access point to stop any scan in progress and return the radio to its configured channel.
+func (ap *AccessPoint) CancelChannelScan() error {
+	if !ap.networkSecurityEnabled {
+		return nil
+	}
+
+	httpRequest, err := http.NewRequest("DELETE", ap.apiUrl+"/scan", nil)
+	if err != nil {
+		return err
+	}
+	if ap.password != "" {
+		httpRequest.Header.Add("Authorization", fmt.Sprintf("Bearer %s", ap.password))
+	}
+	httpClient := http.Client{Timeout: time.Second * channelScanCancelTimeoutSec}
+	httpResponse, err := httpClient.Do(httpRequest)
+	if err != nil {
+		ap.checkAndLogApiError(err)
+		return fmt.Errorf("failed to cancel access point channel scan: %v", err)
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(httpResponse.Body)
+		return fmt.Errorf("access point returned status %d: %s", httpResponse.StatusCode, string(body))
+	}
+	return nil
+}
+
+// Replaces the scan history with the given samples, which are expected to be oldest first, such as when restoring it
+// from the database.
+func (ap *AccessPoint) SetChannelScanHistory(samples []ChannelScanSample) {
+	ap.channelScanMutex.Lock()
+	defer ap.channelScanMutex.Unlock()
+
+	ap.channelScanHistory = make(map[int][]ChannelScanSample)
+	for _, sample := range samples {
+		ap.appendChannelScanSample(sample)
+	}
+}
+
+// Returns a copy of the scan history for each channel, oldest sample first.
+func (ap *AccessPoint) GetChannelScanHistory() map[int][]ChannelScanSample {
+	ap.channelScanMutex.Lock()
+	defer ap.channelScanMutex.Unlock()
+
+	history := make(map[int][]ChannelScanSample, len(ap.channelScanHistory))
+	for channel, samples := range ap.channelScanHistory {
+		history[channel] = append([]ChannelScanSample(nil), samples...)
+	}
+	return history
+}
+
+// Returns the most recent scan sample for each channel that has been scanned, ordered by channel number.
+func (ap *AccessPoint) LatestChannelScans() []ChannelScanSample {
+	ap.channelScanMutex.Lock()
+	defer ap.channelScanMutex.Unlock()
+
+	var latestSamples []ChannelScanSample
+	for _, samples := range ap.channelScanHistory {
+		if len(samples) > 0 {
+			latestSamples = append(latestSamples, samples[len(samples)-1])
+		}
+	}
+	sort.Slice(latestSamples, func(i, j int) bool {
+		return latestSamples[i].Channel < latestSamples[j].Channel
+	})
+	return latestSamples
+}
+
+// Returns the most recent scan sample for the channel the access point is configured to use, or nil if that channel
+// has never been scanned.
+func (ap *AccessPoint) CurrentChannelConditions() *ChannelScanSample {
+	ap.channelScanMutex.Lock()
+	defer ap.channelScanMutex.Unlock()
+
+	samples := ap.channelScanHistory[ap.channel]
+	if len(samples) == 0 {
+		return nil
+	}
+	sample := samples[len(samples)-1]
+	return &sample
+}
+
+// Returns the least congested of the given allowed channels based on the average utilization and noise observed over
+// the scan history, or nil if none of them have been scanned. Ties are broken in favor of the currently configured
+// channel to avoid needless changes.
+func (ap *AccessPoint) RecommendChannel(allowedChannels []int) *ChannelRecommendation {
+	ap.channelScanMutex.Lock()
+	defer ap.channelScanMutex.Unlock()
+
+	var recommendations []ChannelRecommendation
+	for _, channel := range allowedChannels {
+		samples := ap.channelScanHistory[channel]
+		if len(samples) == 0 {
+			continue
+		}
+		recommendation := ChannelRecommendation{Channel: channel, SampleCount: len(samples)}
+		for _, sample := range samples {
+			recommendation.AverageUtilizationPercent += sample.UtilizationPercent
+			recommendation.AverageNoiseDbm += float64(sample.NoiseDbm)
+		}
+		recommendation.AverageUtilizationPercent /= float64(len(samples))
+		recommendation.AverageNoiseDbm /= float64(len(samples))
+		recommendation.Score = channelCongestionScore(
+			recommendation.AverageUtilizationPercent, recommendation.AverageNoiseDbm,
+		)
+		recommendations = append(recommendations, recommendation)
+	}
+	if len(recommendations) == 0 {
+		return nil
+	}
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score < recommendations[j].Score
+		}
+		if recommendations[i].Channel == ap.channel || recommendations[j].Channel == ap.channel {
+			return recommendations[i].Channel == ap.channel
+		}
+		return recommendations[i].Channel < recommendations[j].Channel
+	})
+	return &recommendations[0]
+}
+
+// Parses the given comma-separated list of channels that the event is permitted to use. Returns every channel the
+// access point supports if the list is blank, or an error if it contains a channel that the access point doesn't
+// support.
+func ParseAllowedChannels(channelList string) ([]int, error) {
+	if strings.TrimSpace(channelList) == "" {
+		return ApChannels, nil
+	}
+	var allowedChannels []int
+	for _, channelString := range strings.Split(channelList, ",") {
+		channelString = strings.TrimSpace(channelString)
+		if channelString == "" {
+			continue
+		}
+		channel, err := strconv.Atoi(channelString)
+		if err != nil || !isApChannel(channel) {
+			return nil, fmt.Errorf("%q is not a channel that the access point supports", channelString)
+		}
+		allowedChannels = append(allowedChannels, channel)
+	}
+	return allowedChannels, nil
+}
+
+// Returns true if the access point can be configured to use the given channel.
+func isApChannel(channel int) bool {
+	for _, apChannel := range ApChannels {
+		if channel == apChannel {
+			return true
+		}
+	}
+	return false
+}
+
+// Appends the results of the given scan to the per-channel history and returns them as samples.
+func (ap *AccessPoint) recordChannelScan(scanResponse channelScanResponse, scanTime time.Time) []ChannelScanSample {
+	ap.channelScanMutex.Lock()
+	defer ap.channelScanMutex.Unlock()
+
+	if ap.channelScanHistory == nil {
+		ap.channelScanHistory = make(map[int][]ChannelScanSample)
+	}
+	var samples []ChannelScanSample
+	for _, result := range scanResponse.Channels {
+		sample := ChannelScanSample{
+			Time:               scanTime,
+			Channel:            result.Channel,
+			UtilizationPercent: result.UtilizationPercent,
+			NoiseDbm:           result.NoiseDbm,
+			NetworkCount:       result.NetworkCount,
+		}
+		ap.appendChannelScanSample(sample)
+		samples = append(samples, sample)
+	}
+	return samples
+}
+
+// Appends the given sample to the history for its channel, discarding the oldest samples once the history is full. The
+// caller must hold the channel scan mutex.
+func (ap *AccessPoint) appendChannelScanSample(sample ChannelScanSample) {
+	samples := append(ap.channelScanHistory[sample.Channel], sample)
+	if len(samples) > ChannelScanHistoryLength {
+		samples = samples[len(samples)-ChannelScanHistoryLength:]
+	}
+	ap.channelScanHistory[sample.Channel] = samples
+}
+
+// Returns a figure of merit for a channel where lower is better; each dB of noise above the nominal noise floor is
+// weighted the same as a percentage point of airtime utilization.
+func channelCongestionScore(utilizationPercent, noiseDbm float64) float64 {
+	noisePenalty := noiseDbm - noiseFloorDbm
+	if noisePenalty < 0 {
+		noisePenalty = 0
+	}
+	return utilizationPercent + noisePenalty
+}
+
+// Returns true if the given channel conditions are poor enough that they are a likely cause of robot link problems.
+func ChannelIsCongested(utilizationPercent float64, noiseDbm int) bool {
+	return utilizationPercent >= congestedUtilization || noiseDbm >= congestedNoiseDbm
+}
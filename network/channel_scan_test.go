@@ -0,0 +1,242 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAccessPoint_ScanChannels(t *testing.T) {
+	var ap AccessPoint
+	wifiStatuses := [6]*TeamWifiStatus{{}, {}, {}, {}, {}, {}}
+	ap.SetSettings("dummy", "password3", 36, true, wifiStatuses)
+
+	scanResponse := channelScanResponse{
+		Channels: []channelScanResult{
+			{Channel: 36, UtilizationPercent: 60, NoiseDbm: -90, NetworkCount: 7},
+			{Channel: 149, UtilizationPercent: 10, NoiseDbm: -95, NetworkCount: 1},
+			{Channel: 37, UtilizationPercent: 5, NoiseDbm: -85, NetworkCount: 0},
+		},
+	}
+
+	// Mock the radio API server.
+	scanCount := 0
+	radioServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/scan", r.URL.Path)
+		assert.Equal(t, "Bearer password3", r.Header.Get("Authorization"))
+		scanCount++
+		assert.Nil(t, json.NewEncoder(w).Encode(scanResponse))
+	}))
+	defer radioServer.Close()
+	ap.apiUrl = radioServer.URL
+
+	assert.Nil(t, ap.RecommendChannel(ApChannels))
+	assert.Nil(t, ap.CurrentChannelConditions())
+	samples, err := ap.ScanChannels(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, scanCount)
+	if assert.Equal(t, 3, len(samples)) {
+		assert.Equal(t, 36, samples[0].Channel)
+		assert.Equal(t, 60.0, samples[0].UtilizationPercent)
+	}
+	history := ap.GetChannelScanHistory()
+	assert.Equal(t, 3, len(history))
+	if assert.Equal(t, 1, len(history[149])) {
+		assert.Equal(t, 10.0, history[149][0].UtilizationPercent)
+		assert.Equal(t, -95, history[149][0].NoiseDbm)
+		assert.Equal(t, 1, history[149][0].NetworkCount)
+	}
+	latestScans := ap.LatestChannelScans()
+	if assert.Equal(t, 3, len(latestScans)) {
+		assert.Equal(t, 36, latestScans[0].Channel)
+		assert.Equal(t, 37, latestScans[1].Channel)
+		assert.Equal(t, 149, latestScans[2].Channel)
+	}
+	if conditions := ap.CurrentChannelConditions(); assert.NotNil(t, conditions) {
+		assert.Equal(t, 36, conditions.Channel)
+		assert.Equal(t, 60.0, conditions.UtilizationPercent)
+	}
+	if recommendation := ap.RecommendChannel(ApChannels); assert.NotNil(t, recommendation) {
+		assert.Equal(t, 149, recommendation.Channel)
+		assert.Equal(t, 10.0, recommendation.Score)
+	}
+
+	// Congestion on the recommended channel should be averaged over the history.
+	scanResponse.Channels[1].UtilizationPercent = 90
+	_, err = ap.ScanChannels(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(ap.GetChannelScanHistory()[149]))
+	if recommendation := ap.RecommendChannel(ApChannels); assert.NotNil(t, recommendation) {
+		assert.Equal(t, 37, recommendation.Channel)
+		assert.Equal(t, 15.0, recommendation.Score)
+		assert.Equal(t, 2, recommendation.SampleCount)
+	}
+
+	// Channels that the event isn't allowed to use should never be recommended.
+	if recommendation := ap.RecommendChannel([]int{36, 149}); assert.NotNil(t, recommendation) {
+		assert.Equal(t, 149, recommendation.Channel)
+	}
+	assert.Nil(t, ap.RecommendChannel([]int{40}))
+
+	// Scanning is skipped when network security is disabled.
+	ap.networkSecurityEnabled = false
+	samples, err = ap.ScanChannels(context.Background())
+	assert.Nil(t, err)
+	assert.Nil(t, samples)
+	assert.Equal(t, 2, scanCount)
+
+	// Radio API returns an error.
+	ap.networkSecurityEnabled = true
+	radioServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "scan in progress", 503)
+	}))
+	defer radioServer.Close()
+	ap.apiUrl = radioServer.URL
+	_, err = ap.ScanChannels(context.Background())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "returned status 503: scan in progress")
+	}
+	assert.Equal(t, 2, len(ap.GetChannelScanHistory()[149]))
+
+	// Scan is abandoned if the context is cancelled.
+	radioServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer radioServer.Close()
+	ap.apiUrl = radioServer.URL
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err = ap.ScanChannels(ctx)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "context canceled")
+	}
+}
+
+func TestAccessPoint_CancelChannelScan(t *testing.T) {
+	var ap AccessPoint
+	wifiStatuses := [6]*TeamWifiStatus{{}, {}, {}, {}, {}, {}}
+	ap.SetSettings("dummy", "password3", 36, true, wifiStatuses)
+
+	// Mock the radio API server.
+	cancelCount := 0
+	radioServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/scan", r.URL.Path)
+		assert.Equal(t, "Bearer password3", r.Header.Get("Authorization"))
+		cancelCount++
+	}))
+	defer radioServer.Close()
+	ap.apiUrl = radioServer.URL
+
+	assert.Nil(t, ap.CancelChannelScan())
+	assert.Equal(t, 1, cancelCount)
+
+	// Cancelling is skipped when network security is disabled.
+	ap.networkSecurityEnabled = false
+	assert.Nil(t, ap.CancelChannelScan())
+	assert.Equal(t, 1, cancelCount)
+
+	// Radio API returns an error.
+	ap.networkSecurityEnabled = true
+	radioServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no scan in progress", 409)
+	}))
+	defer radioServer.Close()
+	ap.apiUrl = radioServer.URL
+	err := ap.CancelChannelScan()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "returned status 409: no scan in progress")
+	}
+}
+
+func TestAccessPoint_SetChannelScanHistory(t *testing.T) {
+	var ap AccessPoint
+	ap.recordChannelScan(channelScanResponse{Channels: []channelScanResult{{Channel: 36}}}, time.Now())
+
+	scanTime := time.Unix(1000, 0)
+	var samples []ChannelScanSample
+	for i := 0; i < ChannelScanHistoryLength+2; i++ {
+		samples = append(
+			samples,
+			ChannelScanSample{
+				Time: scanTime.Add(time.Duration(i) * time.Minute), Channel: 149, UtilizationPercent: float64(i),
+			},
+		)
+	}
+	ap.SetChannelScanHistory(samples)
+	history := ap.GetChannelScanHistory()
+	assert.Equal(t, 1, len(history))
+	if assert.Equal(t, ChannelScanHistoryLength, len(history[149])) {
+		assert.Equal(t, 2.0, history[149][0].UtilizationPercent)
+		assert.Equal(t, float64(ChannelScanHistoryLength+1), history[149][len(history[149])-1].UtilizationPercent)
+	}
+}
+
+func TestAccessPoint_RecommendChannelPrefersCurrentChannel(t *testing.T) {
+	var ap AccessPoint
+	wifiStatuses := [6]*TeamWifiStatus{{}, {}, {}, {}, {}, {}}
+	ap.SetSettings("dummy", "", 157, true, wifiStatuses)
+
+	ap.recordChannelScan(
+		channelScanResponse{
+			Channels: []channelScanResult{
+				{Channel: 36, UtilizationPercent: 20, NoiseDbm: -97},
+				{Channel: 157, UtilizationPercent: 20, NoiseDbm: -99},
+				{Channel: 161, UtilizationPercent: 20, NoiseDbm: -92},
+			},
+		},
+		time.Now(),
+	)
+	if recommendation := ap.RecommendChannel(ApChannels); assert.NotNil(t, recommendation) {
+		assert.Equal(t, 157, recommendation.Channel)
+	}
+}
+
+func TestParseAllowedChannels(t *testing.T) {
+	allowedChannels, err := ParseAllowedChannels("")
+	assert.Nil(t, err)
+	assert.Equal(t, ApChannels, allowedChannels)
+
+	allowedChannels, err = ParseAllowedChannels(" 36, 149,5 ,")
+	assert.Nil(t, err)
+	assert.Equal(t, []int{36, 149, 5}, allowedChannels)
+
+	_, err = ParseAllowedChannels("36,52")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "\"52\" is not a channel that the access point supports")
+	}
+	_, err = ParseAllowedChannels("36,abc")
+	assert.NotNil(t, err)
+}
+
+func TestAccessPoint_recordChannelScanHistoryLength(t *testing.T) {
+	var ap AccessPoint
+	scanTime := time.Unix(1000, 0)
+	for i := 0; i < ChannelScanHistoryLength+5; i++ {
+		ap.recordChannelScan(
+			channelScanResponse{Channels: []channelScanResult{{Channel: 5, UtilizationPercent: float64(i)}}},
+			scanTime.Add(time.Duration(i)*time.Minute),
+		)
+	}
+	samples := ap.GetChannelScanHistory()[5]
+	if assert.Equal(t, ChannelScanHistoryLength, len(samples)) {
+		assert.Equal(t, 5.0, samples[0].UtilizationPercent)
+		assert.Equal(t, scanTime.Add(5*time.Minute), samples[0].Time)
+		assert.Equal(t, float64(ChannelScanHistoryLength+4), samples[len(samples)-1].UtilizationPercent)
+	}
+}
+
+func TestChannelIsCongested(t *testing.T) {
+	assert.False(t, ChannelIsCongested(10, -95))
+	assert.True(t, ChannelIsCongested(50, -95))
+	assert.True(t, ChannelIsCongested(10, -85))
+}
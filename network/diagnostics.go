@@ -0,0 +1,208 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Methods for gathering per-team wired network diagnostics from the switch and by pinging team devices.
+
+package network
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	radioAddressSuffix = 1
+	rioAddressSuffix   = 2
+	pingTimeoutSec     = 1
+)
+
+// Represents the state of the wired network for a single team, used to distinguish cabling faults from robot
+// configuration issues.
+type TeamNetworkDiagnostics struct {
+	TeamId                  int
+	DhcpLeases              []DhcpBinding
+	RadioArpPresent         bool
+	RioArpPresent           bool
+	RioPingLatencyMs        float64
+	SwitchPort              string
+	SwitchPortLinkUp        bool
+	SwitchPortInputPackets  int
+	SwitchPortOutputPackets int
+	SwitchPortInputErrors   int
+	SwitchPortCrcErrors     int
+	SwitchPortOutputErrors  int
+	LastUpdated             time.Time
+}
+
+// Represents the counters for a single switch interface as reported by "show interfaces".
+type switchPortCounters struct {
+	linkUp        bool
+	inputPackets  int
+	outputPackets int
+	inputErrors   int
+	crcErrors     int
+	outputErrors  int
+}
+
+var (
+	arpLineRe         = regexp.MustCompile(`^Internet\s+(\d+(\.\d+){3})\s+\S+\s+([0-9a-fA-F]{4}(\.[0-9a-fA-F]{4}){2})`)
+	interfaceHeaderRe = regexp.MustCompile(`^(\S+) is ([a-z ]+), line protocol is (\w+)`)
+	inputPacketsRe    = regexp.MustCompile(`^(\d+) packets input`)
+	outputPacketsRe   = regexp.MustCompile(`^(\d+) packets output`)
+	inputErrorsRe     = regexp.MustCompile(`^(\d+) input errors, (\d+) CRC`)
+	outputErrorsRe    = regexp.MustCompile(`^(\d+) output errors`)
+	pingLatencyRe     = regexp.MustCompile(`time[=<]\s*([\d.]+)\s*ms`)
+	pingCommandRunner = runPingCommand // Mutable for testing
+)
+
+// Queries the switch for the DHCP leases, ARP entries, and port counters pertaining to each team VLAN and populates the
+// given diagnostics structures with them. The switch port for each team VLAN is taken from the switch's running
+// configuration, which is read the first time through and again whenever the team VLANs are configured. Does nothing if
+// the switch is in the middle of being configured.
+func (sw *Switch) UpdateTeamDiagnostics(teams [6]*model.Team, diagnostics [6]*TeamNetworkDiagnostics) error {
+	if !sw.mutex.TryLock() {
+		return nil
+	}
+	defer sw.mutex.Unlock()
+
+	if sw.teamVlanPorts == nil {
+		output, err := sw.runCommand("show running-config\n")
+		if err != nil {
+			return fmt.Errorf("failed to read switch configuration: %v", err)
+		}
+		sw.teamVlanPorts = getTeamVlanPorts(parseSwitchRunningConfig(output))
+	}
+
+	command := "show ip dhcp binding\nshow ip arp\n"
+	for _, vlan := range teamVlans {
+		if port, ok := sw.teamVlanPorts[vlan]; ok {
+			command += fmt.Sprintf("show interfaces %s\n", port)
+		}
+	}
+	output, err := sw.runCommand(command)
+	if err != nil {
+		return fmt.Errorf("failed to read diagnostics from switch: %v", err)
+	}
+	bindings := parseSwitchDhcpBindings(output)
+	arpAddresses := parseSwitchArpTable(output)
+	portCounters := parseSwitchPortCounters(output)
+
+	for i, vlan := range teamVlans {
+		teamDiagnostics := diagnostics[i]
+		teamDiagnostics.DhcpLeases = nil
+		teamDiagnostics.RadioArpPresent = false
+		teamDiagnostics.RioArpPresent = false
+		teamDiagnostics.SwitchPort = sw.teamVlanPorts[vlan]
+		teamDiagnostics.LastUpdated = time.Now()
+		if counters, ok := portCounters[teamDiagnostics.SwitchPort]; ok {
+			teamDiagnostics.SwitchPortLinkUp = counters.linkUp
+			teamDiagnostics.SwitchPortInputPackets = counters.inputPackets
+			teamDiagnostics.SwitchPortOutputPackets = counters.outputPackets
+			teamDiagnostics.SwitchPortInputErrors = counters.inputErrors
+			teamDiagnostics.SwitchPortCrcErrors = counters.crcErrors
+			teamDiagnostics.SwitchPortOutputErrors = counters.outputErrors
+		}
+		if teams[i] == nil {
+			teamDiagnostics.TeamId = 0
+			continue
+		}
+
+		teamDiagnostics.TeamId = teams[i].Id
+		subnetPrefix := teamSubnetPrefix(teams[i].Id)
+		for _, binding := range bindings {
+			if strings.HasPrefix(binding.IpAddress, subnetPrefix) {
+				teamDiagnostics.DhcpLeases = append(teamDiagnostics.DhcpLeases, binding)
+			}
+		}
+		_, teamDiagnostics.RadioArpPresent = arpAddresses[fmt.Sprintf("%s%d", subnetPrefix, radioAddressSuffix)]
+		_, teamDiagnostics.RioArpPresent = arpAddresses[fmt.Sprintf("%s%d", subnetPrefix, rioAddressSuffix)]
+	}
+	return nil
+}
+
+// Pings the given team's roboRIO at 10.TE.AM.2 and returns the round-trip latency in milliseconds, or -1 if there was
+// no reply.
+func PingTeamRio(teamId int) float64 {
+	latencyMs, err := pingCommandRunner(fmt.Sprintf("%s%d", teamSubnetPrefix(teamId), rioAddressSuffix))
+	if err != nil {
+		return -1
+	}
+	return latencyMs
+}
+
+// Returns the first three octets of the given team's 10.TE.AM.x subnet, including the trailing dot.
+func teamSubnetPrefix(teamId int) string {
+	return fmt.Sprintf("10.%d.%d.", teamId/100, teamId%100)
+}
+
+// Extracts the set of IP addresses that have a resolved hardware address from the output of the "show ip arp" command.
+func parseSwitchArpTable(output string) map[string]string {
+	arpAddresses := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		if match := arpLineRe.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			arpAddresses[match[1]] = clientIdToMacAddress(match[3])
+		}
+	}
+	return arpAddresses
+}
+
+// Extracts the link state and packet/error counters for each interface from the output of the "show interfaces"
+// command, keyed by interface name.
+func parseSwitchPortCounters(output string) map[string]*switchPortCounters {
+	portCounters := make(map[string]*switchPortCounters)
+	var currentPort *switchPortCounters
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r ")
+		if match := interfaceHeaderRe.FindStringSubmatch(line); match != nil {
+			currentPort = &switchPortCounters{linkUp: match[2] == "up" && match[3] == "up"}
+			portCounters[match[1]] = currentPort
+			continue
+		}
+		if currentPort == nil || !strings.HasPrefix(line, " ") {
+			continue
+		}
+
+		line = strings.TrimSpace(line)
+		if match := inputPacketsRe.FindStringSubmatch(line); match != nil {
+			currentPort.inputPackets, _ = strconv.Atoi(match[1])
+		} else if match = outputPacketsRe.FindStringSubmatch(line); match != nil {
+			currentPort.outputPackets, _ = strconv.Atoi(match[1])
+		} else if match = inputErrorsRe.FindStringSubmatch(line); match != nil {
+			currentPort.inputErrors, _ = strconv.Atoi(match[1])
+			currentPort.crcErrors, _ = strconv.Atoi(match[2])
+		} else if match = outputErrorsRe.FindStringSubmatch(line); match != nil {
+			currentPort.outputErrors, _ = strconv.Atoi(match[1])
+		}
+	}
+	return portCounters
+}
+
+// Invokes the operating system's ping utility to send a single echo request to the given address and returns the
+// round-trip latency in milliseconds.
+func runPingCommand(address string) (float64, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("ping", "-n", "1", "-w", strconv.Itoa(pingTimeoutSec*1000), address)
+	} else {
+		cmd = exec.Command("ping", "-c", "1", "-W", strconv.Itoa(pingTimeoutSec), address)
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, err
+	}
+	return parsePingOutput(string(output))
+}
+
+// Extracts the round-trip latency in milliseconds from the output of the ping utility.
+func parsePingOutput(output string) (float64, error) {
+	match := pingLatencyRe.FindStringSubmatch(output)
+	if match == nil {
+		return 0, fmt.Errorf("no ping reply found in output: %s", output)
+	}
+	return strconv.ParseFloat(match[1], 64)
+}
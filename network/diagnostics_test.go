@@ -0,0 +1,120 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package network
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSwitch_UpdateTeamDiagnostics(t *testing.T) {
+	sw := NewSwitch("127.0.0.1", "password")
+	sw.port = 9070
+	var commands []string
+	diagnostics := [6]*TeamNetworkDiagnostics{{}, {}, {}, {}, {}, {}}
+	teams := [6]*model.Team{{Id: 1114}, nil, nil, nil, {Id: 254}, nil}
+
+	// The switch ports should be read from the running configuration the first time through.
+	mockTelnet(
+		t,
+		sw.port,
+		&commands,
+		readSwitchTestData(t, "switch_running_config_6_teams.txt"),
+		readSwitchTestData(t, "switch_diagnostics.txt"),
+	)
+	assert.Nil(t, sw.UpdateTeamDiagnostics(teams, diagnostics))
+	if assert.Equal(t, 2, len(commands)) {
+		assert.Equal(t, "password\nenable\npassword\nterminal length 0\nshow running-config\nexit\n", commands[0])
+		assert.Equal(
+			t,
+			"password\nenable\npassword\nterminal length 0\nshow ip dhcp binding\nshow ip arp\n"+
+				"show interfaces GigabitEthernet0/11\nshow interfaces GigabitEthernet0/13\n"+
+				"show interfaces GigabitEthernet0/15\nshow interfaces GigabitEthernet0/12\n"+
+				"show interfaces GigabitEthernet0/14\nshow interfaces GigabitEthernet0/16\nexit\n",
+			commands[1],
+		)
+	}
+
+	red1 := diagnostics[0]
+	assert.Equal(t, 1114, red1.TeamId)
+	assert.Equal(t, []DhcpBinding{{"10.11.14.20", "00:80:2f:6f:00:1b"}}, red1.DhcpLeases)
+	assert.True(t, red1.RadioArpPresent)
+	assert.False(t, red1.RioArpPresent)
+	assert.Equal(t, "GigabitEthernet0/11", red1.SwitchPort)
+	assert.True(t, red1.SwitchPortLinkUp)
+	assert.Equal(t, 18342, red1.SwitchPortInputPackets)
+	assert.Equal(t, 20451, red1.SwitchPortOutputPackets)
+	assert.Equal(t, 27, red1.SwitchPortInputErrors)
+	assert.Equal(t, 25, red1.SwitchPortCrcErrors)
+	assert.Equal(t, 3, red1.SwitchPortOutputErrors)
+
+	red2 := diagnostics[1]
+	assert.Equal(t, 0, red2.TeamId)
+	assert.Nil(t, red2.DhcpLeases)
+	assert.Equal(t, "GigabitEthernet0/13", red2.SwitchPort)
+	assert.False(t, red2.SwitchPortLinkUp)
+
+	blue2 := diagnostics[4]
+	assert.Equal(t, 254, blue2.TeamId)
+	assert.Equal(t, []DhcpBinding{{"10.2.54.20", "00:80:2f:5c:11:02"}}, blue2.DhcpLeases)
+	assert.True(t, blue2.RadioArpPresent)
+	assert.True(t, blue2.RioArpPresent)
+	assert.True(t, blue2.SwitchPortLinkUp)
+	assert.Equal(t, 0, blue2.SwitchPortInputErrors)
+
+	assert.False(t, diagnostics[5].SwitchPortLinkUp)
+
+	// Subsequent updates should reuse the ports and only query ports for VLANs that have one.
+	sw.port += 1
+	sw.teamVlanPorts = map[int]string{red1Vlan: "GigabitEthernet0/3"}
+	mockTelnet(t, sw.port, &commands, readSwitchTestData(t, "switch_diagnostics.txt"))
+	assert.Nil(t, sw.UpdateTeamDiagnostics(teams, diagnostics))
+	if assert.Equal(t, 1, len(commands)) {
+		assert.Equal(
+			t,
+			"password\nenable\npassword\nterminal length 0\nshow ip dhcp binding\nshow ip arp\n"+
+				"show interfaces GigabitEthernet0/3\nexit\n",
+			commands[0],
+		)
+	}
+	assert.Equal(t, "GigabitEthernet0/3", diagnostics[0].SwitchPort)
+	assert.Equal(t, "", diagnostics[1].SwitchPort)
+
+	// Should return an error if the switch can't be reached.
+	sw.port += 1
+	assert.NotNil(t, sw.UpdateTeamDiagnostics(teams, diagnostics))
+}
+
+func TestPingTeamRio(t *testing.T) {
+	var pingedAddress string
+	pingCommandRunner = func(address string) (float64, error) {
+		pingedAddress = address
+		if address == "10.2.54.2" {
+			return 1.25, nil
+		}
+		return 0, fmt.Errorf("timeout")
+	}
+	defer func() { pingCommandRunner = runPingCommand }()
+
+	assert.Equal(t, 1.25, PingTeamRio(254))
+	assert.Equal(t, "10.2.54.2", pingedAddress)
+	assert.Equal(t, -1.0, PingTeamRio(1503))
+	assert.Equal(t, "10.15.3.2", pingedAddress)
+}
+
+func TestParsePingOutput(t *testing.T) {
+	latency, err := parsePingOutput(
+		"PING 10.2.54.2 (10.2.54.2) 56(84) bytes of data.\n64 bytes from 10.2.54.2: icmp_seq=1 ttl=64 time=0.842 ms\n",
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.842, latency)
+
+	latency, err = parsePingOutput("Reply from 10.2.54.2: bytes=32 time<1ms TTL=64\n")
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, latency)
+
+	_, err = parsePingOutput("Request timed out.\n")
+	assert.NotNil(t, err)
+}
@@ -10,7 +10,11 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/Team254/cheesy-arena/model"
+	"log"
 	"net"
+	"regexp"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -18,6 +22,7 @@ import (
 const (
 	switchConfigBackoffDurationSec = 5
 	switchConfigPauseDurationSec   = 2
+	switchConfigMaxAttempts        = 2
 	switchTeamGatewayAddress       = 4
 	switchTelnetPort               = 23
 )
@@ -31,6 +36,9 @@ const (
 	blue3Vlan = 60
 )
 
+// Team VLANs in the same order as the alliance stations (R1, R2, R3, B1, B2, B3).
+var teamVlans = [6]int{red1Vlan, red2Vlan, red3Vlan, blue1Vlan, blue2Vlan, blue3Vlan}
+
 type Switch struct {
 	address               string
 	port                  int
@@ -39,8 +47,27 @@ type Switch struct {
 	configBackoffDuration time.Duration
 	configPauseDuration   time.Duration
 	Status                string
+	DhcpBindings          []DhcpBinding
+	teamVlanPorts         map[int]string
+}
+
+// Represents a DHCP lease that the switch has handed out to a device.
+type DhcpBinding struct {
+	IpAddress       string
+	HardwareAddress string
+}
+
+// Represents the team-specific portion of the switch's running configuration for a single VLAN.
+type switchVlanConfig struct {
+	interfaceIpAddress string
+	dhcpPoolNetwork    string
+	dhcpDefaultRouter  string
+	accessListEntries  []string
+	accessPorts        []string
 }
 
+var dhcpBindingLineRe = regexp.MustCompile(`^(\d+\.\d+\.\d+\.\d+)\s+([0-9a-fA-F.]+)\s`)
+
 var ServerIpAddress = "10.0.100.5" // The DS will try to connect to this address only.
 
 func NewSwitch(address, password string) *Switch {
@@ -54,13 +81,35 @@ func NewSwitch(address, password string) *Switch {
 	}
 }
 
-// Sets up wired networks for the given set of teams.
+// Sets up wired networks for the given set of teams, reading back the switch configuration afterwards to verify that it
+// took effect and retrying if it didn't.
 func (sw *Switch) ConfigureTeamEthernet(teams [6]*model.Team) error {
 	// Make sure multiple configurations aren't being set at the same time.
 	sw.mutex.Lock()
 	defer sw.mutex.Unlock()
 	sw.Status = "CONFIGURING"
 
+	var err error
+	for attempt := 1; attempt <= switchConfigMaxAttempts; attempt++ {
+		if err = sw.applyTeamEthernetConfiguration(teams); err != nil {
+			sw.Status = "ERROR"
+			return err
+		}
+		if err = sw.verifyTeamEthernetConfiguration(teams); err == nil {
+			sw.Status = "ACTIVE"
+			return nil
+		}
+		log.Printf(
+			"Switch configuration attempt %d of %d did not take effect: %v", attempt, switchConfigMaxAttempts, err,
+		)
+	}
+
+	sw.Status = "ERROR"
+	return err
+}
+
+// Sends the commands to remove the old team VLANs and create the new ones.
+func (sw *Switch) applyTeamEthernetConfiguration(teams [6]*model.Team) error {
 	// Remove old team VLANs to reset the switch state.
 	removeTeamVlansCommand := ""
 	for vlan := 10; vlan <= 60; vlan += 10 {
@@ -70,7 +119,6 @@ func (sw *Switch) ConfigureTeamEthernet(teams [6]*model.Team) error {
 	}
 	_, err := sw.runConfigCommand(removeTeamVlansCommand)
 	if err != nil {
-		sw.Status = "ERROR"
 		return err
 	}
 	time.Sleep(sw.configPauseDuration)
@@ -111,24 +159,40 @@ func (sw *Switch) ConfigureTeamEthernet(teams [6]*model.Team) error {
 			switchTeamGatewayAddress,
 		)
 	}
-	addTeamVlan(teams[0], red1Vlan)
-	addTeamVlan(teams[1], red2Vlan)
-	addTeamVlan(teams[2], red3Vlan)
-	addTeamVlan(teams[3], blue1Vlan)
-	addTeamVlan(teams[4], blue2Vlan)
-	addTeamVlan(teams[5], blue3Vlan)
+	for i, vlan := range teamVlans {
+		addTeamVlan(teams[i], vlan)
+	}
 	if len(addTeamVlansCommand) > 0 {
 		_, err = sw.runConfigCommand(addTeamVlansCommand)
 		if err != nil {
-			sw.Status = "ERROR"
 			return err
 		}
 	}
 
-	// Give some time for the configuration to take before another one can be attempted.
+	// Give some time for the configuration to take before it is verified or another one is attempted.
 	time.Sleep(sw.configBackoffDuration)
 
-	sw.Status = "ACTIVE"
+	return nil
+}
+
+// Reads back the running configuration and DHCP bindings from the switch and returns an error detailing every
+// discrepancy if the team VLANs, DHCP pools, and access lists don't match the given set of teams.
+func (sw *Switch) verifyTeamEthernetConfiguration(teams [6]*model.Team) error {
+	output, err := sw.runCommand("show running-config\nshow ip dhcp binding\n")
+	if err != nil {
+		return fmt.Errorf("failed to read back switch configuration: %v", err)
+	}
+	vlanConfigs := parseSwitchRunningConfig(output)
+	sw.DhcpBindings = parseSwitchDhcpBindings(output)
+	sw.teamVlanPorts = getTeamVlanPorts(vlanConfigs)
+
+	var mismatches []string
+	for i, vlan := range teamVlans {
+		mismatches = append(mismatches, checkSwitchVlanConfig(teams[i], vlan, vlanConfigs[vlan])...)
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("switch configuration does not match expected teams: %s", strings.Join(mismatches, "; "))
+	}
 	return nil
 }
 
@@ -168,3 +232,188 @@ func (sw *Switch) runCommand(command string) (string, error) {
 func (sw *Switch) runConfigCommand(command string) (string, error) {
 	return sw.runCommand(fmt.Sprintf("config terminal\n%send\ncopy running-config startup-config\n\n", command))
 }
+
+// Extracts the team-relevant VLAN interface, DHCP pool, access list, and access port configuration from the output of
+// the "show running-config" command, keyed by VLAN number.
+func parseSwitchRunningConfig(output string) map[int]*switchVlanConfig {
+	vlanConfigs := make(map[int]*switchVlanConfig)
+	getVlanConfig := func(vlan int) *switchVlanConfig {
+		if _, ok := vlanConfigs[vlan]; !ok {
+			vlanConfigs[vlan] = new(switchVlanConfig)
+		}
+		return vlanConfigs[vlan]
+	}
+
+	var currentInterface, currentPool *switchVlanConfig
+	var currentPort string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r ")
+		if strings.HasPrefix(line, " ") {
+			// This line belongs to the most recent top-level section.
+			fields := strings.Fields(line)
+			if currentInterface != nil && len(fields) >= 3 && fields[0] == "ip" && fields[1] == "address" {
+				currentInterface.interfaceIpAddress = fields[2]
+			} else if currentPool != nil && len(fields) >= 2 && fields[0] == "network" {
+				currentPool.dhcpPoolNetwork = fields[1]
+			} else if currentPool != nil && len(fields) >= 2 && fields[0] == "default-router" {
+				currentPool.dhcpDefaultRouter = fields[1]
+			} else if currentPort != "" && len(fields) >= 4 && fields[0] == "switchport" && fields[1] == "access" &&
+				fields[2] == "vlan" {
+				if vlan, err := strconv.Atoi(fields[3]); err == nil {
+					vlanConfig := getVlanConfig(vlan)
+					vlanConfig.accessPorts = append(vlanConfig.accessPorts, currentPort)
+				}
+			}
+			continue
+		}
+
+		// Any top-level line ends the previous section.
+		currentInterface = nil
+		currentPool = nil
+		currentPort = ""
+		if vlanString, ok := strings.CutPrefix(line, "interface Vlan"); ok {
+			if vlan, err := strconv.Atoi(vlanString); err == nil {
+				currentInterface = getVlanConfig(vlan)
+			}
+		} else if portName, ok := strings.CutPrefix(line, "interface "); ok {
+			currentPort = portName
+		} else if vlanString, ok := strings.CutPrefix(line, "ip dhcp pool dhcp"); ok {
+			if vlan, err := strconv.Atoi(vlanString); err == nil {
+				currentPool = getVlanConfig(vlan)
+			}
+		} else if aclString, ok := strings.CutPrefix(line, "access-list "); ok {
+			// Team access lists are numbered as 100 plus the VLAN number.
+			aclNumberString, entry, _ := strings.Cut(aclString, " ")
+			if aclNumber, err := strconv.Atoi(aclNumberString); err == nil && aclNumber > 100 && aclNumber < 200 {
+				vlanConfig := getVlanConfig(aclNumber - 100)
+				vlanConfig.accessListEntries = append(vlanConfig.accessListEntries, entry)
+			}
+		}
+	}
+	return vlanConfigs
+}
+
+// Returns the physical switch port that each team VLAN is patched to, keyed by VLAN number. Only the first access port
+// is used if a VLAN has several; VLANs that are only carried on trunk ports have no entry.
+func getTeamVlanPorts(vlanConfigs map[int]*switchVlanConfig) map[int]string {
+	teamVlanPorts := make(map[int]string)
+	for _, vlan := range teamVlans {
+		if vlanConfig, ok := vlanConfigs[vlan]; ok && len(vlanConfig.accessPorts) > 0 {
+			teamVlanPorts[vlan] = vlanConfig.accessPorts[0]
+		}
+	}
+	return teamVlanPorts
+}
+
+// Extracts the list of leases from the output of the "show ip dhcp binding" command.
+func parseSwitchDhcpBindings(output string) []DhcpBinding {
+	var bindings []DhcpBinding
+	for _, line := range strings.Split(output, "\n") {
+		match := dhcpBindingLineRe.FindStringSubmatch(strings.TrimSpace(line) + " ")
+		if match == nil {
+			continue
+		}
+		bindings = append(bindings, DhcpBinding{IpAddress: match[1], HardwareAddress: clientIdToMacAddress(match[2])})
+	}
+	return bindings
+}
+
+// Converts a Cisco-formatted DHCP client ID (e.g. "0100.1122.3344.55") to a colon-separated MAC address.
+func clientIdToMacAddress(clientId string) string {
+	hex := strings.ToLower(strings.ReplaceAll(clientId, ".", ""))
+	if len(hex) == 14 && strings.HasPrefix(hex, "01") {
+		// Strip the leading hardware type byte that indicates Ethernet.
+		hex = hex[2:]
+	}
+	if len(hex) != 12 {
+		return clientId
+	}
+	octets := make([]string, 6)
+	for i := range octets {
+		octets[i] = hex[2*i : 2*i+2]
+	}
+	return strings.Join(octets, ":")
+}
+
+// Returns a description of each way in which the given VLAN's running configuration differs from what is expected for
+// the given team, or an empty slice if it matches.
+func checkSwitchVlanConfig(team *model.Team, vlan int, vlanConfig *switchVlanConfig) []string {
+	if vlanConfig == nil {
+		vlanConfig = new(switchVlanConfig)
+	}
+
+	var mismatches []string
+	if team == nil {
+		if vlanConfig.interfaceIpAddress != "" {
+			mismatches = append(
+				mismatches,
+				fmt.Sprintf("VLAN %d should be empty but has IP address %s", vlan, vlanConfig.interfaceIpAddress),
+			)
+		}
+		if vlanConfig.dhcpPoolNetwork != "" {
+			mismatches = append(
+				mismatches,
+				fmt.Sprintf("VLAN %d should be empty but has DHCP pool %s", vlan, vlanConfig.dhcpPoolNetwork),
+			)
+		}
+		if len(vlanConfig.accessListEntries) > 0 {
+			mismatches = append(mismatches, fmt.Sprintf("VLAN %d should be empty but has access list 1%d", vlan, vlan))
+		}
+		return mismatches
+	}
+
+	teamPartialIp := fmt.Sprintf("%d.%d", team.Id/100, team.Id%100)
+	expectedGateway := fmt.Sprintf("10.%s.%d", teamPartialIp, switchTeamGatewayAddress)
+	expectedNetwork := fmt.Sprintf("10.%s.0", teamPartialIp)
+	expectedAccessListEntry := fmt.Sprintf("permit ip %s 0.0.0.255 host %s", expectedNetwork, ServerIpAddress)
+	if vlanConfig.interfaceIpAddress != expectedGateway {
+		mismatches = append(
+			mismatches,
+			fmt.Sprintf(
+				"VLAN %d for team %d has IP address %q; expected %q",
+				vlan,
+				team.Id,
+				vlanConfig.interfaceIpAddress,
+				expectedGateway,
+			),
+		)
+	}
+	if vlanConfig.dhcpPoolNetwork != expectedNetwork {
+		mismatches = append(
+			mismatches,
+			fmt.Sprintf(
+				"VLAN %d for team %d has DHCP pool network %q; expected %q",
+				vlan,
+				team.Id,
+				vlanConfig.dhcpPoolNetwork,
+				expectedNetwork,
+			),
+		)
+	}
+	if vlanConfig.dhcpDefaultRouter != expectedGateway {
+		mismatches = append(
+			mismatches,
+			fmt.Sprintf(
+				"VLAN %d for team %d has DHCP default router %q; expected %q",
+				vlan,
+				team.Id,
+				vlanConfig.dhcpDefaultRouter,
+				expectedGateway,
+			),
+		)
+	}
+	accessListFound := false
+	for _, entry := range vlanConfig.accessListEntries {
+		if entry == expectedAccessListEntry {
+			accessListFound = true
+			break
+		}
+	}
+	if !accessListFound {
+		mismatches = append(
+			mismatches,
+			fmt.Sprintf("VLAN %d for team %d is missing access list entry %q", vlan, team.Id, expectedAccessListEntry),
+		)
+	}
+	return mismatches
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/stretchr/testify/assert"
 	"net"
+	"os"
 	"testing"
 	"time"
 )
@@ -19,7 +20,7 @@ func TestConfigureSwitch(t *testing.T) {
 	sw.port = 9050
 	sw.configBackoffDuration = time.Millisecond
 	sw.configPauseDuration = time.Millisecond
-	var command1, command2 string
+	var commands []string
 	expectedResetCommand := "password\nenable\npassword\nterminal length 0\nconfig terminal\n" +
 		"interface Vlan10\nno ip address\nno access-list 110\nno ip dhcp pool dhcp10\n" +
 		"interface Vlan20\nno ip address\nno access-list 120\nno ip dhcp pool dhcp20\n" +
@@ -28,19 +29,24 @@ func TestConfigureSwitch(t *testing.T) {
 		"interface Vlan50\nno ip address\nno access-list 150\nno ip dhcp pool dhcp50\n" +
 		"interface Vlan60\nno ip address\nno access-list 160\nno ip dhcp pool dhcp60\n" +
 		"end\ncopy running-config startup-config\n\nexit\n"
+	expectedVerifyCommand := "password\nenable\npassword\nterminal length 0\nshow running-config\n" +
+		"show ip dhcp binding\nexit\n"
 
 	// Should remove all previous VLANs and do nothing else if current configuration is blank.
-	mockTelnet(t, sw.port, &command1, &command2)
+	mockTelnet(t, sw.port, &commands, "", readSwitchTestData(t, "switch_running_config_0_teams.txt"))
 	assert.Nil(t, sw.ConfigureTeamEthernet([6]*model.Team{nil, nil, nil, nil, nil, nil}))
-	assert.Equal(t, expectedResetCommand, command1)
-	assert.Equal(t, "", command2)
+	if assert.Equal(t, 2, len(commands)) {
+		assert.Equal(t, expectedResetCommand, commands[0])
+		assert.Equal(t, expectedVerifyCommand, commands[1])
+	}
 	assert.Equal(t, "ACTIVE", sw.Status)
 
 	// Should configure one team if only one is present.
 	sw.port += 1
-	mockTelnet(t, sw.port, &command1, &command2)
+	mockTelnet(t, sw.port, &commands, "", "", readSwitchTestData(t, "switch_running_config_1_team.txt"))
 	assert.Nil(t, sw.ConfigureTeamEthernet([6]*model.Team{nil, nil, nil, nil, {Id: 254}, nil}))
-	assert.Equal(t, expectedResetCommand, command1)
+	assert.Equal(t, 3, len(commands))
+	assert.Equal(t, expectedResetCommand, commands[0])
 	assert.Equal(
 		t,
 		"password\nenable\npassword\nterminal length 0\nconfig terminal\n"+
@@ -51,17 +57,20 @@ func TestConfigureSwitch(t *testing.T) {
 			"access-list 150 permit icmp any any\n"+
 			"interface Vlan50\nip address 10.2.54.4 255.255.255.0\n"+
 			"end\ncopy running-config startup-config\n\nexit\n",
-		command2,
+		commands[1],
 	)
+	assert.Equal(t, expectedVerifyCommand, commands[2])
+	assert.Equal(t, "ACTIVE", sw.Status)
 
 	// Should configure all teams if all are present.
 	sw.port += 1
-	mockTelnet(t, sw.port, &command1, &command2)
+	mockTelnet(t, sw.port, &commands, "", "", readSwitchTestData(t, "switch_running_config_6_teams.txt"))
 	assert.Nil(
 		t,
 		sw.ConfigureTeamEthernet([6]*model.Team{{Id: 1114}, {Id: 254}, {Id: 296}, {Id: 1503}, {Id: 1678}, {Id: 1538}}),
 	)
-	assert.Equal(t, expectedResetCommand, command1)
+	assert.Equal(t, 3, len(commands))
+	assert.Equal(t, expectedResetCommand, commands[0])
 	assert.Equal(
 		t,
 		"password\nenable\npassword\nterminal length 0\nconfig terminal\n"+
@@ -102,35 +111,134 @@ func TestConfigureSwitch(t *testing.T) {
 			"access-list 160 permit icmp any any\n"+
 			"interface Vlan60\nip address 10.15.38.4 255.255.255.0\n"+
 			"end\ncopy running-config startup-config\n\nexit\n",
-		command2,
+		commands[1],
+	)
+	assert.Equal(t, expectedVerifyCommand, commands[2])
+	assert.Equal(t, "ACTIVE", sw.Status)
+}
+
+func TestConfigureSwitchVerificationMismatch(t *testing.T) {
+	sw := NewSwitch("127.0.0.1", "password")
+	sw.port = 9060
+	sw.configBackoffDuration = time.Millisecond
+	sw.configPauseDuration = time.Millisecond
+	var commands []string
+	teams := [6]*model.Team{nil, nil, nil, nil, {Id: 254}, nil}
+
+	// Should reconfigure if the first read-back doesn't match, and succeed if the second one does.
+	staleConfig := readSwitchTestData(t, "switch_running_config_0_teams.txt")
+	goodConfig := readSwitchTestData(t, "switch_running_config_1_team.txt")
+	mockTelnet(t, sw.port, &commands, "", "", staleConfig, "", "", goodConfig)
+	assert.Nil(t, sw.ConfigureTeamEthernet(teams))
+	assert.Equal(t, 6, len(commands))
+	assert.Equal(t, "ACTIVE", sw.Status)
+
+	// Should give up and return a detailed error if the configuration never takes effect.
+	sw.port += 1
+	mockTelnet(t, sw.port, &commands, "", "", staleConfig, "", "", staleConfig)
+	err := sw.ConfigureTeamEthernet(teams)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), `VLAN 50 for team 254 has IP address ""; expected "10.2.54.4"`)
+		assert.Contains(t, err.Error(), `VLAN 50 for team 254 has DHCP pool network ""; expected "10.2.54.0"`)
+		assert.Contains(
+			t,
+			err.Error(),
+			`VLAN 50 for team 254 is missing access list entry "permit ip 10.2.54.0 0.0.0.255 host 10.0.100.5"`,
+		)
+	}
+	assert.Equal(t, 6, len(commands))
+	assert.Equal(t, "ERROR", sw.Status)
+
+	// Should report leftover configuration for stations that should be empty.
+	sw.port += 1
+	mockTelnet(t, sw.port, &commands, "", goodConfig, "", goodConfig)
+	err = sw.ConfigureTeamEthernet([6]*model.Team{})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "VLAN 50 should be empty but has IP address 10.2.54.4")
+		assert.Contains(t, err.Error(), "VLAN 50 should be empty but has DHCP pool 10.2.54.0")
+		assert.Contains(t, err.Error(), "VLAN 50 should be empty but has access list 150")
+	}
+	assert.Equal(t, "ERROR", sw.Status)
+}
+
+func TestParseSwitchRunningConfig(t *testing.T) {
+	vlanConfigs := parseSwitchRunningConfig(readSwitchTestData(t, "switch_running_config_6_teams.txt"))
+	if assert.Contains(t, vlanConfigs, 20) {
+		assert.Equal(t, "10.2.54.4", vlanConfigs[20].interfaceIpAddress)
+		assert.Equal(t, "10.2.54.0", vlanConfigs[20].dhcpPoolNetwork)
+		assert.Equal(t, "10.2.54.4", vlanConfigs[20].dhcpDefaultRouter)
+		assert.Equal(
+			t,
+			[]string{
+				"permit ip 10.2.54.0 0.0.0.255 host 10.0.100.5",
+				"permit udp any eq bootpc any eq bootps",
+				"permit icmp any any",
+			},
+			vlanConfigs[20].accessListEntries,
+		)
+		assert.Equal(t, []string{"GigabitEthernet0/13"}, vlanConfigs[20].accessPorts)
+	}
+	if assert.Contains(t, vlanConfigs, 100) {
+		assert.Equal(t, "10.0.100.2", vlanConfigs[100].interfaceIpAddress)
+	}
+	assert.Equal(
+		t,
+		map[int]string{
+			10: "GigabitEthernet0/11",
+			20: "GigabitEthernet0/13",
+			30: "GigabitEthernet0/15",
+			40: "GigabitEthernet0/12",
+			50: "GigabitEthernet0/14",
+			60: "GigabitEthernet0/16",
+		},
+		getTeamVlanPorts(vlanConfigs),
+	)
+
+	vlanConfigs = parseSwitchRunningConfig(readSwitchTestData(t, "switch_running_config_0_teams.txt"))
+	if assert.Contains(t, vlanConfigs, 10) {
+		assert.Equal(t, switchVlanConfig{}, *vlanConfigs[10])
+	}
+}
+
+func TestParseSwitchDhcpBindings(t *testing.T) {
+	bindings := parseSwitchDhcpBindings(readSwitchTestData(t, "switch_running_config_1_team.txt"))
+	assert.Equal(
+		t,
+		[]DhcpBinding{
+			{"10.0.100.126", "00:1b:21:3a:4f:5c"},
+			{"10.2.54.20", "00:80:23:5c:11:02"},
+			{"10.2.54.21", "00:80:23:5c:11:a7"},
+		},
+		bindings,
 	)
+	assert.Empty(t, parseSwitchDhcpBindings("ChezySwitch#show ip dhcp binding\nIP address  Client-ID/\n"))
 }
 
-func mockTelnet(t *testing.T, port int, command1 *string, command2 *string) {
+func readSwitchTestData(t *testing.T, filename string) string {
+	data, err := os.ReadFile("testdata/" + filename)
+	assert.Nil(t, err)
+	return string(data)
+}
+
+// Starts a fake Telnet server that accepts one connection for each of the given responses, recording the commands
+// received on each connection and replying with the corresponding response.
+func mockTelnet(t *testing.T, port int, commands *[]string, responses ...string) {
+	*commands = []string{}
 	go func() {
 		ln, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 		assert.Nil(t, err)
 		defer ln.Close()
-		*command1 = ""
-		*command2 = ""
 
-		// Fake the first connection.
-		conn1, err := ln.Accept()
-		assert.Nil(t, err)
-		conn1.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
-		var reader bytes.Buffer
-		reader.ReadFrom(conn1)
-		*command1 = reader.String()
-		conn1.Close()
-
-		// Fake the second connection.
-		conn2, err := ln.Accept()
-		assert.Nil(t, err)
-		conn2.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
-		reader.Reset()
-		reader.ReadFrom(conn2)
-		*command2 = reader.String()
-		conn2.Close()
+		for _, response := range responses {
+			conn, err := ln.Accept()
+			assert.Nil(t, err)
+			conn.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+			var reader bytes.Buffer
+			reader.ReadFrom(conn)
+			*commands = append(*commands, reader.String())
+			conn.Write([]byte(response))
+			conn.Close()
+		}
 	}()
 	time.Sleep(100 * time.Millisecond) // Give it some time to open the socket.
 }
@@ -16,8 +16,11 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"io"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"regexp"
 	"strconv"
+	"time"
 )
 
 const (
@@ -289,6 +292,55 @@ func (client *TbaClient) DownloadTeamAvatar(teamNumber, year int) error {
 	return fmt.Errorf("No avatar found for team %d in year %d.", teamNumber, year)
 }
 
+// Fills in the given team's details, recent awards, and avatar from TBA, leaving them blank if the team isn't found.
+func (client *TbaClient) PopulateTeamInfo(team *model.Team) error {
+	tbaTeam, err := client.GetTeam(team.Id)
+	if err != nil {
+		return err
+	}
+
+	// Check if the result is valid. If a team is not found, it will just not have its detail fields filled out.
+	if tbaTeam.TeamNumber == 0 {
+		return nil
+	}
+
+	team.Name = tbaTeam.Name
+	team.Nickname = tbaTeam.Nickname
+	team.City = tbaTeam.City
+	team.StateProv = tbaTeam.StateProv
+	team.Country = tbaTeam.Country
+	schoolNameRe := regexp.MustCompile("^.*\\S&(\\S.*?$)")
+	matches := schoolNameRe.FindStringSubmatch(tbaTeam.Name)
+	if len(matches) > 0 {
+		team.SchoolName = matches[1]
+	}
+	team.RookieYear = tbaTeam.RookieYear
+	team.RobotName, err = client.GetRobotName(team.Id, time.Now().Year())
+	if err != nil {
+		return err
+	}
+
+	// Generate string of recent awards in reverse chronological order.
+	recentAwards, err := client.GetTeamAwards(team.Id)
+	if err != nil {
+		return err
+	}
+	var accomplishmentsBuffer bytes.Buffer
+	for i := len(recentAwards) - 1; i >= 0; i-- {
+		award := recentAwards[i]
+		if time.Now().Year()-award.Year <= 1 {
+			accomplishmentsBuffer.WriteString(fmt.Sprintf("<p>%d %s - %s</p>", award.Year, award.EventName,
+				award.Name))
+		}
+	}
+	team.Accomplishments = accomplishmentsBuffer.String()
+
+	// Download and store the team's avatar; if there isn't one, ignore the error.
+	client.DownloadTeamAvatar(team.Id, time.Now().Year())
+
+	return nil
+}
+
 // Uploads the event team list to The Blue Alliance.
 func (client *TbaClient) PublishTeams(database *model.Database) error {
 	teams, err := database.GetAllTeams()
@@ -315,6 +367,7 @@ func (client *TbaClient) PublishTeams(database *model.Database) error {
 		body, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("Got status code %d from TBA: %s", resp.StatusCode, body)
 	}
+	recordTbaPublish(database, model.TbaPublishTeams)
 	return nil
 }
 
@@ -388,6 +441,7 @@ func (client *TbaClient) PublishMatches(database *model.Database) error {
 		body, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("Got status code %d from TBA: %s", resp.StatusCode, body)
 	}
+	recordTbaPublish(database, model.TbaPublishMatches)
 	return nil
 }
 
@@ -431,6 +485,7 @@ func (client *TbaClient) PublishRankings(database *model.Database) error {
 		body, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("Got status code %d from TBA: %s", resp.StatusCode, body)
 	}
+	recordTbaPublish(database, model.TbaPublishRankings)
 	return nil
 }
 
@@ -482,6 +537,7 @@ func (client *TbaClient) PublishAlliances(database *model.Database) error {
 		return fmt.Errorf("Got status code %d from TBA: %s", resp.StatusCode, body)
 	}
 
+	recordTbaPublish(database, model.TbaPublishAlliances)
 	return nil
 }
 
@@ -513,9 +569,18 @@ func (client *TbaClient) PublishAwards(database *model.Database) error {
 		body, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("Got status code %d from TBA: %s", resp.StatusCode, body)
 	}
+	recordTbaPublish(database, model.TbaPublishAwards)
 	return nil
 }
 
+// Records the time of a successful publish. A failure to record it is only logged, since the data has already been
+// published and reporting an error would prompt the operator to needlessly publish it again.
+func recordTbaPublish(database *model.Database, kind string) {
+	if err := database.RecordTbaPublish(kind); err != nil {
+		log.Printf("Failed to record the publishing of %s to TBA: %v", kind, err)
+	}
+}
+
 // Clears out the existing match data on The Blue Alliance for the event.
 func (client *TbaClient) DeletePublishedMatches() error {
 	resp, err := client.postRequest("matches", "delete_all", []byte(client.eventCode))
@@ -36,6 +36,16 @@ func TestPublishTeams(t *testing.T) {
 	client := NewTbaClient("my_event_code", "my_secret_id", "my_secret")
 	client.BaseUrl = tbaServer.URL
 
+	publishRecord, _ := database.GetTbaPublishRecord(model.TbaPublishTeams)
+	assert.Nil(t, publishRecord)
+	assert.Nil(t, client.PublishTeams(database))
+	publishRecord, _ = database.GetTbaPublishRecord(model.TbaPublishTeams)
+	assert.NotNil(t, publishRecord)
+
+	// Check that a failure to record the publish doesn't turn a successful publish into an error.
+	tbaServer.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		database.Close()
+	})
 	assert.Nil(t, client.PublishTeams(database))
 }
 
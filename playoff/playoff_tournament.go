@@ -87,6 +87,17 @@ func (tournament *PlayoffTournament) FinalistAllianceId() int {
 	return tournament.finalMatchup.LosingAllianceId()
 }
 
+// FieldTimeoutBefore returns the description of the field timeout that is scheduled immediately before the match with
+// the given order, and false if there isn't one.
+func (tournament *PlayoffTournament) FieldTimeoutBefore(typeOrder int) (string, bool) {
+	for _, breakSpec := range tournament.breakSpecs {
+		if breakSpec.orderBefore == typeOrder {
+			return breakSpec.description, true
+		}
+	}
+	return "", false
+}
+
 // Traverse calls the given function on each match group in the tournament, in reverse round order of play.
 func (tournament *PlayoffTournament) Traverse(visitFunction func(MatchGroup) error) error {
 	return tournament.finalMatchup.traverse(visitFunction)
@@ -37,6 +37,19 @@ func TestPlayoffTournamentGetters(t *testing.T) {
 	assert.Equal(t, 1, playoffTournament.FinalistAllianceId())
 }
 
+func TestPlayoffTournamentFieldTimeoutBefore(t *testing.T) {
+	playoffTournament, err := NewPlayoffTournament(model.DoubleEliminationPlayoff, 8)
+	assert.Nil(t, err)
+	description, ok := playoffTournament.FieldTimeoutBefore(11)
+	assert.True(t, ok)
+	assert.Equal(t, "Field Break", description)
+	description, ok = playoffTournament.FieldTimeoutBefore(13)
+	assert.True(t, ok)
+	assert.Equal(t, "Awards Break", description)
+	_, ok = playoffTournament.FieldTimeoutBefore(12)
+	assert.False(t, ok)
+}
+
 func TestPlayoffTournamentCreateMatchesAndBreaks(t *testing.T) {
 	database := setupTestDb(t)
 	tournament.CreateTestAlliances(database, 8)
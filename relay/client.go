@@ -0,0 +1,257 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Venue side of the display relay, which dials out to the relay and serves its requests from the local web server.
+
+package relay
+
+import (
+	"fmt"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// How often the client retries a failed connection and checks for configuration changes.
+const clientRetryPeriod = 5 * time.Second
+
+// The settings governing whether and where the venue server connects to a relay.
+type ClientConfig struct {
+	Enabled bool
+	Url     string // Base URL of the relay, e.g. "wss://relay.example.com".
+	Key     string
+}
+
+type Client struct {
+	localAddress string
+	configFunc   func() ClientConfig
+	httpClient   *http.Client
+	retryPeriod  time.Duration
+	stopped      chan struct{}
+	stopOnce     sync.Once
+	connected    bool
+	mutex        sync.Mutex
+}
+
+// Represents a single connection to the relay and the local websocket streams opened through it.
+type clientSession struct {
+	client     *Client
+	conn       *gorillawebsocket.Conn
+	writeMutex sync.Mutex
+	streams    map[int]*gorillawebsocket.Conn
+	mutex      sync.Mutex
+}
+
+// Creates a client that serves relayed requests from the web server at the given local address, using the
+// configuration returned by the given function, which is checked periodically for changes.
+func NewClient(localAddress string, configFunc func() ClientConfig) *Client {
+	return &Client{
+		localAddress: localAddress,
+		configFunc:   configFunc,
+		httpClient: &http.Client{
+			// Pass redirects through to the public client rather than following them.
+			CheckRedirect: func(req *http.Request, via []*http.Request) error { return http.ErrUseLastResponse },
+			Timeout:       requestTimeout,
+		},
+		retryPeriod: clientRetryPeriod,
+		stopped:     make(chan struct{}),
+	}
+}
+
+// Loops to maintain the connection to the relay whenever it is enabled, until the client is stopped.
+func (client *Client) Run() {
+	for {
+		config := client.configFunc()
+		if config.Enabled && config.Url != "" {
+			if err := client.runSession(config); err != nil {
+				log.Printf("Relay connection error: %v", err)
+			}
+		}
+		select {
+		case <-time.After(client.retryPeriod):
+		case <-client.stopped:
+			return
+		}
+	}
+}
+
+// Drops any connection to the relay and stops the client from reconnecting.
+func (client *Client) Stop() {
+	client.stopOnce.Do(func() {
+		close(client.stopped)
+	})
+}
+
+// Returns true if the client is currently connected to the relay.
+func (client *Client) IsConnected() bool {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return client.connected
+}
+
+// Connects to the relay and serves its requests until the connection is lost or the configuration changes.
+func (client *Client) runSession(config ClientConfig) error {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+config.Key)
+	conn, resp, err := gorillawebsocket.DefaultDialer.Dial(strings.TrimSuffix(config.Url, "/")+ConnectPath, header)
+	if err != nil {
+		if resp != nil {
+			return fmt.Errorf("relay rejected connection with status %d", resp.StatusCode)
+		}
+		return err
+	}
+	session := &clientSession{client: client, conn: conn, streams: make(map[int]*gorillawebsocket.Conn)}
+	defer session.close()
+	log.Printf("Connected to relay at %s.", config.Url)
+	client.setConnected(true)
+	defer client.setConnected(false)
+
+	// Drop the connection if the relay is disabled or reconfigured, or if the client is stopped.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(client.retryPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if client.configFunc() != config {
+					_ = conn.Close()
+					return
+				}
+			case <-client.stopped:
+				_ = conn.Close()
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		var message frame
+		if err = conn.ReadJSON(&message); err != nil {
+			return err
+		}
+		switch message.Type {
+		case requestFrame:
+			go session.handleRequest(message)
+		case openFrame:
+			go session.handleOpen(message)
+		case closeFrame:
+			session.closeStream(message.Id)
+		default:
+			log.Printf("Unexpected frame type from relay: %q", message.Type)
+		}
+	}
+}
+
+func (client *Client) setConnected(connected bool) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.connected = connected
+}
+
+// Performs the given request against the local web server and sends the response back to the relay.
+func (session *clientSession) handleRequest(request frame) {
+	response := frame{Type: responseFrame, Id: request.Id}
+	if !isPublicPath(request.Path) || isStreamPath(request.Path) {
+		response.Status = http.StatusForbidden
+	} else if status, header, body, err := session.get(request); err != nil {
+		log.Printf("Error serving relayed request for %s: %v", request.Path, err)
+		response.Status = http.StatusBadGateway
+	} else {
+		response.Status, response.Header, response.Body = status, header, body
+	}
+	_ = session.write(response)
+}
+
+func (session *clientSession) get(request frame) (int, http.Header, []byte, error) {
+	req, err := http.NewRequest("GET", "http://"+session.client.localAddress+relayDisplayPath(request.Path), nil)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	req.Header = relayedHeader(request.Header)
+	resp, err := session.client.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodyBytes))
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	return resp.StatusCode, resp.Header, body, nil
+}
+
+// Opens the given websocket endpoint on the local web server and passes its messages on to the relay until either end
+// closes the stream.
+func (session *clientSession) handleOpen(request frame) {
+	if !isPublicPath(request.Path) || !isStreamPath(request.Path) {
+		_ = session.write(frame{Type: closeFrame, Id: request.Id})
+		return
+	}
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(
+		"ws://"+session.client.localAddress+relayDisplayPath(request.Path), relayedHeader(request.Header),
+	)
+	if err != nil {
+		log.Printf("Error opening relayed stream for %s: %v", request.Path, err)
+		_ = session.write(frame{Type: closeFrame, Id: request.Id})
+		return
+	}
+	session.mutex.Lock()
+	session.streams[request.Id] = conn
+	session.mutex.Unlock()
+	defer session.closeStream(request.Id)
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			_ = session.write(frame{Type: closeFrame, Id: request.Id})
+			return
+		}
+		if err = session.write(frame{Type: messageFrame, Id: request.Id, Body: data}); err != nil {
+			return
+		}
+	}
+}
+
+// Closes the local end of the given stream, if it is open.
+func (session *clientSession) closeStream(id int) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	if conn, ok := session.streams[id]; ok {
+		_ = conn.Close()
+		delete(session.streams, id)
+	}
+}
+
+func (session *clientSession) write(message frame) error {
+	session.writeMutex.Lock()
+	defer session.writeMutex.Unlock()
+	return session.conn.WriteJSON(message)
+}
+
+// Closes the connection to the relay along with all of the local streams opened through it.
+func (session *clientSession) close() {
+	_ = session.conn.Close()
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	for id, conn := range session.streams {
+		_ = conn.Close()
+		delete(session.streams, id)
+	}
+}
+
+// Returns a copy of the given header from the relay, marked so that the local web server can tell it was relayed.
+func relayedHeader(header http.Header) http.Header {
+	relayed := header.Clone()
+	if relayed == nil {
+		relayed = make(http.Header)
+	}
+	relayed.Set(RelayedRequestHeader, "true")
+	return relayed
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Message format and access rules shared by both ends of the tunnel between a venue server and a public relay.
+
+package relay
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const (
+	// Path on the relay to which the venue server connects to establish the tunnel.
+	ConnectPath = "/relay/connect"
+
+	requestFrame  frameType = "request"  // Relay to venue: perform an HTTP GET.
+	responseFrame frameType = "response" // Venue to relay: the result of an HTTP GET.
+	openFrame     frameType = "open"     // Relay to venue: open a websocket stream.
+	messageFrame  frameType = "message"  // Venue to relay: a message on an open websocket stream.
+	closeFrame    frameType = "close"    // Either direction: a websocket stream has ended.
+
+	// Header with which the venue end of the tunnel marks the requests it makes to the local web server.
+	RelayedRequestHeader = "X-Cheesy-Arena-Relay"
+
+	// Prefix given to the IDs of displays opened through the relay, which keeps them apart from the venue's own.
+	RelayDisplayIdPrefix = "relay-"
+
+	maxResponseBodyBytes = 32 << 20
+)
+
+type frameType string
+
+// Represents a single message on the tunnel, which multiplexes HTTP requests and websocket streams by ID.
+type frame struct {
+	Type   frameType   `json:"type"`
+	Id     int         `json:"id"`
+	Path   string      `json:"path,omitempty"` // Includes the query string.
+	Header http.Header `json:"header,omitempty"`
+	Status int         `json:"status,omitempty"`
+	Body   []byte      `json:"body,omitempty"` // The response body, or the contents of a stream message.
+}
+
+// Displays that may be served to the public, along with any sub-paths they load besides their websocket. The pit
+// display is excluded since it shows inspection details, as is the field monitor since it has an FTA mode.
+var publicDisplays = map[string][]string{
+	"alliance_station": nil,
+	"announcer":        {"match_load", "score_posted"},
+	"audience":         nil,
+	"bracket":          nil,
+	"logo":             nil,
+	"queueing":         {"match_load"},
+	"rankings":         nil,
+	"team_signs":       nil,
+	"twitch":           nil,
+	"wall":             nil,
+	"webpage":          nil,
+}
+
+// Returns true if the given path is one of the read-only pages or endpoints that may be served to the public. It is
+// checked on both ends so that a compromised relay can't reach the venue server's control pages.
+func isPublicPath(path string) bool {
+	path, _, _ = strings.Cut(path, "?")
+	if strings.Contains(path, "..") {
+		return false
+	}
+	if displayPath, ok := strings.CutPrefix(path, "/displays/"); ok {
+		display, subPath, _ := strings.Cut(displayPath, "/")
+		subPaths, ok := publicDisplays[display]
+		return ok && (subPath == "" || subPath == "websocket" || slices.Contains(subPaths, subPath))
+	}
+	return path == "/display" || path == "/display/websocket" || strings.HasPrefix(path, "/api/") ||
+		strings.HasPrefix(path, "/static/")
+}
+
+// Returns true if the given path is a websocket endpoint that should be relayed as a stream rather than a request.
+func isStreamPath(path string) bool {
+	path, _, _ = strings.Cut(path, "?")
+	return strings.HasSuffix(path, "/websocket")
+}
+
+// Returns true if the given request was made on behalf of the relay rather than by a client at the venue.
+func IsRelayedRequest(r *http.Request) bool {
+	return r.Header.Get(RelayedRequestHeader) != ""
+}
+
+// Rewrites any display ID in the given path into the relay's namespace, so that a public viewer can't take over the
+// configuration of one of the venue's own displays by reusing its ID.
+func relayDisplayPath(path string) string {
+	path, rawQuery, found := strings.Cut(path, "?")
+	if !found {
+		return path
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return path
+	}
+	if displayId := query.Get("displayId"); displayId != "" && !strings.HasPrefix(displayId, RelayDisplayIdPrefix) {
+		query.Set("displayId", RelayDisplayIdPrefix+displayId)
+	}
+	return path + "?" + query.Encode()
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package relay
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsPublicPath(t *testing.T) {
+	assert.True(t, isPublicPath("/display"))
+	assert.True(t, isPublicPath("/display/websocket?displayId=100"))
+	assert.True(t, isPublicPath("/displays/audience?displayId=100&background=%23000"))
+	assert.True(t, isPublicPath("/displays/audience/websocket?displayId=100"))
+	assert.True(t, isPublicPath("/displays/announcer/score_posted"))
+	assert.True(t, isPublicPath("/api/rankings"))
+	assert.True(t, isPublicPath("/static/js/audience_display.js"))
+
+	assert.False(t, isPublicPath("/"))
+	assert.False(t, isPublicPath("/match_play"))
+	assert.False(t, isPublicPath("/setup/settings"))
+	assert.False(t, isPublicPath("/setup/displays/websocket"))
+	assert.False(t, isPublicPath("/panels/referee?display=/displays/"))
+	assert.False(t, isPublicPath("/displays/../setup/settings"))
+	assert.False(t, isPublicPath("/displays/pit?displayId=100"))
+	assert.False(t, isPublicPath("/displays/pit/websocket"))
+	assert.False(t, isPublicPath("/displays/field_monitor?fta=true"))
+	assert.False(t, isPublicPath("/displays/audience/match_load"))
+	assert.False(t, isPublicPath("/displays/unknown"))
+
+	assert.True(t, isStreamPath("/displays/audience/websocket?displayId=100"))
+	assert.False(t, isStreamPath("/displays/audience?websocket"))
+}
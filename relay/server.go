@@ -0,0 +1,361 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Public side of the display relay, which re-serves the read-only displays of a venue server that has dialed in.
+
+package relay
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"github.com/Team254/cheesy-arena/websocket"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	viewerKeyParam       = "key"
+	viewerKeyCookie      = "relay_viewer_key"
+	requestTimeout       = 30 * time.Second
+	streamBufferSize     = 64
+	venueConnectionError = "The venue server is not connected to the relay."
+)
+
+// Headers of the venue's responses that aren't passed on to the public.
+var strippedResponseHeaders = []string{"Connection", "Content-Length", "Set-Cookie", "Transfer-Encoding"}
+
+type Server struct {
+	relayKey  string
+	viewerKey string
+	upgrader  gorillawebsocket.Upgrader
+	venue     *venueConnection
+	mutex     sync.Mutex
+}
+
+// Represents the tunnel from the currently connected venue server.
+type venueConnection struct {
+	conn       *gorillawebsocket.Conn
+	writeMutex sync.Mutex
+	nextId     int
+	responses  map[int]chan frame
+	streams    map[int]chan []byte
+	mutex      sync.Mutex
+}
+
+// Creates a relay that accepts a venue server presenting the given key, and serves its displays to viewers presenting
+// the given viewer key, or to anyone if it is blank.
+func NewServer(relayKey, viewerKey string) *Server {
+	return &Server{
+		relayKey:  relayKey,
+		viewerKey: viewerKey,
+		upgrader:  gorillawebsocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 1024},
+	}
+}
+
+// Returns the handler serving the relay's public pages and the endpoint through which the venue server connects.
+func (server *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+ConnectPath, server.connectHandler)
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/display", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("GET /display", server.requestHandler)
+	mux.HandleFunc("GET /display/websocket", server.streamHandler)
+	mux.HandleFunc("GET /displays/", server.requestHandler)
+	mux.HandleFunc("GET /displays/{display}/websocket", server.streamHandler)
+	mux.HandleFunc("GET /api/", server.requestHandler)
+	mux.HandleFunc("GET /api/arena/websocket", server.streamHandler)
+	mux.HandleFunc("GET /static/", server.requestHandler)
+	return websocket.NewFallbackHandler(mux)
+}
+
+// Returns true if a venue server is currently connected.
+func (server *Server) IsVenueConnected() bool {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.venue != nil
+}
+
+// Accepts the tunnel connection from the venue server, replacing any existing one.
+func (server *Server) connectHandler(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if server.relayKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(server.relayKey)) != 1 {
+		http.Error(w, "Invalid relay key", http.StatusUnauthorized)
+		return
+	}
+	conn, err := server.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Error accepting venue connection: %v", err)
+		return
+	}
+
+	venue := &venueConnection{conn: conn, responses: make(map[int]chan frame), streams: make(map[int]chan []byte)}
+	server.mutex.Lock()
+	previousVenue := server.venue
+	server.venue = venue
+	server.mutex.Unlock()
+	if previousVenue != nil {
+		_ = previousVenue.conn.Close()
+	}
+	log.Printf("Venue server connected to relay from %s.", r.RemoteAddr)
+
+	venue.readFrames()
+
+	server.mutex.Lock()
+	if server.venue == venue {
+		server.venue = nil
+	}
+	server.mutex.Unlock()
+	log.Printf("Venue server disconnected from relay.")
+}
+
+// Forwards a public HTTP request to the venue server and relays the response.
+func (server *Server) requestHandler(w http.ResponseWriter, r *http.Request) {
+	path, ok := server.authorizeViewer(w, r)
+	if !ok {
+		return
+	}
+	venue := server.getVenue()
+	if venue == nil {
+		http.Error(w, venueConnectionError, http.StatusServiceUnavailable)
+		return
+	}
+
+	id, responses := venue.register()
+	defer venue.unregister(id)
+	if err := venue.write(frame{Type: requestFrame, Id: id, Path: path, Header: forwardedHeader(r)}); err != nil {
+		http.Error(w, venueConnectionError, http.StatusServiceUnavailable)
+		return
+	}
+	select {
+	case response, ok := <-responses:
+		if !ok {
+			http.Error(w, venueConnectionError, http.StatusServiceUnavailable)
+			return
+		}
+		for _, key := range strippedResponseHeaders {
+			response.Header.Del(key)
+		}
+		for key, values := range response.Header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(response.Status)
+		_, _ = w.Write(response.Body)
+	case <-time.After(requestTimeout):
+		http.Error(w, "Timed out waiting for the venue server.", http.StatusGatewayTimeout)
+	}
+}
+
+// Relays the messages of a venue websocket endpoint to a public client. Messages from the client are discarded since
+// the relayed displays are read-only.
+func (server *Server) streamHandler(w http.ResponseWriter, r *http.Request) {
+	path, ok := server.authorizeViewer(w, r)
+	if !ok {
+		return
+	}
+	venue := server.getVenue()
+	if venue == nil {
+		http.Error(w, venueConnectionError, http.StatusServiceUnavailable)
+		return
+	}
+
+	ws, err := websocket.NewWebsocket(w, r)
+	if err != nil {
+		log.Printf("Error accepting relayed websocket: %v", err)
+		return
+	}
+	defer ws.Close()
+
+	id, messages := venue.openStream()
+	defer venue.closeStream(id)
+	if err = venue.write(frame{Type: openFrame, Id: id, Path: path, Header: forwardedHeader(r)}); err != nil {
+		return
+	}
+
+	go func() {
+		for {
+			if _, _, err := ws.Read(); err != nil {
+				// The client has gone away; end the stream.
+				venue.endStream(id)
+				return
+			}
+		}
+	}()
+
+	for data := range messages {
+		var message websocket.Message
+		if err = json.Unmarshal(data, &message); err != nil {
+			log.Printf("Invalid relayed message: %v", err)
+			continue
+		}
+		if err = ws.WriteMessage(message); err != nil {
+			return
+		}
+	}
+}
+
+// Checks that the request is for a public path and carries the viewer key, if one is required, and returns the path and
+// query string to request from the venue server with the key removed. Writes an error response and returns false if
+// the request is not allowed.
+func (server *Server) authorizeViewer(w http.ResponseWriter, r *http.Request) (string, bool) {
+	query := r.URL.Query()
+	key := query.Get(viewerKeyParam)
+	query.Del(viewerKeyParam)
+	path := r.URL.Path
+	if encodedQuery := query.Encode(); encodedQuery != "" {
+		path += "?" + encodedQuery
+	}
+
+	if !isPublicPath(r.URL.Path) {
+		http.NotFound(w, r)
+		return "", false
+	}
+	if server.viewerKey == "" || strings.HasPrefix(r.URL.Path, "/static/") {
+		return path, true
+	}
+	if key == "" {
+		if cookie, err := r.Cookie(viewerKeyCookie); err == nil {
+			key = cookie.Value
+		}
+	} else {
+		// Remember the key so that it needn't be present on the requests that the page makes.
+		http.SetCookie(w, &http.Cookie{Name: viewerKeyCookie, Value: key, Path: "/", HttpOnly: true})
+	}
+	if subtle.ConstantTimeCompare([]byte(key), []byte(server.viewerKey)) != 1 {
+		http.Error(w, "A valid viewer key is required to access this relay.", http.StatusUnauthorized)
+		return "", false
+	}
+	return path, true
+}
+
+func (server *Server) getVenue() *venueConnection {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.venue
+}
+
+// Returns the headers to pass on to the venue server for the given public request.
+func forwardedHeader(r *http.Request) http.Header {
+	header := make(http.Header)
+	// Always use the address of the connection itself so that a viewer can't impersonate a venue address.
+	ipAddress, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ipAddress = r.RemoteAddr
+	}
+	header.Set("X-Real-IP", ipAddress)
+	if userAgent := r.Header.Get("User-Agent"); userAgent != "" {
+		header.Set("User-Agent", userAgent)
+	}
+	return header
+}
+
+// Loops to dispatch the frames sent by the venue server until the connection is closed, then ends all outstanding
+// requests and streams.
+func (venue *venueConnection) readFrames() {
+	defer func() {
+		_ = venue.conn.Close()
+		venue.mutex.Lock()
+		defer venue.mutex.Unlock()
+		for id, responses := range venue.responses {
+			close(responses)
+			delete(venue.responses, id)
+		}
+		for id, messages := range venue.streams {
+			close(messages)
+			delete(venue.streams, id)
+		}
+	}()
+
+	for {
+		var message frame
+		if err := venue.conn.ReadJSON(&message); err != nil {
+			if !gorillawebsocket.IsCloseError(err, gorillawebsocket.CloseNormalClosure) && err != io.EOF {
+				log.Printf("Error reading from venue connection: %v", err)
+			}
+			return
+		}
+
+		venue.mutex.Lock()
+		switch message.Type {
+		case responseFrame:
+			if responses, ok := venue.responses[message.Id]; ok {
+				responses <- message
+			}
+		case messageFrame:
+			if messages, ok := venue.streams[message.Id]; ok {
+				select {
+				case messages <- message.Body:
+				default:
+					// The client isn't keeping up; it will detect the gap in sequence numbers and resync.
+				}
+			}
+		case closeFrame:
+			if messages, ok := venue.streams[message.Id]; ok {
+				close(messages)
+				delete(venue.streams, message.Id)
+			}
+		default:
+			log.Printf("Unexpected frame type from venue server: %q", message.Type)
+		}
+		venue.mutex.Unlock()
+	}
+}
+
+func (venue *venueConnection) write(message frame) error {
+	venue.writeMutex.Lock()
+	defer venue.writeMutex.Unlock()
+	if err := venue.conn.WriteJSON(message); err != nil {
+		return fmt.Errorf("error writing to venue connection: %v", err)
+	}
+	return nil
+}
+
+// Allocates an ID for a request and returns it along with the channel on which its response will be delivered.
+func (venue *venueConnection) register() (int, chan frame) {
+	venue.mutex.Lock()
+	defer venue.mutex.Unlock()
+	venue.nextId++
+	responses := make(chan frame, 1)
+	venue.responses[venue.nextId] = responses
+	return venue.nextId, responses
+}
+
+func (venue *venueConnection) unregister(id int) {
+	venue.mutex.Lock()
+	defer venue.mutex.Unlock()
+	delete(venue.responses, id)
+}
+
+// Allocates an ID for a stream and returns it along with the channel on which its messages will be delivered. The
+// channel is closed when the stream ends.
+func (venue *venueConnection) openStream() (int, chan []byte) {
+	venue.mutex.Lock()
+	defer venue.mutex.Unlock()
+	venue.nextId++
+	messages := make(chan []byte, streamBufferSize)
+	venue.streams[venue.nextId] = messages
+	return venue.nextId, messages
+}
+
+// Ends the given stream from the relay side, if it hasn't already ended.
+func (venue *venueConnection) endStream(id int) {
+	venue.mutex.Lock()
+	messages, ok := venue.streams[id]
+	if ok {
+		close(messages)
+		delete(venue.streams, id)
+	}
+	venue.mutex.Unlock()
+}
+
+// Ends the given stream and tells the venue server to close its end.
+func (venue *venueConnection) closeStream(id int) {
+	venue.endStream(id)
+	_ = venue.write(frame{Type: closeFrame, Id: id})
+}
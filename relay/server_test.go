@@ -0,0 +1,239 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package relay
+
+import (
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/websocket"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Starts a fake venue server with a display page, a display websocket, and a control page that must not be relayed.
+func startTestVenue(t *testing.T, notifier *websocket.Notifier) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /displays/audience", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("displayId") == "" {
+			http.Redirect(w, r, "/displays/audience?displayId=100", 302)
+			return
+		}
+		w.Header().Set("Set-Cookie", "secret=1")
+		w.Write([]byte("audience " + r.URL.RawQuery + " " + r.Header.Get("X-Real-IP")))
+	})
+	mux.HandleFunc("GET /displays/audience/websocket", func(w http.ResponseWriter, r *http.Request) {
+		ws, err := websocket.NewWebsocket(w, r)
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer ws.Close()
+		go ws.HandleNotifiers(notifier)
+		for {
+			messageType, _, err := ws.Read()
+			if err != nil {
+				return
+			}
+			assert.Fail(t, "Venue should not receive messages from relayed clients", messageType)
+		}
+	})
+	mux.HandleFunc("GET /setup/settings", func(w http.ResponseWriter, r *http.Request) {
+		assert.Fail(t, "Control pages should not be reachable through the relay")
+	})
+	return httptest.NewServer(mux)
+}
+
+// Connects a relay client from the given venue to the given relay and waits for the connection to be established. The
+// caller is responsible for stopping the returned client.
+func connectTestClient(t *testing.T, venue, relay *httptest.Server, relayServer *Server, key string) *Client {
+	config := ClientConfig{Enabled: true, Url: "ws" + strings.TrimPrefix(relay.URL, "http"), Key: key}
+	client := NewClient(strings.TrimPrefix(venue.URL, "http://"), func() ClientConfig { return config })
+	client.retryPeriod = 10 * time.Millisecond
+	go client.Run()
+	assert.Eventually(t, relayServer.IsVenueConnected, time.Second, time.Millisecond)
+	assert.Eventually(t, client.IsConnected, time.Second, time.Millisecond)
+	return client
+}
+
+func getBody(t *testing.T, client *http.Client, url string) (int, string) {
+	resp, err := client.Get(url)
+	if !assert.Nil(t, err) {
+		return 0, ""
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestRelay(t *testing.T) {
+	notifier := websocket.NewNotifier("matchTime", func() any { return "bootstrap" })
+	venue := startTestVenue(t, notifier)
+	defer venue.Close()
+	relayServer := NewServer("relaykey", "")
+	relay := httptest.NewServer(relayServer.Handler())
+	defer relay.Close()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+
+	// Requests should fail cleanly while no venue is connected.
+	status, _ := getBody(t, client, relay.URL+"/displays/audience?displayId=100")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	venueClient := connectTestClient(t, venue, relay, relayServer, "relaykey")
+	defer venueClient.Stop()
+
+	// Pages and redirects should be relayed, with the client's address passed along.
+	resp, err := client.Get(relay.URL + "/displays/audience?displayId=100")
+	if assert.Nil(t, err) {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "audience displayId=relay-100 127.0.0.1", string(body))
+		assert.Empty(t, resp.Header.Get("Set-Cookie"))
+	}
+	resp, err = client.Get(relay.URL + "/displays/audience")
+	if assert.Nil(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, 302, resp.StatusCode)
+		assert.Equal(t, "/displays/audience?displayId=100", resp.Header.Get("Location"))
+	}
+	// A client-supplied address should be ignored, and pages outside the public displays should not be relayed.
+	req, _ := http.NewRequest("GET", relay.URL+"/displays/audience?displayId=100", nil)
+	req.Header.Set("X-Real-IP", "10.0.100.5")
+	resp, err = client.Do(req)
+	if assert.Nil(t, err) {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, "audience displayId=relay-100 127.0.0.1", string(body))
+	}
+	status, _ = getBody(t, client, relay.URL+"/setup/settings")
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = getBody(t, client, relay.URL+"/displays/pit?displayId=100")
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = getBody(t, client, relay.URL+"/")
+	assert.Equal(t, http.StatusMovedPermanently, status)
+
+	// Websocket messages should be relayed along with their sequence numbers.
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(relay.URL, "http")+"/displays/audience/websocket?displayId=100", nil,
+	)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer conn.Close()
+	var message websocket.Message
+	assert.Nil(t, conn.ReadJSON(&message))
+	assert.Equal(t, websocket.Message{Type: "matchTime", Data: "bootstrap"}, message)
+	assert.Nil(t, conn.WriteJSON(websocket.Message{Type: "startMatch"}))
+	notifier.NotifyWithMessage("update")
+	assert.Nil(t, conn.ReadJSON(&message))
+	assert.Equal(t, websocket.Message{Type: "matchTime", Data: "update", Sequence: 1}, message)
+}
+
+func TestRelayAuthentication(t *testing.T) {
+	venue := startTestVenue(t, websocket.NewNotifier("matchTime", nil))
+	defer venue.Close()
+	relayServer := NewServer("relaykey", "viewerkey")
+	relay := httptest.NewServer(relayServer.Handler())
+	defer relay.Close()
+
+	// The venue server should be rejected if it presents the wrong key.
+	config := ClientConfig{Enabled: true, Url: "ws" + strings.TrimPrefix(relay.URL, "http"), Key: "wrongkey"}
+	client := NewClient(strings.TrimPrefix(venue.URL, "http://"), func() ClientConfig { return config })
+	assert.EqualError(t, client.runSession(config), "relay rejected connection with status 401")
+	assert.False(t, relayServer.IsVenueConnected())
+
+	venueClient := connectTestClient(t, venue, relay, relayServer, "relaykey")
+	defer venueClient.Stop()
+
+	// Viewers should need the key, which is stripped before the request is relayed and then remembered in a cookie.
+	status, _ := getBody(t, http.DefaultClient, relay.URL+"/displays/audience?displayId=100")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	status, _ = getBody(t, http.DefaultClient, relay.URL+"/displays/audience?displayId=100&key=wrong")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	resp, err := http.Get(relay.URL + "/displays/audience?displayId=100&key=viewerkey")
+	if assert.Nil(t, err) {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, "audience displayId=relay-100 127.0.0.1", string(body))
+		if assert.Equal(t, 1, len(resp.Cookies())) {
+			assert.Equal(t, "viewerkey", resp.Cookies()[0].Value)
+		}
+	}
+	req, _ := http.NewRequest("GET", relay.URL+"/displays/audience?displayId=100", nil)
+	req.AddCookie(&http.Cookie{Name: viewerKeyCookie, Value: "viewerkey"})
+	resp, err = http.DefaultClient.Do(req)
+	if assert.Nil(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// Stopping the client should drop the connection to the relay.
+	venueClient.Stop()
+	assert.Eventually(t, func() bool { return !relayServer.IsVenueConnected() }, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool { return !venueClient.IsConnected() }, time.Second, time.Millisecond)
+}
+
+func TestRelayDisplayNamespace(t *testing.T) {
+	arena := field.SetupTestArena(t, "relay")
+	venueDisplay := field.DisplayConfiguration{
+		Id:            "100",
+		Nickname:      "Stage",
+		Type:          field.AudienceDisplay,
+		Configuration: map[string]string{"background": "#0f0"},
+	}
+	arena.RegisterDisplay(&venueDisplay, "10.0.100.5")
+
+	// Register displays the way the venue server does, keeping relayed and local displays apart.
+	registeredIds := make(chan string, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /displays/audience/websocket", func(w http.ResponseWriter, r *http.Request) {
+		displayConfig, err := field.DisplayFromUrl(r.URL.Path, r.URL.Query())
+		if !assert.Nil(t, err) {
+			return
+		}
+		if IsRelayedRequest(r) != strings.HasPrefix(displayConfig.Id, RelayDisplayIdPrefix) {
+			http.Error(w, "invalid display ID", http.StatusBadRequest)
+			return
+		}
+		ws, err := websocket.NewWebsocket(w, r)
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer ws.Close()
+		arena.RegisterDisplay(displayConfig, "127.0.0.1")
+		registeredIds <- displayConfig.Id
+		_, _, _ = ws.Read()
+	})
+	venue := httptest.NewServer(mux)
+	defer venue.Close()
+	relayServer := NewServer("relaykey", "")
+	relay := httptest.NewServer(relayServer.Handler())
+	defer relay.Close()
+	venueClient := connectTestClient(t, venue, relay, relayServer, "relaykey")
+	defer venueClient.Stop()
+
+	// A viewer reusing the ID of one of the venue's displays should get a display of its own.
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(relay.URL, "http")+"/displays/audience/websocket?displayId=100&background=%23f00", nil,
+	)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer conn.Close()
+	select {
+	case displayId := <-registeredIds:
+		assert.Equal(t, "relay-100", displayId)
+	case <-time.After(time.Second):
+		assert.Fail(t, "Relayed display was not registered")
+	}
+	if assert.Contains(t, arena.Displays, "100") {
+		assert.Equal(t, venueDisplay, arena.Displays["100"].DisplayConfiguration)
+	}
+	if assert.Contains(t, arena.Displays, "relay-100") {
+		assert.Equal(t, "#f00", arena.Displays["relay-100"].DisplayConfiguration.Configuration["background"])
+	}
+}
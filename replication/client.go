@@ -0,0 +1,203 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Standby side of database replication, which keeps a local copy of the primary's database up to date.
+
+package replication
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// How long the client waits before reconnecting after losing the primary; overridden in tests.
+var clientRetryPeriod = 5 * time.Second
+
+// The operations through which the client maintains the standby's copy of the database.
+type Replica interface {
+	// Replaces the database wholesale with the given snapshot.
+	LoadSnapshot(snapshot []byte) error
+	ApplyChange(change *model.DatabaseChange) error
+	// Keeps the given periodic snapshot on disk as a fallback, without loading it.
+	SaveSnapshot(snapshot []byte) error
+}
+
+// Describes the state of the standby's replication from the primary.
+type ClientStatus struct {
+	PrimaryUrl       string
+	Connected        bool
+	LastChangeNumber int
+	LastChangeTime   time.Time
+	LastSnapshotTime time.Time
+	LastError        string
+}
+
+type Client struct {
+	key        string
+	replica    Replica
+	status     ClientStatus
+	conn       *gorillawebsocket.Conn
+	stopped    bool
+	mutex      sync.Mutex
+	applyMutex sync.Mutex
+}
+
+// Creates a client that replicates the database of the primary at the given base URL, e.g. "http://10.0.100.5:8080",
+// into the given replica.
+func NewClient(primaryUrl, key string, replica Replica) *Client {
+	return &Client{key: key, replica: replica, status: ClientStatus{PrimaryUrl: primaryUrl}}
+}
+
+// Loops to maintain the connection to the primary until the client is stopped.
+func (client *Client) Run() {
+	for !client.isStopped() {
+		err := client.runSession()
+		if client.isStopped() {
+			return
+		}
+		if err != nil {
+			log.Printf("Replication connection error: %v", err)
+			client.mutex.Lock()
+			client.status.LastError = err.Error()
+			client.mutex.Unlock()
+		}
+		time.Sleep(clientRetryPeriod)
+	}
+}
+
+// Disconnects from the primary and stops replicating. Once it returns, no further changes will be made to the replica.
+func (client *Client) Stop() {
+	client.mutex.Lock()
+	client.stopped = true
+	if client.conn != nil {
+		_ = client.conn.Close()
+	}
+	client.mutex.Unlock()
+
+	// Wait for any change that is being applied to finish.
+	client.applyMutex.Lock()
+	client.applyMutex.Unlock()
+}
+
+func (client *Client) Status() ClientStatus {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return client.status
+}
+
+func (client *Client) isStopped() bool {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return client.stopped
+}
+
+// Connects to the primary, loads its snapshot, and applies its changes until the connection is lost or a change is
+// missed, in which case reconnecting starts over from a new snapshot.
+func (client *Client) runSession() error {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+client.key)
+	conn, resp, err := gorillawebsocket.DefaultDialer.Dial(connectUrl(client.status.PrimaryUrl), header)
+	if err != nil {
+		if resp != nil {
+			return fmt.Errorf("primary rejected connection with status %d", resp.StatusCode)
+		}
+		return err
+	}
+	client.mutex.Lock()
+	if client.stopped {
+		client.mutex.Unlock()
+		_ = conn.Close()
+		return nil
+	}
+	client.conn = conn
+	client.mutex.Unlock()
+	defer func() {
+		_ = conn.Close()
+		client.mutex.Lock()
+		client.conn = nil
+		client.status.Connected = false
+		client.mutex.Unlock()
+	}()
+
+	nextChangeNumber := 0
+	var snapshot []byte
+	for {
+		// Treat the primary as gone if it misses several heartbeats.
+		_ = conn.SetReadDeadline(time.Now().Add(3 * heartbeatPeriod))
+		var received message
+		if err = conn.ReadJSON(&received); err != nil {
+			return err
+		}
+
+		if snapshot != nil && received.Type != snapshotMessage {
+			return fmt.Errorf("received a %s message partway through a snapshot", received.Type)
+		}
+		switch received.Type {
+		case snapshotMessage:
+			snapshot = append(snapshot, received.Snapshot...)
+			if received.More {
+				continue
+			}
+			completeSnapshot := snapshot
+			snapshot = nil
+			if received.Initial {
+				err = client.apply(func() error { return client.replica.LoadSnapshot(completeSnapshot) })
+				if err != nil {
+					return fmt.Errorf("error loading snapshot: %v", err)
+				}
+				nextChangeNumber = received.ChangeNumber + 1
+				log.Printf("Loaded database snapshot from primary at %s.", client.status.PrimaryUrl)
+			} else if err = client.replica.SaveSnapshot(completeSnapshot); err != nil {
+				log.Printf("Error saving periodic snapshot from primary: %v", err)
+			}
+			client.mutex.Lock()
+			client.status.Connected = nextChangeNumber > 0
+			client.status.LastSnapshotTime = time.Now()
+			client.status.LastError = ""
+			client.mutex.Unlock()
+		case changeMessage:
+			if nextChangeNumber == 0 || received.Change == nil {
+				return fmt.Errorf("received a change before the initial snapshot")
+			}
+			if received.Change.Number != nextChangeNumber {
+				return fmt.Errorf(
+					"expected change %d but received %d; reconnecting to resync",
+					nextChangeNumber,
+					received.Change.Number,
+				)
+			}
+			if err = client.apply(func() error { return client.replica.ApplyChange(received.Change) }); err != nil {
+				return fmt.Errorf("error applying change %d: %v", received.Change.Number, err)
+			}
+			nextChangeNumber++
+			client.mutex.Lock()
+			client.status.LastChangeNumber = received.Change.Number
+			client.status.LastChangeTime = time.Now()
+			client.mutex.Unlock()
+		case heartbeatMessage:
+		default:
+			log.Printf("Unexpected message type from primary: %q", received.Type)
+			continue
+		}
+
+		if nextChangeNumber > 0 {
+			if err = conn.WriteJSON(message{Type: ackMessage, ChangeNumber: nextChangeNumber - 1}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Runs the given modification of the replica unless the client has been stopped.
+func (client *Client) apply(modify func() error) error {
+	client.applyMutex.Lock()
+	defer client.applyMutex.Unlock()
+	if client.isStopped() {
+		return fmt.Errorf("replication has been stopped")
+	}
+	return modify()
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Message format shared by both ends of the connection between a primary server and its hot standby.
+
+package replication
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"strings"
+	"time"
+)
+
+// Path on the primary to which the standby connects to receive the database.
+const ConnectPath = "/replication/connect"
+
+const (
+	snapshotMessage  messageType = "snapshot"  // Primary to standby: a full copy of the database.
+	changeMessage    messageType = "change"    // Primary to standby: a single committed change.
+	heartbeatMessage messageType = "heartbeat" // Primary to standby: nothing has changed, but the primary is alive.
+	ackMessage       messageType = "ack"       // Standby to primary: the number of the last change applied.
+)
+
+// How often the primary sends heartbeats and full snapshots, and the most snapshot data it sends in one message so that
+// a large database doesn't have to be encoded into a single huge message; overridden in tests.
+var (
+	heartbeatPeriod    = 5 * time.Second
+	snapshotPeriod     = 5 * time.Minute
+	snapshotChunkBytes = 1 << 20
+)
+
+type messageType string
+
+type message struct {
+	Type messageType `json:"type"`
+	// For a snapshot, the number of the last change that it includes; for an ack, the last change applied.
+	ChangeNumber int `json:"changeNumber,omitempty"`
+	// True for the snapshot that starts the connection, which the standby loads, rather than a periodic one that it
+	// only keeps on disk.
+	Initial  bool   `json:"initial,omitempty"`
+	Snapshot []byte `json:"snapshot,omitempty"`
+	// True for each piece of a snapshot except the last; the pieces are sent consecutively.
+	More   bool                  `json:"more,omitempty"`
+	Change *model.DatabaseChange `json:"change,omitempty"`
+}
+
+// Converts the given HTTP base URL of the primary to that of its replication websocket endpoint.
+func connectUrl(primaryUrl string) string {
+	url := strings.TrimSuffix(primaryUrl, "/")
+	if strings.HasPrefix(url, "http") {
+		url = "ws" + strings.TrimPrefix(url, "http")
+	}
+	return url + ConnectPath
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package replication
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Replica that keeps its copy of the database in a file, as the standby server does.
+type testReplica struct {
+	path      string
+	database  *model.Database
+	snapshots int
+	loads     int
+	mutex     sync.Mutex
+}
+
+func (replica *testReplica) LoadSnapshot(snapshot []byte) error {
+	replica.mutex.Lock()
+	defer replica.mutex.Unlock()
+	if replica.database != nil {
+		replica.database.Close()
+	}
+	if err := os.WriteFile(replica.path, snapshot, 0644); err != nil {
+		return err
+	}
+	var err error
+	replica.database, err = model.OpenDatabase(replica.path)
+	replica.loads++
+	return err
+}
+
+func (replica *testReplica) ApplyChange(change *model.DatabaseChange) error {
+	replica.mutex.Lock()
+	defer replica.mutex.Unlock()
+	return replica.database.ApplyChange(change)
+}
+
+func (replica *testReplica) SaveSnapshot(snapshot []byte) error {
+	replica.mutex.Lock()
+	defer replica.mutex.Unlock()
+	replica.snapshots++
+	return nil
+}
+
+func (replica *testReplica) getTeamIds() []int {
+	replica.mutex.Lock()
+	defer replica.mutex.Unlock()
+	if replica.database == nil {
+		return nil
+	}
+	teams, _ := replica.database.GetAllTeams()
+	var teamIds []int
+	for _, team := range teams {
+		teamIds = append(teamIds, team.Id)
+	}
+	return teamIds
+}
+
+func (replica *testReplica) close() {
+	if replica.database != nil {
+		replica.database.Close()
+	}
+	os.Remove(replica.path)
+}
+
+func TestMain(m *testing.M) {
+	// Shorten the periods once up front since connections from earlier tests may still be winding down.
+	heartbeatPeriod = 10 * time.Millisecond
+	snapshotPeriod = 50 * time.Millisecond
+	clientRetryPeriod = 10 * time.Millisecond
+	snapshotChunkBytes = 4096
+	os.Exit(m.Run())
+}
+
+func startTestPrimary(t *testing.T, key string) (*model.Database, *Server, *httptest.Server) {
+	database := model.SetupTestDb(t, "replication")
+	server := NewServer(func() *model.Database { return database }, func() string { return key })
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+ConnectPath, server.ConnectHandler)
+	return database, server, httptest.NewServer(mux)
+}
+
+func TestReplication(t *testing.T) {
+	database, server, primary := startTestPrimary(t, "secret")
+	defer primary.Close()
+	defer database.Close()
+	database.CreateTeam(&model.Team{Id: 254})
+
+	replica := &testReplica{path: filepath.Join(model.BaseDir, "replica_test.db")}
+	defer replica.close()
+	client := NewClient(primary.URL, "secret", replica)
+	go client.Run()
+	assert.Eventually(t, func() bool { return client.Status().Connected }, time.Second, time.Millisecond)
+	assert.Equal(t, []int{254}, replica.getTeamIds())
+
+	database.CreateTeam(&model.Team{Id: 1114})
+	database.DeleteTeam(254)
+	assert.Eventually(
+		t,
+		func() bool { return assert.ObjectsAreEqual([]int{1114}, replica.getTeamIds()) },
+		time.Second,
+		time.Millisecond,
+	)
+	assert.Equal(t, 3, client.Status().LastChangeNumber)
+	assert.Eventually(
+		t,
+		func() bool {
+			standbys := server.Standbys()
+			return len(standbys) == 1 && standbys[0].LastSentChange == 3 && standbys[0].LastAckedChange == 3
+		},
+		time.Second,
+		time.Millisecond,
+	)
+
+	// Check that periodic snapshots are kept without being loaded.
+	assert.Eventually(
+		t,
+		func() bool {
+			replica.mutex.Lock()
+			defer replica.mutex.Unlock()
+			return replica.snapshots > 0
+		},
+		time.Second,
+		time.Millisecond,
+	)
+	assert.Equal(t, 1, replica.loads)
+
+	// Check that nothing more is replicated once the client is stopped.
+	client.Stop()
+	database.CreateTeam(&model.Team{Id: 1678})
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, []int{1114}, replica.getTeamIds())
+	assert.Eventually(t, func() bool { return len(server.Standbys()) == 0 }, time.Second, time.Millisecond)
+}
+
+func TestReplicationResyncsAfterDatabaseReplaced(t *testing.T) {
+	database, _, unusedPrimary := startTestPrimary(t, "secret")
+	unusedPrimary.Close()
+	defer database.Close()
+	currentDatabase := database
+	var databaseMutex sync.Mutex
+	server := NewServer(
+		func() *model.Database {
+			databaseMutex.Lock()
+			defer databaseMutex.Unlock()
+			return currentDatabase
+		},
+		func() string { return "secret" },
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+ConnectPath, server.ConnectHandler)
+	primary := httptest.NewServer(mux)
+	defer primary.Close()
+
+	replica := &testReplica{path: filepath.Join(model.BaseDir, "replica_test.db")}
+	defer replica.close()
+	client := NewClient(primary.URL, "secret", replica)
+	go client.Run()
+	defer client.Stop()
+	assert.Eventually(t, func() bool { return client.Status().Connected }, time.Second, time.Millisecond)
+
+	restoredPath := filepath.Join(model.BaseDir, "restored_test.db")
+	os.Remove(restoredPath)
+	defer os.Remove(restoredPath)
+	restoredDatabase, err := model.OpenDatabase(restoredPath)
+	assert.Nil(t, err)
+	defer restoredDatabase.Close()
+	restoredDatabase.CreateTeam(&model.Team{Id: 973})
+	databaseMutex.Lock()
+	currentDatabase = restoredDatabase
+	databaseMutex.Unlock()
+
+	assert.Eventually(
+		t,
+		func() bool { return assert.ObjectsAreEqual([]int{973}, replica.getTeamIds()) },
+		time.Second,
+		time.Millisecond,
+	)
+}
+
+func TestReplicationAuthentication(t *testing.T) {
+	database, _, primary := startTestPrimary(t, "secret")
+	defer primary.Close()
+	defer database.Close()
+
+	replica := &testReplica{path: filepath.Join(model.BaseDir, "replica_test.db")}
+	defer replica.close()
+	client := NewClient(primary.URL, "wrong", replica)
+	assert.EqualError(t, client.runSession(), "primary rejected connection with status 401")
+
+	database, _, disabledPrimary := startTestPrimary(t, "")
+	defer disabledPrimary.Close()
+	defer database.Close()
+	client = NewClient(disabledPrimary.URL, "", replica)
+	assert.EqualError(t, client.runSession(), "primary rejected connection with status 401")
+	assert.Nil(t, replica.database)
+}
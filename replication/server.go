@@ -0,0 +1,182 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Primary side of database replication, which streams the database and its changes to connected standbys.
+
+package replication
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"github.com/Team254/cheesy-arena/model"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Server struct {
+	databaseFunc func() *model.Database
+	keyFunc      func() string
+	upgrader     gorillawebsocket.Upgrader
+	standbys     map[*StandbyStatus]struct{}
+	mutex        sync.Mutex
+}
+
+// Describes the replication state of a connected standby.
+type StandbyStatus struct {
+	Address         string
+	ConnectedAt     time.Time
+	LastSentChange  int
+	LastAckedChange int
+	LastAckTime     time.Time
+}
+
+// Creates a server that replicates the database returned by the given function, which may change if the database is
+// restored, to standbys presenting the key returned by the other given function. Replication is disabled while the key
+// is blank.
+func NewServer(databaseFunc func() *model.Database, keyFunc func() string) *Server {
+	return &Server{
+		databaseFunc: databaseFunc,
+		keyFunc:      keyFunc,
+		upgrader:     gorillawebsocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 1024},
+		standbys:     make(map[*StandbyStatus]struct{}),
+	}
+}
+
+// Returns the status of each connected standby, in order of connection.
+func (server *Server) Standbys() []StandbyStatus {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	var standbys []StandbyStatus
+	for status := range server.standbys {
+		standbys = append(standbys, *status)
+	}
+	sort.Slice(standbys, func(i, j int) bool {
+		return standbys[i].ConnectedAt.Before(standbys[j].ConnectedAt)
+	})
+	return standbys
+}
+
+// Accepts a connection from a standby and sends it a snapshot of the database followed by every subsequent change,
+// until either end disconnects.
+func (server *Server) ConnectHandler(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	replicationKey := server.keyFunc()
+	if replicationKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(replicationKey)) != 1 {
+		http.Error(w, "Invalid replication key", http.StatusUnauthorized)
+		return
+	}
+	conn, err := server.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Error accepting standby connection: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	database := server.databaseFunc()
+	var snapshot bytes.Buffer
+	subscription, err := database.SubscribeToChanges(&snapshot)
+	if err != nil {
+		log.Printf("Error taking snapshot for standby: %v", err)
+		return
+	}
+	defer subscription.Close()
+
+	status := &StandbyStatus{
+		Address: r.RemoteAddr, ConnectedAt: time.Now(), LastSentChange: subscription.SnapshotChangeNumber,
+	}
+	server.mutex.Lock()
+	server.standbys[status] = struct{}{}
+	server.mutex.Unlock()
+	defer func() {
+		server.mutex.Lock()
+		delete(server.standbys, status)
+		server.mutex.Unlock()
+		log.Printf("Standby at %s disconnected.", r.RemoteAddr)
+	}()
+	log.Printf("Standby connected from %s.", r.RemoteAddr)
+
+	err = writeSnapshot(
+		conn, message{Type: snapshotMessage, ChangeNumber: subscription.SnapshotChangeNumber, Initial: true},
+		snapshot.Bytes(),
+	)
+	if err != nil {
+		return
+	}
+
+	// Read the standby's acknowledgements in the background; the loop ending means that the standby has gone away.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			var ack message
+			if err := conn.ReadJSON(&ack); err != nil {
+				return
+			}
+			if ack.Type == ackMessage {
+				server.mutex.Lock()
+				status.LastAckedChange = ack.ChangeNumber
+				status.LastAckTime = time.Now()
+				server.mutex.Unlock()
+			}
+		}
+	}()
+
+	heartbeatTicker := time.NewTicker(heartbeatPeriod)
+	defer heartbeatTicker.Stop()
+	snapshotTicker := time.NewTicker(snapshotPeriod)
+	defer snapshotTicker.Stop()
+	for {
+		select {
+		case change, ok := <-subscription.Changes:
+			if !ok {
+				log.Printf("Standby at %s fell behind; disconnecting it so that it resyncs.", r.RemoteAddr)
+				return
+			}
+			if err = conn.WriteJSON(message{Type: changeMessage, Change: &change}); err != nil {
+				return
+			}
+			server.mutex.Lock()
+			status.LastSentChange = change.Number
+			server.mutex.Unlock()
+		case <-heartbeatTicker.C:
+			if server.databaseFunc() != database {
+				// The database has been restored from a backup; start the standby over with the new one.
+				return
+			}
+			if err = conn.WriteJSON(message{Type: heartbeatMessage}); err != nil {
+				return
+			}
+		case <-snapshotTicker.C:
+			snapshot.Reset()
+			if err = database.WriteBackup(&snapshot); err != nil {
+				log.Printf("Error taking snapshot for standby: %v", err)
+				continue
+			}
+			if err = writeSnapshot(conn, message{Type: snapshotMessage}, snapshot.Bytes()); err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// Sends the given snapshot over the given connection as a series of consecutive messages based on the given one.
+func writeSnapshot(conn *gorillawebsocket.Conn, snapshotMessage message, snapshot []byte) error {
+	for {
+		chunk := snapshot[:min(len(snapshot), snapshotChunkBytes)]
+		snapshot = snapshot[len(chunk):]
+		snapshotMessage.Snapshot = chunk
+		snapshotMessage.More = len(snapshot) > 0
+		if err := conn.WriteJSON(snapshotMessage); err != nil {
+			return err
+		}
+		if !snapshotMessage.More {
+			return nil
+		}
+	}
+}
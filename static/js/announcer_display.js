@@ -22,6 +22,7 @@ const handleEventStatus = function(data) {
     $("#cycleTimeMessage").text("Last cycle time: " + data.CycleTime);
   }
   $("#earlyLateMessage").text(data.EarlyLateMessage);
+  $("#agendaMessage").text(data.AgendaMessage);
 };
 
 // Handles a websocket message to update the teams for the current match.
@@ -1,7 +1,8 @@
 // Copyright 2014 Team 254. All Rights Reserved.
 // Author: pat@patfairbank.com (Patrick Fairbank)
 //
-// Shared code for initiating websocket connections back to the server for full-duplex communication.
+// Shared code for initiating websocket connections back to the server for full-duplex communication, falling back to
+// Server-Sent Events or long polling on networks where websockets don't work.
 
 var CheesyWebsocket = function(path, events) {
   var that = this;
@@ -49,21 +50,206 @@ var CheesyWebsocket = function(path, events) {
     };
   }
 
-  this.connect = function() {
-    this.websocket = $.websocket(url, {
-      open: function() {
-        console.log("Websocket connected to the server at " + url + ".")
+  // Wrap each event handler to track the sequence numbers of notifier messages. The server drops messages for clients
+  // that fall behind, so a gap in the sequence means the client state may be stale; reconnecting resynchronizes it,
+  // since the server sends the latest state of every notifier upon connection. Repeated gaps back off exponentially
+  // between reconnections so that a client that can't keep up doesn't churn the connection; the messages that do
+  // arrive in the meantime are still handled since each one carries the notifier's full latest state.
+  var lastSequences = {};
+  var resyncing = false;
+  var resyncTimer = null;
+  var resyncDelayMs = 0;
+  var lastResyncTime = 0;
+  var minResyncDelayMs = 1000;
+  var maxResyncDelayMs = 30000;
+  var scheduleResync = function(messageType, numMissed) {
+    if (resyncing || resyncTimer !== null) {
+      return;
+    }
+    if (Date.now() - lastResyncTime > 2 * maxResyncDelayMs) {
+      // The connection has been stable for a while, so start backing off from scratch.
+      resyncDelayMs = 0;
+    }
+    console.log("Missed " + numMissed + " '" + messageType + "' message(s); resyncing in " + resyncDelayMs + " ms.");
+    resyncTimer = setTimeout(function() {
+      resyncTimer = null;
+      resyncing = true;
+      lastResyncTime = Date.now();
+      that.websocket.close();
+    }, resyncDelayMs);
+    resyncDelayMs = Math.min(Math.max(2 * resyncDelayMs, minResyncDelayMs), maxResyncDelayMs);
+  };
+  $.each(events, function(messageType, handler) {
+    events[messageType] = function(event) {
+      if (event.seq) {
+        var lastSequence = lastSequences[messageType];
+        lastSequences[messageType] = event.seq;
+        if (lastSequence !== undefined && event.seq > lastSequence + 1) {
+          scheduleResync(messageType, event.seq - lastSequence - 1);
+        }
+      }
+      handler.call(this, event);
+    };
+  });
+
+  // Paths of the parallel endpoints serving the same messages over Server-Sent Events and long polling, which are used
+  // in turn if the websocket can't be established (e.g. because a proxy on the venue network breaks websockets).
+  var basePath = path.replace(/\/websocket$/, "");
+  var transports = [
+    {name: "websocket", connect: function(onOpen, onClose) { return connectWebsocket(onOpen, onClose); }},
+    {name: "Server-Sent Events", connect: function(onOpen, onClose) { return connectEventSource(onOpen, onClose); }},
+    {name: "long polling", connect: function(onOpen, onClose) { return connectLongPoll(onOpen, onClose); }},
+  ];
+  var transportIndex = 0;
+
+  // Invokes the registered handler for the given message, as the websocket plugin does.
+  var dispatch = function(message) {
+    var handler = events[message.type];
+    if (handler) {
+      handler.call(that, message);
+    }
+  };
+
+  // Sends a message to the server on behalf of a fallback transport session.
+  var postMessage = function(sessionId, type, data) {
+    var message = {type: type};
+    if (data) {
+      message.data = data;
+    }
+    $.ajax({type: "POST", url: "/stream/" + sessionId, contentType: "application/json", data: JSON.stringify(message)});
+  };
+
+  var connectWebsocket = function(onOpen, onClose) {
+    return $.websocket(url, {open: onOpen, close: onClose, events: events});
+  };
+
+  var connectEventSource = function(onOpen, onClose) {
+    var sessionId = null;
+    var closed = false;
+    var source = new EventSource(basePath + "/events" + window.location.search);
+    var close = function() {
+      if (!closed) {
+        closed = true;
+        source.close();
+        onClose();
+      }
+    };
+    source.addEventListener("session", function(event) {
+      sessionId = event.data;
+      onOpen();
+    });
+    source.onmessage = function(event) {
+      dispatch(JSON.parse(event.data));
+    };
+    source.onerror = close;
+    return {
+      send: function(type, data) {
+        postMessage(sessionId, type, data);
       },
-      close: function() {
-        console.log("Websocket lost connection to the server. Reconnecting in 3 seconds...");
-        setTimeout(that.connect, 3000);
+      close: close,
+    };
+  };
+
+  var connectLongPoll = function(onOpen, onClose) {
+    var sessionId = null;
+    var closed = false;
+    var close = function() {
+      if (!closed) {
+        closed = true;
+        if (sessionId !== null) {
+          $.ajax({type: "DELETE", url: "/stream/" + sessionId});
+        }
+        onClose();
+      }
+    };
+    var poll = function() {
+      if (closed) {
+        return;
+      }
+      $.ajax({url: "/stream/" + sessionId, dataType: "json", cache: false}).done(function(messages) {
+        $.each(messages, function(i, message) {
+          if (!closed) {
+            dispatch(message);
+          }
+        });
+        poll();
+      }).fail(close);
+    };
+    $.ajax({url: basePath + "/poll" + window.location.search, dataType: "json", cache: false}).done(function(response) {
+      sessionId = response.session;
+      onOpen();
+      poll();
+    }).fail(close);
+    return {
+      send: function(type, data) {
+        postMessage(sessionId, type, data);
       },
-      events: events
-    });
+      close: close,
+    };
   };
 
-  this.send = function(type, data) {
-    this.websocket.send(type, data);
+  // While connected using a fallback transport, periodically check whether a websocket can now be established (e.g.
+  // because the proxy that was breaking it has gone away) and switch back to it if so.
+  var websocketProbePeriodMs = 60000;
+  var websocketProbeTimer = null;
+  var upgrading = false;
+  var probeWebsocket = function() {
+    websocketProbeTimer = null;
+    var probe = new WebSocket(url);
+    probe.onopen = function() {
+      probe.close();
+      if (transportIndex > 0) {
+        console.log("Websocket is available again; switching back from " + transports[transportIndex].name + ".");
+        transportIndex = 0;
+        upgrading = true;
+        that.websocket.close();
+      }
+    };
+    probe.onerror = function() {
+      websocketProbeTimer = setTimeout(probeWebsocket, websocketProbePeriodMs);
+    };
+  };
+
+  this.connect = function() {
+    lastSequences = {};
+    clearTimeout(resyncTimer);
+    resyncTimer = null;
+    clearTimeout(websocketProbeTimer);
+    websocketProbeTimer = null;
+    var transport = transports[transportIndex];
+    var opened = false;
+    that.websocket = transport.connect(
+      function() {
+        opened = true;
+        console.log("Connected to the server at " + path + " using " + transport.name + ".");
+        if (transportIndex > 0) {
+          websocketProbeTimer = setTimeout(probeWebsocket, websocketProbePeriodMs);
+        }
+      },
+      function() {
+        clearTimeout(websocketProbeTimer);
+        websocketProbeTimer = null;
+        if (resyncing || upgrading) {
+          resyncing = false;
+          upgrading = false;
+          that.connect();
+          return;
+        }
+        if (!opened) {
+          // Try the next transport right away, cycling back around to the websocket after a pause so that the best
+          // transport is used once the server or network recovers.
+          transportIndex = (transportIndex + 1) % transports.length;
+          if (transportIndex > 0) {
+            console.log("Could not connect using " + transport.name + "; falling back to " +
+              transports[transportIndex].name + ".");
+            that.connect();
+            return;
+          }
+        }
+        console.log("Lost connection to the server. Reconnecting in 3 seconds...");
+        setTimeout(that.connect, 3000);
+      }
+    );
   };
 
   this.connect();
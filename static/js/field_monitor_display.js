@@ -37,6 +37,7 @@ var handleArenaStatus = function(data) {
     var teamRobotElement = $(teamElementPrefix + "Robot");
     var teamBypassElement = $(teamElementPrefix + "Bypass");
     var teamBandwidthElement = $(teamElementPrefix + "Bandwidth");
+    var teamNetworkElement = $(teamElementPrefix + "Network");
 
     teamNotesTextElement.attr("data-station", station);
 
@@ -77,6 +78,8 @@ var handleArenaStatus = function(data) {
       teamEthernetElement.text("ETH");
     }
 
+    handleNetworkDiagnostics(teamNetworkElement, stationStatus);
+
     const wifiStatus = stationStatus.WifiStatus;
     teamRadioTextElement.text(wifiStatus.TeamId);
 
@@ -147,6 +150,44 @@ var handleArenaStatus = function(data) {
   });
 };
 
+// Formats the wired network diagnostics for the given team station, to help distinguish cabling faults from robot
+// configuration issues.
+var handleNetworkDiagnostics = function(networkElement, stationStatus) {
+  var diagnostics = stationStatus.NetworkDiagnostics;
+  var portElement = networkElement.find(".network-port");
+  var dhcpElement = networkElement.find(".network-dhcp");
+  var arpElement = networkElement.find(".network-arp");
+  var pingElement = networkElement.find(".network-ping");
+
+  if (!stationStatus.Team || diagnostics.TeamId !== stationStatus.Team.Id) {
+    portElement.text("");
+    dhcpElement.text("");
+    arpElement.text("");
+    pingElement.text("");
+    return;
+  }
+
+  var portErrors = diagnostics.SwitchPortInputErrors + diagnostics.SwitchPortOutputErrors;
+  portElement.text("Port " + (diagnostics.SwitchPortLinkUp ? "up" : "down") + " / " + portErrors + " err");
+  portElement.attr("data-status-ok", diagnostics.SwitchPortLinkUp && portErrors === 0);
+
+  var numLeases = diagnostics.DhcpLeases ? diagnostics.DhcpLeases.length : 0;
+  dhcpElement.text("DHCP " + numLeases);
+  dhcpElement.attr("data-status-ok", numLeases > 0);
+
+  arpElement.text("ARP Radio:" + (diagnostics.RadioArpPresent ? "Y" : "N") + " Rio:" +
+    (diagnostics.RioArpPresent ? "Y" : "N"));
+  arpElement.attr("data-status-ok", diagnostics.RadioArpPresent && diagnostics.RioArpPresent);
+
+  if (diagnostics.RioPingLatencyMs >= 0) {
+    pingElement.text("Ping " + diagnostics.RioPingLatencyMs.toFixed(1) + "ms");
+    pingElement.attr("data-status-ok", true);
+  } else {
+    pingElement.text("Ping -");
+    pingElement.attr("data-status-ok", false);
+  }
+};
+
 // Handles a websocket message to update the match time countdown.
 var handleMatchTime = function(data) {
   translateMatchTime(data, function(matchState, matchStateText, countdownSec) {
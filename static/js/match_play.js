@@ -6,6 +6,7 @@
 var websocket;
 let scoreIsReady;
 let isReplay;
+let timeoutsRemaining = { red: 0, blue: 0 };
 const lowBatteryThreshold = 8;
 
 // Sends a websocket message to load the specified match.
@@ -18,6 +19,11 @@ const showResult = function(matchId) {
   websocket.send("showResult", { matchId: matchId });
 }
 
+// Sends a websocket message to move the specified match to a different field.
+const assignField = function(matchId, fieldNumber) {
+  websocket.send("assignField", { matchId: matchId, fieldNumber: parseInt(fieldNumber) });
+}
+
 // Sends a websocket message to load all teams into their respective alliance stations.
 const substituteTeams = function(team, position) {
   const teams = {
@@ -43,6 +49,14 @@ const startMatch = function() {
       { muteMatchSounds: $("#muteMatchSounds").prop("checked") });
 };
 
+// Sends a websocket message to start the match despite any failing readiness checks that can be overridden.
+const overrideStartMatch = function() {
+  if (confirm("Some readiness checks are failing. Start the match anyway? The override will be logged.")) {
+    websocket.send("startMatch",
+        { muteMatchSounds: $("#muteMatchSounds").prop("checked"), override: true });
+  }
+};
+
 // Sends a websocket message to abort the match.
 const abortMatch = function() {
   websocket.send("abortMatch");
@@ -89,12 +103,22 @@ const setAllianceStationDisplay = function() {
 
 // Sends a websocket message to start the timeout.
 const startTimeout = function() {
+  websocket.send("startTimeout", getTimeoutDurationSec());
+};
+
+// Sends a websocket message to start a timeout called by the given alliance, charged against its allowance.
+const startAllianceTimeout = function(alliance) {
+  websocket.send("startAllianceTimeout", { alliance: alliance, durationSec: getTimeoutDurationSec() });
+};
+
+// Returns the number of seconds entered in the timeout duration field.
+const getTimeoutDurationSec = function() {
   const duration = $("#timeoutDuration").val().split(":");
   let durationSec = parseFloat(duration[0]);
   if (duration.length > 1) {
     durationSec = durationSec * 60 + parseFloat(duration[1]);
   }
-  websocket.send("startTimeout", durationSec);
+  return durationSec;
 };
 
 const confirmCommit = function() {
@@ -178,6 +202,31 @@ const handleArenaStatus = function(data) {
     }
   });
 
+  // Update the list of failing match readiness checks.
+  const readinessProblems = [];
+  $.each(data.MatchReadiness.Stations, function(i, stationReadiness) {
+    $.each(stationReadiness.Checks, function(j, check) {
+      if (!check.IsPassing) {
+        readinessProblems.push({ label: `${stationReadiness.Station} ${check.Name}`, check: check });
+      }
+    });
+  });
+  $.each(data.MatchReadiness.FieldChecks, function(i, check) {
+    if (!check.IsPassing) {
+      readinessProblems.push({ label: check.Name, check: check });
+    }
+  });
+  $("#matchReadiness").empty();
+  $.each(readinessProblems, function(i, problem) {
+    const item = $("<li>").attr("title", problem.check.Detail).text(problem.label);
+    item.addClass(problem.check.IsRequired ? "text-danger" : "text-warning");
+    $("#matchReadiness").append(item);
+  });
+  if (readinessProblems.length === 0) {
+    $("#matchReadiness").append($("<li>").addClass("text-success").text("All checks passing"));
+  }
+  $("#overrideStartMatch").toggle(data.CanOverrideStartMatch);
+
   // Enable/disable the buttons based on the current match state.
   switch (matchStates[data.MatchState]) {
     case "PRE_MATCH":
@@ -252,6 +301,10 @@ const handleArenaStatus = function(data) {
       break;
   }
 
+  $.each(timeoutsRemaining, function(alliance, remaining) {
+    $(`#${alliance}AllianceTimeout`).prop("disabled", matchStates[data.MatchState] !== "PRE_MATCH" || remaining === 0);
+  });
+
   $("#accessPointStatus").attr("data-status", data.AccessPointStatus);
   $("#switchStatus").attr("data-status", data.SwitchStatus);
 
@@ -286,6 +339,12 @@ const handleMatchLoad = function(data) {
   });
   $("#playoffRedAllianceInfo").html(formatPlayoffAllianceInfo(data.Match.PlayoffRedAlliance, data.RedOffFieldTeams));
   $("#playoffBlueAllianceInfo").html(formatPlayoffAllianceInfo(data.Match.PlayoffBlueAlliance, data.BlueOffFieldTeams));
+  timeoutsRemaining = { red: data.RedTimeoutsRemaining, blue: data.BlueTimeoutsRemaining };
+  $("#allianceTimeouts").toggle(data.Match.Type === matchTypePlayoff);
+  $("#redTimeoutsRemaining").text(data.RedTimeoutsRemaining);
+  $("#blueTimeoutsRemaining").text(data.BlueTimeoutsRemaining);
+  $("#inspectionWarning").text(`Not passed inspection: ${data.UninspectedTeamIds.join(", ")}`);
+  $("#inspectionWarning").toggle(data.UninspectedTeamIds.length > 0);
 
   $("#substituteTeams").prop("disabled", true);
   $("#showOverlay").prop("disabled", false);
@@ -0,0 +1,35 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Client-side logic for the pit display.
+
+var websocket;
+
+// Handles a websocket message to update the inspection status of the teams.
+var handleInspection = function(data) {
+  $("#numPassed").text(data.NumPassed);
+  $("#numFailed").text(data.NumFailed);
+  $("#numNotInspected").text(data.NumNotInspected);
+
+  $("#teams").empty();
+  $.each(data.Teams, function(i, team) {
+    var status = "none";
+    if (team.IsInspected) {
+      status = team.Passed ? "passed" : "failed";
+    }
+    $("#teams").append($("<div>").addClass("inspection-team").attr("data-status", status).text(team.TeamId));
+  });
+};
+
+// Handles a websocket message to update the event status message.
+var handleEventStatus = function(data) {
+  $("#earlyLateMessage").text(data.EarlyLateMessage);
+  $("#agendaMessage").text(data.AgendaMessage);
+};
+
+$(function() {
+  // Set up the websocket back to the server.
+  websocket = new CheesyWebsocket("/displays/pit/websocket", {
+    eventStatus: function(event) { handleEventStatus(event.data); },
+    inspection: function(event) { handleInspection(event.data); },
+  });
+});
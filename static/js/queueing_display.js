@@ -28,6 +28,7 @@ var handleMatchTime = function(data) {
 // Handles a websocket message to update the event status message.
 var handleEventStatus = function(data) {
   $("#earlyLateMessage").text(data.EarlyLateMessage);
+  $("#agendaMessage").text(data.AgendaMessage);
 };
 
 $(function() {
@@ -0,0 +1,43 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Client-side logic for the team signs display.
+
+var websocket;
+
+// Handles a websocket message to update the simulated state of all the signs.
+const handleTeamSigns = function(data) {
+  $.each(data, function(position, sign) {
+    const signDiv = $(`#${position}`);
+    const frontDiv = signDiv.find(".sign-front");
+    const rearDiv = signDiv.find(".sign-rear");
+    signDiv.toggleClass("sign-inactive", sign === null);
+    if (sign === null) {
+      frontDiv.text("");
+      rearDiv.text("");
+      return;
+    }
+
+    frontDiv.text(sign.FrontText);
+    rearDiv.text(sign.RearText);
+    const color = sign.FrontColor;
+    frontDiv.css("color", `rgba(${color.R}, ${color.G}, ${color.B}, ${color.A / 255})`);
+  });
+};
+
+$(function() {
+  // Read the configuration for this display from the URL query string. A position (e.g. "Red1") turns the display into
+  // a full-screen kiosk for that one sign.
+  const urlParams = new URLSearchParams(window.location.search);
+  const position = urlParams.get("position");
+  if (position) {
+    $(".sign").not(`#${position}`).remove();
+    $(".alliance").not(`:has(#${position})`).remove();
+    $("#disabledMessage").remove();
+    $("body").addClass("kiosk");
+  }
+
+  // Set up the websocket back to the server.
+  websocket = new CheesyWebsocket("/displays/team_signs/websocket", {
+    teamSigns: function(event) { handleTeamSigns(event.data); },
+  });
+});
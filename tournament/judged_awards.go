@@ -0,0 +1,173 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Functions for turning the judges' deliberations into awards and for ordering the award ceremony.
+
+package tournament
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+)
+
+// Represents one award as it is presented during the ceremony, in the order in which it is presented.
+type AwardCeremonyItem struct {
+	AwardName   string
+	Script      string
+	Awards      []model.Award
+	LowerThirds []model.LowerThird
+}
+
+// Generates an award and its lower thirds for each winner chosen by the judges, replacing the awards generated by any
+// previous finalization. Returns an error without changing anything if an award has no winner chosen.
+func FinalizeJudgedAwards(database *model.Database) error {
+	awardDefinitions, err := database.GetAllAwardDefinitions()
+	if err != nil {
+		return err
+	}
+	nominationsByDefinition := make(map[int][]model.AwardNomination)
+	for _, awardDefinition := range awardDefinitions {
+		nominations, err := database.GetAwardNominationsByDefinition(awardDefinition.Id)
+		if err != nil {
+			return err
+		}
+		hasWinner := false
+		for _, nomination := range nominations {
+			hasWinner = hasWinner || nomination.IsWinner
+		}
+		if !hasWinner {
+			return fmt.Errorf("No winner has been chosen for the %s.", awardDefinition.Name)
+		}
+		nominationsByDefinition[awardDefinition.Id] = nominations
+	}
+
+	for _, awardDefinition := range awardDefinitions {
+		isFirstWinner := true
+		for _, nomination := range nominationsByDefinition[awardDefinition.Id] {
+			if err = deleteNominationAward(database, &nomination); err != nil {
+				return err
+			}
+			if nomination.IsWinner {
+				award := model.Award{
+					Type:       model.JudgedAward,
+					AwardName:  awardDefinition.Name,
+					TeamId:     nomination.TeamId,
+					PersonName: nomination.PersonName,
+				}
+				if err = CreateOrUpdateAward(database, &award, isFirstWinner); err != nil {
+					return err
+				}
+				isFirstWinner = false
+				nomination.AwardId = award.Id
+			}
+			if err = database.UpdateAwardNomination(&nomination); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Deletes the given award definition along with its nominations and any awards generated from them.
+func DeleteAwardDefinition(database *model.Database, awardDefinitionId int) error {
+	nominations, err := database.GetAwardNominationsByDefinition(awardDefinitionId)
+	if err != nil {
+		return err
+	}
+	for _, nomination := range nominations {
+		if err = DeleteAwardNomination(database, &nomination); err != nil {
+			return err
+		}
+	}
+	return database.DeleteAwardDefinition(awardDefinitionId)
+}
+
+// Deletes the given nomination along with any award generated from it.
+func DeleteAwardNomination(database *model.Database, nomination *model.AwardNomination) error {
+	if err := deleteNominationAward(database, nomination); err != nil {
+		return err
+	}
+	return database.DeleteAwardNomination(nomination.Id)
+}
+
+// Returns the awards in the order in which they are presented at the ceremony: the judged awards in their configured
+// order, then any awards that were entered by hand, and lastly the finalists and winners of the playoff tournament.
+func GetAwardCeremony(database *model.Database) ([]AwardCeremonyItem, error) {
+	awards, err := database.GetAllAwards()
+	if err != nil {
+		return nil, err
+	}
+	awardsById := make(map[int]model.Award)
+	for _, award := range awards {
+		awardsById[award.Id] = award
+	}
+
+	var ceremony []AwardCeremonyItem
+	placedAwardIds := make(map[int]bool)
+	awardDefinitions, err := database.GetAllAwardDefinitions()
+	if err != nil {
+		return nil, err
+	}
+	for _, awardDefinition := range awardDefinitions {
+		nominations, err := database.GetAwardNominationsByDefinition(awardDefinition.Id)
+		if err != nil {
+			return nil, err
+		}
+		item := AwardCeremonyItem{AwardName: awardDefinition.Name, Script: awardDefinition.Script}
+		for _, nomination := range nominations {
+			if award, ok := awardsById[nomination.AwardId]; ok {
+				item.Awards = append(item.Awards, award)
+				placedAwardIds[award.Id] = true
+			}
+		}
+		if len(item.Awards) > 0 {
+			ceremony = append(ceremony, item)
+		}
+	}
+
+	// Group the remaining awards by name, keeping the order in which each name first appears.
+	for _, awardType := range []model.AwardType{model.JudgedAward, model.FinalistAward, model.WinnerAward} {
+		itemIndexByName := make(map[string]int)
+		for _, award := range awards {
+			if award.Type != awardType || placedAwardIds[award.Id] {
+				continue
+			}
+			index, ok := itemIndexByName[award.AwardName]
+			if !ok {
+				index = len(ceremony)
+				itemIndexByName[award.AwardName] = index
+				ceremony = append(ceremony, AwardCeremonyItem{AwardName: award.AwardName})
+			}
+			ceremony[index].Awards = append(ceremony[index].Awards, award)
+		}
+	}
+
+	for i := range ceremony {
+		for _, award := range ceremony[i].Awards {
+			lowerThirds, err := database.GetLowerThirdsByAwardId(award.Id)
+			if err != nil {
+				return nil, err
+			}
+			ceremony[i].LowerThirds = append(ceremony[i].LowerThirds, lowerThirds...)
+		}
+	}
+	return ceremony, nil
+}
+
+// Deletes the award previously generated from the given nomination, if there is one, and clears the reference to it.
+func deleteNominationAward(database *model.Database, nomination *model.AwardNomination) error {
+	if nomination.AwardId == 0 {
+		return nil
+	}
+	award, err := database.GetAwardById(nomination.AwardId)
+	if err != nil {
+		return err
+	}
+	if award != nil {
+		if err = DeleteAward(database, award.Id); err != nil {
+			return err
+		}
+	}
+	nomination.AwardId = 0
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFinalizeJudgedAwards(t *testing.T) {
+	database := setupTestDb(t)
+	database.CreateTeam(&model.Team{Id: 254, Nickname: "The Cheesy Poofs"})
+	database.CreateTeam(&model.Team{Id: 1114, Nickname: "Simbotics"})
+	database.CreateTeam(&model.Team{Id: 2056, Nickname: "OP Robotics"})
+	safetyAward := model.AwardDefinition{Name: "Safety Award", CeremonyOrder: 2}
+	imageryAward := model.AwardDefinition{Name: "Imagery Award", CeremonyOrder: 1}
+	database.CreateAwardDefinition(&safetyAward)
+	database.CreateAwardDefinition(&imageryAward)
+	nomination1 := model.AwardNomination{AwardDefinitionId: safetyAward.Id, TeamId: 254}
+	nomination2 := model.AwardNomination{AwardDefinitionId: safetyAward.Id, TeamId: 1114, IsWinner: true}
+	nomination3 := model.AwardNomination{AwardDefinitionId: imageryAward.Id, TeamId: 2056}
+	database.CreateAwardNomination(&nomination1)
+	database.CreateAwardNomination(&nomination2)
+	database.CreateAwardNomination(&nomination3)
+
+	err := FinalizeJudgedAwards(database)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "No winner has been chosen for the Imagery Award.", err.Error())
+	}
+	awards, _ := database.GetAllAwards()
+	assert.Empty(t, awards)
+
+	nomination3.IsWinner = true
+	nomination3.PersonName = "Jane Doe"
+	database.UpdateAwardNomination(&nomination3)
+	assert.Nil(t, FinalizeJudgedAwards(database))
+	awards, _ = database.GetAllAwards()
+	if assert.Equal(t, 2, len(awards)) {
+		assert.Equal(t, "Imagery Award", awards[0].AwardName)
+		assert.Equal(t, 2056, awards[0].TeamId)
+		assert.Equal(t, "Jane Doe", awards[0].PersonName)
+		assert.Equal(t, "Safety Award", awards[1].AwardName)
+		assert.Equal(t, 1114, awards[1].TeamId)
+	}
+	nomination, _ := database.GetAwardNominationById(nomination2.Id)
+	assert.Equal(t, awards[1].Id, nomination.AwardId)
+	lowerThirds, _ := database.GetAllLowerThirds()
+	assert.Equal(t, 4, len(lowerThirds))
+
+	// Change the winner and check that finalizing again replaces the previously generated award.
+	nomination, _ = database.GetAwardNominationById(nomination2.Id)
+	nomination.IsWinner = false
+	database.UpdateAwardNomination(nomination)
+	nomination, _ = database.GetAwardNominationById(nomination1.Id)
+	nomination.IsWinner = true
+	database.UpdateAwardNomination(nomination)
+	assert.Nil(t, FinalizeJudgedAwards(database))
+	awards, _ = database.GetAwardsByType(model.JudgedAward)
+	if assert.Equal(t, 2, len(awards)) {
+		assert.Equal(t, "Imagery Award", awards[0].AwardName)
+		assert.Equal(t, "Safety Award", awards[1].AwardName)
+		assert.Equal(t, 254, awards[1].TeamId)
+	}
+	nomination, _ = database.GetAwardNominationById(nomination2.Id)
+	assert.Equal(t, 0, nomination.AwardId)
+	lowerThirds, _ = database.GetAllLowerThirds()
+	assert.Equal(t, 4, len(lowerThirds))
+
+	// Check that deleting an award definition cleans up the awards generated from it.
+	assert.Nil(t, DeleteAwardDefinition(database, safetyAward.Id))
+	awards, _ = database.GetAllAwards()
+	if assert.Equal(t, 1, len(awards)) {
+		assert.Equal(t, "Imagery Award", awards[0].AwardName)
+	}
+	nominations, _ := database.GetAwardNominationsByDefinition(safetyAward.Id)
+	assert.Empty(t, nominations)
+	lowerThirds, _ = database.GetAllLowerThirds()
+	assert.Equal(t, 2, len(lowerThirds))
+}
+
+func TestGetAwardCeremony(t *testing.T) {
+	database := setupTestDb(t)
+	database.CreateTeam(&model.Team{Id: 254, Nickname: "The Cheesy Poofs"})
+	database.CreateTeam(&model.Team{Id: 1114, Nickname: "Simbotics"})
+	CreateOrUpdateAward(database, &model.Award{Type: model.WinnerAward, AwardName: "Winner", TeamId: 254}, true)
+	CreateOrUpdateAward(database, &model.Award{Type: model.FinalistAward, AwardName: "Finalist", TeamId: 1114}, true)
+	CreateOrUpdateAward(database, &model.Award{Type: model.JudgedAward, AwardName: "Volunteer of the Year"}, true)
+	safetyAward := model.AwardDefinition{Name: "Safety Award", Script: "Safety first.", CeremonyOrder: 1}
+	database.CreateAwardDefinition(&safetyAward)
+	database.CreateAwardNomination(&model.AwardNomination{AwardDefinitionId: safetyAward.Id, TeamId: 254})
+	database.CreateAwardNomination(
+		&model.AwardNomination{AwardDefinitionId: safetyAward.Id, TeamId: 1114, IsWinner: true},
+	)
+
+	// Check that unfinalized awards are left out.
+	ceremony, err := GetAwardCeremony(database)
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(ceremony)) {
+		assert.Equal(t, "Volunteer of the Year", ceremony[0].AwardName)
+		assert.Equal(t, "Finalist", ceremony[1].AwardName)
+		assert.Equal(t, "Winner", ceremony[2].AwardName)
+	}
+
+	assert.Nil(t, FinalizeJudgedAwards(database))
+	ceremony, err = GetAwardCeremony(database)
+	assert.Nil(t, err)
+	if assert.Equal(t, 4, len(ceremony)) {
+		assert.Equal(t, "Safety Award", ceremony[0].AwardName)
+		assert.Equal(t, "Safety first.", ceremony[0].Script)
+		if assert.Equal(t, 1, len(ceremony[0].Awards)) {
+			assert.Equal(t, 1114, ceremony[0].Awards[0].TeamId)
+		}
+		if assert.Equal(t, 2, len(ceremony[0].LowerThirds)) {
+			assert.Equal(t, "", ceremony[0].LowerThirds[0].BottomText)
+			assert.Equal(t, "Team 1114, Simbotics", ceremony[0].LowerThirds[1].BottomText)
+		}
+		assert.Equal(t, "Volunteer of the Year", ceremony[1].AwardName)
+		assert.Equal(t, "Winner", ceremony[3].AwardName)
+	}
+}
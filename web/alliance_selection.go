@@ -12,6 +12,7 @@ import (
 	"github.com/Team254/cheesy-arena/websocket"
 	"io"
 	"log"
+	"math"
 	"net/http"
 	"strconv"
 	"time"
@@ -23,6 +24,9 @@ var allianceSelectionTimeLimitSec = 45
 // Global var to hold a ticker used for the alliance selection timer.
 var allianceSelectionTicker *time.Ticker
 
+// Global var to hold the time at which the running alliance selection timer will expire, or zero if it isn't running.
+var allianceSelectionTimerEndTime time.Time
+
 // Shows the alliance selection page.
 func (web *Web) allianceSelectionGetHandler(w http.ResponseWriter, r *http.Request) {
 	if !web.userIsAdmin(w, r) {
@@ -43,6 +47,12 @@ func (web *Web) allianceSelectionPostHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Keep the previous selections so that the changes to them can be recorded.
+	previousTeamIds := make([][]int, len(web.arena.AllianceSelectionAlliances))
+	for i, alliance := range web.arena.AllianceSelectionAlliances {
+		previousTeamIds[i] = append([]int{}, alliance.TeamIds...)
+	}
+
 	// Reset picked state for each team in preparation for reconstructing it.
 	for i := range web.arena.AllianceSelectionRankedTeams {
 		web.arena.AllianceSelectionRankedTeams[i].Picked = false
@@ -68,6 +78,10 @@ func (web *Web) allianceSelectionPostHandler(w http.ResponseWriter, r *http.Requ
 								fmt.Sprintf("Team %d is already part of an alliance.", teamId))
 							return
 						}
+						if team.Declined && j > 0 {
+							web.renderAllianceSelection(w, r, declinedTeamMessage(teamId))
+							return
+						}
 						found = true
 						web.arena.AllianceSelectionRankedTeams[k].Picked = true
 						web.arena.AllianceSelectionAlliances[i].TeamIds[j] = teamId
@@ -88,10 +102,256 @@ func (web *Web) allianceSelectionPostHandler(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
+	for i, alliance := range web.arena.AllianceSelectionAlliances {
+		for j, teamId := range alliance.TeamIds {
+			if teamId != previousTeamIds[i][j] {
+				event := model.AllianceSelectionEvent{
+					Time: time.Now(), Type: model.AssignEvent, AllianceId: alliance.Id, Spot: j, TeamId: teamId,
+				}
+				if err := web.arena.Database.CreateAllianceSelectionEvent(&event); err != nil {
+					handleWebErr(w, err)
+					return
+				}
+			}
+		}
+	}
+
 	if allianceSelectionTicker != nil {
-		allianceSelectionTicker.Stop()
-		web.arena.AllianceSelectionShowTimer = false
-		web.arena.AllianceSelectionTimeRemainingSec = 0
+		web.stopAllianceSelectionTimer()
+	}
+	if err := web.saveAllianceSelection(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	web.arena.AllianceSelectionNotifier.Notify()
+	http.Redirect(w, r, "/alliance_selection", 303)
+}
+
+// Records an invitation from the alliance whose turn it is to pick to the given team.
+func (web *Web) allianceSelectionInvitePostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	if !web.canModifyAllianceSelection() {
+		web.renderAllianceSelection(w, r, "Alliance selection has already been finalized.")
+		return
+	}
+	events, err := web.arena.Database.GetAllAllianceSelectionEvents()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if pendingInvite := model.PendingAllianceSelectionInvite(events); pendingInvite != nil {
+		web.renderAllianceSelection(
+			w,
+			r,
+			fmt.Sprintf(
+				"Team %d must accept or decline the invitation from Alliance %d first.",
+				pendingInvite.TeamId,
+				pendingInvite.AllianceId,
+			),
+		)
+		return
+	}
+
+	allianceIndex, spot := web.determineNextCell()
+	if allianceIndex == -1 {
+		web.renderAllianceSelection(w, r, "There are no alliance spots left to fill.")
+		return
+	}
+	teamString := r.PostFormValue("teamId")
+	teamId, err := strconv.Atoi(teamString)
+	if err != nil {
+		web.renderAllianceSelection(w, r, fmt.Sprintf("Invalid team number value '%s'.", teamString))
+		return
+	}
+
+	// If the inviting alliance doesn't have a captain yet, promote the highest-ranked team that remains, which may be
+	// one that has declined an invitation.
+	captainId := 0
+	if spot == 0 {
+		captainId = web.nextAllianceSelectionCaptain()
+		if captainId == 0 {
+			web.renderAllianceSelection(w, r, fmt.Sprintf("No teams remain to captain Alliance %d.", allianceIndex+1))
+			return
+		}
+		if captainId == teamId {
+			web.renderAllianceSelection(
+				w,
+				r,
+				fmt.Sprintf("Team %d is next in line to captain Alliance %d and can't be invited to it.", teamId,
+					allianceIndex+1),
+			)
+			return
+		}
+		spot = 1
+	}
+	if errorMessage := web.checkAllianceSelectionTeam(teamId, spot); errorMessage != "" {
+		web.renderAllianceSelection(w, r, errorMessage)
+		return
+	}
+	if captainId != 0 {
+		web.arena.AllianceSelectionAlliances[allianceIndex].TeamIds[0] = captainId
+		web.setAllianceSelectionTeamPicked(captainId, true)
+		event := model.AllianceSelectionEvent{
+			Time: time.Now(), Type: model.AssignEvent, AllianceId: allianceIndex + 1, Spot: 0, TeamId: captainId,
+		}
+		if err = web.arena.Database.CreateAllianceSelectionEvent(&event); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if err = web.saveAllianceSelection(); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		web.arena.AllianceSelectionNotifier.Notify()
+	}
+
+	event := model.AllianceSelectionEvent{
+		Time: time.Now(), Type: model.InviteEvent, AllianceId: allianceIndex + 1, Spot: spot, TeamId: teamId,
+	}
+	if err = web.arena.Database.CreateAllianceSelectionEvent(&event); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	http.Redirect(w, r, "/alliance_selection", 303)
+}
+
+// Places the invited team into the spot of the alliance that invited it.
+func (web *Web) allianceSelectionAcceptPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	pendingInvite, ok := web.getPendingAllianceSelectionInvite(w, r)
+	if !ok {
+		return
+	}
+	spot := web.getAllianceSelectionSpot(pendingInvite)
+	if spot == nil || *spot != 0 {
+		web.renderAllianceSelection(
+			w, r, fmt.Sprintf("The spot that Alliance %d invited team %d to has since been filled.",
+				pendingInvite.AllianceId, pendingInvite.TeamId),
+		)
+		return
+	}
+	if errorMessage := web.checkAllianceSelectionTeam(pendingInvite.TeamId, pendingInvite.Spot); errorMessage != "" {
+		web.renderAllianceSelection(w, r, errorMessage)
+		return
+	}
+
+	*spot = pendingInvite.TeamId
+	web.setAllianceSelectionTeamPicked(pendingInvite.TeamId, true)
+	event := *pendingInvite
+	event.Id = 0
+	event.Time = time.Now()
+	event.Type = model.AcceptEvent
+	if err := web.arena.Database.CreateAllianceSelectionEvent(&event); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if allianceSelectionTicker != nil {
+		web.stopAllianceSelectionTimer()
+	}
+	if err := web.saveAllianceSelection(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	web.arena.AllianceSelectionNotifier.Notify()
+	http.Redirect(w, r, "/alliance_selection", 303)
+}
+
+// Records that the invited team turned down the invitation, leaving the spot open and making the team ineligible to be
+// picked by any alliance.
+func (web *Web) allianceSelectionDeclinePostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	pendingInvite, ok := web.getPendingAllianceSelectionInvite(w, r)
+	if !ok {
+		return
+	}
+	event := *pendingInvite
+	event.Id = 0
+	event.Time = time.Now()
+	event.Type = model.DeclineEvent
+	if err := web.arena.Database.CreateAllianceSelectionEvent(&event); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	for i, team := range web.arena.AllianceSelectionRankedTeams {
+		if team.TeamId == pendingInvite.TeamId {
+			web.arena.AllianceSelectionRankedTeams[i].Declined = true
+		}
+	}
+	if err := web.saveAllianceSelection(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	web.arena.AllianceSelectionNotifier.Notify()
+	http.Redirect(w, r, "/alliance_selection", 303)
+}
+
+// Reverses the most recent pick or declined invitation, taking the picked team back out of its alliance or making the
+// team that declined eligible to be picked again.
+func (web *Web) allianceSelectionUndoPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	if !web.canModifyAllianceSelection() {
+		web.renderAllianceSelection(w, r, "Alliance selection has already been finalized.")
+		return
+	}
+	events, err := web.arena.Database.GetAllAllianceSelectionEvents()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	lastEvent := model.LastUndoableAllianceSelectionEvent(events)
+	if lastEvent == nil {
+		web.renderAllianceSelection(w, r, "There is nothing to undo.")
+		return
+	}
+	if lastEvent.Type == model.DeclineEvent {
+		for i, team := range web.arena.AllianceSelectionRankedTeams {
+			if team.TeamId == lastEvent.TeamId {
+				web.arena.AllianceSelectionRankedTeams[i].Declined = false
+			}
+		}
+	} else {
+		spot := web.getAllianceSelectionSpot(lastEvent)
+		if spot == nil || *spot != lastEvent.TeamId {
+			web.renderAllianceSelection(
+				w,
+				r,
+				fmt.Sprintf("Can't undo the pick of team %d since its spot has since been changed.", lastEvent.TeamId),
+			)
+			return
+		}
+		*spot = 0
+		web.setAllianceSelectionTeamPicked(lastEvent.TeamId, false)
+	}
+	event := model.AllianceSelectionEvent{
+		Time:          time.Now(),
+		Type:          model.UndoEvent,
+		AllianceId:    lastEvent.AllianceId,
+		Spot:          lastEvent.Spot,
+		TeamId:        lastEvent.TeamId,
+		UndoneEventId: lastEvent.Id,
+	}
+	if err = web.arena.Database.CreateAllianceSelectionEvent(&event); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if err = web.saveAllianceSelection(); err != nil {
+		handleWebErr(w, err)
+		return
 	}
 
 	web.arena.AllianceSelectionNotifier.Notify()
@@ -138,6 +398,10 @@ func (web *Web) allianceSelectionStartHandler(w http.ResponseWriter, r *http.Req
 			Picked: false,
 		}
 	}
+	if err = web.saveAllianceSelection(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
 
 	web.arena.AllianceSelectionNotifier.Notify()
 	http.Redirect(w, r, "/alliance_selection", 303)
@@ -161,11 +425,23 @@ func (web *Web) allianceSelectionResetHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// Delete the saved alliances.
+	// Delete the saved alliances, the record of the selection and anything else that belongs to the alliances.
 	if err = web.arena.Database.TruncateAlliances(); err != nil {
 		handleWebErr(w, err)
 		return
 	}
+	if err = web.arena.Database.TruncateAllianceSelection(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if err = web.arena.Database.TruncateAllianceTimeouts(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if err = web.arena.Database.TruncateLineups(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
 
 	web.arena.AllianceSelectionAlliances = []model.Alliance{}
 	web.arena.AllianceSelectionRankedTeams = []model.AllianceSelectionRankedTeam{}
@@ -214,6 +490,19 @@ func (web *Web) allianceSelectionFinalizeHandler(w http.ResponseWriter, r *http.
 			handleWebErr(w, err)
 			return
 		}
+
+		// Issue the PIN that the alliance captain will use to submit lineups.
+		if err = web.resetLineupPin(alliance.Id); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	// The alliances are now saved in their final form, so the in-progress selection no longer needs to be kept; its
+	// events are retained for the log.
+	if err = web.arena.Database.DeleteAllianceSelection(); err != nil {
+		handleWebErr(w, err)
+		return
 	}
 
 	// Generate the first round of playoff matches.
@@ -299,25 +588,17 @@ func (web *Web) allianceSelectionWebsocketHandler(w http.ResponseWriter, r *http
 			}
 		case "startTimer":
 			if !web.arena.AllianceSelectionShowTimer {
-				web.arena.AllianceSelectionShowTimer = true
-				web.arena.AllianceSelectionTimeRemainingSec = allianceSelectionTimeLimitSec
-				web.arena.AllianceSelectionNotifier.Notify()
-				allianceSelectionTicker = time.NewTicker(time.Second)
-				go func() {
-					for range allianceSelectionTicker.C {
-						web.arena.AllianceSelectionTimeRemainingSec--
-						web.arena.AllianceSelectionNotifier.Notify()
-						if web.arena.AllianceSelectionTimeRemainingSec == 0 {
-							allianceSelectionTicker.Stop()
-						}
-					}
-				}()
+				timeLimit := time.Duration(allianceSelectionTimeLimitSec) * time.Second
+				web.startAllianceSelectionTimer(time.Now().Add(timeLimit))
+				if err = web.saveAllianceSelection(); err != nil {
+					ws.WriteError(err.Error())
+				}
 			}
 		case "stopTimer":
-			allianceSelectionTicker.Stop()
-			web.arena.AllianceSelectionShowTimer = false
-			web.arena.AllianceSelectionTimeRemainingSec = 0
-			web.arena.AllianceSelectionNotifier.Notify()
+			web.stopAllianceSelectionTimer()
+			if err = web.saveAllianceSelection(); err != nil {
+				ws.WriteError(err.Error())
+			}
 		default:
 			ws.WriteError(fmt.Sprintf("Invalid message type '%s'.", messageType))
 		}
@@ -341,15 +622,22 @@ func (web *Web) renderAllianceSelection(w http.ResponseWriter, r *http.Request,
 		handleWebErr(w, err)
 		return
 	}
+	events, err := web.arena.Database.GetAllAllianceSelectionEvents()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
 	nextRow, nextCol := web.determineNextCell()
 	data := struct {
 		*model.EventSettings
-		Alliances    []model.Alliance
-		RankedTeams  []model.AllianceSelectionRankedTeam
-		NextRow      int
-		NextCol      int
-		ErrorMessage string
-		TimeLimitSec int
+		Alliances     []model.Alliance
+		RankedTeams   []model.AllianceSelectionRankedTeam
+		NextRow       int
+		NextCol       int
+		ErrorMessage  string
+		TimeLimitSec  int
+		PendingInvite *model.AllianceSelectionEvent
+		CanUndo       bool
 	}{
 		web.arena.EventSettings,
 		web.arena.AllianceSelectionAlliances,
@@ -358,6 +646,8 @@ func (web *Web) renderAllianceSelection(w http.ResponseWriter, r *http.Request,
 		nextCol,
 		errorMessage,
 		allianceSelectionTimeLimitSec,
+		model.PendingAllianceSelectionInvite(events),
+		model.LastUndoableAllianceSelectionEvent(events) != nil,
 	}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
@@ -366,6 +656,156 @@ func (web *Web) renderAllianceSelection(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+// Persists the state of the alliance selection so that it can be resumed if the server is restarted partway through.
+func (web *Web) saveAllianceSelection() error {
+	if len(web.arena.AllianceSelectionAlliances) == 0 || !web.canModifyAllianceSelection() {
+		// There is no selection underway to save.
+		return nil
+	}
+	allianceSelection := model.AllianceSelection{
+		Alliances:    web.arena.AllianceSelectionAlliances,
+		RankedTeams:  web.arena.AllianceSelectionRankedTeams,
+		ShowTimer:    web.arena.AllianceSelectionShowTimer,
+		TimerEndTime: allianceSelectionTimerEndTime,
+	}
+	return web.arena.Database.SaveAllianceSelection(&allianceSelection)
+}
+
+// Restores the alliance selection that was underway when the server was last stopped, if any, including its timer.
+func (web *Web) loadAllianceSelection() error {
+	allianceSelection, err := web.arena.Database.GetAllianceSelection()
+	if err != nil {
+		return err
+	}
+	if allianceSelection == nil {
+		// Any finalized alliances will be reloaded from the database when the alliance selection page is rendered.
+		allianceSelection = &model.AllianceSelection{
+			Alliances: []model.Alliance{}, RankedTeams: []model.AllianceSelectionRankedTeam{},
+		}
+	}
+	web.arena.AllianceSelectionAlliances = allianceSelection.Alliances
+	web.arena.AllianceSelectionRankedTeams = allianceSelection.RankedTeams
+	if allianceSelection.ShowTimer {
+		web.startAllianceSelectionTimer(allianceSelection.TimerEndTime)
+	} else {
+		web.stopAllianceSelectionTimer()
+	}
+	return nil
+}
+
+// Shows the alliance selection timer and starts it counting down to the given time.
+func (web *Web) startAllianceSelectionTimer(endTime time.Time) {
+	if allianceSelectionTicker != nil {
+		allianceSelectionTicker.Stop()
+	}
+	allianceSelectionTimerEndTime = endTime
+	web.arena.AllianceSelectionShowTimer = true
+	web.arena.AllianceSelectionTimeRemainingSec = max(int(math.Ceil(time.Until(endTime).Seconds())), 0)
+	web.arena.AllianceSelectionNotifier.Notify()
+	if web.arena.AllianceSelectionTimeRemainingSec == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Second)
+	allianceSelectionTicker = ticker
+	go func() {
+		for range ticker.C {
+			web.arena.AllianceSelectionTimeRemainingSec--
+			web.arena.AllianceSelectionNotifier.Notify()
+			if web.arena.AllianceSelectionTimeRemainingSec == 0 {
+				ticker.Stop()
+			}
+		}
+	}()
+}
+
+// Stops and hides the alliance selection timer.
+func (web *Web) stopAllianceSelectionTimer() {
+	if allianceSelectionTicker != nil {
+		allianceSelectionTicker.Stop()
+	}
+	allianceSelectionTimerEndTime = time.Time{}
+	web.arena.AllianceSelectionShowTimer = false
+	web.arena.AllianceSelectionTimeRemainingSec = 0
+	web.arena.AllianceSelectionNotifier.Notify()
+}
+
+// Returns the invitation awaiting a response, or renders an error and returns false if there isn't one.
+func (web *Web) getPendingAllianceSelectionInvite(
+	w http.ResponseWriter, r *http.Request,
+) (*model.AllianceSelectionEvent, bool) {
+	if !web.canModifyAllianceSelection() {
+		web.renderAllianceSelection(w, r, "Alliance selection has already been finalized.")
+		return nil, false
+	}
+	events, err := web.arena.Database.GetAllAllianceSelectionEvents()
+	if err != nil {
+		handleWebErr(w, err)
+		return nil, false
+	}
+	pendingInvite := model.PendingAllianceSelectionInvite(events)
+	if pendingInvite == nil {
+		web.renderAllianceSelection(w, r, "There is no invitation awaiting a response.")
+		return nil, false
+	}
+	return pendingInvite, true
+}
+
+// Returns a pointer to the alliance spot that the given event refers to, or nil if no such spot exists.
+func (web *Web) getAllianceSelectionSpot(event *model.AllianceSelectionEvent) *int {
+	allianceIndex := event.AllianceId - 1
+	if allianceIndex < 0 || allianceIndex >= len(web.arena.AllianceSelectionAlliances) {
+		return nil
+	}
+	teamIds := web.arena.AllianceSelectionAlliances[allianceIndex].TeamIds
+	if event.Spot < 0 || event.Spot >= len(teamIds) {
+		return nil
+	}
+	return &teamIds[event.Spot]
+}
+
+// Returns a message explaining why the given team can't fill the given spot of an alliance, or the empty string if it
+// can.
+func (web *Web) checkAllianceSelectionTeam(teamId, spot int) string {
+	for _, team := range web.arena.AllianceSelectionRankedTeams {
+		if team.TeamId == teamId {
+			if team.Picked {
+				return fmt.Sprintf("Team %d is already part of an alliance.", teamId)
+			}
+			if team.Declined && spot > 0 {
+				return declinedTeamMessage(teamId)
+			}
+			return ""
+		}
+	}
+	return fmt.Sprintf("Team %d has not played any matches at this event and is ineligible for selection.", teamId)
+}
+
+func declinedTeamMessage(teamId int) string {
+	return fmt.Sprintf(
+		"Team %d has declined an invitation and can no longer be picked, only serve as a captain.", teamId,
+	)
+}
+
+// Returns the highest-ranked team that isn't yet part of an alliance, or zero if there are none left.
+func (web *Web) nextAllianceSelectionCaptain() int {
+	for _, team := range web.arena.AllianceSelectionRankedTeams {
+		if !team.Picked {
+			return team.TeamId
+		}
+	}
+	return 0
+}
+
+// Marks the given team in the ranked list as being part of an alliance or not.
+func (web *Web) setAllianceSelectionTeamPicked(teamId int, picked bool) {
+	for i, team := range web.arena.AllianceSelectionRankedTeams {
+		if team.TeamId == teamId {
+			web.arena.AllianceSelectionRankedTeams[i].Picked = picked
+		}
+	}
+}
+
 // Returns true if it is safe to change the alliance selection (i.e. no playoff matches exist yet).
 func (web *Web) canModifyAllianceSelection() bool {
 	matches, err := web.arena.Database.GetMatchesByType(model.Playoff, true)
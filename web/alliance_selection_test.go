@@ -11,6 +11,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestAllianceSelection(t *testing.T) {
@@ -92,6 +93,12 @@ func TestAllianceSelection(t *testing.T) {
 		assert.Equal(t, 101, alliances[0].Lineup[1])
 		assert.Equal(t, 103, alliances[0].Lineup[2])
 	}
+	for _, alliance := range alliances {
+		lineupPin, _ := web.arena.Database.GetLineupPinByAlliance(alliance.Id)
+		if assert.NotNil(t, lineupPin) {
+			assert.Regexp(t, "^[0-9]{8}$", lineupPin.Pin)
+		}
+	}
 	matches, err := web.arena.Database.GetMatchesByType(model.Playoff, false)
 	assert.Nil(t, err)
 	assert.Equal(t, 16, len(matches))
@@ -214,6 +221,197 @@ func TestAllianceSelectionReset(t *testing.T) {
 	assert.NotEmpty(t, matches)
 }
 
+func TestAllianceSelectionInvitations(t *testing.T) {
+	web := setupTestWeb(t)
+
+	web.arena.EventSettings.PlayoffType = model.SingleEliminationPlayoff
+	web.arena.EventSettings.NumPlayoffAlliances = 2
+	for i := 1; i <= 6; i++ {
+		web.arena.Database.CreateRanking(&game.Ranking{TeamId: 100 + i, Rank: i})
+	}
+	recorder := web.postHttpResponse("/alliance_selection/start", "")
+	assert.Equal(t, 303, recorder.Code)
+
+	// Respond without an invitation and invite ineligible teams.
+	recorder = web.postHttpResponse("/alliance_selection/accept", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "no invitation awaiting a response")
+	recorder = web.postHttpResponse("/alliance_selection/invite", "teamId=asdf")
+	assert.Contains(t, recorder.Body.String(), "Invalid team number")
+	recorder = web.postHttpResponse("/alliance_selection/invite", "teamId=101")
+	assert.Contains(t, recorder.Body.String(), "Team 101 is next in line to captain Alliance 1")
+	recorder = web.postHttpResponse("/alliance_selection/invite", "teamId=254")
+	assert.Contains(t, recorder.Body.String(), "ineligible for selection")
+	assert.Equal(t, []int{0, 0, 0}, web.arena.AllianceSelectionAlliances[0].TeamIds)
+
+	// Invite a team that declines, which should promote the top-ranked team to captain.
+	recorder = web.postHttpResponse("/alliance_selection/invite", "teamId=102")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, []int{101, 0, 0}, web.arena.AllianceSelectionAlliances[0].TeamIds)
+	recorder = web.getHttpResponse("/alliance_selection")
+	assert.Contains(t, recorder.Body.String(), "Alliance 1 has invited team 102.")
+	recorder = web.postHttpResponse("/alliance_selection/invite", "teamId=103")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 102 must accept or decline the invitation from Alliance 1 first.")
+	recorder = web.postHttpResponse("/alliance_selection/decline", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, []int{101, 0, 0}, web.arena.AllianceSelectionAlliances[0].TeamIds)
+	assert.True(t, web.arena.AllianceSelectionRankedTeams[1].Declined)
+	assert.False(t, web.arena.AllianceSelectionRankedTeams[1].Picked)
+	recorder = web.getHttpResponse("/alliance_selection")
+	assert.Contains(t, recorder.Body.String(), "Declined")
+
+	// Check that a decline entered by mistake can be undone, and then decline again.
+	recorder = web.postHttpResponse("/alliance_selection/undo", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.False(t, web.arena.AllianceSelectionRankedTeams[1].Declined)
+	assert.Equal(t, []int{101, 0, 0}, web.arena.AllianceSelectionAlliances[0].TeamIds)
+	recorder = web.postHttpResponse("/alliance_selection/invite", "teamId=102")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.postHttpResponse("/alliance_selection/decline", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.True(t, web.arena.AllianceSelectionRankedTeams[1].Declined)
+
+	// Check that the team that declined can't be picked afterward.
+	recorder = web.postHttpResponse("/alliance_selection/invite", "teamId=102")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 102 has declined an invitation and can no longer be picked")
+	recorder = web.postHttpResponse("/alliance_selection", "selection0_0=101&selection0_1=102")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 102 has declined an invitation and can no longer be picked")
+	recorder = web.postHttpResponse("/alliance_selection", "selection0_0=101")
+	assert.Equal(t, 303, recorder.Code)
+
+	// Invite a team that accepts.
+	recorder = web.postHttpResponse("/alliance_selection/invite", "teamId=103")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.postHttpResponse("/alliance_selection/accept", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, []int{101, 103, 0}, web.arena.AllianceSelectionAlliances[0].TeamIds)
+	assert.True(t, web.arena.AllianceSelectionRankedTeams[2].Picked)
+	recorder = web.getHttpResponse("/alliance_selection")
+	assert.NotContains(t, recorder.Body.String(), "has invited team")
+
+	// Check that the team that declined is still promoted to captain the next alliance.
+	recorder = web.postHttpResponse("/alliance_selection/invite", "teamId=104")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, []int{102, 0, 0}, web.arena.AllianceSelectionAlliances[1].TeamIds)
+	recorder = web.postHttpResponse("/alliance_selection/accept", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, []int{102, 104, 0}, web.arena.AllianceSelectionAlliances[1].TeamIds)
+
+	// Undo the picks one at a time.
+	recorder = web.postHttpResponse("/alliance_selection/undo", "")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.postHttpResponse("/alliance_selection/undo", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, []int{0, 0, 0}, web.arena.AllianceSelectionAlliances[1].TeamIds)
+	recorder = web.postHttpResponse("/alliance_selection/undo", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, []int{101, 0, 0}, web.arena.AllianceSelectionAlliances[0].TeamIds)
+	assert.False(t, web.arena.AllianceSelectionRankedTeams[2].Picked)
+	recorder = web.postHttpResponse("/alliance_selection/undo", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.False(t, web.arena.AllianceSelectionRankedTeams[1].Declined)
+	recorder = web.postHttpResponse("/alliance_selection/undo", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, []int{0, 0, 0}, web.arena.AllianceSelectionAlliances[0].TeamIds)
+	recorder = web.postHttpResponse("/alliance_selection/undo", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "There is nothing to undo.")
+
+	events, _ := web.arena.Database.GetAllAllianceSelectionEvents()
+	var eventTypes []model.AllianceSelectionEventType
+	for _, event := range events {
+		eventTypes = append(eventTypes, event.Type)
+	}
+	assert.Equal(
+		t,
+		[]model.AllianceSelectionEventType{
+			model.AssignEvent,
+			model.InviteEvent,
+			model.DeclineEvent,
+			model.UndoEvent,
+			model.InviteEvent,
+			model.DeclineEvent,
+			model.InviteEvent,
+			model.AcceptEvent,
+			model.AssignEvent,
+			model.InviteEvent,
+			model.AcceptEvent,
+			model.UndoEvent,
+			model.UndoEvent,
+			model.UndoEvent,
+			model.UndoEvent,
+			model.UndoEvent,
+		},
+		eventTypes,
+	)
+
+	// Check that a pick can't be undone once its spot has been changed by hand.
+	recorder = web.postHttpResponse("/alliance_selection", "selection0_0=101")
+	assert.Equal(t, 303, recorder.Code)
+	web.arena.AllianceSelectionAlliances[0].TeamIds[0] = 105
+	recorder = web.postHttpResponse("/alliance_selection/undo", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "its spot has since been changed")
+
+	// Check that resetting the alliance selection clears its history and the declines.
+	recorder = web.postHttpResponse("/alliance_selection/reset", "")
+	assert.Equal(t, 303, recorder.Code)
+	events, _ = web.arena.Database.GetAllAllianceSelectionEvents()
+	assert.Empty(t, events)
+	recorder = web.postHttpResponse("/alliance_selection/start", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.False(t, web.arena.AllianceSelectionRankedTeams[1].Declined)
+}
+
+func TestAllianceSelectionResume(t *testing.T) {
+	web := setupTestWeb(t)
+
+	web.arena.EventSettings.PlayoffType = model.SingleEliminationPlayoff
+	web.arena.EventSettings.NumPlayoffAlliances = 2
+	for i := 1; i <= 6; i++ {
+		web.arena.Database.CreateRanking(&game.Ranking{TeamId: 100 + i, Rank: i})
+	}
+	recorder := web.postHttpResponse("/alliance_selection/start", "")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.postHttpResponse("/alliance_selection", "selection0_0=101&selection0_1=102")
+	assert.Equal(t, 303, recorder.Code)
+	web.startAllianceSelectionTimer(time.Now().Add(30 * time.Second))
+	assert.Nil(t, web.saveAllianceSelection())
+
+	// Simulate a restart of the server and check that the selection picks up where it left off.
+	web.stopAllianceSelectionTimer()
+	web.arena.AllianceSelectionAlliances = []model.Alliance{}
+	web.arena.AllianceSelectionRankedTeams = []model.AllianceSelectionRankedTeam{}
+	web = NewWeb(web.arena)
+	if assert.Equal(t, 2, len(web.arena.AllianceSelectionAlliances)) {
+		assert.Equal(t, []int{101, 102, 0}, web.arena.AllianceSelectionAlliances[0].TeamIds)
+	}
+	if assert.Equal(t, 6, len(web.arena.AllianceSelectionRankedTeams)) {
+		assert.True(t, web.arena.AllianceSelectionRankedTeams[1].Picked)
+		assert.False(t, web.arena.AllianceSelectionRankedTeams[2].Picked)
+	}
+	assert.True(t, web.arena.AllianceSelectionShowTimer)
+	assert.Greater(t, web.arena.AllianceSelectionTimeRemainingSec, 25)
+	recorder = web.postHttpResponse("/alliance_selection/undo", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, []int{101, 0, 0}, web.arena.AllianceSelectionAlliances[0].TeamIds)
+
+	// Finalize the alliance selection and check that it is no longer resumed.
+	recorder = web.postHttpResponse("/alliance_selection", "selection0_0=101&selection0_1=102&selection0_2=103&"+
+		"selection1_0=104&selection1_1=105&selection1_2=106")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.postHttpResponse("/alliance_selection/finalize", "startTime=2014-01-01 01:00:00 PM")
+	assert.Equal(t, 303, recorder.Code)
+	allianceSelection, err := web.arena.Database.GetAllianceSelection()
+	assert.Nil(t, err)
+	assert.Nil(t, allianceSelection)
+	events, _ := web.arena.Database.GetAllAllianceSelectionEvents()
+	assert.NotEmpty(t, events)
+}
+
 func TestAllianceSelectionAutofocus(t *testing.T) {
 	web := setupTestWeb(t)
 
@@ -48,6 +48,7 @@ func (web *Web) allianceStationDisplayWebsocketHandler(w http.ResponseWriter, r
 		return
 	}
 	defer ws.Close()
+	ws.SetDropCounter(display.DropCounter())
 
 	// Subscribe the websocket to the notifiers whose messages will be passed on to the client.
 	ws.HandleNotifiers(display.Notifier, web.arena.MatchTimingNotifier, web.arena.AllianceStationDisplayModeNotifier,
@@ -78,6 +78,7 @@ func (web *Web) announcerDisplayWebsocketHandler(w http.ResponseWriter, r *http.
 		return
 	}
 	defer ws.Close()
+	ws.SetDropCounter(display.DropCounter())
 
 	// Subscribe the websocket to the notifiers whose messages will be passed on to the client.
 	ws.HandleNotifiers(
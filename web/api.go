@@ -100,6 +100,12 @@ func (web *Web) sponsorSlidesApiHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Lead the slideshow with what's next on the agenda so that the audience knows what to expect.
+	if agendaMessage := web.arena.GetEventStatus().AgendaMessage; agendaMessage != "" {
+		agendaSlide := model.SponsorSlide{Line1: agendaMessage, DisplayTimeSec: 10}
+		sponsors = append([]model.SponsorSlide{agendaSlide}, sponsors...)
+	}
+
 	if sponsors == nil {
 		// Go marshals an empty slice to null, so explicitly create it so that it appears as an empty JSON array.
 		sponsors = make([]model.SponsorSlide, 0)
@@ -4,9 +4,12 @@
 package web
 
 import (
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/relay"
 	"github.com/Team254/cheesy-arena/websocket"
 	gorillawebsocket "github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"net/http"
 	"testing"
 )
 
@@ -81,3 +84,36 @@ func TestAudienceDisplayWebsocket(t *testing.T) {
 	web.arena.LowerThirdNotifier.Notify()
 	readWebsocketType(t, ws, "lowerThird")
 }
+
+func TestAudienceDisplayRelayed(t *testing.T) {
+	web := setupTestWeb(t)
+	relayed := map[string]string{relay.RelayedRequestHeader: "true"}
+	venueDisplay := field.DisplayConfiguration{
+		Id: "100", Type: field.AudienceDisplay, Configuration: map[string]string{"background": "#0f0"},
+	}
+	web.arena.RegisterDisplay(&venueDisplay, "10.0.100.5")
+
+	// New displays opened through the relay should be given IDs in the relay's namespace.
+	recorder := web.getHttpResponseWithHeaders("/displays/audience", relayed)
+	assert.Equal(t, 302, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("Location"), "displayId=relay-101")
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	dial := func(query string, header http.Header) int {
+		conn, resp, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/displays/audience/websocket?"+query, header)
+		if err == nil {
+			conn.Close()
+		}
+		return resp.StatusCode
+	}
+	relayedHeader := http.Header{relay.RelayedRequestHeader: {"true"}}
+
+	// Relayed connections may not touch the venue's displays, nor local connections the relay's.
+	assert.Equal(t, 500, dial("displayId=100&background=%23f00", relayedHeader))
+	assert.Equal(t, 500, dial("displayId=relay-100&background=%23f00", nil))
+	assert.Equal(t, venueDisplay, web.arena.Displays["100"].DisplayConfiguration)
+	assert.NotContains(t, web.arena.Displays, "relay-100")
+	assert.Equal(t, 101, dial("displayId=relay-100&background=%23f00", relayedHeader))
+	assert.Contains(t, web.arena.Displays, "relay-100")
+}
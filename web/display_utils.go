@@ -8,6 +8,7 @@ package web
 import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/relay"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -23,11 +24,18 @@ func (web *Web) enforceDisplayConfiguration(w http.ResponseWriter, r *http.Reque
 	var displayId string
 	if displayId = r.URL.Query().Get("displayId"); displayId == "" {
 		displayId = web.arena.NextDisplayId()
+		if relay.IsRelayedRequest(r) {
+			displayId = relay.RelayDisplayIdPrefix + displayId
+		}
 		allPresent = false
 	}
 	if nickname := r.URL.Query().Get("nickname"); nickname != "" {
 		configuration["nickname"] = nickname
 	}
+	if fieldNumber := r.URL.Query().Get(fieldParameter); fieldNumber != "" {
+		// Keep the display on the field it was opened for.
+		configuration[fieldParameter] = fieldNumber
+	}
 
 	// Get display-specific fields from the query parameters.
 	if defaults != nil {
@@ -61,6 +69,11 @@ func (web *Web) registerDisplay(r *http.Request) (*field.Display, error) {
 		return nil, err
 	}
 
+	// Keep displays opened through the relay from touching the venue's own displays, and vice versa.
+	if relay.IsRelayedRequest(r) != strings.HasPrefix(displayConfig.Id, relay.RelayDisplayIdPrefix) {
+		return nil, fmt.Errorf("display ID '%s' is not valid for this connection", displayConfig.Id)
+	}
+
 	// Extract the source IP address of the request and store it in the display object.
 	var ipAddress string
 	if ipAddress = r.Header.Get("X-Real-IP"); ipAddress == "" {
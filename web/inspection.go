@@ -0,0 +1,121 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Web routes for recording the robot and safety inspections of the teams.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Shows the inspection status of every team, along with the inspection form and history for the selected team.
+func (web *Web) inspectionGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	teamId, _ := strconv.Atoi(r.URL.Query().Get("teamId"))
+	web.renderInspection(w, r, teamId, "")
+}
+
+// Records the result of an inspection of a team.
+func (web *Web) inspectionPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	teamId, _ := strconv.Atoi(r.PostFormValue("teamId"))
+	team, err := web.arena.Database.GetTeamById(teamId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if team == nil {
+		web.renderInspection(w, r, 0, fmt.Sprintf("Team %d isn't present at this event.", teamId))
+		return
+	}
+
+	inspection := model.TeamInspection{
+		TeamId:      team.Id,
+		Inspector:   r.PostFormValue("inspector"),
+		Passed:      r.PostFormValue("result") == "passed",
+		BumpersOk:   r.PostFormValue("bumpersOk") == "on",
+		Notes:       r.PostFormValue("notes"),
+		InspectedAt: time.Now(),
+	}
+	if inspection.Inspector == "" {
+		web.renderInspection(w, r, team.Id, "The inspector's name must be entered.")
+		return
+	}
+	if result := r.PostFormValue("result"); result != "passed" && result != "failed" {
+		web.renderInspection(w, r, team.Id, "The inspection must be marked as passed or failed.")
+		return
+	}
+	if weight := r.PostFormValue("weightLbs"); weight != "" {
+		if inspection.WeightLbs, err = strconv.ParseFloat(weight, 64); err != nil || inspection.WeightLbs < 0 {
+			web.renderInspection(w, r, team.Id, "Must specify a valid robot weight.")
+			return
+		}
+	}
+	if err = web.arena.Database.CreateTeamInspection(&inspection); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Refresh the pit display and the uninspected team warning for the current match.
+	web.arena.InspectionNotifier.Notify()
+	web.arena.MatchLoadNotifier.Notify()
+
+	http.Redirect(w, r, "/inspection", 303)
+}
+
+func (web *Web) renderInspection(w http.ResponseWriter, r *http.Request, teamId int, errorMessage string) {
+	summary, err := web.arena.GetInspectionSummary()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var team *model.Team
+	var history []model.TeamInspection
+	if teamId > 0 {
+		if team, err = web.arena.Database.GetTeamById(teamId); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if team != nil {
+			inspections, err := web.arena.Database.GetTeamInspectionsByTeam(teamId)
+			if err != nil {
+				handleWebErr(w, err)
+				return
+			}
+			// Show the most recent inspection first.
+			for i := len(inspections) - 1; i >= 0; i-- {
+				history = append(history, inspections[i])
+			}
+		}
+	}
+
+	template, err := web.parseFiles("templates/inspection.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Summary      *field.InspectionSummary
+		Team         *model.Team
+		History      []model.TeamInspection
+		Inspector    string
+		ErrorMessage string
+	}{web.arena.EventSettings, summary, team, history, r.PostFormValue("inspector"), errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
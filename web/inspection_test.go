@@ -0,0 +1,65 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestInspection(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254, Nickname: "The Cheesy Poofs"})
+	web.arena.Database.CreateTeam(&model.Team{Id: 1114, Nickname: "Simbotics"})
+
+	recorder := web.getHttpResponse("/inspection")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Not Inspected: 2")
+	assert.Contains(t, recorder.Body.String(), "/inspection?teamId=1114")
+
+	recorder = web.getHttpResponse("/inspection?teamId=254")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The Cheesy Poofs")
+	assert.Contains(t, recorder.Body.String(), "Team 254 hasn't been inspected yet.")
+
+	// Check the validation of the inspection form.
+	recorder = web.postHttpResponse("/inspection", "teamId=9999&inspector=Alice&result=passed")
+	assert.Contains(t, recorder.Body.String(), "Team 9999 isn't present at this event.")
+	recorder = web.postHttpResponse("/inspection", "teamId=254&result=passed")
+	assert.Contains(t, recorder.Body.String(), "The inspector's name must be entered.")
+	recorder = web.postHttpResponse("/inspection", "teamId=254&inspector=Alice")
+	assert.Contains(t, recorder.Body.String(), "The inspection must be marked as passed or failed.")
+	recorder = web.postHttpResponse("/inspection", "teamId=254&inspector=Alice&result=passed&weightLbs=heavy")
+	assert.Contains(t, recorder.Body.String(), "Must specify a valid robot weight.")
+	inspections, _ := web.arena.Database.GetAllTeamInspections()
+	assert.Empty(t, inspections)
+
+	// Record a failed inspection followed by a passed one.
+	recorder = web.postHttpResponse(
+		"/inspection", "teamId=254&inspector=Alice&result=failed&weightLbs=127.5&notes=Too heavy",
+	)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	recorder = web.postHttpResponse(
+		"/inspection", "teamId=254&inspector=Bob&result=passed&weightLbs=124&bumpersOk=on",
+	)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	inspections, _ = web.arena.Database.GetTeamInspectionsByTeam(254)
+	if assert.Equal(t, 2, len(inspections)) {
+		assert.Equal(t, "Alice", inspections[0].Inspector)
+		assert.False(t, inspections[0].Passed)
+		assert.Equal(t, 127.5, inspections[0].WeightLbs)
+		assert.False(t, inspections[0].BumpersOk)
+		assert.Equal(t, "Too heavy", inspections[0].Notes)
+		assert.Equal(t, "Bob", inspections[1].Inspector)
+		assert.True(t, inspections[1].Passed)
+		assert.True(t, inspections[1].BumpersOk)
+	}
+
+	recorder = web.getHttpResponse("/inspection")
+	assert.Contains(t, recorder.Body.String(), "Passed: 1")
+	assert.Contains(t, recorder.Body.String(), "Not Inspected: 1")
+	recorder = web.getHttpResponse("/inspection?teamId=254")
+	assert.Contains(t, recorder.Body.String(), "Too heavy")
+	assert.Contains(t, recorder.Body.String(), "127.5")
+}
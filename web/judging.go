@@ -0,0 +1,256 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Web routes for the judges' nominations and deliberations, and for running the award ceremony.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/tournament"
+	"net/http"
+	"strconv"
+)
+
+// Holds an award definition along with the teams that have been nominated for it.
+type judgedAward struct {
+	model.AwardDefinition
+	Nominations []model.AwardNomination
+}
+
+// Shows the judged awards along with their nominations.
+func (web *Web) judgingGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	web.renderJudging(w, r, "")
+}
+
+// Saves the new or modified award definition to the database, or deletes it.
+func (web *Web) judgingAwardsPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	awardDefinitionId, _ := strconv.Atoi(r.PostFormValue("id"))
+	if r.PostFormValue("action") == "delete" {
+		if err := tournament.DeleteAwardDefinition(web.arena.Database, awardDefinitionId); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	} else {
+		ceremonyOrder, _ := strconv.Atoi(r.PostFormValue("ceremonyOrder"))
+		awardDefinition := model.AwardDefinition{
+			Id:            awardDefinitionId,
+			Name:          r.PostFormValue("name"),
+			Script:        r.PostFormValue("script"),
+			CeremonyOrder: ceremonyOrder,
+		}
+		if awardDefinition.Name == "" {
+			web.renderJudging(w, r, "The award must have a name.")
+			return
+		}
+		var err error
+		if awardDefinition.Id == 0 {
+			err = web.arena.Database.CreateAwardDefinition(&awardDefinition)
+		} else {
+			err = web.arena.Database.UpdateAwardDefinition(&awardDefinition)
+		}
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	http.Redirect(w, r, "/judging", 303)
+}
+
+// Adds, modifies or deletes a nomination for a judged award.
+func (web *Web) judgingNominationsPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	nominationId, _ := strconv.Atoi(r.PostFormValue("id"))
+	nomination := &model.AwardNomination{}
+	if nominationId > 0 {
+		var err error
+		if nomination, err = web.arena.Database.GetAwardNominationById(nominationId); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if nomination == nil {
+			handleWebErr(w, fmt.Errorf("nomination %d doesn't exist", nominationId))
+			return
+		}
+	}
+
+	if r.PostFormValue("action") == "delete" {
+		if err := tournament.DeleteAwardNomination(web.arena.Database, nomination); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		http.Redirect(w, r, "/judging", 303)
+		return
+	}
+
+	if nominationId == 0 {
+		nomination.AwardDefinitionId, _ = strconv.Atoi(r.PostFormValue("awardDefinitionId"))
+		awardDefinition, err := web.arena.Database.GetAwardDefinitionById(nomination.AwardDefinitionId)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if awardDefinition == nil {
+			handleWebErr(w, fmt.Errorf("award %d doesn't exist", nomination.AwardDefinitionId))
+			return
+		}
+	}
+	nomination.TeamId, _ = strconv.Atoi(r.PostFormValue("teamId"))
+	nomination.PersonName = r.PostFormValue("personName")
+	nomination.Notes = r.PostFormValue("notes")
+	nomination.IsWinner = r.PostFormValue("isWinner") == "on"
+	if nomination.TeamId == 0 && nomination.PersonName == "" {
+		web.renderJudging(w, r, "A nomination must be for a team or a person.")
+		return
+	}
+	if nomination.TeamId > 0 {
+		team, err := web.arena.Database.GetTeamById(nomination.TeamId)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if team == nil {
+			web.renderJudging(w, r, fmt.Sprintf("Team %d isn't present at this event.", nomination.TeamId))
+			return
+		}
+	}
+
+	var err error
+	if nomination.Id == 0 {
+		err = web.arena.Database.CreateAwardNomination(nomination)
+	} else {
+		err = web.arena.Database.UpdateAwardNomination(nomination)
+	}
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	http.Redirect(w, r, "/judging", 303)
+}
+
+// Generates the awards and lower thirds for the winners chosen by the judges. They aren't published until after the
+// ceremony so as not to spoil it.
+func (web *Web) judgingFinalizePostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	if err := tournament.FinalizeJudgedAwards(web.arena.Database); err != nil {
+		web.renderJudging(w, r, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, "/judging/ceremony", 303)
+}
+
+// Shows the run-of-show for the award ceremony, from which each award's lower thirds are displayed in turn.
+func (web *Web) judgingCeremonyGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	ceremony, err := tournament.GetAwardCeremony(web.arena.Database)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	template, err := web.parseFiles("templates/judging_ceremony.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var shownLowerThirdId int
+	if web.arena.ShowLowerThird && web.arena.LowerThird != nil {
+		shownLowerThirdId = web.arena.LowerThird.Id
+	}
+	data := struct {
+		*model.EventSettings
+		Ceremony          []tournament.AwardCeremonyItem
+		ShownLowerThirdId int
+	}{web.arena.EventSettings, ceremony, shownLowerThirdId}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Shows or hides the given lower third on the audience display.
+func (web *Web) judgingCeremonyPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	if r.PostFormValue("action") == "hide" {
+		web.arena.ShowLowerThird = false
+	} else {
+		lowerThirdId, _ := strconv.Atoi(r.PostFormValue("lowerThirdId"))
+		lowerThird, err := web.arena.Database.GetLowerThirdById(lowerThirdId)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if lowerThird == nil {
+			handleWebErr(w, fmt.Errorf("lower third %d doesn't exist", lowerThirdId))
+			return
+		}
+		web.arena.LowerThird = lowerThird
+		web.arena.ShowLowerThird = true
+	}
+	web.arena.LowerThirdNotifier.Notify()
+
+	http.Redirect(w, r, "/judging/ceremony", 303)
+}
+
+func (web *Web) renderJudging(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	awardDefinitions, err := web.arena.Database.GetAllAwardDefinitions()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var judgedAwards []judgedAward
+	for _, awardDefinition := range awardDefinitions {
+		nominations, err := web.arena.Database.GetAwardNominationsByDefinition(awardDefinition.Id)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		judgedAwards = append(judgedAwards, judgedAward{awardDefinition, nominations})
+	}
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	template, err := web.parseFiles("templates/judging.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		JudgedAwards []judgedAward
+		Teams        []model.Team
+		ErrorMessage string
+	}{web.arena.EventSettings, judgedAwards, teams, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestJudging(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254, Nickname: "The Cheesy Poofs"})
+	web.arena.Database.CreateTeam(&model.Team{Id: 1114, Nickname: "Simbotics"})
+
+	recorder := web.postHttpResponse("/judging/awards", "name=&ceremonyOrder=1")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The award must have a name.")
+	recorder = web.postHttpResponse("/judging/awards", "name=Safety+Award&script=Safety+first.&ceremonyOrder=1")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.getHttpResponse("/judging")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Safety Award")
+	assert.Contains(t, recorder.Body.String(), "Safety first.")
+
+	// Nominate some teams.
+	recorder = web.postHttpResponse("/judging/nominations", "awardDefinitionId=1&teamId=0")
+	assert.Contains(t, recorder.Body.String(), "A nomination must be for a team or a person.")
+	recorder = web.postHttpResponse("/judging/nominations", "awardDefinitionId=1&teamId=9999")
+	assert.Contains(t, recorder.Body.String(), "Team 9999 isn't present at this event.")
+	recorder = web.postHttpResponse("/judging/nominations", "awardDefinitionId=1&teamId=254&notes=Great+bumpers")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.postHttpResponse("/judging/nominations", "awardDefinitionId=1&teamId=1114")
+	assert.Equal(t, 303, recorder.Code)
+	nominations, _ := web.arena.Database.GetAwardNominationsByDefinition(1)
+	if assert.Equal(t, 2, len(nominations)) {
+		assert.Equal(t, "Great bumpers", nominations[0].Notes)
+		assert.False(t, nominations[0].IsWinner)
+	}
+
+	// Try to finalize before and after a winner has been chosen.
+	recorder = web.postHttpResponse("/judging/finalize", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No winner has been chosen for the Safety Award.")
+	recorder = web.postHttpResponse(
+		"/judging/nominations", fmt.Sprintf("id=%d&teamId=1114&isWinner=on", nominations[1].Id),
+	)
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.postHttpResponse("/judging/finalize", "")
+	assert.Equal(t, 303, recorder.Code)
+	awards, _ := web.arena.Database.GetAllAwards()
+	if assert.Equal(t, 1, len(awards)) {
+		assert.Equal(t, "Safety Award", awards[0].AwardName)
+		assert.Equal(t, 1114, awards[0].TeamId)
+	}
+
+	// Check that finalizing doesn't publish the awards ahead of the ceremony.
+	web.arena.TbaClient.BaseUrl = "fakeurl"
+	web.arena.EventSettings.TbaPublishingEnabled = true
+	recorder = web.postHttpResponse("/judging/finalize", "")
+	assert.Equal(t, 303, recorder.Code)
+	web.arena.EventSettings.TbaPublishingEnabled = false
+
+	// Check that deleting a nomination also removes its award.
+	recorder = web.postHttpResponse("/judging/nominations", fmt.Sprintf("id=%d&action=delete", nominations[1].Id))
+	assert.Equal(t, 303, recorder.Code)
+	awards, _ = web.arena.Database.GetAllAwards()
+	assert.Empty(t, awards)
+	recorder = web.postHttpResponse("/judging/awards", "id=1&action=delete")
+	assert.Equal(t, 303, recorder.Code)
+	awardDefinitions, _ := web.arena.Database.GetAllAwardDefinitions()
+	assert.Empty(t, awardDefinitions)
+}
+
+func TestJudgingCeremony(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254, Nickname: "The Cheesy Poofs"})
+	web.arena.Database.CreateAwardDefinition(&model.AwardDefinition{Name: "Safety Award", Script: "Safety first."})
+	web.arena.Database.CreateAwardNomination(&model.AwardNomination{AwardDefinitionId: 1, TeamId: 254, IsWinner: true})
+
+	recorder := web.getHttpResponse("/judging/ceremony")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "There are no awards to present yet.")
+
+	web.postHttpResponse("/judging/finalize", "")
+	recorder = web.getHttpResponse("/judging/ceremony")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Safety first.")
+	assert.Contains(t, recorder.Body.String(), "Team 254, The Cheesy Poofs")
+	lowerThirds, _ := web.arena.Database.GetAllLowerThirds()
+	assert.Equal(t, 2, len(lowerThirds))
+
+	recorder = web.postHttpResponse("/judging/ceremony", fmt.Sprintf("action=show&lowerThirdId=%d", lowerThirds[1].Id))
+	assert.Equal(t, 303, recorder.Code)
+	assert.True(t, web.arena.ShowLowerThird)
+	assert.Equal(t, "Team 254, The Cheesy Poofs", web.arena.LowerThird.BottomText)
+	recorder = web.postHttpResponse("/judging/ceremony", "action=show&lowerThirdId=999")
+	assert.Equal(t, 500, recorder.Code)
+	recorder = web.postHttpResponse("/judging/ceremony", "action=hide")
+	assert.Equal(t, 303, recorder.Code)
+	assert.False(t, web.arena.ShowLowerThird)
+
+	recorder = web.getHttpResponse("/reports/pdf/award_script")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/pdf", recorder.Header()["Content-Type"][0])
+}
@@ -28,7 +28,14 @@ func (web *Web) loginPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	session := model.UserSession{Token: uuid.New().String(), Username: username, CreatedAt: time.Now()}
-	if err := web.arena.Database.CreateUserSession(&session); err != nil {
+	if web.arena.IsStandby() {
+		// Keep the session in memory so that the standby's database remains identical to the primary's. The sessions
+		// are kept by the first field so that they are valid for the pages of every field.
+		primaryWeb := web.fieldWebs()[0]
+		primaryWeb.standbySessionsMutex.Lock()
+		primaryWeb.standbySessions[session.Token] = session
+		primaryWeb.standbySessionsMutex.Unlock()
+	} else if err := web.arena.Database.CreateUserSession(&session); err != nil {
 		handleWebErr(w, err)
 		return
 	}
@@ -82,6 +89,13 @@ func (web *Web) getUserSessionFromCookie(r *http.Request) *model.UserSession {
 	if err != nil {
 		return nil
 	}
+	primaryWeb := web.fieldWebs()[0]
+	primaryWeb.standbySessionsMutex.Lock()
+	standbySession, ok := primaryWeb.standbySessions[token.Value]
+	primaryWeb.standbySessionsMutex.Unlock()
+	if ok {
+		return &standbySession
+	}
 	session, _ := web.arena.Database.GetUserSessionByToken(token.Value)
 	return session
 }
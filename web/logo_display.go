@@ -47,6 +47,7 @@ func (web *Web) logoDisplayWebsocketHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 	defer ws.Close()
+	ws.SetDropCounter(display.DropCounter())
 
 	// Subscribe the websocket to the notifiers whose messages will be passed on to the client.
 	ws.HandleNotifiers(display.Notifier, web.arena.ReloadDisplaysNotifier)
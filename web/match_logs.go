@@ -9,49 +9,29 @@ import (
 	"encoding/csv"
 	"fmt"
 	"net/http"
-	"os"
-	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
 )
 
 type MatchLogsListItem struct {
-	Id         int
-	ShortName  string
-	Time       string
-	RedTeams   []int
-	BlueTeams  []int
-	ColorClass string
-	IsComplete bool
-}
-
-type MatchLogRow struct {
-	MatchTimeSec      float64
-	PacketType        int
-	TeamId            int
-	AllianceStation   string
-	DsLinked          bool
-	RadioLinked       bool
-	RioLinked         bool
-	RobotLinked       bool
-	Auto              bool
-	Enabled           bool
-	EmergencyStop     bool
-	AutonomousStop    bool
-	BatteryVoltage    float64
-	MissedPacketCount int
-	DsRobotTripTimeMs int
-	TxRate            float64
-	RxRate            float64
-	SignalNoiseRatio  int
+	Id           int
+	ShortName    string
+	Time         string
+	RedTeams     []int
+	BlueTeams    []int
+	RedProblems  [3]string
+	BlueProblems [3]string
+	ColorClass   string
+	IsComplete   bool
 }
 
 type MatchLog struct {
-	Filename  string
-	StartTime string
-	Rows      []MatchLogRow
+	model.TeamMatchLog
+	Rows           []model.TeamMatchLogRow
+	RadioDropCount int
 }
 
 type MatchLogs struct {
@@ -62,17 +42,22 @@ type MatchLogs struct {
 
 // Shows the match Log interface.
 func (web *Web) matchLogsHandler(w http.ResponseWriter, r *http.Request) {
-	practiceMatches, err := web.buildMatchLogsList(model.Practice)
+	matchLogsByMatchId, err := web.arena.Database.GetTeamMatchLogsByMatchId()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	practiceMatches, err := web.buildMatchLogsList(model.Practice, matchLogsByMatchId)
 	if err != nil {
 		handleWebErr(w, err)
 		return
 	}
-	qualificationMatches, err := web.buildMatchLogsList(model.Qualification)
+	qualificationMatches, err := web.buildMatchLogsList(model.Qualification, matchLogsByMatchId)
 	if err != nil {
 		handleWebErr(w, err)
 		return
 	}
-	playoffMatches, err := web.buildMatchLogsList(model.Playoff)
+	playoffMatches, err := web.buildMatchLogsList(model.Playoff, matchLogsByMatchId)
 	if err != nil {
 		handleWebErr(w, err)
 		return
@@ -106,8 +91,7 @@ func (web *Web) matchLogsHandler(w http.ResponseWriter, r *http.Request) {
 
 // Shows the page to view a log for a match.
 func (web *Web) matchLogsViewGetHandler(w http.ResponseWriter, r *http.Request) {
-	match, matchLogs, _, err := web.getMatchLogFromRequest(r)
-	firstMatch := "0"
+	match, matchLogs, err := web.getMatchLogFromRequest(r)
 	if err != nil {
 		handleWebErr(w, err)
 		return
@@ -118,15 +102,16 @@ func (web *Web) matchLogsViewGetHandler(w http.ResponseWriter, r *http.Request)
 		handleWebErr(w, err)
 		return
 	}
+	firstLogId := 0
 	if len(matchLogs.Logs) > 0 {
-		firstMatch = matchLogs.Logs[0].StartTime
+		firstLogId = matchLogs.Logs[0].Id
 	}
 	data := struct {
 		*model.EventSettings
 		Match      *model.Match
 		MatchLogs  *MatchLogs
-		FirstMatch string
-	}{web.arena.EventSettings, match, matchLogs, firstMatch}
+		FirstLogId int
+	}{web.arena.EventSettings, match, matchLogs, firstLogId}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -134,22 +119,87 @@ func (web *Web) matchLogsViewGetHandler(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// Sends the packets from a single team's match log to the client as a CSV download.
+func (web *Web) matchLogCsvHandler(w http.ResponseWriter, r *http.Request) {
+	logId, _ := strconv.Atoi(r.PathValue("logId"))
+	matchLog, err := web.arena.Database.GetTeamMatchLogById(logId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if matchLog == nil {
+		http.Error(w, fmt.Sprintf("Error: No such match log: %d", logId), 400)
+		return
+	}
+	rows, err := web.arena.Database.GetTeamMatchLogRows(logId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	filename := fmt.Sprintf(
+		"%s_%s_Match_%s_%d.csv",
+		matchLog.StartedAt.Local().Format("20060102150405"),
+		matchLog.MatchType.String(),
+		matchLog.MatchShortName,
+		matchLog.TeamId,
+	)
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	writer := csv.NewWriter(w)
+	writer.Write(
+		[]string{
+			"matchTimeSec", "packetType", "teamId", "allianceStation", "dsLinked", "radioLinked", "rioLinked",
+			"robotLinked", "auto", "enabled", "emergencyStop", "autonomousStop", "batteryVoltage",
+			"missedPacketCount", "dsRobotTripTimeMs", "rxRate", "txRate", "signalNoiseRatio",
+			"channelUtilizationPercent", "channelNoiseDbm",
+		},
+	)
+	for _, row := range rows {
+		writer.Write(
+			[]string{
+				strconv.FormatFloat(row.MatchTimeSec, 'f', -1, 64),
+				strconv.Itoa(row.PacketType),
+				strconv.Itoa(matchLog.TeamId),
+				matchLog.AllianceStation,
+				strconv.FormatBool(row.DsLinked),
+				strconv.FormatBool(row.RadioLinked),
+				strconv.FormatBool(row.RioLinked),
+				strconv.FormatBool(row.RobotLinked),
+				strconv.FormatBool(row.Auto),
+				strconv.FormatBool(row.Enabled),
+				strconv.FormatBool(row.EmergencyStop),
+				strconv.FormatBool(row.AutonomousStop),
+				strconv.FormatFloat(row.BatteryVoltage, 'f', -1, 64),
+				strconv.Itoa(row.MissedPacketCount),
+				strconv.Itoa(row.DsRobotTripTimeMs),
+				strconv.FormatFloat(row.RxRate, 'f', -1, 64),
+				strconv.FormatFloat(row.TxRate, 'f', -1, 64),
+				strconv.Itoa(row.SignalNoiseRatio),
+				strconv.FormatFloat(row.ChannelUtilizationPercent, 'f', -1, 64),
+				strconv.Itoa(row.ChannelNoiseDbm),
+			},
+		)
+	}
+	writer.Flush()
+}
+
 // Load the match logs for the match referenced in the HTTP query string.
-func (web *Web) getMatchLogFromRequest(r *http.Request) (*model.Match, *MatchLogs, bool, error) {
+func (web *Web) getMatchLogFromRequest(r *http.Request) (*model.Match, *MatchLogs, error) {
 	matchId, _ := strconv.Atoi(r.PathValue("matchId"))
 	stationId := r.PathValue("stationId")
 	match, err := web.arena.Database.GetMatchById(matchId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if match == nil {
+		return nil, nil, fmt.Errorf("Error: No such match: %d", matchId)
+	}
 
 	logs := MatchLogs{
 		TeamId:          0,
 		AllianceStation: stationId,
 	}
-	if err != nil {
-		return nil, nil, false, err
-	}
-	if match == nil {
-		return nil, nil, false, fmt.Errorf("Error: No such match: %d", matchId)
-	}
 	switch stationId {
 	case "R1":
 		logs.TeamId = match.Red1
@@ -164,78 +214,46 @@ func (web *Web) getMatchLogFromRequest(r *http.Request) (*model.Match, *MatchLog
 	case "B3":
 		logs.TeamId = match.Blue3
 	}
-	headerMap := make(map[string]int)
-	//rows []MatchLogRow
-	// Load a csv file.
 	if logs.TeamId == 0 {
-		return nil, nil, false, nil
-	}
-	var files []string
-	files, _ = filepath.Glob(filepath.Join(".", "static", "logs", "*_*_Match_"+match.ShortName+"_"+strconv.Itoa(logs.TeamId)+".csv"))
-	if len(files) == 0 {
-		return match, &logs, false, nil
+		return match, &logs, nil
 	}
 
-	for _, filename := range files {
-		f, _ := os.Open(filename)
-		defer f.Close()
-		// Create a new reader.
-		reader := csv.NewReader(f)
-
-		// Read row
-		header, _ := reader.Read()
-
-		// Add mapping: Column/property name --> record index
-		for i, v := range header {
-			headerMap[v] = i
+	matchLogs, err := web.arena.Database.GetTeamMatchLogsByMatch(match.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, matchLog := range matchLogs {
+		if matchLog.TeamId != logs.TeamId {
+			continue
 		}
-		records, _ := reader.ReadAll()
-
-		var curlog = MatchLog{
-			Filename:  filename,
-			StartTime: filename[12:26],
-			Rows:      make([]MatchLogRow, len(records)),
+		rows, err := web.arena.Database.GetTeamMatchLogRows(matchLog.Id)
+		if err != nil {
+			return nil, nil, err
 		}
-		for i, record := range records {
-			var curRow MatchLogRow
-			curRow.MatchTimeSec, _ = strconv.ParseFloat(record[headerMap["matchTimeSec"]], 64)
-			curRow.PacketType, _ = strconv.Atoi(record[headerMap["packetType"]])
-			curRow.TeamId, _ = strconv.Atoi(record[headerMap["teamId"]])
-			curRow.AllianceStation = record[headerMap["allianceStation"]]
-			curRow.DsLinked, _ = strconv.ParseBool(record[headerMap["dsLinked"]])
-			curRow.RadioLinked, _ = strconv.ParseBool(record[headerMap["radioLinked"]])
-			curRow.RioLinked, _ = strconv.ParseBool(record[headerMap["rioLinked"]])
-			curRow.RobotLinked, _ = strconv.ParseBool(record[headerMap["robotLinked"]])
-			curRow.Auto, _ = strconv.ParseBool(record[headerMap["auto"]])
-			curRow.Enabled, _ = strconv.ParseBool(record[headerMap["enabled"]])
-			curRow.EmergencyStop, _ = strconv.ParseBool(record[headerMap["emergencyStop"]])
-			curRow.AutonomousStop, _ = strconv.ParseBool(record[headerMap["autonomousStop"]])
-			curRow.BatteryVoltage, _ = strconv.ParseFloat(record[headerMap["batteryVoltage"]], 64)
-			curRow.MissedPacketCount, _ = strconv.Atoi(record[headerMap["missedPacketCount"]])
-			curRow.DsRobotTripTimeMs, _ = strconv.Atoi(record[headerMap["dsRobotTripTimeMs"]])
-			if len(headerMap) > 13 {
-
-				curRow.TxRate, _ = strconv.ParseFloat(record[headerMap["txRate"]], 64)
-				curRow.RxRate, _ = strconv.ParseFloat(record[headerMap["rxRate"]], 64)
-				curRow.SignalNoiseRatio, _ = strconv.Atoi(record[headerMap["signalNoiseRatio"]])
-			} else {
-				curRow.TxRate = -1
-				curRow.RxRate = -1
-				curRow.SignalNoiseRatio = -1
-			}
+		curLog := MatchLog{TeamMatchLog: matchLog, Rows: rows}
+		curLog.summarizeRadioDrops()
+		logs.Logs = append(logs.Logs, curLog)
+	}
+	return match, &logs, nil
+}
 
-			// Create new person and add to persons array
-			curlog.Rows[i] = curRow
+// Counts the number of times the radio link was lost while the robot was enabled, to help distinguish robot-side
+// problems from RF environment problems alongside the channel conditions recorded in the analysis.
+func (matchLog *MatchLog) summarizeRadioDrops() {
+	matchLog.RadioDropCount = 0
+	for i := 1; i < len(matchLog.Rows); i++ {
+		previousRow := matchLog.Rows[i-1]
+		row := matchLog.Rows[i]
+		if previousRow.RadioLinked && previousRow.Enabled && row.DsLinked && !row.RadioLinked {
+			matchLog.RadioDropCount++
 		}
-
-		logs.Logs = append(logs.Logs, curlog)
-
 	}
-	return match, &logs, false, nil
 }
 
 // Constructs the list of matches to display in the match Logs interface.
-func (web *Web) buildMatchLogsList(matchType model.MatchType) ([]MatchLogsListItem, error) {
+func (web *Web) buildMatchLogsList(
+	matchType model.MatchType, matchLogsByMatchId map[int][]model.TeamMatchLog,
+) ([]MatchLogsListItem, error) {
 	matches, err := web.arena.Database.GetMatchesByType(matchType, false)
 	if err != nil {
 		return []MatchLogsListItem{}, err
@@ -248,9 +266,9 @@ func (web *Web) buildMatchLogsList(matchType model.MatchType) ([]MatchLogsListIt
 		matchLogsList[i].Time = match.Time.Local().Format("Mon 1/02 03:04 PM")
 		matchLogsList[i].RedTeams = []int{match.Red1, match.Red2, match.Red3}
 		matchLogsList[i].BlueTeams = []int{match.Blue1, match.Blue2, match.Blue3}
-		if err != nil {
-			return []MatchLogsListItem{}, err
-		}
+		matchLogsList[i].RedProblems, matchLogsList[i].BlueProblems = summarizeMatchLogProblems(
+			matchLogsByMatchId[match.Id],
+		)
 		switch match.Status {
 		case game.RedWonMatch:
 			matchLogsList[i].ColorClass = "red"
@@ -269,3 +287,27 @@ func (web *Web) buildMatchLogsList(matchType model.MatchType) ([]MatchLogsListIt
 
 	return matchLogsList, nil
 }
+
+// Returns a description of the problems detected in the most recent log for each station in a match, or the empty
+// string for stations whose robots had no problems.
+func summarizeMatchLogProblems(matchLogs []model.TeamMatchLog) (redProblems, blueProblems [3]string) {
+	// The logs are in chronological order, so those from a replay overwrite those from the original play.
+	for _, matchLog := range matchLogs {
+		problems := strings.Join(matchLog.Analysis.Problems(), ", ")
+		switch matchLog.AllianceStation {
+		case "R1":
+			redProblems[0] = problems
+		case "R2":
+			redProblems[1] = problems
+		case "R3":
+			redProblems[2] = problems
+		case "B1":
+			blueProblems[0] = problems
+		case "B2":
+			blueProblems[1] = problems
+		case "B3":
+			blueProblems[2] = problems
+		}
+	}
+	return
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMatchLogs(t *testing.T) {
+	web := setupTestWeb(t)
+
+	match := model.Match{Type: model.Qualification, ShortName: "Q5", Time: time.Unix(1000, 0), Red1: 254,
+		Blue3: 1114}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	matchLog1 := model.TeamMatchLog{MatchId: match.Id, MatchType: model.Qualification, MatchShortName: "Q5",
+		TeamId: 254, AllianceStation: "R1", StartedAt: time.Unix(2000, 0),
+		Analysis: model.TeamMatchLogAnalysis{BrownoutCount: 1, CommsDropCount: 2, MinBatteryVoltage: 6.2}}
+	rows := []model.TeamMatchLogRow{
+		{MatchTimeSec: 0.5, DsLinked: true, RobotLinked: true, BatteryVoltage: 12.5, ChannelUtilizationPercent: -1,
+			ChannelNoiseDbm: -1},
+		{MatchTimeSec: 1.25, DsLinked: true, Enabled: true, SignalNoiseRatio: 41, ChannelUtilizationPercent: 20,
+			ChannelNoiseDbm: -94},
+	}
+	assert.Nil(t, web.arena.Database.CreateTeamMatchLog(&matchLog1, rows))
+	matchLog2 := model.TeamMatchLog{MatchId: match.Id, MatchType: model.Qualification, MatchShortName: "Q5",
+		TeamId: 1114, AllianceStation: "B3", StartedAt: time.Unix(2000, 0)}
+	assert.Nil(t, web.arena.Database.CreateTeamMatchLog(&matchLog2, nil))
+
+	// Problems should be flagged on the list page.
+	recorder := web.getHttpResponse("/match_logs")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Q5")
+	assert.Contains(t, recorder.Body.String(), "title=\"brownout, comms drop\"")
+	assert.Equal(t, 1, strings.Count(recorder.Body.String(), "bi-exclamation-triangle-fill"))
+
+	recorder = web.getHttpResponse("/match_logs/1/R1/log")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Browned out 1 time(s)")
+	assert.Contains(t, recorder.Body.String(), "Lost communication with the robot 2 time(s)")
+	assert.Contains(t, recorder.Body.String(), "/match_logs/csv/1")
+	recorder = web.getHttpResponse("/match_logs/1/B3/log")
+	assert.Equal(t, 200, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "Browned out")
+
+	recorder = web.getHttpResponse("/match_logs/csv/1")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/csv", recorder.Header()["Content-Type"][0])
+	assert.Contains(t, recorder.Header()["Content-Disposition"][0], "_Qualification_Match_Q5_254.csv")
+	assert.Equal(
+		t,
+		"matchTimeSec,packetType,teamId,allianceStation,dsLinked,radioLinked,rioLinked,robotLinked,auto,enabled,"+
+			"emergencyStop,autonomousStop,batteryVoltage,missedPacketCount,dsRobotTripTimeMs,rxRate,txRate,"+
+			"signalNoiseRatio,channelUtilizationPercent,channelNoiseDbm\n"+
+			"0.5,0,254,R1,true,false,false,true,false,false,false,false,12.5,0,0,0,0,0,-1,-1\n"+
+			"1.25,0,254,R1,true,false,false,false,false,true,false,false,0,0,0,0,0,41,20,-94\n",
+		recorder.Body.String(),
+	)
+
+	recorder = web.getHttpResponse("/match_logs/csv/5")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No such match log")
+}
+
+func TestMatchLogSummarizeRadioDrops(t *testing.T) {
+	matchLog := MatchLog{
+		Rows: []model.TeamMatchLogRow{
+			{DsLinked: true, RadioLinked: true, Enabled: false},
+			{DsLinked: true, RadioLinked: false, Enabled: false},
+			{DsLinked: true, RadioLinked: true, Enabled: true},
+			{DsLinked: true, RadioLinked: false, Enabled: false},
+			{DsLinked: false, RadioLinked: false, Enabled: false},
+		},
+	}
+	matchLog.summarizeRadioDrops()
+	assert.Equal(t, 1, matchLog.RadioDropCount)
+
+	// Shouldn't count a drop when the driver station itself is disconnected.
+	matchLog.Rows[3].DsLinked = false
+	matchLog.summarizeRadioDrops()
+	assert.Equal(t, 0, matchLog.RadioDropCount)
+}
@@ -46,10 +46,14 @@ func (web *Web) matchPlayHandler(w http.ResponseWriter, r *http.Request) {
 		*model.EventSettings
 		PlcIsEnabled          bool
 		PlcArmorBlockStatuses map[string]bool
+		FieldNumber           int
+		MultipleFields        bool
 	}{
 		web.arena.EventSettings,
 		web.arena.Plc.IsEnabled(),
 		web.arena.Plc.GetArmorBlockStatuses(),
+		web.arena.FieldNumber,
+		len(web.arena.Fields()) > 1,
 	}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
@@ -98,9 +102,13 @@ func (web *Web) matchPlayMatchLoadHandler(w http.ResponseWriter, r *http.Request
 	data := struct {
 		MatchesByType    map[model.MatchType]MatchPlayList
 		CurrentMatchType model.MatchType
+		FieldNumber      int
+		NumFields        int
 	}{
 		matchesByType,
 		currentMatchType,
+		web.arena.FieldNumber,
+		len(web.arena.Fields()),
 	}
 	err = template.ExecuteTemplate(w, "match_play_match_load.html", data)
 	if err != nil {
@@ -227,6 +235,20 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 			web.arena.SavedMatch = match
 			web.arena.SavedMatchResult = matchResult
 			web.arena.ScorePostedNotifier.Notify()
+		case "assignField":
+			args := struct {
+				MatchId     int
+				FieldNumber int
+			}{}
+			err = mapstructure.Decode(data, &args)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			if err = web.assignMatchToField(args.MatchId, args.FieldNumber); err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
 		case "substituteTeams":
 			args := struct {
 				Red1  int
@@ -263,6 +285,7 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 		case "startMatch":
 			args := struct {
 				MuteMatchSounds bool
+				Override        bool
 			}{}
 			err = mapstructure.Decode(data, &args)
 			if err != nil {
@@ -270,7 +293,11 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 				continue
 			}
 			web.arena.MuteMatchSounds = args.MuteMatchSounds
-			err = web.arena.StartMatch()
+			if args.Override {
+				err = web.arena.StartMatchWithOverride()
+			} else {
+				err = web.arena.StartMatch()
+			}
 			if err != nil {
 				ws.WriteError(err.Error())
 				continue
@@ -345,6 +372,24 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 				ws.WriteError(err.Error())
 				continue
 			}
+		case "startAllianceTimeout":
+			args := struct {
+				Alliance    string
+				DurationSec int
+			}{}
+			err = mapstructure.Decode(data, &args)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			allianceId := web.arena.CurrentMatch.PlayoffRedAlliance
+			if args.Alliance == "blue" {
+				allianceId = web.arena.CurrentMatch.PlayoffBlueAlliance
+			}
+			if err = web.arena.StartAllianceTimeout(allianceId, args.DurationSec); err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
 		case "setTestMatchName":
 			if web.arena.CurrentMatch.Type != model.Test {
 				// Don't allow changing the name of a non-test match.
@@ -365,6 +410,10 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 
 // Saves the given match and result to the database, supplanting any previous result for the match.
 func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchResult, isMatchReviewEdit bool) error {
+	// Don't let commits on other fields interleave with this one, since they update the same rankings and bracket.
+	web.arena.LockScoreCommits()
+	defer web.arena.UnlockScoreCommits()
+
 	var updatedRankings game.Rankings
 
 	if match.Type == model.Playoff {
@@ -437,8 +486,16 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 				return err
 			}
 
-			// Populate any subsequent playoff matches.
-			if err = web.arena.UpdatePlayoffTournament(); err != nil {
+			// Populate any subsequent playoff matches, keeping the bracket of every field up to date. The other fields'
+			// brackets are replaced with their arena loops held off.
+			web.arena.LockState()
+			for _, fieldArena := range web.arena.Fields() {
+				if err = fieldArena.UpdatePlayoffTournament(); err != nil {
+					break
+				}
+			}
+			web.arena.UnlockState()
+			if err != nil {
 				return err
 			}
 
@@ -484,6 +541,52 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 		web.arena.ScorePostedNotifier.Notify()
 	}
 
+	if updatedRankings != nil {
+		// The rankings are shared between fields, so refresh those shown alongside each field's last posted score,
+		// holding off the other fields' arena loops while doing so.
+		web.arena.LockState()
+		for _, fieldArena := range web.arena.Fields() {
+			if fieldArena == web.arena && !isMatchReviewEdit || !fieldArena.SavedMatch.ShouldUpdateRankings() {
+				continue
+			}
+			fieldArena.SavedRankings = updatedRankings
+			fieldArena.ScorePostedNotifier.Notify()
+		}
+		web.arena.UnlockState()
+	}
+
+	return nil
+}
+
+// Moves the given unplayed match to the given field and refreshes the match lists of all the fields.
+func (web *Web) assignMatchToField(matchId, fieldNumber int) error {
+	fields := web.arena.Fields()
+	if fieldNumber < 1 || fieldNumber > len(fields) {
+		return fmt.Errorf("invalid field number %d", fieldNumber)
+	}
+	match, err := web.arena.Database.GetMatchById(matchId)
+	if err != nil {
+		return err
+	}
+	if match == nil {
+		return fmt.Errorf("invalid match ID %d", matchId)
+	}
+	if match.IsComplete() {
+		return fmt.Errorf("cannot move match %s since it has already been played", match.ShortName)
+	}
+	for _, fieldArena := range fields {
+		if fieldArena.CurrentMatch.Id == match.Id {
+			return fmt.Errorf("cannot move match %s while it is loaded on a field", match.ShortName)
+		}
+	}
+
+	match.FieldNumber = fieldNumber
+	if err = web.arena.Database.UpdateMatch(match); err != nil {
+		return err
+	}
+	for _, fieldArena := range fields {
+		fieldArena.MatchLoadNotifier.Notify()
+	}
 	return nil
 }
 
@@ -515,7 +618,7 @@ func (list MatchPlayList) Swap(i, j int) {
 
 // Constructs the list of matches to display on the side of the match play interface.
 func (web *Web) buildMatchPlayList(matchType model.MatchType) (MatchPlayList, error) {
-	matches, err := web.arena.Database.GetMatchesByType(matchType, false)
+	matches, err := web.arena.GetFieldMatchesByType(matchType)
 	if err != nil {
 		return MatchPlayList{}, err
 	}
@@ -5,6 +5,7 @@ package web
 
 import (
 	"bytes"
+	"fmt"
 	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
@@ -355,6 +356,30 @@ func TestMatchPlayWebsocketCommands(t *testing.T) {
 	assert.Equal(t, "logo", web.arena.AllianceStationDisplayMode)
 }
 
+func TestMatchPlayWebsocketStartMatchOverride(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.StartMatchPolicy = model.OverridableStartMatchPolicy
+	for _, allianceStation := range web.arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/match_play/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+	readWebsocketMultiple(t, ws, 10)
+
+	// The scoring panels aren't connected, so the match can only be started with an override.
+	ws.Write("startMatch", nil)
+	assert.Contains(t, readWebsocketError(t, ws), "Scoring Panels Connected")
+	assert.Equal(t, field.PreMatch, web.arena.MatchState)
+	ws.Write("startMatch", map[string]any{"override": true})
+	readWebsocketType(t, ws, "eventStatus")
+	assert.Equal(t, field.StartMatch, web.arena.MatchState)
+}
+
 func TestMatchPlayWebsocketLoadMatch(t *testing.T) {
 	web := setupTestWeb(t)
 	tournament.CreateTestAlliances(web.arena.Database, 8)
@@ -409,6 +434,39 @@ func TestMatchPlayWebsocketLoadMatch(t *testing.T) {
 	assert.Contains(t, readWebsocketError(t, ws), "invalid match ID 254")
 }
 
+func TestMatchPlayWebsocketAllianceTimeout(t *testing.T) {
+	web := setupTestWeb(t)
+	tournament.CreateTestAlliances(web.arena.Database, 8)
+	assert.Nil(t, web.arena.CreatePlayoffMatches(time.Unix(1000, 0)))
+	matches, _ := web.arena.Database.GetMatchesByType(model.Playoff, false)
+	assert.Nil(t, web.arena.LoadMatch(&matches[0]))
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/match_play/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+
+	// Should get a few status updates right after connection.
+	messages := readWebsocketMultiple(t, ws, 10)
+	matchLoad, _ := messages["matchLoad"].(map[string]any)
+	assert.Equal(t, 1.0, matchLoad["RedTimeoutsRemaining"])
+	assert.Equal(t, 1.0, matchLoad["BlueTimeoutsRemaining"])
+
+	ws.Write("startAllianceTimeout", map[string]any{"alliance": "blue", "durationSec": 300})
+	messages = readWebsocketMultiple(t, ws, 4) // matchTiming, matchLoad, allianceStationDisplayMode, matchLoad
+	matchLoad, _ = messages["matchLoad"].(map[string]any)
+	assert.Equal(t, 1.0, matchLoad["RedTimeoutsRemaining"])
+	assert.Equal(t, 0.0, matchLoad["BlueTimeoutsRemaining"])
+	assert.Equal(t, "Alliance 8 Timeout", matchLoad["BreakDescription"])
+	assert.Equal(t, field.TimeoutActive, web.arena.MatchState)
+	assert.Equal(t, 300, game.MatchTiming.TimeoutDurationSec)
+
+	ws.Write("startAllianceTimeout", map[string]any{"alliance": "red", "durationSec": 300})
+	assert.Contains(t, readWebsocketError(t, ws), "cannot start timeout while there is a match still in progress")
+}
+
 func TestMatchPlayWebsocketShowAndClearResult(t *testing.T) {
 	web := setupTestWeb(t)
 
@@ -527,3 +585,57 @@ func getStatusMatchTime(t *testing.T, messages map[string]any) (bool, field.Matc
 	}
 	return statusReceived, matchTime
 }
+
+func TestMatchPlayMultipleFields(t *testing.T) {
+	web := setupTestWeb(t)
+	web2 := setupTestSecondField(t, web)
+
+	for i := 1; i <= 4; i++ {
+		match := model.Match{Type: model.Qualification, TypeOrder: i, ShortName: fmt.Sprintf("Q%d", i)}
+		assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	}
+
+	// Each field should list only the matches assigned to it.
+	recorder := web.getHttpResponse("/match_play/match_load")
+	assert.Contains(t, recorder.Body.String(), ">Q1<")
+	assert.NotContains(t, recorder.Body.String(), ">Q2<")
+	assert.Contains(t, recorder.Body.String(), ">Q3<")
+	assert.Contains(t, recorder.Body.String(), "Field 2")
+	recorder = web2.getHttpResponse("/match_play/match_load")
+	assert.NotContains(t, recorder.Body.String(), ">Q1<")
+	assert.Contains(t, recorder.Body.String(), ">Q2<")
+	recorder = web2.getHttpResponse("/match_play")
+	assert.Contains(t, recorder.Body.String(), "Match Play (Field 2)")
+
+	// Moving a match should change the field it is listed on.
+	match, _ := web.arena.Database.GetMatchByTypeOrder(model.Qualification, 3)
+	assert.Nil(t, web.assignMatchToField(match.Id, 2))
+	recorder = web.getHttpResponse("/match_play/match_load")
+	assert.NotContains(t, recorder.Body.String(), ">Q3<")
+	recorder = web2.getHttpResponse("/match_play/match_load")
+	assert.Contains(t, recorder.Body.String(), ">Q3<")
+
+	assert.EqualError(t, web.assignMatchToField(match.Id, 3), "invalid field number 3")
+	assert.EqualError(t, web.assignMatchToField(254, 1), "invalid match ID 254")
+	assert.Nil(t, web2.arena.LoadMatch(match))
+	assert.EqualError(t, web.assignMatchToField(match.Id, 1), "cannot move match Q3 while it is loaded on a field")
+	match, _ = web.arena.Database.GetMatchByTypeOrder(model.Qualification, 1)
+	match.Status = game.RedWonMatch
+	assert.Nil(t, web.arena.Database.UpdateMatch(match))
+	assert.EqualError(
+		t, web.assignMatchToField(match.Id, 2), "cannot move match Q1 since it has already been played",
+	)
+
+	// Committing a qualification match on one field should refresh the rankings posted on the other.
+	match, _ = web.arena.Database.GetMatchByTypeOrder(model.Qualification, 2)
+	web2.arena.SavedMatch = match
+	web2.arena.SavedRankings = game.Rankings{}
+	match, _ = web.arena.Database.GetMatchByTypeOrder(model.Qualification, 1)
+	match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3 = 254, 1114, 2056, 1678, 118, 148
+	matchResult := model.NewMatchResult()
+	matchResult.MatchId = match.Id
+	matchResult.RedScore = &game.Score{LeaveStatuses: [3]bool{true, false, false}}
+	assert.Nil(t, web.commitMatchScore(match, matchResult, false))
+	assert.Equal(t, 6, len(web.arena.SavedRankings))
+	assert.Equal(t, web.arena.SavedRankings, web2.arena.SavedRankings)
+}
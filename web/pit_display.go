@@ -0,0 +1,58 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Web handlers for the pit display.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/websocket"
+	"net/http"
+)
+
+// Renders the display which summarizes the inspection status of the teams for the pits.
+func (web *Web) pitDisplayHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.enforceDisplayConfiguration(w, r, nil) {
+		return
+	}
+
+	template, err := web.parseFiles("templates/pit_display.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+	}{web.arena.EventSettings}
+	err = template.ExecuteTemplate(w, "pit_display.html", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// The websocket endpoint for the pit display client to receive status updates.
+func (web *Web) pitDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
+	display, err := web.registerDisplay(r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	defer web.arena.MarkDisplayDisconnected(display.DisplayConfiguration.Id)
+
+	ws, err := websocket.NewWebsocket(w, r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	defer ws.Close()
+	ws.SetDropCounter(display.DropCounter())
+
+	// Subscribe the websocket to the notifiers whose messages will be passed on to the client.
+	ws.HandleNotifiers(
+		display.Notifier,
+		web.arena.EventStatusNotifier,
+		web.arena.InspectionNotifier,
+		web.arena.ReloadDisplaysNotifier,
+	)
+}
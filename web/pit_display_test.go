@@ -0,0 +1,50 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/websocket"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPitDisplay(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/displays/pit?displayId=1")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Pit Display - Untitled Event - Cheesy Arena")
+}
+
+func TestPitDisplayWebsocket(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/displays/pit/websocket?displayId=1", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+
+	// Should get a few status updates right after connection.
+	readWebsocketType(t, ws, "displayConfiguration")
+	readWebsocketType(t, ws, "eventStatus")
+	message := readWebsocketType(t, ws, "inspection")
+	assert.Equal(t, 1.0, message.(map[string]any)["NumNotInspected"])
+
+	// Check that the public display only gets each team's pass/fail status and not the details of its inspection.
+	web.arena.Database.CreateTeamInspection(
+		&model.TeamInspection{TeamId: 254, Inspector: "Alice", Passed: false, Notes: "Bumpers too low", WeightLbs: 130},
+	)
+	web.arena.InspectionNotifier.Notify()
+	message = readWebsocketType(t, ws, "inspection")
+	assert.Equal(t, 1.0, message.(map[string]any)["NumFailed"])
+	assert.Equal(
+		t,
+		[]any{map[string]any{"TeamId": 254.0, "IsInspected": true, "Passed": false}},
+		message.(map[string]any)["Teams"],
+	)
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Web routes for calling a backup team into a playoff alliance to replace one of its robots.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+)
+
+// Shows the playoff alliances and the pool of teams available to be called in as backups.
+func (web *Web) playoffBackupsGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	web.renderPlayoffBackups(w, r, "")
+}
+
+// Calls the given backup team into the given alliance in place of one of its robots.
+func (web *Web) playoffBackupsPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	allianceId, _ := strconv.Atoi(r.PostFormValue("allianceId"))
+	backupTeamId, _ := strconv.Atoi(r.PostFormValue("backupTeamId"))
+	replacedTeamId, _ := strconv.Atoi(r.PostFormValue("replacedTeamId"))
+
+	backupPool, err := web.getBackupPool()
+	if err != nil {
+		web.renderPlayoffBackups(w, r, err.Error())
+		return
+	}
+	inPool := false
+	for _, ranking := range backupPool {
+		if ranking.TeamId == backupTeamId {
+			inPool = true
+			break
+		}
+	}
+	if !inPool {
+		web.renderPlayoffBackups(w, r, fmt.Sprintf("Team %d isn't in the pool of backup teams.", backupTeamId))
+		return
+	}
+	if err = web.arena.Database.CallBackupTeam(allianceId, backupTeamId, replacedTeamId); err != nil {
+		web.renderPlayoffBackups(w, r, fmt.Sprintf("Failed to call backup team: %s", err.Error()))
+		return
+	}
+
+	// Carry the new lineup into the alliance's upcoming matches, including any that are loaded but not yet started.
+	for _, fieldArena := range web.arena.Fields() {
+		if err = fieldArena.UpdatePlayoffTournament(); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if err = reloadPlayoffMatchForAlliance(fieldArena, allianceId); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	if web.arena.EventSettings.TbaPublishingEnabled {
+		if err = web.arena.TbaClient.PublishAlliances(web.arena.Database); err != nil {
+			web.renderPlayoffBackups(w, r, fmt.Sprintf("Failed to publish alliances: %s", err.Error()))
+			return
+		}
+	}
+
+	// Signal displays of the alliances to update themselves.
+	web.arena.ScorePostedNotifier.Notify()
+
+	http.Redirect(w, r, "/playoff_backups", 303)
+}
+
+func (web *Web) renderPlayoffBackups(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	alliances, err := web.arena.Database.GetAllAlliances()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	backupPool, err := web.getBackupPool()
+	if err != nil && len(alliances) > 0 {
+		handleWebErr(w, err)
+		return
+	}
+
+	template, err := web.parseFiles("templates/playoff_backups.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Alliances    []model.Alliance
+		BackupPool   game.Rankings
+		ErrorMessage string
+	}{web.arena.EventSettings, alliances, backupPool, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Returns the ranked teams that are not part of any alliance and so can be called in as backups.
+func (web *Web) getBackupPool() (game.Rankings, error) {
+	rankings, err := web.arena.Database.GetAllRankings()
+	if err != nil {
+		return nil, err
+	}
+	backupTeams, allianceBackups, err := web.findBackupTeams(rankings)
+	if err != nil {
+		return nil, err
+	}
+
+	// Leave out the teams that already sit on an alliance as its backup.
+	var backupPool game.Rankings
+	for _, ranking := range backupTeams {
+		if !allianceBackups[ranking.TeamId] {
+			backupPool = append(backupPool, ranking)
+		}
+	}
+	return backupPool, nil
+}
+
+// Reloads the given arena's current match if it is an unstarted playoff match of the given alliance, so that it picks
+// up the alliance's latest lineup.
+func reloadPlayoffMatchForAlliance(arena *field.Arena, allianceId int) error {
+	match := arena.CurrentMatch
+	if match.Type != model.Playoff || arena.MatchState != field.PreMatch ||
+		(match.PlayoffRedAlliance != allianceId && match.PlayoffBlueAlliance != allianceId) {
+		return nil
+	}
+	updatedMatch, err := arena.Database.GetMatchById(match.Id)
+	if err != nil || updatedMatch == nil {
+		return err
+	}
+	return arena.LoadMatch(updatedMatch)
+}
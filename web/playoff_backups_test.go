@@ -0,0 +1,75 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/tournament"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPlayoffBackups(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/playoff_backups")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Backups can't be called until")
+
+	web.arena.EventSettings.PlayoffType = model.SingleEliminationPlayoff
+	web.arena.EventSettings.NumPlayoffAlliances = 2
+	assert.Nil(t, web.arena.CreatePlayoffTournament())
+	tournament.CreateTestAlliances(web.arena.Database, 2)
+	for i, teamId := range []int{101, 201, 102, 202, 103, 203, 104, 204, 9001, 9002} {
+		web.arena.Database.CreateRanking(&game.Ranking{TeamId: teamId, Rank: i + 1})
+	}
+	assert.Nil(t, web.arena.CreatePlayoffMatches(time.Unix(1000, 0)))
+	matches, _ := web.arena.Database.GetMatchesByType(model.Playoff, false)
+	assert.Nil(t, web.arena.LoadMatch(&matches[0]))
+	assert.Equal(t, 102, web.arena.CurrentMatch.Red1)
+
+	recorder = web.getHttpResponse("/playoff_backups")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "With 9001")
+	assert.NotContains(t, recorder.Body.String(), "With 104")
+
+	// Call in backups that aren't in the pool or don't replace a team from the alliance.
+	recorder = web.postHttpResponse("/playoff_backups", "allianceId=1&backupTeamId=204&replacedTeamId=102")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 204 isn't in the pool of backup teams.")
+	recorder = web.postHttpResponse("/playoff_backups", "allianceId=1&backupTeamId=9001&replacedTeamId=202")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "team 202 isn't part of alliance 1")
+
+	// Call in a backup for real and check that it is carried into the lineup of the loaded match.
+	recorder = web.postHttpResponse("/playoff_backups", "allianceId=1&backupTeamId=9001&replacedTeamId=102")
+	assert.Equal(t, 303, recorder.Code)
+	alliance, _ := web.arena.Database.GetAllianceById(1)
+	assert.Equal(t, []int{101, 102, 103, 104, 9001}, alliance.TeamIds)
+	assert.Equal(t, [3]int{9001, 101, 103}, alliance.Lineup)
+	assert.Equal(t, 9001, web.arena.CurrentMatch.Red1)
+	assert.Equal(t, 9001, web.arena.AllianceStations["R1"].Team.Id)
+	matches, _ = web.arena.Database.GetMatchesByType(model.Playoff, false)
+	assert.Equal(t, 9001, matches[0].Red1)
+
+	// Check that the backup has left the pool and that only one can be called per alliance.
+	recorder = web.getHttpResponse("/playoff_backups")
+	assert.NotContains(t, recorder.Body.String(), "With 9001")
+	assert.Contains(t, recorder.Body.String(), "9001 replaced 102")
+	recorder = web.postHttpResponse("/playoff_backups", "allianceId=1&backupTeamId=9002&replacedTeamId=101")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "alliance 1 has already called in backup team 9001")
+	recorder = web.postHttpResponse("/playoff_backups", "allianceId=2&backupTeamId=9001&replacedTeamId=201")
+	assert.Contains(t, recorder.Body.String(), "Team 9001 isn't in the pool of backup teams.")
+
+	// Check that TBA publishing is triggered.
+	web.arena.TbaClient.BaseUrl = "fakeurl"
+	web.arena.EventSettings.TbaPublishingEnabled = true
+	recorder = web.postHttpResponse("/playoff_backups", "allianceId=2&backupTeamId=9002&replacedTeamId=201")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Failed to publish alliances")
+	assert.Equal(t, field.PreMatch, web.arena.MatchState)
+}
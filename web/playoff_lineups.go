@@ -0,0 +1,335 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Web routes for alliance captains to submit the lineup for their next playoff match, and for managing their PINs.
+
+package web
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"math/big"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	lineupPinLength = 8
+	// Number of consecutive incorrect PINs after which sign-ins from a client are refused for the lockout period.
+	maxLineupPinFailures   = 5
+	lineupPinLockoutPeriod = 5 * time.Minute
+)
+
+// Holds the state of an alliance's lineup for its next playoff match.
+type allianceLineup struct {
+	Alliance   model.Alliance
+	Pin        string
+	Match      *model.Match
+	IsRed      bool
+	TeamIds    [3]int
+	Submission *model.LineupSubmission
+	IsClosed   bool
+}
+
+// Tracks the incorrect PINs entered from a client.
+type lineupPinFailures struct {
+	count       int
+	lockedUntil time.Time
+}
+
+// Shows the form for an alliance captain to sign in with their alliance's PIN.
+func (web *Web) lineupGetHandler(w http.ResponseWriter, r *http.Request) {
+	web.renderLineup(w, r, nil, "", "")
+}
+
+// Signs an alliance captain in with their alliance's PIN and, if they have chosen one, saves the lineup for their
+// alliance's next playoff match.
+func (web *Web) lineupPostHandler(w http.ResponseWriter, r *http.Request) {
+	allianceId, _ := strconv.Atoi(r.PostFormValue("allianceId"))
+	lineup, err := web.getAllianceLineup(allianceId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if lineup == nil || lineup.Pin == "" {
+		web.renderLineup(w, r, nil, "Invalid alliance or PIN.", "")
+		return
+	}
+	clientAddress := lineupClientAddress(r)
+	if web.isLineupPinLockedOut(clientAddress) {
+		web.renderLineup(w, r, nil, "Too many incorrect PINs have been entered from this device; try again later.", "")
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(lineup.Pin), []byte(r.PostFormValue("pin"))) != 1 {
+		web.recordLineupPinFailure(clientAddress)
+		web.renderLineup(w, r, nil, "Invalid alliance or PIN.", "")
+		return
+	}
+	web.clearLineupPinFailures(clientAddress)
+	if r.PostFormValue("submit") == "" {
+		web.renderLineup(w, r, lineup, "", "")
+		return
+	}
+
+	var teamIds [3]int
+	for i := range teamIds {
+		teamIds[i], _ = strconv.Atoi(r.PostFormValue(fmt.Sprintf("station%d", i+1)))
+	}
+	if err = web.submitLineup(lineup, teamIds); err != nil {
+		web.renderLineup(w, r, lineup, err.Error(), "")
+		return
+	}
+	web.renderLineup(w, r, lineup, "", fmt.Sprintf("Lineup saved for match %s.", lineup.Match.ShortName))
+}
+
+// Shows each alliance's PIN and the lineup it has submitted for its next playoff match.
+func (web *Web) playoffLineupsGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	alliances, err := web.arena.Database.GetAllAlliances()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var lineups []*allianceLineup
+	for _, alliance := range alliances {
+		lineup, err := web.getAllianceLineup(alliance.Id)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		lineups = append(lineups, lineup)
+	}
+
+	template, err := web.parseFiles("templates/playoff_lineups.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Lineups []*allianceLineup
+	}{web.arena.EventSettings, lineups}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Issues a new PIN for the given alliance, invalidating its old one.
+func (web *Web) playoffLineupsPinPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	allianceId, _ := strconv.Atoi(r.PostFormValue("allianceId"))
+	alliance, err := web.arena.Database.GetAllianceById(allianceId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if alliance == nil {
+		handleWebErr(w, fmt.Errorf("alliance %d doesn't exist", allianceId))
+		return
+	}
+	if err = web.resetLineupPin(allianceId); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	http.Redirect(w, r, "/playoff_lineups", 303)
+}
+
+func (web *Web) renderLineup(
+	w http.ResponseWriter, r *http.Request, lineup *allianceLineup, errorMessage, successMessage string,
+) {
+	template, err := web.parseFiles("templates/lineup.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Lineup         *allianceLineup
+		ErrorMessage   string
+		SuccessMessage string
+	}{web.arena.EventSettings, lineup, errorMessage, successMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Returns the state of the given alliance's lineup for its next playoff match, or nil if the alliance doesn't exist.
+func (web *Web) getAllianceLineup(allianceId int) (*allianceLineup, error) {
+	alliance, err := web.arena.Database.GetAllianceById(allianceId)
+	if err != nil || alliance == nil {
+		return nil, err
+	}
+	lineup := allianceLineup{Alliance: *alliance}
+	lineupPin, err := web.arena.Database.GetLineupPinByAlliance(allianceId)
+	if err != nil {
+		return nil, err
+	}
+	if lineupPin != nil {
+		lineup.Pin = lineupPin.Pin
+	}
+
+	matches, err := web.arena.Database.GetMatchesByType(model.Playoff, false)
+	if err != nil {
+		return nil, err
+	}
+	for i, match := range matches {
+		if !match.IsComplete() && (match.PlayoffRedAlliance == allianceId || match.PlayoffBlueAlliance == allianceId) {
+			lineup.Match = &matches[i]
+			break
+		}
+	}
+	if lineup.Match == nil {
+		return &lineup, nil
+	}
+
+	lineup.IsRed = lineup.Match.PlayoffRedAlliance == allianceId
+	if lineup.IsRed {
+		lineup.TeamIds = [3]int{lineup.Match.Red1, lineup.Match.Red2, lineup.Match.Red3}
+	} else {
+		lineup.TeamIds = [3]int{lineup.Match.Blue1, lineup.Match.Blue2, lineup.Match.Blue3}
+	}
+	if lineup.Submission, err = web.arena.Database.GetLineupSubmission(lineup.Match.Id, allianceId); err != nil {
+		return nil, err
+	}
+	if lineup.Submission != nil {
+		lineup.TeamIds = lineup.Submission.TeamIds
+	}
+
+	// Submissions close once the match is queued behind the one on the field, since its lineup is used to set up the
+	// field network and team signs in advance.
+	for _, fieldArena := range web.arena.Fields() {
+		isQueued, err := fieldArena.IsMatchQueued(lineup.Match)
+		if err != nil {
+			return nil, err
+		}
+		if isQueued {
+			lineup.IsClosed = true
+		}
+	}
+	return &lineup, nil
+}
+
+// Returns the teams of the given alliance that are allowed to play in its matches.
+func (lineup *allianceLineup) EligibleTeamIds() []int {
+	var teamIds []int
+	for _, teamId := range lineup.Alliance.TeamIds {
+		if teamId != lineup.Alliance.ReplacedTeamId {
+			teamIds = append(teamIds, teamId)
+		}
+	}
+	return teamIds
+}
+
+// Validates the given lineup and saves it for the alliance's next playoff match.
+func (web *Web) submitLineup(lineup *allianceLineup, teamIds [3]int) error {
+	if lineup.Match == nil {
+		return fmt.Errorf("Alliance %d has no upcoming playoff match.", lineup.Alliance.Id)
+	}
+	if lineup.IsClosed {
+		return fmt.Errorf(
+			"Lineup submissions for match %s have closed since it has been queued for the field.",
+			lineup.Match.ShortName,
+		)
+	}
+	eligibleTeamIds := lineup.EligibleTeamIds()
+	for i, teamId := range teamIds {
+		if !slices.Contains(eligibleTeamIds, teamId) {
+			return fmt.Errorf("Team %d isn't eligible to play for Alliance %d.", teamId, lineup.Alliance.Id)
+		}
+		if slices.Contains(teamIds[:i], teamId) {
+			return fmt.Errorf("Team %d can't play in more than one station.", teamId)
+		}
+	}
+
+	if lineup.Submission == nil {
+		lineup.Submission = &model.LineupSubmission{MatchId: lineup.Match.Id, AllianceId: lineup.Alliance.Id}
+		lineup.Submission.TeamIds = teamIds
+		lineup.Submission.SubmittedAt = time.Now()
+		if err := web.arena.Database.CreateLineupSubmission(lineup.Submission); err != nil {
+			return err
+		}
+	} else {
+		lineup.Submission.TeamIds = teamIds
+		lineup.Submission.SubmittedAt = time.Now()
+		if err := web.arena.Database.UpdateLineupSubmission(lineup.Submission); err != nil {
+			return err
+		}
+	}
+	lineup.TeamIds = teamIds
+	return nil
+}
+
+// Issues a new random PIN to the given alliance, replacing any that it already had.
+func (web *Web) resetLineupPin(allianceId int) error {
+	pinNumber, err := rand.Int(rand.Reader, new(big.Int).Exp(big.NewInt(10), big.NewInt(lineupPinLength), nil))
+	if err != nil {
+		return err
+	}
+	pin := fmt.Sprintf("%0*d", lineupPinLength, pinNumber.Int64())
+
+	lineupPin, err := web.arena.Database.GetLineupPinByAlliance(allianceId)
+	if err != nil {
+		return err
+	}
+	if lineupPin == nil {
+		return web.arena.Database.CreateLineupPin(&model.LineupPin{AllianceId: allianceId, Pin: pin})
+	}
+	lineupPin.Pin = pin
+	return web.arena.Database.UpdateLineupPin(lineupPin)
+}
+
+// Returns true if sign-ins from the given client are refused because it has entered too many incorrect PINs.
+func (web *Web) isLineupPinLockedOut(clientAddress string) bool {
+	primaryWeb := web.fieldWebs()[0]
+	primaryWeb.lineupPinFailuresMutex.Lock()
+	defer primaryWeb.lineupPinFailuresMutex.Unlock()
+	failures, ok := primaryWeb.lineupPinFailures[clientAddress]
+	return ok && time.Now().Before(failures.lockedUntil)
+}
+
+// Counts an incorrect PIN against the given client, locking it out of further sign-ins once there have been too many.
+func (web *Web) recordLineupPinFailure(clientAddress string) {
+	primaryWeb := web.fieldWebs()[0]
+	primaryWeb.lineupPinFailuresMutex.Lock()
+	defer primaryWeb.lineupPinFailuresMutex.Unlock()
+	failures, ok := primaryWeb.lineupPinFailures[clientAddress]
+	if !ok {
+		failures = &lineupPinFailures{}
+		primaryWeb.lineupPinFailures[clientAddress] = failures
+	}
+	failures.count++
+	if failures.count >= maxLineupPinFailures {
+		failures.count = 0
+		failures.lockedUntil = time.Now().Add(lineupPinLockoutPeriod)
+	}
+}
+
+func (web *Web) clearLineupPinFailures(clientAddress string) {
+	primaryWeb := web.fieldWebs()[0]
+	primaryWeb.lineupPinFailuresMutex.Lock()
+	defer primaryWeb.lineupPinFailuresMutex.Unlock()
+	delete(primaryWeb.lineupPinFailures, clientAddress)
+}
+
+// Returns the address of the client that made the given request, by which incorrect PINs are counted.
+func lineupClientAddress(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
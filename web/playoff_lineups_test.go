@@ -0,0 +1,150 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/tournament"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlayoffLineups(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/playoff_lineups")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Lineups can't be submitted until")
+	recorder = web.postHttpResponse("/playoff_lineups/pin", "allianceId=1")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "alliance 1 doesn't exist")
+
+	web.arena.EventSettings.PlayoffType = model.SingleEliminationPlayoff
+	web.arena.EventSettings.NumPlayoffAlliances = 2
+	assert.Nil(t, web.arena.CreatePlayoffTournament())
+	tournament.CreateTestAlliances(web.arena.Database, 2)
+	assert.Nil(t, web.arena.CreatePlayoffMatches(time.Unix(1000, 0)))
+	for _, teamId := range []int{101, 102, 103, 104, 201, 202, 203, 204} {
+		web.arena.Database.CreateTeam(&model.Team{Id: teamId})
+	}
+
+	// Issue PINs to the alliances.
+	recorder = web.getHttpResponse("/playoff_lineups")
+	assert.Contains(t, recorder.Body.String(), "None")
+	recorder = web.postHttpResponse("/playoff_lineups/pin", "allianceId=1")
+	assert.Equal(t, 303, recorder.Code)
+	lineupPin, _ := web.arena.Database.GetLineupPinByAlliance(1)
+	if !assert.NotNil(t, lineupPin) {
+		return
+	}
+	pin := lineupPin.Pin
+	assert.Regexp(t, "^[0-9]{8}$", pin)
+	recorder = web.getHttpResponse("/playoff_lineups")
+	assert.Contains(t, recorder.Body.String(), pin)
+
+	// Sign in as the alliance captain.
+	recorder = web.getHttpResponse("/lineup")
+	assert.Equal(t, 200, recorder.Code)
+	recorder = web.postHttpResponse("/lineup", "allianceId=2&pin="+pin)
+	assert.Contains(t, recorder.Body.String(), "Invalid alliance or PIN.")
+	recorder = web.postHttpResponse("/lineup", "allianceId=1&pin=wrong")
+	assert.Contains(t, recorder.Body.String(), "Invalid alliance or PIN.")
+	recorder = web.postHttpResponse("/lineup", "allianceId=1&pin="+pin)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Alliance 1 Lineup")
+	assert.Contains(t, recorder.Body.String(), "Final 1")
+	assert.Contains(t, recorder.Body.String(), "No lineup has been submitted yet")
+
+	// Submit invalid lineups.
+	lineupForm := func(station1, station2, station3 int) string {
+		return fmt.Sprintf(
+			"allianceId=1&pin=%s&submit=true&station1=%d&station2=%d&station3=%d", pin, station1, station2, station3,
+		)
+	}
+	recorder = web.postHttpResponse("/lineup", lineupForm(104, 201, 101))
+	assert.Contains(t, recorder.Body.String(), "Team 201 isn't eligible to play for Alliance 1.")
+	recorder = web.postHttpResponse("/lineup", lineupForm(104, 104, 101))
+	assert.Contains(t, recorder.Body.String(), "Team 104 can't play in more than one station.")
+	matches, _ := web.arena.Database.GetMatchesByType(model.Playoff, false)
+	lineupSubmission, _ := web.arena.Database.GetLineupSubmission(matches[0].Id, 1)
+	assert.Nil(t, lineupSubmission)
+
+	// Submit a valid lineup, then change it.
+	recorder = web.postHttpResponse("/lineup", lineupForm(104, 101, 103))
+	assert.Contains(t, recorder.Body.String(), "Lineup saved for match F1.")
+	recorder = web.postHttpResponse("/lineup", lineupForm(104, 102, 103))
+	assert.Contains(t, recorder.Body.String(), "Lineup saved for match F1.")
+	lineupSubmission, _ = web.arena.Database.GetLineupSubmission(matches[0].Id, 1)
+	if assert.NotNil(t, lineupSubmission) {
+		assert.Equal(t, [3]int{104, 102, 103}, lineupSubmission.TeamIds)
+	}
+
+	// Check that the submitted lineup is used when the match is loaded, after which submissions are closed.
+	assert.Nil(t, web.arena.LoadMatch(&matches[0]))
+	assert.Equal(t, 104, web.arena.AllianceStations["R1"].Team.Id)
+	assert.Equal(t, 102, web.arena.AllianceStations["R2"].Team.Id)
+	assert.Equal(t, 202, web.arena.AllianceStations["B1"].Team.Id)
+	recorder = web.postHttpResponse("/lineup", lineupForm(101, 102, 103))
+	assert.Contains(t, recorder.Body.String(), "Lineup submissions for match F1 have closed")
+	recorder = web.getHttpResponse("/playoff_lineups")
+	assert.Contains(t, recorder.Body.String(), "F1 (queued)")
+	assert.Contains(t, recorder.Body.String(), "104 102 103")
+
+	// Check that submissions for the alliance's next match are closed once it is queued behind the one on the field.
+	matches[0].Status = game.RedWonMatch
+	assert.Nil(t, web.arena.Database.UpdateMatch(&matches[0]))
+	recorder = web.postHttpResponse("/lineup", lineupForm(101, 102, 103))
+	assert.Contains(t, recorder.Body.String(), "Lineup submissions for match F2 have closed")
+	recorder = web.getHttpResponse("/playoff_lineups")
+	assert.Contains(t, recorder.Body.String(), "F2 (queued)")
+}
+
+func TestLineupPinLockout(t *testing.T) {
+	web := setupTestWeb(t)
+	web2 := setupTestSecondField(t, web)
+	tournament.CreateTestAlliances(web.arena.Database, 2)
+	assert.Nil(t, web.resetLineupPin(1))
+	assert.Nil(t, web.resetLineupPin(2))
+	lineupPin, _ := web.arena.Database.GetLineupPinByAlliance(1)
+	pin := lineupPin.Pin
+	postLineup := func(web *Web, remoteAddr, body string) string {
+		recorder := httptest.NewRecorder()
+		request, _ := http.NewRequest("POST", "/lineup", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		request.RemoteAddr = remoteAddr
+		web.newHandler().ServeHTTP(recorder, request)
+		return recorder.Body.String()
+	}
+
+	for i := 0; i < maxLineupPinFailures-1; i++ {
+		assert.Contains(t, postLineup(web, "10.0.100.50:1000", "allianceId=1&pin=wrong"), "Invalid alliance or PIN.")
+	}
+	assert.Contains(t, postLineup(web, "10.0.100.50:1001", "allianceId=1&pin="+pin), "Alliance 1 Lineup")
+
+	// A successful sign-in should reset the count, after which too many incorrect PINs should lock out the client,
+	// counting those entered through any field and for any alliance.
+	for i := 0; i < maxLineupPinFailures; i++ {
+		fieldWeb := web
+		if i%2 == 1 {
+			fieldWeb = web2
+		}
+		body := postLineup(fieldWeb, "10.0.100.50:1000", fmt.Sprintf("allianceId=%d&pin=wrong", i%2+1))
+		assert.Contains(t, body, "Invalid alliance or PIN.")
+	}
+	for _, fieldWeb := range []*Web{web, web2} {
+		body := postLineup(fieldWeb, "10.0.100.50:1002", "allianceId=1&pin="+pin)
+		assert.Contains(t, body, "Too many incorrect PINs have been entered from this device")
+		assert.NotContains(t, body, "Alliance 1 Lineup")
+	}
+
+	// Other clients shouldn't be affected, and the lockout should expire.
+	assert.Contains(t, postLineup(web2, "10.0.100.51:1000", "allianceId=1&pin="+pin), "Alliance 1 Lineup")
+	web.lineupPinFailures["10.0.100.50"].lockedUntil = time.Now().Add(-time.Second)
+	assert.Contains(t, postLineup(web, "10.0.100.50:1000", "allianceId=1&pin="+pin), "Alliance 1 Lineup")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/websocket"
 	"net/http"
+	"sort"
 	"time"
 )
 
@@ -42,46 +43,38 @@ func (web *Web) queueingDisplayHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// An upcoming match along with the field it is to be played on and its position in that field's queue.
+type QueueingMatch struct {
+	model.Match
+	FieldNumber       int
+	Position          int
+	RedOffFieldTeams  []int
+	BlueOffFieldTeams []int
+}
+
 // Renders a partial template containing the list of matches.
 func (web *Web) queueingDisplayMatchLoadHandler(w http.ResponseWriter, r *http.Request) {
-	matches, err := web.arena.Database.GetMatchesByType(web.arena.CurrentMatch.Type, false)
-	if err != nil {
-		handleWebErr(w, err)
-		return
-	}
-
+	matchType := web.arena.CurrentMatch.Type
 	numMatchesToShow := numNonPlayoffMatchesToShow
-	if web.arena.CurrentMatch.Type == model.Playoff {
+	if matchType == model.Playoff {
 		numMatchesToShow = numPlayoffMatchesToShow
 	}
 
-	var upcomingMatches []model.Match
-	var redOffFieldTeamsByMatch, blueOffFieldTeamsByMatch [][]int
-	if err != nil {
-		handleWebErr(w, err)
-		return
-	}
-	for i, match := range matches {
-		if match.IsComplete() || match.TypeOrder < web.arena.CurrentMatch.TypeOrder {
-			continue
-		}
-		upcomingMatches = append(upcomingMatches, match)
-		redOffFieldTeams, blueOffFieldTeams, err := web.arena.Database.GetOffFieldTeamIds(&match)
+	// Merge the queues of all the fields so that the display shows the upcoming matches in schedule order.
+	var upcomingMatches []QueueingMatch
+	for _, fieldArena := range web.arena.Fields() {
+		fieldMatches, err := getUpcomingMatches(fieldArena, matchType, numMatchesToShow)
 		if err != nil {
 			handleWebErr(w, err)
 			return
 		}
-		redOffFieldTeamsByMatch = append(redOffFieldTeamsByMatch, redOffFieldTeams)
-		blueOffFieldTeamsByMatch = append(blueOffFieldTeamsByMatch, blueOffFieldTeams)
-
-		if len(upcomingMatches) == numMatchesToShow {
-			break
-		}
-
-		// Don't include any more matches if there is a significant gap before the next one.
-		if i+1 < len(matches) && matches[i+1].Time.Sub(match.Time) > field.MaxMatchGapMin*time.Minute {
-			break
-		}
+		upcomingMatches = append(upcomingMatches, fieldMatches...)
+	}
+	sort.SliceStable(upcomingMatches, func(i, j int) bool {
+		return upcomingMatches[i].TypeOrder < upcomingMatches[j].TypeOrder
+	})
+	if len(upcomingMatches) > numMatchesToShow {
+		upcomingMatches = upcomingMatches[:numMatchesToShow]
 	}
 
 	template, err := web.parseFiles("templates/queueing_display_match_load.html")
@@ -91,13 +84,13 @@ func (web *Web) queueingDisplayMatchLoadHandler(w http.ResponseWriter, r *http.R
 	}
 
 	data := struct {
-		Matches           []model.Match
-		RedOffFieldTeams  [][]int
-		BlueOffFieldTeams [][]int
+		Matches     []QueueingMatch
+		FieldNumber int
+		ShowFields  bool
 	}{
 		upcomingMatches,
-		redOffFieldTeamsByMatch,
-		blueOffFieldTeamsByMatch,
+		web.arena.FieldNumber,
+		len(web.arena.Fields()) > 1,
 	}
 	err = template.ExecuteTemplate(w, "queueing_display_match_load.html", data)
 	if err != nil {
@@ -106,6 +99,39 @@ func (web *Web) queueingDisplayMatchLoadHandler(w http.ResponseWriter, r *http.R
 	}
 }
 
+// Returns up to the given number of unplayed matches of the given type that are queued for the given arena's field.
+func getUpcomingMatches(arena *field.Arena, matchType model.MatchType, numMatchesToShow int) ([]QueueingMatch, error) {
+	matches, err := arena.GetFieldMatchesByType(matchType)
+	if err != nil {
+		return nil, err
+	}
+
+	var upcomingMatches []QueueingMatch
+	for i, match := range matches {
+		if match.IsComplete() || match.TypeOrder < arena.CurrentMatch.TypeOrder {
+			continue
+		}
+		redOffFieldTeams, blueOffFieldTeams, err := arena.Database.GetOffFieldTeamIds(&match)
+		if err != nil {
+			return nil, err
+		}
+		upcomingMatches = append(
+			upcomingMatches,
+			QueueingMatch{match, arena.FieldNumber, len(upcomingMatches), redOffFieldTeams, blueOffFieldTeams},
+		)
+
+		if len(upcomingMatches) == numMatchesToShow {
+			break
+		}
+
+		// Don't include any more matches if there is a significant gap before the next one.
+		if i+1 < len(matches) && matches[i+1].Time.Sub(match.Time) > field.MaxMatchGapMin*time.Minute {
+			break
+		}
+	}
+	return upcomingMatches, nil
+}
+
 // The websocket endpoint for the queueing display to receive updates.
 func (web *Web) queueingDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 	display, err := web.registerDisplay(r)
@@ -121,8 +147,16 @@ func (web *Web) queueingDisplayWebsocketHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 	defer ws.Close()
+	ws.SetDropCounter(display.DropCounter())
 
 	// Subscribe the websocket to the notifiers whose messages will be passed on to the client.
-	ws.HandleNotifiers(display.Notifier, web.arena.MatchTimingNotifier, web.arena.MatchLoadNotifier,
-		web.arena.MatchTimeNotifier, web.arena.EventStatusNotifier, web.arena.ReloadDisplaysNotifier)
+	notifiers := []*websocket.Notifier{display.Notifier, web.arena.MatchTimingNotifier, web.arena.MatchLoadNotifier,
+		web.arena.MatchTimeNotifier, web.arena.EventStatusNotifier, web.arena.ReloadDisplaysNotifier}
+	for _, fieldArena := range web.arena.Fields() {
+		if fieldArena != web.arena {
+			// Refresh the list whenever any of the other fields loads a match too.
+			notifiers = append(notifiers, fieldArena.MatchLoadNotifier)
+		}
+	}
+	ws.HandleNotifiers(notifiers...)
 }
@@ -4,9 +4,12 @@
 package web
 
 import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/websocket"
 	gorillawebsocket "github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -35,3 +38,34 @@ func TestQueueingDisplayWebsocket(t *testing.T) {
 	readWebsocketType(t, ws, "matchTime")
 	readWebsocketType(t, ws, "eventStatus")
 }
+
+func TestQueueingDisplayMultipleFields(t *testing.T) {
+	web := setupTestWeb(t)
+	web2 := setupTestSecondField(t, web)
+
+	for i := 1; i <= 6; i++ {
+		match := model.Match{
+			Type: model.Qualification, TypeOrder: i, ShortName: fmt.Sprintf("Q%d", i), Red1: 100 + i, Blue1: 200 + i,
+		}
+		assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	}
+	match, _ := web.arena.Database.GetMatchByTypeOrder(model.Qualification, 1)
+	assert.Nil(t, web.arena.LoadMatch(match))
+	match, _ = web.arena.Database.GetMatchByTypeOrder(model.Qualification, 2)
+	assert.Nil(t, web2.arena.LoadMatch(match))
+
+	// The upcoming matches of both fields should be shown in order, along with their fields.
+	recorder := web.getHttpResponse("/displays/queueing/match_load")
+	assert.Equal(t, 200, recorder.Code)
+	body := recorder.Body.String()
+	assert.Contains(t, body, "Field 1")
+	assert.Contains(t, body, "Field 2")
+	for i := 1; i <= 5; i++ {
+		assert.Contains(t, body, fmt.Sprintf(">Q%d<", i))
+	}
+	assert.NotContains(t, body, ">Q6<")
+	assert.Less(t, strings.Index(body, ">Q1<"), strings.Index(body, ">Q2<"))
+	assert.Less(t, strings.Index(body, ">Q2<"), strings.Index(body, ">Q3<"))
+	assert.Equal(t, 2, strings.Count(body, "On Field"))
+	assert.Equal(t, 1, strings.Count(body, `id="matchTime"`))
+}
@@ -47,6 +47,7 @@ func (web *Web) rankingsDisplayWebsocketHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 	defer ws.Close()
+	ws.SetDropCounter(display.DropCounter())
 
 	// Subscribe the websocket to the notifiers whose messages will be passed on to the client.
 	ws.HandleNotifiers(display.Notifier, web.arena.EventStatusNotifier, web.arena.ReloadDisplaysNotifier)
@@ -12,10 +12,12 @@ import (
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/network"
 	"github.com/Team254/cheesy-arena/playoff"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/jung-kurt/gofpdf"
@@ -125,6 +127,12 @@ func (web *Web) findBackupTeams(rankings game.Rankings) (game.Rankings, map[int]
 
 	for _, alliance := range alliances {
 		for i, allianceTeamId := range alliance.TeamIds {
+			// A backup that has been called in during the playoffs is now a member of its alliance.
+			if allianceTeamId == alliance.BackupTeamId {
+				pickedTeams[allianceTeamId] = true
+				continue
+			}
+
 			// Teams in third in an alliance are backups at events that use 3 team alliances.
 			if i == 3 {
 				pickedBackups[allianceTeamId] = true
@@ -614,6 +622,122 @@ func (web *Web) teamsPdfReportHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Generates a CSV-formatted report of every inspection of every team. Restricted to admins since it includes the
+// inspectors' names and notes.
+func (web *Web) inspectionsCsvReportHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	inspections, err := web.arena.Database.GetAllTeamInspections()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Don't set the content type as "text/csv", as that will trigger an automatic download in the browser.
+	w.Header().Set("Content-Type", "text/plain")
+	template, err := web.parseFiles("templates/inspections.csv")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	err = template.ExecuteTemplate(w, "inspections.csv", inspections)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Generates a PDF-formatted report of the current inspection status of each team.
+func (web *Web) inspectionsPdfReportHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	summary, err := web.arena.GetInspectionSummary()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// The widths of the table columns in mm, stored here so that they can be referenced for each row.
+	colWidths := map[string]float64{
+		"Team": 15, "Name": 60, "Status": 25, "Inspector": 35, "Weight": 20, "Bumpers": 20, "Time": 20,
+	}
+	rowHeight := 6.5
+
+	pdf := gofpdf.New("P", "mm", "Letter", "font")
+	pdf.AddPage()
+	pdf.SetFont("Arial", "B", 10)
+	pdf.SetFillColor(220, 220, 220)
+
+	// Render table header row.
+	pdf.CellFormat(
+		195,
+		rowHeight,
+		fmt.Sprintf(
+			"Team Inspection Status - %s (%d passed, %d failed, %d not inspected)",
+			web.arena.EventSettings.Name,
+			summary.NumPassed,
+			summary.NumFailed,
+			summary.NumNotInspected,
+		),
+		"",
+		1,
+		"C",
+		false,
+		0,
+		"",
+	)
+	pdf.CellFormat(colWidths["Team"], rowHeight, "Team", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Name"], rowHeight, "Name", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Status"], rowHeight, "Status", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Inspector"], rowHeight, "Inspector", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Weight"], rowHeight, "Weight", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Bumpers"], rowHeight, "Bumpers", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Time"], rowHeight, "Time", "1", 1, "C", true, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	for _, team := range summary.Teams {
+		status := "Not Inspected"
+		var inspector, weight, bumpers, inspectedAt string
+		if inspection := team.LastInspection; inspection != nil {
+			if inspection.Passed {
+				status = "Passed"
+			} else {
+				status = "Failed"
+			}
+			inspector = inspection.Inspector
+			if inspection.WeightLbs > 0 {
+				weight = fmt.Sprintf("%.1f lbs", inspection.WeightLbs)
+			}
+			if inspection.BumpersOk {
+				bumpers = "OK"
+			} else {
+				bumpers = "Not OK"
+			}
+			inspectedAt = inspection.InspectedAt.Local().Format("3:04 PM")
+		}
+		pdf.CellFormat(colWidths["Team"], rowHeight, strconv.Itoa(team.TeamId), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths["Name"], rowHeight, team.Nickname, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths["Status"], rowHeight, status, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths["Inspector"], rowHeight, inspector, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths["Weight"], rowHeight, weight, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths["Bumpers"], rowHeight, bumpers, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths["Time"], rowHeight, inspectedAt, "1", 1, "L", false, 0, "")
+	}
+
+	addTimeGeneratedFooter(pdf)
+
+	// Write out the PDF file as the HTTP response.
+	w.Header().Set("Content-Type", "application/pdf")
+	err = pdf.Output(w)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
 // Generates a CSV-formatted report of the WPA keys, for import into the radio kiosk.
 func (web *Web) wpaKeysCsvReportHandler(w http.ResponseWriter, r *http.Request) {
 	if !web.userIsAdmin(w, r) {
@@ -637,6 +761,127 @@ func (web *Web) wpaKeysCsvReportHandler(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// Generates a PDF-formatted run-of-show for the event, listing each segment of the agenda with its planned and actual
+// times.
+func (web *Web) agendaPdfReportHandler(w http.ResponseWriter, r *http.Request) {
+	segments, err := web.arena.Database.GetAllAgendaSegments()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// The widths of the table columns in mm, stored here so that they can be referenced for each row.
+	colWidths := map[string]float64{"Date": 25, "Planned": 40, "Type": 32, "Title": 63, "Actual": 35}
+	rowHeight := 6.5
+
+	pdf := gofpdf.New("P", "mm", "Letter", "font")
+	pdf.AddPage()
+
+	// Render table header row.
+	pdf.SetFont("Arial", "B", 10)
+	pdf.SetFillColor(220, 220, 220)
+	pdf.CellFormat(195, rowHeight, "Agenda - "+web.arena.EventSettings.Name, "", 1, "C", false, 0, "")
+	pdf.CellFormat(colWidths["Date"], rowHeight, "Date", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Planned"], rowHeight, "Planned", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Type"], rowHeight, "Type", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Title"], rowHeight, "Title", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Actual"], rowHeight, "Actual", "1", 1, "C", true, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	for _, segment := range segments {
+		plannedTimes := fmt.Sprintf(
+			"%s - %s",
+			segment.PlannedStartTime.Local().Format("3:04 PM"),
+			segment.PlannedEndTime.Local().Format("3:04 PM"),
+		)
+		var actualTimes string
+		if !segment.ActualStartTime.IsZero() {
+			actualTimes = segment.ActualStartTime.Local().Format("3:04 PM") + " - "
+			if segment.IsComplete() {
+				actualTimes += segment.ActualEndTime.Local().Format("3:04 PM")
+			}
+		}
+		date := segment.PlannedStartTime.Local().Format("Mon 1/02")
+		pdf.CellFormat(colWidths["Date"], rowHeight, date, "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths["Planned"], rowHeight, plannedTimes, "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths["Type"], rowHeight, segment.Type.String(), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths["Title"], rowHeight, segment.Title, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths["Actual"], rowHeight, actualTimes, "1", 1, "C", false, 0, "")
+	}
+
+	addTimeGeneratedFooter(pdf)
+
+	// Write out the PDF file as the HTTP response.
+	w.Header().Set("Content-Type", "application/pdf")
+	err = pdf.Output(w)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Generates a PDF-formatted log of each step of the alliance selection in the order in which it happened.
+func (web *Web) allianceSelectionPdfReportHandler(w http.ResponseWriter, r *http.Request) {
+	events, err := web.arena.Database.GetAllAllianceSelectionEvents()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// The widths of the table columns in mm, stored here so that they can be referenced for each row.
+	colWidths := map[string]float64{"Step": 15, "Time": 35, "Action": 40, "Alliance": 35, "Spot": 35, "Team": 35}
+	rowHeight := 6.5
+
+	pdf := gofpdf.New("P", "mm", "Letter", "font")
+	pdf.AddPage()
+
+	// Render table header row.
+	pdf.SetFont("Arial", "B", 10)
+	pdf.SetFillColor(220, 220, 220)
+	pdf.CellFormat(195, rowHeight, "Alliance Selection Log - "+web.arena.EventSettings.Name, "", 1, "C", false, 0, "")
+	pdf.CellFormat(colWidths["Step"], rowHeight, "Step", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Time"], rowHeight, "Time", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Action"], rowHeight, "Action", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Alliance"], rowHeight, "Alliance", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Spot"], rowHeight, "Spot", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Team"], rowHeight, "Team", "1", 1, "C", true, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	actions := map[model.AllianceSelectionEventType]string{
+		model.InviteEvent:  "Invited",
+		model.AcceptEvent:  "Accepted",
+		model.DeclineEvent: "Declined",
+		model.AssignEvent:  "Entered",
+		model.UndoEvent:    "Undone",
+	}
+	for i, event := range events {
+		action := actions[event.Type]
+		team := strconv.Itoa(event.TeamId)
+		if event.Type == model.AssignEvent && event.TeamId == 0 {
+			action = "Cleared"
+			team = ""
+		}
+		spot := "Captain"
+		if event.Spot > 0 {
+			spot = fmt.Sprintf("Pick %d", event.Spot)
+		}
+		pdf.CellFormat(colWidths["Step"], rowHeight, strconv.Itoa(i+1), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths["Time"], rowHeight, event.Time.Local().Format("3:04:05 PM"), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths["Action"], rowHeight, action, "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths["Alliance"], rowHeight, strconv.Itoa(event.AllianceId), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths["Spot"], rowHeight, spot, "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths["Team"], rowHeight, team, "1", 1, "C", false, 0, "")
+	}
+
+	addTimeGeneratedFooter(pdf)
+
+	// Write out the PDF file as the HTTP response.
+	w.Header().Set("Content-Type", "application/pdf")
+	err = pdf.Output(w)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
 // Generates a PDF-formatted report of the playoff alliances and the teams contained within.
 func (web *Web) alliancesPdfReportHandler(w http.ResponseWriter, r *http.Request) {
 	alliances, err := web.arena.Database.GetAllAlliances()
@@ -704,10 +949,21 @@ func (web *Web) alliancesPdfReportHandler(w http.ResponseWriter, r *http.Request
 	pdf.SetFont("Arial", "", 10)
 	startX := pdf.GetX()
 	for _, alliance := range alliances {
+		// Note which team was called in as a backup and which one it replaced.
+		nicknames := make(map[int]string, len(alliance.TeamIds))
+		for _, teamId := range alliance.TeamIds {
+			nicknames[teamId] = teamsMap[teamId].Nickname
+			if teamId == alliance.BackupTeamId {
+				nicknames[teamId] += fmt.Sprintf(" (backup for %d)", alliance.ReplacedTeamId)
+			} else if teamId == alliance.ReplacedTeamId {
+				nicknames[teamId] += fmt.Sprintf(" (replaced by %d)", alliance.BackupTeamId)
+			}
+		}
+
 		var allianceHeight float64
 		for _, teamId := range alliance.TeamIds {
 			team := teamsMap[teamId]
-			numNicknameRows := len(pdf.SplitLines([]byte(team.Nickname), colWidths["Name"]))
+			numNicknameRows := len(pdf.SplitLines([]byte(nicknames[teamId]), colWidths["Name"]))
 			location := fmt.Sprintf("%s, %s, %s", team.City, team.StateProv, team.Country)
 			numLocationRows := len(pdf.SplitLines([]byte(location), colWidths["Location"]))
 			teamRowHeight := rowHeight
@@ -728,7 +984,7 @@ func (web *Web) alliancesPdfReportHandler(w http.ResponseWriter, r *http.Request
 		)
 		for _, teamId := range alliance.TeamIds {
 			team := teamsMap[teamId]
-			numNicknameRows := len(pdf.SplitLines([]byte(team.Nickname), colWidths["Name"]))
+			numNicknameRows := len(pdf.SplitLines([]byte(nicknames[teamId]), colWidths["Name"]))
 			location := fmt.Sprintf("%s, %s, %s", team.City, team.StateProv, team.Country)
 			numLocationRows := len(pdf.SplitLines([]byte(location), colWidths["Location"]))
 			teamRowHeight := rowHeight
@@ -738,7 +994,9 @@ func (web *Web) alliancesPdfReportHandler(w http.ResponseWriter, r *http.Request
 			}
 
 			pdf.CellFormat(colWidths["Id"], teamRowHeight, strconv.Itoa(team.Id), "1", 0, "L", false, 0, "")
-			drawMultiLineCell(pdf, colWidths["Name"], teamRowHeight, lineHeight, team.Nickname, "L", numNicknameRows)
+			drawMultiLineCell(
+				pdf, colWidths["Name"], teamRowHeight, lineHeight, nicknames[teamId], "L", numNicknameRows,
+			)
 			drawMultiLineCell(pdf, colWidths["Location"], teamRowHeight, lineHeight, location, "L", numLocationRows)
 			pdf.SetXY(startX+colWidths["Alliance"], pdf.GetY()+teamRowHeight)
 		}
@@ -897,6 +1155,14 @@ func (web *Web) ftaCsvReportHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
+	type ftaReportRow struct {
+		model.Team
+		NetworkDiagnostics *network.TeamNetworkDiagnostics
+	}
+	rows := make([]ftaReportRow, len(teams))
+	for i, team := range teams {
+		rows[i] = ftaReportRow{team, web.arena.GetTeamNetworkDiagnostics(team.Id)}
+	}
 
 	// Don't set the content type as "text/csv", as that will trigger an automatic download in the browser.
 	w.Header().Set("Content-Type", "text/plain")
@@ -905,7 +1171,61 @@ func (web *Web) ftaCsvReportHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
-	err = template.ExecuteTemplate(w, "fta.csv", teams)
+	err = template.ExecuteTemplate(w, "fta.csv", rows)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Generates a PDF-formatted script for the emcee to read during the award ceremony, in the order of presentation.
+func (web *Web) awardScriptPdfReportHandler(w http.ResponseWriter, r *http.Request) {
+	ceremony, err := tournament.GetAwardCeremony(web.arena.Database)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	pdf := gofpdf.New("P", "mm", "Letter", "font")
+	pdf.AddPage()
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(195, 8, "Award Ceremony Script - "+web.arena.EventSettings.Name, "", 1, "C", false, 0, "")
+	for i, item := range ceremony {
+		pdf.Ln(4)
+		pdf.SetFont("Arial", "B", 12)
+		pdf.SetFillColor(220, 220, 220)
+		pdf.CellFormat(195, 8, fmt.Sprintf("%d. %s", i+1, item.AwardName), "1", 1, "L", true, 0, "")
+		if item.Script != "" {
+			pdf.SetFont("Arial", "", 11)
+			pdf.MultiCell(195, 6, item.Script, "", "L", false)
+		}
+		pdf.SetFont("Arial", "B", 11)
+		for _, award := range item.Awards {
+			var recipients []string
+			if award.PersonName != "" {
+				recipients = append(recipients, award.PersonName)
+			}
+			if award.TeamId > 0 {
+				team, err := web.arena.Database.GetTeamById(award.TeamId)
+				if err != nil {
+					handleWebErr(w, err)
+					return
+				}
+				teamName := fmt.Sprintf("Team %d", award.TeamId)
+				if team != nil && team.Nickname != "" {
+					teamName += ", " + team.Nickname
+				}
+				recipients = append(recipients, teamName)
+			}
+			pdf.CellFormat(195, 7, "Recipient: "+strings.Join(recipients, " of "), "", 1, "L", false, 0, "")
+		}
+	}
+
+	addTimeGeneratedFooter(pdf)
+
+	// Write out the PDF file as the HTTP response.
+	w.Header().Set("Content-Type", "application/pdf")
+	err = pdf.Output(w)
 	if err != nil {
 		handleWebErr(w, err)
 		return
@@ -102,6 +102,70 @@ func TestTeamsCsvReport(t *testing.T) {
 	assert.Equal(t, expectedBody, recorder.Body.String())
 }
 
+func TestInspectionsCsvReport(t *testing.T) {
+	web := setupTestWeb(t)
+
+	web.arena.Database.CreateTeamInspection(&model.TeamInspection{
+		TeamId:      254,
+		Inspector:   "Alice",
+		Passed:      false,
+		WeightLbs:   127.5,
+		Notes:       "Too heavy",
+		InspectedAt: time.Date(2025, 4, 1, 9, 30, 0, 0, time.Local),
+	})
+	web.arena.Database.CreateTeamInspection(&model.TeamInspection{
+		TeamId:      254,
+		Inspector:   "Bob",
+		Passed:      true,
+		WeightLbs:   124,
+		BumpersOk:   true,
+		InspectedAt: time.Date(2025, 4, 1, 10, 15, 0, 0, time.Local),
+	})
+
+	recorder := web.getHttpResponse("/reports/csv/inspections")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
+	expectedBody := "Number,InspectedAt,Inspector,Passed,WeightLbs,BumpersOk,Notes\n" +
+		"254,2025-04-01 09:30:00,\"Alice\",false,127.5,false,\"Too heavy\"\n" +
+		"254,2025-04-01 10:15:00,\"Bob\",true,124,true,\"\"\n\n"
+	assert.Equal(t, expectedBody, recorder.Body.String())
+
+	// Check that the report isn't available to anyone who isn't logged in.
+	web.arena.EventSettings.AdminPassword = "admin"
+	recorder = web.getHttpResponse("/reports/csv/inspections")
+	assert.Equal(t, 307, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "Too heavy")
+}
+
+func TestInspectionsPdfReport(t *testing.T) {
+	web := setupTestWeb(t)
+
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+	web.arena.Database.CreateTeamInspection(&model.TeamInspection{TeamId: 254, Passed: true, InspectedAt: time.Now()})
+	recorder := web.getHttpResponse("/reports/pdf/inspections")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/pdf", recorder.Header()["Content-Type"][0])
+
+	web.arena.EventSettings.AdminPassword = "admin"
+	recorder = web.getHttpResponse("/reports/pdf/inspections")
+	assert.Equal(t, 307, recorder.Code)
+}
+
+func TestFtaCsvReport(t *testing.T) {
+	web := setupTestWeb(t)
+
+	web.arena.Database.CreateTeam(&model.Team{Id: 254, HasConnected: true, FtaNotes: "Loose radio"})
+	web.arena.Database.CreateTeam(&model.Team{Id: 1114})
+
+	recorder := web.getHttpResponse("/reports/csv/fta")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
+	expectedBody := "Number,HasConnected,FtaNotes,DhcpLeases,RadioArpPresent,RioArpPresent,RioPingLatencyMs," +
+		"SwitchPortLinkUp,SwitchPortInputErrors,SwitchPortCrcErrors,SwitchPortOutputErrors\n" +
+		"254,true,Loose radio,,,,,,,,\n1114,false,,,,,,,,,\n,,\n"
+	assert.Equal(t, expectedBody, recorder.Body.String())
+}
+
 func TestTeamsPdfReport(t *testing.T) {
 	web := setupTestWeb(t)
 
@@ -130,10 +194,26 @@ func TestWpaKeysCsvReport(t *testing.T) {
 	assert.Equal(t, "254,12345678\r\n1114,9876543210\r\n", recorder.Body.String())
 }
 
+func TestAllianceSelectionPdfReport(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateAllianceSelectionEvent(
+		&model.AllianceSelectionEvent{Type: model.AssignEvent, AllianceId: 1, Spot: 0, TeamId: 254},
+	)
+	web.arena.Database.CreateAllianceSelectionEvent(
+		&model.AllianceSelectionEvent{Type: model.InviteEvent, AllianceId: 1, Spot: 1, TeamId: 1114},
+	)
+
+	// Can't really parse the PDF content and check it, so just check that what's sent back is a PDF.
+	recorder := web.getHttpResponse("/reports/pdf/alliance_selection")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/pdf", recorder.Header()["Content-Type"][0])
+}
+
 func TestAlliancesPdfReport(t *testing.T) {
 	web := setupTestWeb(t)
 	tournament.CreateTestAlliances(web.arena.Database, 8)
 	web.arena.CreatePlayoffTournament()
+	assert.Nil(t, web.arena.Database.CallBackupTeam(2, 9001, 201))
 
 	// Can't really parse the PDF content and check it, so just check that what's sent back is a PDF.
 	recorder := web.getHttpResponse("/reports/pdf/alliances")
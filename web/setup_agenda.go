@@ -0,0 +1,120 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Web routes for planning the event's run-of-show and tracking its progress.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const agendaTimeFormat = "2006-01-02 03:04:05 PM"
+
+// Shows the agenda configuration page.
+func (web *Web) agendaGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	web.renderAgenda(w, r, "")
+}
+
+// Saves, deletes, starts or ends the given agenda segment.
+func (web *Web) agendaPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	segmentId, _ := strconv.Atoi(r.PostFormValue("id"))
+	var err error
+	switch r.PostFormValue("action") {
+	case "delete":
+		err = web.arena.Database.DeleteAgendaSegment(segmentId)
+	case "start":
+		err = web.arena.StartAgendaSegment(segmentId)
+	case "end":
+		err = web.arena.EndAgendaSegment(segmentId)
+	default:
+		err = web.saveAgendaSegment(r, segmentId)
+	}
+	if err != nil {
+		web.renderAgenda(w, r, err.Error())
+		return
+	}
+
+	// Refresh the displays that show what's next on the agenda.
+	web.arena.UpdateAgendaStatus()
+
+	http.Redirect(w, r, "/setup/agenda", 303)
+}
+
+func (web *Web) renderAgenda(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	segments, err := web.arena.Database.GetAllAgendaSegments()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	template, err := web.parseFiles("templates/setup_agenda.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Segments     []model.AgendaSegment
+		SegmentTypes []model.AgendaSegmentType
+		TimeFormat   string
+		ErrorMessage string
+	}{web.arena.EventSettings, segments, model.AgendaSegmentTypes, agendaTimeFormat, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Creates or updates the plan for the given agenda segment from the posted form, leaving its progress untouched.
+func (web *Web) saveAgendaSegment(r *http.Request, segmentId int) error {
+	segment := &model.AgendaSegment{}
+	if segmentId > 0 {
+		var err error
+		if segment, err = web.arena.Database.GetAgendaSegmentById(segmentId); err != nil {
+			return err
+		}
+		if segment == nil {
+			return fmt.Errorf("agenda segment %d doesn't exist", segmentId)
+		}
+	}
+
+	segmentType, _ := strconv.Atoi(r.PostFormValue("type"))
+	segment.Type = model.AgendaSegmentType(segmentType)
+	segment.Title = r.PostFormValue("title")
+	if segment.Title == "" {
+		segment.Title = segment.Type.String()
+	}
+	location, _ := time.LoadLocation("Local")
+	var err error
+	segment.PlannedStartTime, err = time.ParseInLocation(
+		agendaTimeFormat, r.PostFormValue("plannedStartTime"), location,
+	)
+	if err != nil {
+		return fmt.Errorf("Must specify a valid planned start time for %s.", segment.Title)
+	}
+	segment.PlannedEndTime, err = time.ParseInLocation(agendaTimeFormat, r.PostFormValue("plannedEndTime"), location)
+	if err != nil {
+		return fmt.Errorf("Must specify a valid planned end time for %s.", segment.Title)
+	}
+	if !segment.PlannedEndTime.After(segment.PlannedStartTime) {
+		return fmt.Errorf("The planned end time for %s must be after its start time.", segment.Title)
+	}
+
+	if segment.Id == 0 {
+		return web.arena.Database.CreateAgendaSegment(segment)
+	}
+	return web.arena.Database.UpdateAgendaSegment(segment)
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSetupAgenda(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/agenda")
+	assert.Equal(t, 200, recorder.Code)
+
+	// Add some segments, including some with invalid times.
+	startTime := time.Now().Add(time.Hour)
+	form := url.Values{
+		"type":             {"6"},
+		"title":            {"Lunch"},
+		"plannedStartTime": {"12:00"},
+		"plannedEndTime":   {startTime.Add(time.Hour).Format(agendaTimeFormat)},
+	}
+	recorder = web.postHttpResponse("/setup/agenda", form.Encode())
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Must specify a valid planned start time for Lunch.")
+	form.Set("plannedStartTime", startTime.Add(2*time.Hour).Format(agendaTimeFormat))
+	recorder = web.postHttpResponse("/setup/agenda", form.Encode())
+	assert.Contains(t, recorder.Body.String(), "The planned end time for Lunch must be after its start time.")
+	form.Set("plannedStartTime", startTime.Format(agendaTimeFormat))
+	recorder = web.postHttpResponse("/setup/agenda", form.Encode())
+	assert.Equal(t, 303, recorder.Code)
+	form = url.Values{
+		"type":             {"1"},
+		"plannedStartTime": {startTime.Add(-2 * time.Hour).Format(agendaTimeFormat)},
+		"plannedEndTime":   {startTime.Add(-90 * time.Minute).Format(agendaTimeFormat)},
+	}
+	recorder = web.postHttpResponse("/setup/agenda", form.Encode())
+	assert.Equal(t, 303, recorder.Code)
+	segments, _ := web.arena.Database.GetAllAgendaSegments()
+	if assert.Equal(t, 2, len(segments)) {
+		assert.Equal(t, model.CeremonySegment, segments[0].Type)
+		assert.Equal(t, "Ceremony", segments[0].Title)
+		assert.Equal(t, model.MealSegment, segments[1].Type)
+		assert.Equal(t, "Lunch", segments[1].Title)
+	}
+	// The ceremony's planned end has already passed without it being started, so it is treated as skipped.
+	assert.Contains(t, web.arena.EventStatus.AgendaMessage, "Up next: Lunch at ")
+	recorder = web.getHttpResponse("/setup/agenda")
+	assert.Contains(t, recorder.Body.String(), "Lunch")
+
+	// Start and end the segments.
+	recorder = web.postHttpResponse("/setup/agenda", "action=start&id=2")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "Event is running 60 minutes late", web.arena.EventStatus.EarlyLateMessage)
+	assert.Contains(t, web.arena.EventStatus.AgendaMessage, "Up next: Lunch at ")
+	recorder = web.postHttpResponse("/setup/agenda", "action=end&id=1")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "agenda segment Lunch isn't in progress")
+	recorder = web.postHttpResponse("/setup/agenda", "action=end&id=2")
+	assert.Equal(t, 303, recorder.Code)
+	segment, _ := web.arena.Database.GetAgendaSegmentById(2)
+	assert.True(t, segment.IsComplete())
+
+	// Check that the next segment leads the audience display slideshow.
+	recorder = web.getHttpResponse("/api/sponsor_slides")
+	assert.Contains(t, recorder.Body.String(), "Up next: Lunch at ")
+
+	recorder = web.getHttpResponse("/reports/pdf/agenda")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/pdf", recorder.Header()["Content-Type"][0])
+
+	recorder = web.postHttpResponse("/setup/agenda", "action=delete&id=1")
+	assert.Equal(t, 303, recorder.Code)
+	segments, _ = web.arena.Database.GetAllAgendaSegments()
+	assert.Equal(t, 1, len(segments))
+	assert.Equal(t, "", web.arena.EventStatus.AgendaMessage)
+}
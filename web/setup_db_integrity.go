@@ -0,0 +1,70 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Web routes for checking the database for inconsistencies and repairing them.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+)
+
+// Shows the results of checking the database for corruption and inconsistencies between tables.
+func (web *Web) dbIntegrityGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	web.renderDbIntegrity(w, r, "")
+}
+
+// Backs up the database and repairs the inconsistencies that can be fixed automatically.
+func (web *Web) dbIntegrityRepairHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	numRepaired, err := web.arena.Database.RepairIntegrity(web.arena.EventSettings.Name)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	web.renderDbIntegrity(w, r, fmt.Sprintf("Repaired %d problem(s) after backing up the database.", numRepaired))
+}
+
+func (web *Web) renderDbIntegrity(w http.ResponseWriter, r *http.Request, message string) {
+	problems, err := web.arena.Database.CheckIntegrity()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var consistencyErrors []string
+	for _, err := range web.arena.Database.CheckConsistency() {
+		consistencyErrors = append(consistencyErrors, err.Error())
+	}
+	numRepairable := 0
+	for _, problem := range problems {
+		if problem.Repairable() {
+			numRepairable++
+		}
+	}
+
+	template, err := web.parseFiles("templates/setup_db_integrity.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Message           string
+		ConsistencyErrors []string
+		Problems          []model.IntegrityProblem
+		NumRepairable     int
+	}{web.arena.EventSettings, message, consistencyErrors, problems, numRepairable}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
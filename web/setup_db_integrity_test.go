@@ -0,0 +1,35 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetupDbIntegrity(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/db/integrity")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No problems were found.")
+
+	web.arena.Database.CreateMatchResult(model.BuildTestMatchResult(1000, 1))
+	web.arena.Database.CreateRanking(&game.Ranking{TeamId: 254})
+	web.arena.Database.CreateAward(&model.Award{AwardName: "Winner", TeamId: 1114})
+	recorder = web.getHttpResponse("/setup/db/integrity")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Found 3 problem(s), of which 2 can be repaired automatically.")
+	assert.Contains(t, recorder.Body.String(), "Result is for match 1000, which doesn't exist.")
+	assert.Contains(t, recorder.Body.String(), "Repair 2 Problem(s)")
+
+	recorder = web.postHttpResponse("/setup/db/integrity/repair", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Repaired 2 problem(s) after backing up the database.")
+	assert.Contains(t, recorder.Body.String(), "Found 1 problem(s), of which 0 can be repaired automatically.")
+	assert.NotContains(t, recorder.Body.String(), "Repair 0 Problem(s)")
+	rankings, _ := web.arena.Database.GetAllRankings()
+	assert.Empty(t, rankings)
+}
@@ -9,6 +9,7 @@ import (
 	gorillawebsocket "github.com/gorilla/websocket"
 	"github.com/mitchellh/mapstructure"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -92,6 +93,34 @@ func TestSetupDisplaysWebsocketReloadDisplays(t *testing.T) {
 	assert.Equal(t, nil, readWebsocketType(t, displayWs, "reload"))
 }
 
+func TestSetupDisplaysDroppedMessages(t *testing.T) {
+	web := setupTestWeb(t)
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/setup/displays/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+	readDisplayConfiguration(t, ws)
+
+	// Connect a display that never reads its messages, and flood it until some have to be dropped.
+	displayConn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/displays/logo/websocket?displayId=1", nil)
+	assert.Nil(t, err)
+	defer displayConn.Close()
+	message := readDisplayConfiguration(t, ws)
+	assert.Equal(t, int64(0), message["1"].DroppedMessageCount)
+	for i := 0; i < 1000 && web.arena.Displays["1"].DropCounter().Count() == 0; i++ {
+		web.arena.ReloadDisplaysNotifier.NotifyWithMessage(strings.Repeat("x", 10000))
+	}
+	droppedCount := web.arena.Displays["1"].DropCounter().Count()
+	assert.Greater(t, droppedCount, int64(0))
+
+	web.arena.DisplayConfigurationNotifier.Notify()
+	message = readDisplayConfiguration(t, ws)
+	assert.Greater(t, message["1"].DroppedMessageCount, int64(0))
+}
+
 func readDisplayConfiguration(t *testing.T, ws *websocket.Websocket) map[string]field.Display {
 	message := readWebsocketType(t, ws, "displayConfiguration")
 	var displayConfigurationMessage map[string]field.Display
@@ -0,0 +1,116 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Web routes for configuring the fields of a multi-field event.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+)
+
+const maxFields = 4
+
+// Shows the field configuration page.
+func (web *Web) fieldsGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	template, err := web.parseFiles("templates/setup_fields.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var fieldSettings []*model.FieldSettings
+	for fieldNumber := 2; fieldNumber <= web.arena.EventSettings.NumFields; fieldNumber++ {
+		settings, err := web.arena.Database.GetFieldSettings(fieldNumber)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		fieldSettings = append(fieldSettings, settings)
+	}
+	data := struct {
+		*model.EventSettings
+		MaxFields        int
+		NumRunningFields int
+		FieldSettings    []*model.FieldSettings
+	}{web.arena.EventSettings, maxFields, len(web.arena.Fields()), fieldSettings}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Saves the number of fields at the event, which takes effect the next time the server is started.
+func (web *Web) fieldsPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	numFields, _ := strconv.Atoi(r.PostFormValue("numFields"))
+	if numFields < 1 || numFields > maxFields {
+		handleWebErr(w, fmt.Errorf("number of fields must be between 1 and %d", maxFields))
+		return
+	}
+	eventSettings := web.arena.EventSettings
+	eventSettings.NumFields = numFields
+	if err := web.arena.Database.UpdateEventSettings(eventSettings); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if err := web.loadAllFieldSettings(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	http.Redirect(w, r, "/setup/fields", 303)
+}
+
+// Saves the network and PLC settings of one of the additional fields.
+func (web *Web) fieldSettingsPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	fieldNumber, err := strconv.Atoi(r.PathValue("fieldNumber"))
+	if err != nil || fieldNumber < 2 || fieldNumber > maxFields {
+		handleWebErr(w, fmt.Errorf("invalid field number %q", r.PathValue("fieldNumber")))
+		return
+	}
+	fieldSettings, err := web.arena.Database.GetFieldSettings(fieldNumber)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	fieldSettings.ApAddress = r.PostFormValue("apAddress")
+	fieldSettings.ApPassword = r.PostFormValue("apPassword")
+	fieldSettings.ApChannel, _ = strconv.Atoi(r.PostFormValue("apChannel"))
+	fieldSettings.SwitchAddress = r.PostFormValue("switchAddress")
+	fieldSettings.SwitchPassword = r.PostFormValue("switchPassword")
+	fieldSettings.PlcAddress = r.PostFormValue("plcAddress")
+	if err = web.arena.Database.UpdateFieldSettings(fieldSettings); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if err = web.loadAllFieldSettings(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	http.Redirect(w, r, "/setup/fields", 303)
+}
+
+// Reloads the settings of every running field after a change to the event or field settings.
+func (web *Web) loadAllFieldSettings() error {
+	for _, fieldArena := range web.arena.Fields() {
+		if err := fieldArena.LoadSettings(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
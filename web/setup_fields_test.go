@@ -0,0 +1,126 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Sets up a second field sharing the database of the given web's arena, and returns the web for it.
+func setupTestSecondField(t *testing.T, web *Web) *Web {
+	web.arena.EventSettings.NumFields = 2
+	assert.Nil(t, web.arena.Database.UpdateEventSettings(web.arena.EventSettings))
+	arena2, err := field.NewFieldArena(web.arena.Database, 2)
+	assert.Nil(t, err)
+	field.LinkFields(web.arena, arena2)
+	web2 := NewWeb(arena2)
+	LinkFieldWebs(web, web2)
+	return web2
+}
+
+func TestSetupFields(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/fields")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Number of fields")
+	assert.NotContains(t, recorder.Body.String(), "Save Field 2")
+
+	// Adding a field should take effect only after a restart.
+	recorder = web.postHttpResponse("/setup/fields", "numFields=2")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, 2, web.arena.EventSettings.NumFields)
+	recorder = web.getHttpResponse("/setup/fields")
+	assert.Contains(t, recorder.Body.String(), "Save Field 2")
+	assert.Contains(t, recorder.Body.String(), "Restart Cheesy Arena")
+
+	recorder = web.postHttpResponse("/setup/fields", "numFields=5")
+	assert.Contains(t, recorder.Body.String(), "number of fields must be between 1 and 4")
+	assert.Equal(t, 2, web.arena.EventSettings.NumFields)
+}
+
+func TestSetupFieldSettings(t *testing.T) {
+	web := setupTestWeb(t)
+	web2 := setupTestSecondField(t, web)
+
+	recorder := web.postHttpResponse(
+		"/setup/fields/2",
+		"apAddress=10.0.200.10&apPassword=pass&apChannel=149&switchAddress=10.0.200.1&switchPassword=pass2&"+
+			"plcAddress=10.0.200.40",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	fieldSettings, _ := web.arena.Database.GetFieldSettings(2)
+	assert.Equal(
+		t,
+		model.FieldSettings{
+			Id:             fieldSettings.Id,
+			FieldNumber:    2,
+			ApAddress:      "10.0.200.10",
+			ApPassword:     "pass",
+			ApChannel:      149,
+			SwitchAddress:  "10.0.200.1",
+			SwitchPassword: "pass2",
+			PlcAddress:     "10.0.200.40",
+		},
+		*fieldSettings,
+	)
+
+	// The running arena for the field should pick up the new settings, leaving the first field untouched.
+	assert.True(t, web2.arena.Plc.IsEnabled())
+	assert.False(t, web.arena.Plc.IsEnabled())
+	recorder = web.getHttpResponse("/setup/fields")
+	assert.Contains(t, recorder.Body.String(), "10.0.200.40")
+	assert.NotContains(t, recorder.Body.String(), "Restart Cheesy Arena")
+
+	recorder = web.postHttpResponse("/setup/fields/1", "plcAddress=10.0.200.40")
+	assert.Contains(t, recorder.Body.String(), "invalid field number")
+}
+
+func TestFieldSelection(t *testing.T) {
+	web := setupTestWeb(t)
+	setupTestSecondField(t, web)
+	handler := web.newFieldsHandler()
+	getResponse := func(path string, cookie *http.Cookie) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request, _ := http.NewRequest("GET", path, nil)
+		if cookie != nil {
+			request.AddCookie(cookie)
+		}
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// Requests without a selected field should go to the first one.
+	recorder := getResponse("/match_play", nil)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Match Play (Field 1)")
+
+	// Selecting a field should serve its pages and remember it for the requests that follow.
+	recorder = getResponse("/match_play?field=2", nil)
+	assert.Contains(t, recorder.Body.String(), "Match Play (Field 2)")
+	cookies := recorder.Result().Cookies()
+	if assert.Equal(t, 1, len(cookies)) {
+		assert.Equal(t, "field", cookies[0].Name)
+		assert.Equal(t, "2", cookies[0].Value)
+		recorder = getResponse("/match_play", cookies[0])
+		assert.Contains(t, recorder.Body.String(), "Match Play (Field 2)")
+		recorder = getResponse("/match_play?field=1", cookies[0])
+		assert.Contains(t, recorder.Body.String(), "Match Play (Field 1)")
+	}
+
+	// Invalid selections should fall back to the first field.
+	recorder = getResponse("/match_play?field=3", nil)
+	assert.Contains(t, recorder.Body.String(), "Match Play (Field 1)")
+	recorder = getResponse("/match_play", &http.Cookie{Name: "field", Value: "asdf"})
+	assert.Contains(t, recorder.Body.String(), "Match Play (Field 1)")
+
+	// Displays should keep the field they were opened for when their configuration is filled in.
+	recorder = getResponse("/displays/audience?field=2", nil)
+	assert.Equal(t, 302, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("Location"), "field=2")
+}
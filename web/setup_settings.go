@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/network"
 )
 
 // Shows the event settings editing page.
@@ -74,6 +75,7 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	eventSettings.SelectionRound2Order = r.PostFormValue("selectionRound2Order")
 	eventSettings.SelectionRound3Order = r.PostFormValue("selectionRound3Order")
 	eventSettings.SelectionShowUnpickedTeams = r.PostFormValue("selectionShowUnpickedTeams") == "on"
+	eventSettings.PlayoffTimeoutsPerAlliance, _ = strconv.Atoi(r.PostFormValue("playoffTimeoutsPerAlliance"))
 	eventSettings.TbaDownloadEnabled = r.PostFormValue("tbaDownloadEnabled") == "on"
 	eventSettings.TbaPublishingEnabled = r.PostFormValue("tbaPublishingEnabled") == "on"
 	eventSettings.TbaEventCode = r.PostFormValue("tbaEventCode")
@@ -84,10 +86,22 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	eventSettings.ApAddress = r.PostFormValue("apAddress")
 	eventSettings.ApPassword = r.PostFormValue("apPassword")
 	eventSettings.ApChannel, _ = strconv.Atoi(r.PostFormValue("apChannel"))
+	eventSettings.ApAllowedChannels = r.PostFormValue("apAllowedChannels")
+	if _, err := network.ParseAllowedChannels(eventSettings.ApAllowedChannels); err != nil {
+		web.renderSettings(w, r, fmt.Sprintf("Invalid allowed AP channels: %v", err))
+		return
+	}
 	eventSettings.SwitchAddress = r.PostFormValue("switchAddress")
 	eventSettings.SwitchPassword = r.PostFormValue("switchPassword")
 	eventSettings.PlcAddress = r.PostFormValue("plcAddress")
+	startMatchPolicy, _ := strconv.Atoi(r.PostFormValue("startMatchPolicy"))
+	eventSettings.StartMatchPolicy = model.StartMatchPolicy(startMatchPolicy)
+	eventSettings.MinRobotBatteryVoltage, _ = strconv.ParseFloat(r.PostFormValue("minRobotBatteryVoltage"), 64)
 	eventSettings.AdminPassword = r.PostFormValue("adminPassword")
+	eventSettings.RelayEnabled = r.PostFormValue("relayEnabled") == "on"
+	eventSettings.RelayUrl = r.PostFormValue("relayUrl")
+	eventSettings.RelayKey = r.PostFormValue("relayKey")
+	eventSettings.ReplicationKey = r.PostFormValue("replicationKey")
 	eventSettings.TeamSignRed1Id, _ = strconv.Atoi(r.PostFormValue("teamSignRed1Id"))
 	eventSettings.TeamSignRed2Id, _ = strconv.Atoi(r.PostFormValue("teamSignRed2Id"))
 	eventSettings.TeamSignRed3Id, _ = strconv.Atoi(r.PostFormValue("teamSignRed3Id"))
@@ -96,6 +110,26 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	eventSettings.TeamSignBlue2Id, _ = strconv.Atoi(r.PostFormValue("teamSignBlue2Id"))
 	eventSettings.TeamSignBlue3Id, _ = strconv.Atoi(r.PostFormValue("teamSignBlue3Id"))
 	eventSettings.TeamSignBlueTimerId, _ = strconv.Atoi(r.PostFormValue("teamSignBlueTimerId"))
+	eventSettings.TeamSignRed1OutputType = parseTeamSignOutputType(r.PostFormValue("teamSignRed1OutputType"))
+	eventSettings.TeamSignRed2OutputType = parseTeamSignOutputType(r.PostFormValue("teamSignRed2OutputType"))
+	eventSettings.TeamSignRed3OutputType = parseTeamSignOutputType(r.PostFormValue("teamSignRed3OutputType"))
+	eventSettings.TeamSignRedTimerOutputType = parseTeamSignOutputType(r.PostFormValue("teamSignRedTimerOutputType"))
+	eventSettings.TeamSignBlue1OutputType = parseTeamSignOutputType(r.PostFormValue("teamSignBlue1OutputType"))
+	eventSettings.TeamSignBlue2OutputType = parseTeamSignOutputType(r.PostFormValue("teamSignBlue2OutputType"))
+	eventSettings.TeamSignBlue3OutputType = parseTeamSignOutputType(r.PostFormValue("teamSignBlue3OutputType"))
+	eventSettings.TeamSignBlueTimerOutputType = parseTeamSignOutputType(r.PostFormValue("teamSignBlueTimerOutputType"))
+	eventSettings.TeamSignRed1Address = r.PostFormValue("teamSignRed1Address")
+	eventSettings.TeamSignRed2Address = r.PostFormValue("teamSignRed2Address")
+	eventSettings.TeamSignRed3Address = r.PostFormValue("teamSignRed3Address")
+	eventSettings.TeamSignRedTimerAddress = r.PostFormValue("teamSignRedTimerAddress")
+	eventSettings.TeamSignBlue1Address = r.PostFormValue("teamSignBlue1Address")
+	eventSettings.TeamSignBlue2Address = r.PostFormValue("teamSignBlue2Address")
+	eventSettings.TeamSignBlue3Address = r.PostFormValue("teamSignBlue3Address")
+	eventSettings.TeamSignBlueTimerAddress = r.PostFormValue("teamSignBlueTimerAddress")
+	eventSettings.TeamSignMatrixWidth, _ = strconv.Atoi(r.PostFormValue("teamSignMatrixWidth"))
+	eventSettings.TeamSignMatrixHeight, _ = strconv.Atoi(r.PostFormValue("teamSignMatrixHeight"))
+	eventSettings.TeamSignMatrixSerpentine = r.PostFormValue("teamSignMatrixSerpentine") == "on"
+	eventSettings.TeamSignSimulatorEnabled = r.PostFormValue("teamSignSimulatorEnabled") == "on"
 	eventSettings.BlackmagicAddresses = r.PostFormValue("blackmagicAddresses")
 	eventSettings.WarmupDurationSec, _ = strconv.Atoi(r.PostFormValue("warmupDurationSec"))
 	eventSettings.AutoDurationSec, _ = strconv.Atoi(r.PostFormValue("autoDurationSec"))
@@ -113,8 +147,8 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Refresh the arena in case any of the settings changed.
-	err = web.arena.LoadSettings()
+	// Refresh the arenas in case any of the settings changed.
+	err = web.loadAllFieldSettings()
 	if err != nil {
 		handleWebErr(w, err)
 		return
@@ -168,6 +202,7 @@ func (web *Web) restoreDbHandler(w http.ResponseWriter, r *http.Request) {
 	defer tempFile.Close()
 	tempFilePath := tempFile.Name()
 	defer os.Remove(tempFilePath)
+	defer os.Remove(model.PacketLogPath(tempFilePath))
 	_, err = io.Copy(tempFile, file)
 	if err != nil {
 		handleWebErr(w, err)
@@ -190,29 +225,39 @@ func (web *Web) restoreDbHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Replace the current database with the new one.
-	web.arena.Database.Close()
-	err = os.Remove(web.arena.Database.Path)
-	if err != nil {
+	if err = web.replaceDatabase(tempFilePath); err != nil {
 		handleWebErr(w, err)
 		return
 	}
-	err = os.Rename(tempFilePath, web.arena.Database.Path)
+
+	http.Redirect(w, r, "/setup/settings", 303)
+}
+
+// Replaces the current database with the already validated one at the given path, which is moved into its place, and
+// reloads the settings of every field from it.
+func (web *Web) replaceDatabase(newDbPath string) error {
+	web.arena.LockState()
+	defer web.arena.UnlockState()
+	web.arena.Database.Close()
+	err := os.Remove(web.arena.Database.Path)
 	if err != nil {
-		handleWebErr(w, err)
-		return
+		return err
 	}
-	web.arena.Database, err = model.OpenDatabase(web.arena.Database.Path)
+	err = os.Rename(newDbPath, web.arena.Database.Path)
 	if err != nil {
-		handleWebErr(w, err)
-		return
+		return err
 	}
-	err = web.arena.LoadSettings()
+	database, err := model.OpenDatabase(web.arena.Database.Path)
 	if err != nil {
-		handleWebErr(w, err)
-		return
+		return err
 	}
-
-	http.Redirect(w, r, "/setup/settings", 303)
+	for _, fieldArena := range web.arena.Fields() {
+		fieldArena.Database = database
+	}
+	if err = web.loadAllFieldSettings(); err != nil {
+		return err
+	}
+	return web.loadAllianceSelection()
 }
 
 // Deletes all match data including and beyond the given tournament stage.
@@ -259,6 +304,18 @@ func (web *Web) clearDbHandler(w http.ResponseWriter, r *http.Request) {
 			handleWebErr(w, err)
 			return
 		}
+		if err = web.arena.Database.TruncateAllianceSelection(); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if err = web.arena.Database.TruncateAllianceTimeouts(); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if err = web.arena.Database.TruncateLineups(); err != nil {
+			handleWebErr(w, err)
+			return
+		}
 		web.arena.AllianceSelectionAlliances = []model.Alliance{}
 		web.arena.AllianceSelectionRankedTeams = []model.AllianceSelectionRankedTeam{}
 	}
@@ -371,6 +428,24 @@ func (web *Web) settingsPublishTeamsHandler(w http.ResponseWriter, r *http.Reque
 	http.Redirect(w, r, "/setup/settings", 303)
 }
 
+// Triggers an immediate access point channel scan to refresh the channel recommendation.
+func (web *Web) settingsScanChannelsHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	if !web.arena.EventSettings.NetworkSecurityEnabled {
+		web.renderSettings(w, r, "Advanced network security must be enabled to scan access point channels.")
+		return
+	}
+	if err := web.arena.ScanAccessPointChannels(); err != nil {
+		web.renderSettings(w, r, "Failed to scan access point channels: "+err.Error())
+		return
+	}
+
+	http.Redirect(w, r, "/setup/settings", 303)
+}
+
 func (web *Web) renderSettings(w http.ResponseWriter, r *http.Request, errorMessage string) {
 	template, err := web.parseFiles("templates/setup_settings.html", "templates/base.html")
 	if err != nil {
@@ -379,8 +454,19 @@ func (web *Web) renderSettings(w http.ResponseWriter, r *http.Request, errorMess
 	}
 	data := struct {
 		*model.EventSettings
-		ErrorMessage string
-	}{web.arena.EventSettings, errorMessage}
+		ErrorMessage            string
+		ChannelRecommendation   *network.ChannelRecommendation
+		ChannelScans            []network.ChannelScanSample
+		TeamSignOutputTypeNames map[model.TeamSignOutputType]string
+		RelayConnected          bool
+	}{
+		web.arena.EventSettings,
+		errorMessage,
+		web.arena.AccessPointChannelRecommendation(),
+		web.arena.AccessPointLatestChannelScans(),
+		model.TeamSignOutputTypeNames,
+		web.relayClient != nil && web.relayClient.IsConnected(),
+	}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -388,7 +474,16 @@ func (web *Web) renderSettings(w http.ResponseWriter, r *http.Request, errorMess
 	}
 }
 
-// Deletes all match data (matches, results, and scheduled breaks) for the given match type.
+// Returns the sign output type for the given form value, defaulting to a Cypress sign if it is missing or invalid.
+func parseTeamSignOutputType(value string) model.TeamSignOutputType {
+	outputType, _ := strconv.Atoi(value)
+	if _, ok := model.TeamSignOutputTypeNames[model.TeamSignOutputType(outputType)]; !ok {
+		return model.CypressTeamSignOutput
+	}
+	return model.TeamSignOutputType(outputType)
+}
+
+// Deletes all match data (matches, results, logs, start overrides, and scheduled breaks) for the given match type.
 func (web *Web) deleteMatchDataForType(matchType model.MatchType) error {
 	matches, err := web.arena.Database.GetMatchesByType(matchType, true)
 	if err != nil {
@@ -410,6 +505,26 @@ func (web *Web) deleteMatchDataForType(matchType model.MatchType) error {
 			}
 		}
 
+		matchLogs, err := web.arena.Database.GetTeamMatchLogsByMatch(match.Id)
+		if err != nil {
+			return err
+		}
+		for _, matchLog := range matchLogs {
+			if err = web.arena.Database.DeleteTeamMatchLog(matchLog.Id); err != nil {
+				return err
+			}
+		}
+
+		matchStartOverrides, err := web.arena.Database.GetMatchStartOverridesByMatch(match.Id)
+		if err != nil {
+			return err
+		}
+		for _, matchStartOverride := range matchStartOverrides {
+			if err = web.arena.Database.DeleteMatchStartOverride(matchStartOverride.Id); err != nil {
+				return err
+			}
+		}
+
 		if err = web.arena.Database.DeleteMatch(match.Id); err != nil {
 			return err
 		}
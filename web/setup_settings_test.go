@@ -5,14 +5,17 @@ package web
 
 import (
 	"bytes"
+	"fmt"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/relay"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -65,6 +68,75 @@ func TestSetupSettingsInvalidValues(t *testing.T) {
 	// Changing the playoff size after alliance selection is finalized.
 	recorder = web.postHttpResponse("/setup/settings", "numPlayoffAlliances=2")
 	assert.Contains(t, recorder.Body.String(), "Cannot change playoff type or size after alliance selection")
+
+	// Allowed AP channel that the access point doesn't support.
+	recorder = web.postHttpResponse(
+		"/setup/settings", "playoffType=SingleEliminationPlayoff&numPlayoffAlliances=8&apAllowedChannels=36,52",
+	)
+	assert.Contains(t, recorder.Body.String(), "Invalid allowed AP channels")
+}
+
+func TestSetupSettingsTeamSignOutputs(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse(
+		"/setup/settings",
+		"playoffType=SingleEliminationPlayoff&numPlayoffAlliances=8&teamSignRed1OutputType=1&"+
+			"teamSignRed1Address=127.0.0.1&teamSignBlueTimerOutputType=3&teamSignRed2OutputType=99&"+
+			"teamSignMatrixWidth=64&teamSignMatrixHeight=16&teamSignMatrixSerpentine=on",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, model.DdpTeamSignOutput, web.arena.EventSettings.TeamSignRed1OutputType)
+	assert.Equal(t, "127.0.0.1", web.arena.EventSettings.TeamSignRed1Address)
+	assert.Equal(t, model.KioskTeamSignOutput, web.arena.EventSettings.TeamSignBlueTimerOutputType)
+	assert.Equal(t, model.CypressTeamSignOutput, web.arena.EventSettings.TeamSignRed2OutputType)
+	assert.Equal(t, 64, web.arena.EventSettings.TeamSignMatrixWidth)
+	assert.Equal(t, 16, web.arena.EventSettings.TeamSignMatrixHeight)
+	assert.True(t, web.arena.EventSettings.TeamSignMatrixSerpentine)
+
+	recorder = web.getHttpResponse("/setup/settings")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "<option value=\"1\" selected>LED Matrix (WLED/DDP)</option>")
+	assert.Contains(t, recorder.Body.String(), "<option value=\"3\" selected>Browser Kiosk</option>")
+	assert.Contains(t, recorder.Body.String(), "name=\"teamSignRed1Address\" value=\"127.0.0.1\"")
+	assert.Contains(t, recorder.Body.String(), "teamSignMatrixSerpentine\" checked")
+}
+
+func TestSetupSettingsRelay(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse(
+		"/setup/settings",
+		"playoffType=SingleEliminationPlayoff&numPlayoffAlliances=8&relayEnabled=on&"+
+			"relayUrl=wss%3A%2F%2Frelay.example.com&relayKey=secret",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.True(t, web.arena.EventSettings.RelayEnabled)
+	assert.Equal(t, "wss://relay.example.com", web.arena.EventSettings.RelayUrl)
+	assert.Equal(t, "secret", web.arena.EventSettings.RelayKey)
+	assert.Equal(t, relay.ClientConfig{Enabled: true, Url: "wss://relay.example.com", Key: "secret"}, web.relayConfig())
+
+	recorder = web.getHttpResponse("/setup/settings")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "relayEnabled\" checked")
+	assert.Contains(t, recorder.Body.String(), "Not connected")
+
+	// A standby shouldn't connect to the relay in place of the primary until it is promoted.
+	assert.Nil(t, web.arena.EnterStandby())
+	assert.False(t, web.relayConfig().Enabled)
+}
+
+func TestSetupSettingsReplicationKey(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse(
+		"/setup/settings", "playoffType=SingleEliminationPlayoff&numPlayoffAlliances=8&replicationKey=standbysecret",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "standbysecret", web.arena.EventSettings.ReplicationKey)
+	recorder = web.getHttpResponse("/setup/standby")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No standby is connected.")
 }
 
 func TestSetupSettingsClearDb(t *testing.T) {
@@ -77,6 +149,8 @@ func TestSetupSettingsClearDb(t *testing.T) {
 		assert.Nil(t, web.arena.Database.CreateMatchResult(&model.MatchResult{MatchId: 1, PlayNumber: 2}))
 		assert.Nil(t, web.arena.Database.CreateMatchResult(&model.MatchResult{MatchId: 2, PlayNumber: 1}))
 		assert.Nil(t, web.arena.Database.CreateMatchResult(&model.MatchResult{MatchId: 3, PlayNumber: 1}))
+		assert.Nil(t, web.arena.Database.CreateTeamMatchLog(&model.TeamMatchLog{MatchId: 1, TeamId: 254}, nil))
+		assert.Nil(t, web.arena.Database.CreateTeamMatchLog(&model.TeamMatchLog{MatchId: 2, TeamId: 254}, nil))
 		assert.Nil(t, web.arena.Database.CreateRanking(&game.Ranking{TeamId: 254}))
 		assert.Nil(t, web.arena.Database.CreateAlliance(&model.Alliance{Id: 1}))
 		web.arena.AllianceSelectionAlliances = append(web.arena.AllianceSelectionAlliances, model.Alliance{Id: 1})
@@ -93,6 +167,10 @@ func TestSetupSettingsClearDb(t *testing.T) {
 	assert.Empty(t, matches)
 	matchResult, _ := web.arena.Database.GetMatchResultForMatch(1)
 	assert.Nil(t, matchResult)
+	matchLogs, _ := web.arena.Database.GetTeamMatchLogsByMatch(1)
+	assert.Empty(t, matchLogs)
+	matchLogs, _ = web.arena.Database.GetTeamMatchLogsByMatch(2)
+	assert.NotEmpty(t, matchLogs)
 	matches, _ = web.arena.Database.GetMatchesByType(model.Qualification, true)
 	assert.NotEmpty(t, matches)
 	matchResult, _ = web.arena.Database.GetMatchResultForMatch(2)
@@ -244,3 +322,39 @@ func (web *Web) postFileHttpResponse(path string, paramName string, file *bytes.
 	web.newHandler().ServeHTTP(recorder, req)
 	return recorder
 }
+
+func TestSetupSettingsScanChannels(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/settings/scan_channels")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Advanced network security must be enabled")
+
+	// Mock the radio API server.
+	apServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/scan", r.URL.Path)
+		fmt.Fprint(
+			w,
+			`{"channels":[{"channel":36,"utilizationPercent":70,"noiseDbm":-90,"networkCount":4},`+
+				`{"channel":149,"utilizationPercent":12,"noiseDbm":-96,"networkCount":1}]}`,
+		)
+	}))
+	defer apServer.Close()
+	web.arena.EventSettings.NetworkSecurityEnabled = true
+	web.arena.EventSettings.ApAddress = strings.TrimPrefix(apServer.URL, "http://")
+	assert.Nil(t, web.arena.Database.UpdateEventSettings(web.arena.EventSettings))
+	assert.Nil(t, web.arena.LoadSettings())
+
+	recorder = web.getHttpResponse("/setup/settings")
+	assert.Contains(t, recorder.Body.String(), "No channel scans have been performed yet.")
+	recorder = web.getHttpResponse("/setup/settings/scan_channels")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.getHttpResponse("/setup/settings")
+	assert.Contains(t, recorder.Body.String(), "Recommended: channel 149")
+	assert.Contains(t, recorder.Body.String(), "70%")
+
+	// The recommendation should be limited to the allowed channels.
+	web.arena.EventSettings.ApAllowedChannels = "36,40"
+	recorder = web.getHttpResponse("/setup/settings")
+	assert.Contains(t, recorder.Body.String(), "Recommended: channel 36")
+}
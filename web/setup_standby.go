@@ -0,0 +1,233 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Web routes and helpers for running as a hot standby that replicates the database of a primary server.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/replication"
+	"github.com/Team254/cheesy-arena/websocket"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Name of the file in the backups directory to which the standby keeps the latest periodic snapshot of the primary.
+const standbySnapshotFilename = "standby_snapshot.db"
+
+// Maintains the standby's copy of the database on behalf of the replication client.
+type standbyReplica struct {
+	web *Web
+}
+
+// Puts every field into standby mode and starts replicating the database from the primary at the given base URL, e.g.
+// "http://10.0.100.5:8080". Must be called on the first field before the arenas are run.
+func (web *Web) StartStandby(primaryUrl, key string) error {
+	for _, fieldArena := range web.arena.Fields() {
+		if err := fieldArena.EnterStandby(); err != nil {
+			return err
+		}
+	}
+	web.standbyClient = replication.NewClient(primaryUrl, key, &standbyReplica{web: web})
+	go web.standbyClient.Run()
+	log.Printf("Running as a read-only standby of %s.", primaryUrl)
+	return nil
+}
+
+// Shows the state of replication, either from the primary if this server is a standby or to the connected standbys.
+func (web *Web) standbyGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	template, err := web.parseFiles("templates/setup_standby.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var standbyStatus *replication.ClientStatus
+	if web.standbyClient != nil {
+		status := web.standbyClient.Status()
+		standbyStatus = &status
+	}
+	data := struct {
+		*model.EventSettings
+		Standby       bool
+		PrimaryField  bool
+		StandbyStatus *replication.ClientStatus
+		Standbys      []replication.StandbyStatus
+	}{
+		web.arena.EventSettings,
+		web.arena.IsStandby(),
+		web.arena.IsPrimaryField(),
+		standbyStatus,
+		web.replicationServer.Standbys(),
+	}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Promotes this standby to be the primary. Requests made from the server itself are also accepted so that promotion
+// can be done from the command line without a password.
+func (web *Web) standbyPromotePostHandler(w http.ResponseWriter, r *http.Request) {
+	if !isLocalRequest(r) && !web.userIsAdmin(w, r) {
+		return
+	}
+
+	if err := web.promote(); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	http.Redirect(w, r, "/setup/standby", 303)
+}
+
+// Stops replicating from the primary and takes its place, connecting to the field hardware.
+func (web *Web) promote() error {
+	if !web.arena.IsStandby() {
+		return fmt.Errorf("this server is not a standby")
+	}
+	if web.standbyClient == nil {
+		return fmt.Errorf("the standby must be promoted through the server for field 1")
+	}
+	web.standbyClient.Stop()
+	for _, fieldArena := range web.arena.Fields() {
+		if err := fieldArena.Promote(); err != nil {
+			return err
+		}
+	}
+	log.Printf("Promoted standby to primary; it no longer replicates from %s.", web.standbyClient.Status().PrimaryUrl)
+	return nil
+}
+
+// Wraps the given handler to turn away requests that could modify the database while the server is a standby, since
+// they would conflict with the changes replicated from the primary.
+func (web *Web) standbyGate(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if web.arena.IsStandby() {
+			if !standbyAllowsRequest(r) {
+				http.Error(
+					w,
+					"This server is a read-only standby; promote it at /setup/standby to make changes.",
+					http.StatusServiceUnavailable,
+				)
+				return
+			}
+			if !isStreamingRequest(r) && r.URL.Path != "/setup/standby/promote" {
+				// Keep the replicated changes from being applied while the request is reading the state they touch.
+				// Streaming requests are left out since they last as long as the client is connected, and promotion
+				// takes exclusive access to the state itself.
+				web.arena.RLockState()
+				defer web.arena.RUnlockState()
+			}
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// Returns true if the given request is for one of the read-only pages that a standby serves, or for logging in and
+// promoting it.
+func standbyAllowsRequest(r *http.Request) bool {
+	path := r.URL.Path
+	if strings.HasPrefix(path, websocket.FallbackSessionPath) {
+		// Fallback sessions can only exist for websockets that were allowed to be opened.
+		return true
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		return path == "/" || path == "/login" || path == "/display" || path == "/display/websocket" ||
+			path == "/setup/standby" || path == replication.ConnectPath || strings.HasPrefix(path, "/displays/") ||
+			strings.HasPrefix(path, "/api/") || strings.HasPrefix(path, "/reports/")
+	case http.MethodPost:
+		return path == "/login" || path == "/setup/standby/promote"
+	}
+	return false
+}
+
+// Returns true if the given request is for a websocket or one of its fallback transports, which stay open for as long
+// as the client is connected.
+func isStreamingRequest(r *http.Request) bool {
+	path := r.URL.Path
+	return strings.HasPrefix(path, websocket.FallbackSessionPath) || strings.HasSuffix(path, "/websocket") ||
+		strings.HasSuffix(path, "/events") || strings.HasSuffix(path, "/poll")
+}
+
+// Returns true if the request was made from the server itself rather than over the network or through a proxy.
+func isLocalRequest(r *http.Request) bool {
+	if r.Header.Get("X-Real-IP") != "" || r.Header.Get("X-Forwarded-For") != "" {
+		return false
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Replaces the standby's database with the given snapshot of the primary's, after checking that it can be opened.
+func (replica *standbyReplica) LoadSnapshot(snapshot []byte) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(replica.web.arena.Database.Path), "standby-db-")
+	if err != nil {
+		return err
+	}
+	tempFilePath := tempFile.Name()
+	defer os.Remove(tempFilePath)
+	defer os.Remove(model.PacketLogPath(tempFilePath))
+	_, err = tempFile.Write(snapshot)
+	tempFile.Close()
+	if err != nil {
+		return err
+	}
+	tempDb, err := model.OpenDatabase(tempFilePath)
+	if err != nil {
+		return err
+	}
+	tempDb.Close()
+	return replica.web.replaceDatabase(tempFilePath)
+}
+
+// Applies the given change from the primary and refreshes the in-memory state that is derived from the changed table.
+// Holds exclusive access to the state of the fields throughout, so that neither the arena loops nor the requests being
+// served see it partway through the update.
+func (replica *standbyReplica) ApplyChange(change *model.DatabaseChange) error {
+	web := replica.web
+	web.arena.LockState()
+	defer web.arena.UnlockState()
+	if err := web.arena.Database.ApplyChange(change); err != nil {
+		return err
+	}
+	switch change.Table {
+	case "EventSettings", "FieldSettings":
+		return web.loadAllFieldSettings()
+	case "AllianceSelection":
+		return web.loadAllianceSelection()
+	case "Alliance", "Match":
+		for _, fieldArena := range web.arena.Fields() {
+			// Errors are expected partway through a batch of related changes, such as between the alliances and the
+			// playoff matches being created, and resolve once the rest of the batch arrives.
+			_ = fieldArena.UpdatePlayoffTournament()
+		}
+	}
+	return nil
+}
+
+// Keeps the given periodic snapshot of the primary in the backups directory, replacing the previous one.
+func (replica *standbyReplica) SaveSnapshot(snapshot []byte) error {
+	backupsPath := filepath.Join(model.BaseDir, "db/backups")
+	if err := os.MkdirAll(backupsPath, 0755); err != nil {
+		return err
+	}
+	snapshotPath := filepath.Join(backupsPath, standbySnapshotFilename)
+	if err := os.WriteFile(snapshotPath+".tmp", snapshot, 0644); err != nil {
+		return err
+	}
+	return os.Rename(snapshotPath+".tmp", snapshotPath)
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStandbyGate(t *testing.T) {
+	web := setupTestWeb(t)
+	assert.Nil(t, web.arena.EnterStandby())
+
+	recorder := web.getHttpResponse("/match_play")
+	assert.Equal(t, 503, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "read-only standby")
+	recorder = web.postHttpResponse("/setup/teams", "teamNumbers=254")
+	assert.Equal(t, 503, recorder.Code)
+	teams, _ := web.arena.Database.GetAllTeams()
+	assert.Empty(t, teams)
+
+	recorder = web.getHttpResponse("/api/rankings")
+	assert.Equal(t, 200, recorder.Code)
+	recorder = web.getHttpResponse(
+		"/displays/audience?displayId=1&background=%23000&reversed=false&overlayLocation=top",
+	)
+	assert.Equal(t, 200, recorder.Code)
+	recorder = web.getHttpResponse("/setup/standby")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "This server is a read-only standby")
+
+	// Promotion over the network should require the admin password, if one is set.
+	web.arena.EventSettings.AdminPassword = "password"
+	recorder = web.postHttpResponse("/setup/standby/promote", "")
+	assert.Equal(t, 307, recorder.Code)
+	assert.True(t, web.arena.IsStandby())
+
+	// Logging in should keep the session in memory rather than writing it to the replicated database.
+	recorder = web.postHttpResponse("/login", "username=admin&password=password")
+	assert.Equal(t, 303, recorder.Code)
+	cookie := recorder.Header().Get("Set-Cookie")
+	token, _, _ := strings.Cut(strings.TrimPrefix(cookie, "session_token="), ";")
+	session, err := web.arena.Database.GetUserSessionByToken(token)
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+	recorder = web.getHttpResponseWithHeaders("/setup/standby", map[string]string{"Cookie": cookie})
+	assert.Equal(t, 200, recorder.Code)
+}
+
+func TestStandbyReplicationAndPromotion(t *testing.T) {
+	primaryWeb := setupTestWeb(t)
+	primaryWeb.arena.EventSettings.ReplicationKey = "secret"
+	assert.Nil(t, primaryWeb.arena.Database.UpdateEventSettings(primaryWeb.arena.EventSettings))
+	primaryWeb.arena.Database.CreateTeam(&model.Team{Id: 254})
+	server := httptest.NewServer(primaryWeb.newHandler())
+	defer server.Close()
+
+	standbyArena := field.SetupTestArena(t, "standby")
+	field.DisableTestHardwareLoops(standbyArena)
+	standbyWeb := NewWeb(standbyArena)
+	assert.Nil(t, standbyWeb.StartStandby(server.URL, "secret"))
+	defer os.Remove(filepath.Join(model.BaseDir, "db/backups", standbySnapshotFilename))
+	assert.True(t, standbyArena.IsStandby())
+	assert.Eventually(
+		t, func() bool { return standbyWeb.standbyClient.Status().Connected }, time.Second, 10*time.Millisecond,
+	)
+	team, _ := standbyArena.Database.GetTeamById(254)
+	assert.NotNil(t, team)
+
+	// Iterate the standby's arena loop the way Run does while changes are replicated, so that running with -race
+	// checks that they don't conflict.
+	stopLoop := make(chan struct{})
+	loopStopped := make(chan struct{})
+	go func() {
+		defer close(loopStopped)
+		for {
+			select {
+			case <-stopLoop:
+				return
+			default:
+			}
+			standbyArena.RLockState()
+			standbyArena.Update()
+			standbyArena.RUnlockState()
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	// Changes to the primary should be replicated, including settings, which should be reloaded.
+	primaryWeb.arena.Database.CreateTeam(&model.Team{Id: 1114})
+	primaryWeb.arena.EventSettings.Name = "Replicated Event"
+	assert.Nil(t, primaryWeb.arena.Database.UpdateEventSettings(primaryWeb.arena.EventSettings))
+	assert.Eventually(
+		t,
+		func() bool {
+			standbyArena.RLockState()
+			defer standbyArena.RUnlockState()
+			return standbyArena.EventSettings.Name == "Replicated Event"
+		},
+		time.Second,
+		10*time.Millisecond,
+	)
+	team, _ = standbyArena.Database.GetTeamById(1114)
+	assert.NotNil(t, team)
+	recorder := primaryWeb.getHttpResponse("/setup/standby")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Last change applied")
+	assert.Equal(t, 1, len(primaryWeb.replicationServer.Standbys()))
+
+	// Promoting the standby from the same machine shouldn't need a password.
+	standbyArena.EventSettings.AdminPassword = "password"
+	request, _ := http.NewRequest("POST", "/setup/standby/promote", nil)
+	request.RemoteAddr = "127.0.0.1:5000"
+	recorder = httptest.NewRecorder()
+	standbyWeb.newHandler().ServeHTTP(recorder, request)
+	assert.Equal(t, 303, recorder.Code)
+	assert.False(t, standbyArena.IsStandby())
+	close(stopLoop)
+	<-loopStopped
+	assert.Nil(t, standbyArena.Database.CreateTeam(&model.Team{Id: 1678}))
+	primaryWeb.arena.Database.CreateTeam(&model.Team{Id: 2056})
+	time.Sleep(50 * time.Millisecond)
+	team, _ = standbyArena.Database.GetTeamById(2056)
+	assert.Nil(t, team)
+
+	recorder = httptest.NewRecorder()
+	standbyWeb.newHandler().ServeHTTP(recorder, request)
+	assert.Equal(t, 409, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "not a standby")
+}
+
+func TestReplicationRequiresKey(t *testing.T) {
+	web := setupTestWeb(t)
+	recorder := web.getHttpResponseWithHeaders("/replication/connect", map[string]string{"Authorization": "Bearer "})
+	assert.Equal(t, 401, recorder.Code)
+}
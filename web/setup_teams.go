@@ -6,15 +6,12 @@
 package web
 
 import (
-	"bytes"
 	"fmt"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/dchest/uniuri"
 	"net/http"
-	"regexp"
 	"strconv"
 	"strings"
-	"time"
 )
 
 const wpaKeyLength = 8
@@ -55,7 +52,7 @@ func (web *Web) teamsPostHandler(w http.ResponseWriter, r *http.Request) {
 	for _, teamNumber := range teamNumbers {
 		team := model.Team{Id: teamNumber}
 		if web.arena.EventSettings.TbaDownloadEnabled {
-			if err := web.populateOfficialTeamInfo(&team); err != nil {
+			if err := web.arena.TbaClient.PopulateTeamInfo(&team); err != nil {
 				handleWebErr(w, err)
 				return
 			}
@@ -87,7 +84,7 @@ func (web *Web) teamsRefreshHandler(w http.ResponseWriter, r *http.Request) {
 	progInc := 95.00 / float64(len(teams))
 
 	for _, team := range teams {
-		if err = web.populateOfficialTeamInfo(&team); err != nil {
+		if err = web.arena.TbaClient.PopulateTeamInfo(&team); err != nil {
 			handleWebErr(w, err)
 			return
 		}
@@ -120,6 +117,10 @@ func (web *Web) teamsClearHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
+	if err = web.arena.Database.TruncateTeamInspections(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
 	http.Redirect(w, r, "/setup/teams", 303)
 }
 
@@ -156,6 +157,46 @@ func (web *Web) teamEditGetHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Shows the page summarizing a team's Wi-Fi link quality across all the matches it has played.
+func (web *Web) teamWifiHistoryGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	teamId, _ := strconv.Atoi(r.PathValue("id"))
+	team, err := web.arena.Database.GetTeamById(teamId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if team == nil {
+		http.Error(w, fmt.Sprintf("Error: No such team: %d", teamId), 400)
+		return
+	}
+	records, err := web.arena.Database.GetTeamWifiRecordsByTeam(teamId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	template, err := web.parseFiles("templates/team_wifi_history.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Team    *model.Team
+		Summary model.TeamWifiSummary
+		Records []model.TeamWifiRecord
+	}{web.arena.EventSettings, team, model.SummarizeTeamWifiRecords(records), records}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
 // Updates a team's fields.
 func (web *Web) teamEditPostHandler(w http.ResponseWriter, r *http.Request) {
 	if !web.userIsAdmin(w, r) {
@@ -224,6 +265,17 @@ func (web *Web) teamDeletePostHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
+	teamInspections, err := web.arena.Database.GetTeamInspectionsByTeam(team.Id)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	for _, teamInspection := range teamInspections {
+		if err = web.arena.Database.DeleteTeamInspection(teamInspection.Id); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
 	http.Redirect(w, r, "/setup/teams", 303)
 }
 
@@ -291,52 +343,3 @@ func (web *Web) canModifyTeamList() bool {
 	}
 	return true
 }
-
-// Returns the data for the given team number.
-func (web *Web) populateOfficialTeamInfo(team *model.Team) error {
-	tbaTeam, err := web.arena.TbaClient.GetTeam(team.Id)
-	if err != nil {
-		return err
-	}
-
-	// Check if the result is valid. If a team is not found, it will just not have its detail fields filled out.
-	if tbaTeam.TeamNumber == 0 {
-		return nil
-	}
-
-	team.Name = tbaTeam.Name
-	team.Nickname = tbaTeam.Nickname
-	team.City = tbaTeam.City
-	team.StateProv = tbaTeam.StateProv
-	team.Country = tbaTeam.Country
-	schoolNameRe := regexp.MustCompile("^.*\\S&(\\S.*?$)")
-	matches := schoolNameRe.FindStringSubmatch(tbaTeam.Name)
-	if len(matches) > 0 {
-		team.SchoolName = matches[1]
-	}
-	team.RookieYear = tbaTeam.RookieYear
-	team.RobotName, err = web.arena.TbaClient.GetRobotName(team.Id, time.Now().Year())
-	if err != nil {
-		return err
-	}
-
-	// Generate string of recent awards in reverse chronological order.
-	recentAwards, err := web.arena.TbaClient.GetTeamAwards(team.Id)
-	if err != nil {
-		return err
-	}
-	var accomplishmentsBuffer bytes.Buffer
-	for i := len(recentAwards) - 1; i >= 0; i-- {
-		award := recentAwards[i]
-		if time.Now().Year()-award.Year <= 1 {
-			accomplishmentsBuffer.WriteString(fmt.Sprintf("<p>%d %s - %s</p>", award.Year, award.EventName,
-				award.Name))
-		}
-	}
-	team.Accomplishments = accomplishmentsBuffer.String()
-
-	// Download and store the team's avatar; if there isn't one, ignore the error.
-	web.arena.TbaClient.DownloadTeamAvatar(team.Id, time.Now().Year())
-
-	return nil
-}
@@ -11,6 +11,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestSetupTeams(t *testing.T) {
@@ -216,3 +217,41 @@ func TestSetupTeamsProgress(t *testing.T) {
 	assert.Equal(t, 200, recorder.Code)
 	assert.Equal(t, "25", recorder.Body.String())
 }
+
+func TestSetupTeamWifiHistory(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/teams/254/wifi")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No such team")
+
+	assert.Nil(t, web.arena.Database.CreateTeam(&model.Team{Id: 254}))
+	recorder = web.getHttpResponse("/setup/teams/254/wifi")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No matches have been recorded for this team yet.")
+
+	assert.Nil(
+		t,
+		web.arena.Database.CreateTeamWifiRecord(
+			&model.TeamWifiRecord{
+				TeamId:              254,
+				MatchShortName:      "Q12",
+				AllianceStation:     "R2",
+				StartedAt:           time.Now(),
+				SnrSamples:          []int{41, 17, 39},
+				EnabledSec:          150,
+				RadioDownEnabledSec: 2.5,
+				OverBandwidthSec:    4,
+				MaxMBits:            6.5,
+			},
+		),
+	)
+	recorder = web.getHttpResponse("/setup/teams/254/wifi")
+	assert.Equal(t, 200, recorder.Code)
+	body := recorder.Body.String()
+	assert.Contains(t, body, "Q12")
+	assert.Contains(t, body, "39 dB")
+	assert.Contains(t, body, "17 dB")
+	assert.Contains(t, body, "2.5 s of 150 s")
+	assert.Contains(t, body, "peak 6.50 Mbps")
+}
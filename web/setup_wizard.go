@@ -0,0 +1,339 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Web route for the checklist that guides the scorekeeper through each stage of running an event.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Represents one step of the event lifecycle along with the problems, if any, that stand in the way of completing it.
+// The problems of an advisory step don't necessarily block the event, so the scorekeeper can mark it done anyway, after
+// which those problems are shown as acknowledged until any new ones arise.
+type wizardStep struct {
+	Key                  string
+	Title                string
+	Url                  string
+	IsAdvisory           bool
+	IsAcknowledged       bool
+	IsComplete           bool
+	IsCurrent            bool
+	Problems             []string
+	AcknowledgedProblems []string
+	Note                 string
+}
+
+// Shows the checklist of steps in running an event, validating each one against the current state of the event.
+func (web *Web) wizardGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	steps, err := web.getWizardSteps()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	template, err := web.parseFiles("templates/setup_wizard.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Steps []*wizardStep
+	}{web.arena.EventSettings, steps}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Marks the given advisory step as done, acknowledging whatever problems it currently has.
+func (web *Web) wizardAcknowledgePostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	step, err := web.getAdvisoryWizardStep(r.PathValue("stepKey"))
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	problems := slices.Concat(step.AcknowledgedProblems, step.Problems)
+	if err = web.arena.Database.AcknowledgeWizardStep(step.Key, problems); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	http.Redirect(w, r, "/setup/wizard", 303)
+}
+
+// Clears the acknowledgement of the given advisory step so that its problems count against it again.
+func (web *Web) wizardUndoPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	step, err := web.getAdvisoryWizardStep(r.PathValue("stepKey"))
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if err = web.arena.Database.DeleteWizardStepAcknowledgement(step.Key); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	http.Redirect(w, r, "/setup/wizard", 303)
+}
+
+// Returns the advisory step having the given key, or an error if there isn't one.
+func (web *Web) getAdvisoryWizardStep(stepKey string) (*wizardStep, error) {
+	steps, err := web.getWizardSteps()
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range steps {
+		if step.Key == stepKey && step.IsAdvisory {
+			return step, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid wizard step: %s", stepKey)
+}
+
+// Returns the steps of the event lifecycle in order, with the first incomplete one marked as current.
+func (web *Web) getWizardSteps() ([]*wizardStep, error) {
+	database := web.arena.Database
+	settings := web.arena.EventSettings
+	var steps []*wizardStep
+
+	// Event settings.
+	step := &wizardStep{Key: "settings", Title: "Configure event settings", Url: "/setup/settings", IsAdvisory: true}
+	if settings.Name == "Untitled Event" {
+		step.Problems = append(step.Problems, "The event hasn't been given a name.")
+	}
+	if settings.NetworkSecurityEnabled && (settings.ApAddress == "" || settings.SwitchAddress == "") {
+		step.Problems = append(step.Problems, "Network security is enabled but the AP or switch address isn't set.")
+	}
+	if settings.TbaPublishingEnabled &&
+		(settings.TbaEventCode == "" || settings.TbaSecretId == "" || settings.TbaSecret == "") {
+		step.Problems = append(step.Problems, "TBA publishing is enabled but the event code or secret isn't set.")
+	}
+	step.IsComplete = len(step.Problems) == 0
+	steps = append(steps, step)
+
+	// Team list.
+	step = &wizardStep{Key: "teams", Title: "Enter the team list", Url: "/setup/teams"}
+	teams, err := database.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	if len(teams) < 6 {
+		step.Problems = append(step.Problems, fmt.Sprintf("Only %d teams have been entered.", len(teams)))
+	}
+	if settings.NetworkSecurityEnabled {
+		var teamsWithoutKeys []string
+		for _, team := range teams {
+			if len(team.WpaKey) < 8 {
+				teamsWithoutKeys = append(teamsWithoutKeys, fmt.Sprint(team.Id))
+			}
+		}
+		if len(teamsWithoutKeys) > 0 {
+			step.Problems = append(
+				step.Problems,
+				fmt.Sprintf("WPA keys haven't been generated for teams %s.", strings.Join(teamsWithoutKeys, ", ")),
+			)
+		}
+	}
+	if !web.canModifyTeamList() {
+		step.Note = "The team list is locked since the qualification schedule has been saved."
+	}
+	step.IsComplete = len(step.Problems) == 0
+	steps = append(steps, step)
+
+	// Field hardware.
+	step = &wizardStep{
+		Key:        "field_hardware",
+		Title:      "Check the field hardware",
+		Url:        "/setup/field_testing",
+		IsAdvisory: true,
+	}
+	step.Problems = web.arena.GetFieldHardwareProblems()
+	step.IsComplete = len(step.Problems) == 0
+	steps = append(steps, step)
+
+	// Match schedules.
+	for _, matchType := range []model.MatchType{model.Practice, model.Qualification} {
+		matchTypeName := strings.ToLower(matchType.String())
+		step = &wizardStep{
+			Key:   matchTypeName + "_schedule",
+			Title: fmt.Sprintf("Generate and save the %s schedule", matchTypeName),
+			Url:   fmt.Sprintf("/setup/schedule?matchType=%s", matchTypeName),
+		}
+		matches, err := database.GetMatchesByType(matchType, false)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			step.Problems = append(step.Problems, fmt.Sprintf("No %s matches have been saved.", matchTypeName))
+		}
+		step.IsComplete = len(step.Problems) == 0
+		steps = append(steps, step)
+	}
+
+	// Qualification matches.
+	step = &wizardStep{Key: "qualifications", Title: "Play the qualification matches", Url: "/match_play"}
+	qualificationMatches, err := database.GetMatchesByType(model.Qualification, false)
+	if err != nil {
+		return nil, err
+	}
+	numRemaining := 0
+	for _, match := range qualificationMatches {
+		if !match.IsComplete() {
+			numRemaining++
+		}
+	}
+	if len(qualificationMatches) == 0 {
+		step.Problems = append(step.Problems, "The qualification schedule hasn't been generated.")
+	} else if numRemaining > 0 {
+		step.Problems = append(
+			step.Problems,
+			fmt.Sprintf("%d of %d qualification matches remain.", numRemaining, len(qualificationMatches)),
+		)
+	}
+	step.IsComplete = len(step.Problems) == 0
+	qualificationsComplete := step.IsComplete
+	steps = append(steps, step)
+
+	// Alliance selection.
+	step = &wizardStep{Key: "alliance_selection", Title: "Run alliance selection", Url: "/alliance_selection"}
+	if web.canModifyAllianceSelection() {
+		if !qualificationsComplete {
+			step.Problems = append(
+				step.Problems, "The qualification matches must be complete before alliance selection.",
+			)
+		}
+		step.Problems = append(step.Problems, "Alliance selection hasn't been finalized.")
+	}
+	step.IsComplete = len(step.Problems) == 0
+	steps = append(steps, step)
+
+	// Playoff matches.
+	step = &wizardStep{Key: "playoffs", Title: "Play the playoff matches", Url: "/match_play"}
+	if !web.arena.PlayoffTournament.IsComplete() {
+		step.Problems = append(step.Problems, "The playoff tournament isn't complete.")
+	}
+	step.IsComplete = len(step.Problems) == 0
+	steps = append(steps, step)
+
+	// Awards.
+	step = &wizardStep{Key: "awards", Title: "Finalize the awards", Url: "/judging"}
+	awardDefinitions, err := database.GetAllAwardDefinitions()
+	if err != nil {
+		return nil, err
+	}
+	for _, awardDefinition := range awardDefinitions {
+		nominations, err := database.GetAwardNominationsByDefinition(awardDefinition.Id)
+		if err != nil {
+			return nil, err
+		}
+		isFinalized := false
+		for _, nomination := range nominations {
+			isFinalized = isFinalized || nomination.AwardId > 0
+		}
+		if !isFinalized {
+			step.Problems = append(
+				step.Problems, fmt.Sprintf("The %s hasn't been finalized.", awardDefinition.Name),
+			)
+		}
+	}
+	winnerAwards, err := database.GetAwardsByType(model.WinnerAward)
+	if err != nil {
+		return nil, err
+	}
+	if len(winnerAwards) == 0 {
+		step.Problems = append(step.Problems, "The winner awards haven't been generated.")
+	}
+	step.IsComplete = len(step.Problems) == 0
+	steps = append(steps, step)
+
+	// Publishing to The Blue Alliance.
+	step = &wizardStep{
+		Key:        "tba_publishing",
+		Title:      "Publish the results to The Blue Alliance",
+		Url:        "/setup/settings",
+		IsAdvisory: true,
+	}
+	if settings.TbaPublishingEnabled {
+		var publishTimes []string
+		for _, kind := range []string{
+			model.TbaPublishTeams,
+			model.TbaPublishMatches,
+			model.TbaPublishRankings,
+			model.TbaPublishAlliances,
+			model.TbaPublishAwards,
+		} {
+			publishRecord, err := database.GetTbaPublishRecord(kind)
+			if err != nil {
+				return nil, err
+			}
+			if publishRecord == nil {
+				step.Problems = append(step.Problems, fmt.Sprintf("The %s haven't been published.", kind))
+			} else {
+				publishTimes = append(
+					publishTimes, fmt.Sprintf("%s at %s", kind, publishRecord.PublishedAt.Local().Format("3:04 PM")),
+				)
+			}
+		}
+		if len(publishTimes) > 0 {
+			step.Note = "Last published: " + strings.Join(publishTimes, ", ") + "."
+		}
+	} else {
+		step.Note = "TBA publishing is disabled."
+	}
+	step.IsComplete = len(step.Problems) == 0
+	steps = append(steps, step)
+
+	for _, step := range steps {
+		if step.IsAdvisory {
+			if err = web.applyWizardStepAcknowledgement(step); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, step := range steps {
+		if !step.IsComplete {
+			step.IsCurrent = true
+			break
+		}
+	}
+	return steps, nil
+}
+
+// Sets aside the problems of the given advisory step that were acknowledged when it was marked done, and considers the
+// step complete if no others have arisen since.
+func (web *Web) applyWizardStepAcknowledgement(step *wizardStep) error {
+	acknowledgement, err := web.arena.Database.GetWizardStepAcknowledgement(step.Key)
+	if err != nil || acknowledgement == nil {
+		return err
+	}
+
+	step.IsAcknowledged = true
+	var outstandingProblems []string
+	for _, problem := range step.Problems {
+		if slices.Contains(acknowledgement.Problems, problem) {
+			step.AcknowledgedProblems = append(step.AcknowledgedProblems, problem)
+		} else {
+			outstandingProblems = append(outstandingProblems, problem)
+		}
+	}
+	step.Problems = outstandingProblems
+	step.IsComplete = len(step.Problems) == 0
+	return nil
+}
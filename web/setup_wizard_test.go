@@ -0,0 +1,172 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/tournament"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSetupWizard(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/wizard")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The event hasn't been given a name.")
+	steps, err := web.getWizardSteps()
+	assert.Nil(t, err)
+	assertWizardCurrentStep(t, steps, "Configure event settings")
+
+	web.arena.EventSettings.Name = "Chezy Champs"
+	web.arena.EventSettings.NetworkSecurityEnabled = true
+	web.arena.EventSettings.ApAddress = "10.0.100.2"
+	steps, _ = web.getWizardSteps()
+	assertWizardCurrentStep(t, steps, "Configure event settings")
+	assert.Equal(t, []string{"Network security is enabled but the AP or switch address isn't set."}, steps[0].Problems)
+	web.arena.EventSettings.NetworkSecurityEnabled = false
+
+	// Enter the teams.
+	steps, _ = web.getWizardSteps()
+	assertWizardCurrentStep(t, steps, "Enter the team list")
+	assert.Equal(t, []string{"Only 0 teams have been entered."}, steps[1].Problems)
+	for i := 1; i <= 6; i++ {
+		web.arena.Database.CreateTeam(&model.Team{Id: 100 + i})
+	}
+	web.arena.EventSettings.NetworkSecurityEnabled = true
+	web.arena.EventSettings.SwitchAddress = "10.0.100.3"
+	steps, _ = web.getWizardSteps()
+	assert.Equal(
+		t, []string{"WPA keys haven't been generated for teams 101, 102, 103, 104, 105, 106."}, steps[1].Problems,
+	)
+	assert.Equal(t, "The access point isn't active (status UNKNOWN).", steps[2].Problems[0])
+	web.arena.EventSettings.NetworkSecurityEnabled = false
+
+	// Generate the schedules and play the qualification matches.
+	steps, _ = web.getWizardSteps()
+	assertWizardCurrentStep(t, steps, "Generate and save the practice schedule")
+	assert.Equal(t, "", steps[1].Note)
+	web.arena.Database.CreateMatch(&model.Match{Type: model.Practice, TypeOrder: 1})
+	match := model.Match{Type: model.Qualification, TypeOrder: 1}
+	web.arena.Database.CreateMatch(&match)
+	steps, _ = web.getWizardSteps()
+	assertWizardCurrentStep(t, steps, "Play the qualification matches")
+	assert.Equal(t, []string{"1 of 1 qualification matches remain."}, steps[5].Problems)
+	assert.Equal(t, "The team list is locked since the qualification schedule has been saved.", steps[1].Note)
+	assert.Equal(
+		t,
+		[]string{
+			"The qualification matches must be complete before alliance selection.",
+			"Alliance selection hasn't been finalized.",
+		},
+		steps[6].Problems,
+	)
+	match.Status = game.RedWonMatch
+	web.arena.Database.UpdateMatch(&match)
+	steps, _ = web.getWizardSteps()
+	assertWizardCurrentStep(t, steps, "Run alliance selection")
+	assert.Equal(t, []string{"Alliance selection hasn't been finalized."}, steps[6].Problems)
+
+	// Create the playoff tournament.
+	web.arena.EventSettings.PlayoffType = model.SingleEliminationPlayoff
+	web.arena.EventSettings.NumPlayoffAlliances = 2
+	assert.Nil(t, web.arena.CreatePlayoffTournament())
+	tournament.CreateTestAlliances(web.arena.Database, 2)
+	assert.Nil(t, web.arena.CreatePlayoffMatches(time.Unix(1000, 0)))
+	steps, _ = web.getWizardSteps()
+	assertWizardCurrentStep(t, steps, "Play the playoff matches")
+	assert.Equal(t, []string{"The winner awards haven't been generated."}, steps[8].Problems)
+
+	// Check the awards and publishing steps.
+	web.arena.Database.CreateAwardDefinition(&model.AwardDefinition{Name: "Safety Award"})
+	web.arena.EventSettings.TbaPublishingEnabled = true
+	steps, _ = web.getWizardSteps()
+	assert.Equal(
+		t,
+		[]string{"The Safety Award hasn't been finalized.", "The winner awards haven't been generated."},
+		steps[8].Problems,
+	)
+	assert.Equal(t, 5, len(steps[9].Problems))
+	assert.Nil(t, web.arena.Database.RecordTbaPublish(model.TbaPublishTeams))
+	steps, _ = web.getWizardSteps()
+	assert.Equal(t, 4, len(steps[9].Problems))
+	assert.Contains(t, steps[9].Note, "Last published: teams at ")
+	web.arena.EventSettings.TbaPublishingEnabled = false
+	steps, _ = web.getWizardSteps()
+	assert.True(t, steps[9].IsComplete)
+	assert.Equal(t, "TBA publishing is disabled.", steps[9].Note)
+}
+
+func TestSetupWizardAcknowledgement(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.Name = "Chezy Champs"
+	web.arena.EventSettings.NetworkSecurityEnabled = true
+	web.arena.EventSettings.ApAddress = "10.0.100.2"
+	web.arena.EventSettings.SwitchAddress = "10.0.100.3"
+	steps, _ := web.getWizardSteps()
+	assert.False(t, steps[2].IsComplete)
+	assert.Equal(t, 2, len(steps[2].Problems))
+
+	// Marking an advisory step done should acknowledge its current problems.
+	recorder := web.getHttpResponse("/setup/wizard")
+	assert.Contains(t, recorder.Body.String(), "/setup/wizard/field_hardware/acknowledge")
+	recorder = web.postHttpResponse("/setup/wizard/field_hardware/acknowledge", "")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	steps, _ = web.getWizardSteps()
+	assert.True(t, steps[2].IsComplete)
+	assert.True(t, steps[2].IsAcknowledged)
+	assert.Empty(t, steps[2].Problems)
+	assert.Equal(
+		t,
+		[]string{"The access point isn't active (status UNKNOWN).", "The switch isn't active (status UNKNOWN)."},
+		steps[2].AcknowledgedProblems,
+	)
+	recorder = web.getHttpResponse("/setup/wizard")
+	assert.Contains(t, recorder.Body.String(), "Acknowledged: The access point isn't active (status UNKNOWN).")
+	assert.Contains(t, recorder.Body.String(), "/setup/wizard/field_hardware/undo")
+
+	// A problem that arises after the acknowledgement should count against the step again.
+	web.arena.EventSettings.NetworkSecurityEnabled = false
+	web.arena.EventSettings.TbaPublishingEnabled = true
+	recorder = web.postHttpResponse("/setup/wizard/settings/acknowledge", "")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	steps, _ = web.getWizardSteps()
+	assert.True(t, steps[0].IsComplete)
+	web.arena.EventSettings.Name = "Untitled Event"
+	steps, _ = web.getWizardSteps()
+	assert.False(t, steps[0].IsComplete)
+	assert.Equal(t, []string{"The event hasn't been given a name."}, steps[0].Problems)
+	assert.Equal(
+		t,
+		[]string{"TBA publishing is enabled but the event code or secret isn't set."},
+		steps[0].AcknowledgedProblems,
+	)
+
+	// Undoing the acknowledgement should bring back its problems.
+	recorder = web.postHttpResponse("/setup/wizard/settings/undo", "")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	steps, _ = web.getWizardSteps()
+	assert.False(t, steps[0].IsAcknowledged)
+	assert.Equal(t, 2, len(steps[0].Problems))
+	assert.Empty(t, steps[0].AcknowledgedProblems)
+
+	// Only advisory steps can be marked done.
+	recorder = web.postHttpResponse("/setup/wizard/teams/acknowledge", "")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid wizard step: teams")
+	steps, _ = web.getWizardSteps()
+	assert.False(t, steps[1].IsComplete)
+}
+
+func assertWizardCurrentStep(t *testing.T, steps []*wizardStep, title string) {
+	for _, step := range steps {
+		if step.IsCurrent {
+			assert.Equal(t, title, step.Title)
+			return
+		}
+	}
+	assert.Fail(t, "no step is current")
+}
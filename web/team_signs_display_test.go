@@ -0,0 +1,40 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/websocket"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTeamSignsDisplay(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/displays/team_signs?displayId=1&position=")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team Signs Display - Untitled Event - Cheesy Arena")
+	assert.Contains(t, recorder.Body.String(), "The team sign simulator is not enabled in the event settings")
+	assert.Contains(t, recorder.Body.String(), "id=\"BlueTimer\"")
+}
+
+func TestTeamSignsDisplayWebsocket(t *testing.T) {
+	web := setupTestWeb(t)
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/displays/team_signs/websocket?displayId=1", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+
+	// Should get a few status updates right after connection.
+	messages := readWebsocketMultiple(t, ws, 2)
+	assert.Contains(t, messages, "displayConfiguration")
+	if assert.Contains(t, messages, "teamSigns") {
+		teamSigns := messages["teamSigns"].(map[string]any)
+		assert.Contains(t, teamSigns, "Red1")
+		assert.Nil(t, teamSigns["Red1"])
+	}
+}
@@ -9,28 +9,53 @@ import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/game"
 	"log"
+	"net"
 	"net/http"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 
 	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/relay"
+	"github.com/Team254/cheesy-arena/replication"
+	"github.com/Team254/cheesy-arena/websocket"
 )
 
 const (
 	sessionTokenCookie = "session_token"
 	adminUser          = "admin"
+	// Name of the query parameter, and of the cookie remembering it, that selects the field whose pages are served.
+	fieldParameter = "field"
 )
 
 type Web struct {
-	arena           *field.Arena
-	templateHelpers template.FuncMap
+	arena             *field.Arena
+	fields            []*Web
+	templateHelpers   template.FuncMap
+	relayClient       *relay.Client
+	replicationServer *replication.Server
+	standbyClient     *replication.Client
+	// Sessions of users who logged in while the server was a standby, which can't be stored in the replicated database.
+	standbySessions      map[string]model.UserSession
+	standbySessionsMutex sync.Mutex
+	// Incorrect lineup PINs by client address; kept by the first field so that they are counted across all fields.
+	lineupPinFailures      map[string]*lineupPinFailures
+	lineupPinFailuresMutex sync.Mutex
 }
 
 func NewWeb(arena *field.Arena) *Web {
-	web := &Web{arena: arena}
+	web := &Web{
+		arena:             arena,
+		standbySessions:   make(map[string]model.UserSession),
+		lineupPinFailures: make(map[string]*lineupPinFailures),
+	}
+	web.replicationServer = replication.NewServer(
+		func() *model.Database { return web.arena.Database },
+		func() string { return web.arena.EventSettings.ReplicationKey },
+	)
 
 	// Helper functions that can be used inside templates.
 	web.templateHelpers = template.FuncMap{
@@ -83,17 +108,62 @@ func NewWeb(arena *field.Arena) *Web {
 		"tieMatch":       game.TieMatch.Get,
 	}
 
+	// The alliance selection is conducted for the event as a whole, so only the first field resumes it.
+	if arena.IsPrimaryField() {
+		if err := web.loadAllianceSelection(); err != nil {
+			log.Printf("Failed to resume alliance selection: %v", err)
+		}
+	}
+
 	return web
 }
 
-// Starts the webserver and blocks, waiting on requests. Does not return until the application exits.
-func (web *Web) ServeWebInterface(port int) {
-	http.Handle("/static/", http.StripPrefix("/static/", addNoCacheHeader(http.FileServer(http.Dir("static/")))))
-	http.Handle("/", web.newHandler())
-	log.Printf("Serving HTTP requests on port %d", port)
+// Links the webs of the given fields, which must be given in order of field number, so that the first serves the pages
+// of all of them.
+func LinkFieldWebs(webs ...*Web) {
+	for _, web := range webs {
+		web.fields = webs
+	}
+}
+
+// Returns the webs of all the fields at the event in order of field number, including this one.
+func (web *Web) fieldWebs() []*Web {
+	if len(web.fields) == 0 {
+		return []*Web{web}
+	}
+	return web.fields
+}
+
+// Starts the webserver for every field on the given address, or all addresses if blank, and blocks, waiting on
+// requests. Must be called on the web of the first field. Does not return until the application exits.
+func (web *Web) ServeWebInterface(bindAddress string, port int) {
+	mux := http.NewServeMux()
+	mux.Handle("/static/", http.StripPrefix("/static/", addNoCacheHeader(http.FileServer(http.Dir("static/")))))
+	mux.Handle("/", web.newFieldsHandler())
+	log.Printf("Serving HTTP requests for %d field(s) on port %d", len(web.fieldWebs()), port)
+
+	// Maintain the outbound connection to the public display relay, if one is configured. The relay passes along the
+	// query string of each display, so the displays of every field can be relayed.
+	localAddress := bindAddress
+	if localAddress == "" || net.ParseIP(localAddress).IsUnspecified() {
+		localAddress = "127.0.0.1"
+	}
+	web.relayClient = relay.NewClient(net.JoinHostPort(localAddress, strconv.Itoa(port)), web.relayConfig)
+	go web.relayClient.Run()
 
 	// Start Server
-	http.ListenAndServe(fmt.Sprintf(":%d", port), nil)
+	if err := http.ListenAndServe(net.JoinHostPort(bindAddress, strconv.Itoa(port)), mux); err != nil {
+		log.Fatalf("Error serving HTTP requests on port %d: %v", port, err)
+	}
+}
+
+// Returns the current public display relay settings. A standby holds off on connecting, since the relay would drop the
+// primary's connection in favor of it, until it is promoted.
+func (web *Web) relayConfig() relay.ClientConfig {
+	settings := web.arena.EventSettings
+	return relay.ClientConfig{
+		Enabled: settings.RelayEnabled && !web.arena.IsStandby(), Url: settings.RelayUrl, Key: settings.RelayKey,
+	}
 }
 
 // Serves the root page of Cheesy Arena.
@@ -121,16 +191,48 @@ func addNoCacheHeader(handler http.Handler) http.Handler {
 	})
 }
 
-// Sets up the mapping between URLs and handlers.
+// Returns the handler that serves the pages of every field, passing each request on to the handler of the field
+// selected by its "field" query parameter, e.g. "/match_play?field=2". Since the links and redirects between pages
+// don't carry the parameter, the selection is remembered in a cookie for the requests that lack it. Requests that have
+// neither go to the first field.
+func (web *Web) newFieldsHandler() http.Handler {
+	fieldWebs := web.fieldWebs()
+	handlers := make([]http.Handler, len(fieldWebs))
+	for i, fieldWeb := range fieldWebs {
+		handlers[i] = fieldWeb.newHandler()
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fieldNumber, err := strconv.Atoi(r.URL.Query().Get(fieldParameter))
+		if err == nil && fieldNumber >= 1 && fieldNumber <= len(handlers) {
+			if !isStreamingRequest(r) {
+				http.SetCookie(w, &http.Cookie{Name: fieldParameter, Value: strconv.Itoa(fieldNumber), Path: "/"})
+			}
+		} else if cookie, err := r.Cookie(fieldParameter); err == nil {
+			fieldNumber, err = strconv.Atoi(cookie.Value)
+			if err != nil || fieldNumber < 1 || fieldNumber > len(handlers) {
+				fieldNumber = 1
+			}
+		} else {
+			fieldNumber = 1
+		}
+		handlers[fieldNumber-1].ServeHTTP(w, r)
+	})
+}
+
+// Sets up the mapping between URLs and handlers for the pages of this web's field.
 func (web *Web) newHandler() http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("GET /", web.indexHandler)
 	mux.HandleFunc("GET /alliance_selection", web.allianceSelectionGetHandler)
 	mux.HandleFunc("POST /alliance_selection", web.allianceSelectionPostHandler)
 	mux.HandleFunc("GET /alliance_selection/websocket", web.allianceSelectionWebsocketHandler)
+	mux.HandleFunc("POST /alliance_selection/accept", web.allianceSelectionAcceptPostHandler)
+	mux.HandleFunc("POST /alliance_selection/decline", web.allianceSelectionDeclinePostHandler)
 	mux.HandleFunc("POST /alliance_selection/finalize", web.allianceSelectionFinalizeHandler)
+	mux.HandleFunc("POST /alliance_selection/invite", web.allianceSelectionInvitePostHandler)
 	mux.HandleFunc("POST /alliance_selection/reset", web.allianceSelectionResetHandler)
 	mux.HandleFunc("POST /alliance_selection/start", web.allianceSelectionStartHandler)
+	mux.HandleFunc("POST /alliance_selection/undo", web.allianceSelectionUndoPostHandler)
 	mux.HandleFunc("GET /api/alliances", web.alliancesApiHandler)
 	mux.HandleFunc("GET /api/arena/websocket", web.arenaWebsocketApiHandler)
 	mux.HandleFunc("GET /api/bracket/svg", web.bracketSvgApiHandler)
@@ -154,17 +256,31 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /displays/field_monitor/websocket", web.fieldMonitorDisplayWebsocketHandler)
 	mux.HandleFunc("GET /displays/logo", web.logoDisplayHandler)
 	mux.HandleFunc("GET /displays/logo/websocket", web.logoDisplayWebsocketHandler)
+	mux.HandleFunc("GET /displays/pit", web.pitDisplayHandler)
+	mux.HandleFunc("GET /displays/pit/websocket", web.pitDisplayWebsocketHandler)
 	mux.HandleFunc("GET /displays/queueing", web.queueingDisplayHandler)
 	mux.HandleFunc("GET /displays/queueing/match_load", web.queueingDisplayMatchLoadHandler)
 	mux.HandleFunc("GET /displays/queueing/websocket", web.queueingDisplayWebsocketHandler)
 	mux.HandleFunc("GET /displays/rankings", web.rankingsDisplayHandler)
 	mux.HandleFunc("GET /displays/rankings/websocket", web.rankingsDisplayWebsocketHandler)
+	mux.HandleFunc("GET /displays/team_signs", web.teamSignsDisplayHandler)
+	mux.HandleFunc("GET /displays/team_signs/websocket", web.teamSignsDisplayWebsocketHandler)
 	mux.HandleFunc("GET /displays/twitch", web.twitchDisplayHandler)
 	mux.HandleFunc("GET /displays/twitch/websocket", web.twitchDisplayWebsocketHandler)
 	mux.HandleFunc("GET /displays/wall", web.wallDisplayHandler)
 	mux.HandleFunc("GET /displays/wall/websocket", web.wallDisplayWebsocketHandler)
 	mux.HandleFunc("GET /displays/webpage", web.webpageDisplayHandler)
 	mux.HandleFunc("GET /displays/webpage/websocket", web.webpageDisplayWebsocketHandler)
+	mux.HandleFunc("GET /inspection", web.inspectionGetHandler)
+	mux.HandleFunc("POST /inspection", web.inspectionPostHandler)
+	mux.HandleFunc("GET /judging", web.judgingGetHandler)
+	mux.HandleFunc("POST /judging/awards", web.judgingAwardsPostHandler)
+	mux.HandleFunc("GET /judging/ceremony", web.judgingCeremonyGetHandler)
+	mux.HandleFunc("POST /judging/ceremony", web.judgingCeremonyPostHandler)
+	mux.HandleFunc("POST /judging/finalize", web.judgingFinalizePostHandler)
+	mux.HandleFunc("POST /judging/nominations", web.judgingNominationsPostHandler)
+	mux.HandleFunc("GET /lineup", web.lineupGetHandler)
+	mux.HandleFunc("POST /lineup", web.lineupPostHandler)
 	mux.HandleFunc("GET /login", web.loginHandler)
 	mux.HandleFunc("POST /login", web.loginPostHandler)
 	mux.HandleFunc("GET /match_play", web.matchPlayHandler)
@@ -172,6 +288,7 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /match_play/websocket", web.matchPlayWebsocketHandler)
 	mux.HandleFunc("GET /match_logs", web.matchLogsHandler)
 	mux.HandleFunc("GET /match_logs/{matchId}/{stationId}/log", web.matchLogsViewGetHandler)
+	mux.HandleFunc("GET /match_logs/csv/{logId}", web.matchLogCsvHandler)
 	mux.HandleFunc("GET /match_review", web.matchReviewHandler)
 	mux.HandleFunc("GET /match_review/{matchId}/edit", web.matchReviewEditGetHandler)
 	mux.HandleFunc("POST /match_review/{matchId}/edit", web.matchReviewEditPostHandler)
@@ -180,29 +297,46 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /panels/referee", web.refereePanelHandler)
 	mux.HandleFunc("GET /panels/referee/foul_list", web.refereePanelFoulListHandler)
 	mux.HandleFunc("GET /panels/referee/websocket", web.refereePanelWebsocketHandler)
+	mux.HandleFunc("GET /playoff_backups", web.playoffBackupsGetHandler)
+	mux.HandleFunc("POST /playoff_backups", web.playoffBackupsPostHandler)
+	mux.HandleFunc("GET /playoff_lineups", web.playoffLineupsGetHandler)
+	mux.HandleFunc("POST /playoff_lineups/pin", web.playoffLineupsPinPostHandler)
+	mux.HandleFunc("GET "+replication.ConnectPath, web.replicationServer.ConnectHandler)
 	mux.HandleFunc("GET /reports/csv/backups", web.backupTeamsCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/fta", web.ftaCsvReportHandler)
+	mux.HandleFunc("GET /reports/csv/inspections", web.inspectionsCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/rankings", web.rankingsCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/schedule/{type}", web.scheduleCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/teams", web.teamsCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/wpa_keys", web.wpaKeysCsvReportHandler)
+	mux.HandleFunc("GET /reports/pdf/agenda", web.agendaPdfReportHandler)
+	mux.HandleFunc("GET /reports/pdf/alliance_selection", web.allianceSelectionPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/alliances", web.alliancesPdfReportHandler)
+	mux.HandleFunc("GET /reports/pdf/award_script", web.awardScriptPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/backups", web.backupsPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/bracket", web.bracketPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/coupons", web.couponsPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/cycle/{type}", web.cyclePdfReportHandler)
+	mux.HandleFunc("GET /reports/pdf/inspections", web.inspectionsPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/rankings", web.rankingsPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/schedule/{type}", web.schedulePdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/teams", web.teamsPdfReportHandler)
+	mux.HandleFunc("GET /setup/agenda", web.agendaGetHandler)
+	mux.HandleFunc("POST /setup/agenda", web.agendaPostHandler)
 	mux.HandleFunc("GET /setup/awards", web.awardsGetHandler)
 	mux.HandleFunc("POST /setup/awards", web.awardsPostHandler)
 	mux.HandleFunc("GET /setup/breaks", web.breaksGetHandler)
 	mux.HandleFunc("POST /setup/breaks", web.breaksPostHandler)
 	mux.HandleFunc("POST /setup/db/clear/{type}", web.clearDbHandler)
+	mux.HandleFunc("GET /setup/db/integrity", web.dbIntegrityGetHandler)
+	mux.HandleFunc("POST /setup/db/integrity/repair", web.dbIntegrityRepairHandler)
 	mux.HandleFunc("POST /setup/db/restore", web.restoreDbHandler)
 	mux.HandleFunc("GET /setup/db/save", web.saveDbHandler)
 	mux.HandleFunc("GET /setup/displays", web.displaysGetHandler)
 	mux.HandleFunc("GET /setup/displays/websocket", web.displaysWebsocketHandler)
+	mux.HandleFunc("GET /setup/fields", web.fieldsGetHandler)
+	mux.HandleFunc("POST /setup/fields", web.fieldsPostHandler)
+	mux.HandleFunc("POST /setup/fields/{fieldNumber}", web.fieldSettingsPostHandler)
 	mux.HandleFunc("GET /setup/field_testing", web.fieldTestingGetHandler)
 	mux.HandleFunc("GET /setup/field_testing/websocket", web.fieldTestingWebsocketHandler)
 	mux.HandleFunc("GET /setup/lower_thirds", web.lowerThirdsGetHandler)
@@ -217,18 +351,25 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /setup/settings/publish_matches", web.settingsPublishMatchesHandler)
 	mux.HandleFunc("GET /setup/settings/publish_rankings", web.settingsPublishRankingsHandler)
 	mux.HandleFunc("GET /setup/settings/publish_teams", web.settingsPublishTeamsHandler)
+	mux.HandleFunc("GET /setup/settings/scan_channels", web.settingsScanChannelsHandler)
 	mux.HandleFunc("GET /setup/sponsor_slides", web.sponsorSlidesGetHandler)
 	mux.HandleFunc("POST /setup/sponsor_slides", web.sponsorSlidesPostHandler)
+	mux.HandleFunc("GET /setup/standby", web.standbyGetHandler)
+	mux.HandleFunc("POST /setup/standby/promote", web.standbyPromotePostHandler)
 	mux.HandleFunc("GET /setup/teams", web.teamsGetHandler)
 	mux.HandleFunc("POST /setup/teams", web.teamsPostHandler)
 	mux.HandleFunc("POST /setup/teams/{id}/delete", web.teamDeletePostHandler)
 	mux.HandleFunc("GET /setup/teams/{id}/edit", web.teamEditGetHandler)
 	mux.HandleFunc("POST /setup/teams/{id}/edit", web.teamEditPostHandler)
+	mux.HandleFunc("GET /setup/teams/{id}/wifi", web.teamWifiHistoryGetHandler)
 	mux.HandleFunc("POST /setup/teams/clear", web.teamsClearHandler)
 	mux.HandleFunc("GET /setup/teams/generate_wpa_keys", web.teamsGenerateWpaKeysHandler)
 	mux.HandleFunc("GET /setup/teams/progress", web.teamsUpdateProgressBarHandler)
 	mux.HandleFunc("GET /setup/teams/refresh", web.teamsRefreshHandler)
-	return mux
+	mux.HandleFunc("GET /setup/wizard", web.wizardGetHandler)
+	mux.HandleFunc("POST /setup/wizard/{stepKey}/acknowledge", web.wizardAcknowledgePostHandler)
+	mux.HandleFunc("POST /setup/wizard/{stepKey}/undo", web.wizardUndoPostHandler)
+	return web.standbyGate(websocket.NewFallbackHandler(mux))
 }
 
 // Writes the given error out as plain text with a status code of 500.
@@ -4,6 +4,8 @@
 package web
 
 import (
+	"bufio"
+	"encoding/json"
 	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/websocket"
@@ -23,6 +25,53 @@ func TestIndex(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "Home - Untitled Event - Cheesy Arena")
 }
 
+func TestWebsocketFallback(t *testing.T) {
+	web := setupTestWeb(t)
+	server, _ := web.startTestServer()
+	defer server.Close()
+
+	// A display connecting over Server-Sent Events should be registered and bootstrapped as if it used a websocket.
+	resp, err := http.Get(server.URL + "/displays/logo/events?displayId=1&background=%23000")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	reader := bufio.NewReader(resp.Body)
+	var dataLines []string
+	for len(dataLines) < 2 {
+		line, err := reader.ReadString('\n')
+		if !assert.Nil(t, err) {
+			return
+		}
+		if strings.HasPrefix(line, "data: ") {
+			dataLines = append(dataLines, line)
+		}
+	}
+	assert.Contains(t, dataLines[1], `"type":"displayConfiguration"`)
+	if assert.Contains(t, web.arena.Displays, "1") {
+		assert.Equal(t, field.LogoDisplay, web.arena.Displays["1"].DisplayConfiguration.Type)
+		assert.Equal(
+			t, map[string]string{"background": "#000"}, web.arena.Displays["1"].DisplayConfiguration.Configuration,
+		)
+	}
+
+	// Non-display pages should get the same fallback endpoints.
+	resp, err = http.Get(server.URL + "/match_play/poll")
+	if !assert.Nil(t, err) {
+		return
+	}
+	var sessionResponse map[string]string
+	assert.Nil(t, json.NewDecoder(resp.Body).Decode(&sessionResponse))
+	resp.Body.Close()
+	request, _ := http.NewRequest("DELETE", server.URL+websocket.FallbackSessionPath+sessionResponse["session"], nil)
+	resp, err = http.DefaultClient.Do(request)
+	if assert.Nil(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+}
+
 func (web *Web) getHttpResponse(path string) *httptest.ResponseRecorder {
 	recorder := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", path, nil)
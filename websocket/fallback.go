@@ -0,0 +1,303 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+//
+// Server-Sent Events and long-poll transports for clients on networks that break websocket connections.
+
+package websocket
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Path prefix of the endpoints through which fallback clients send messages and, for long polling, receive them.
+	FallbackSessionPath = "/stream/"
+
+	eventStreamPathSuffix      = "/events"
+	longPollPathSuffix         = "/poll"
+	websocketPathSuffix        = "/websocket"
+	longPollTimeout            = 25 * time.Second
+	fallbackSessionTimeout     = 60 * time.Second
+	maxQueuedLongPollMessages  = 500
+	fallbackIncomingBufferSize = 16
+	maxFallbackMessageBytes    = 1 << 20
+)
+
+type fallbackTransport int
+
+const (
+	eventStreamTransport fallbackTransport = iota
+	longPollTransport
+)
+
+type transportContextKey struct{}
+
+// Represents the server side of a single fallback client connection. It stands in for the Gorilla connection underneath
+// a Websocket, so that handlers serve fallback clients without any changes.
+type fallbackSession struct {
+	id          string
+	transport   fallbackTransport
+	writer      http.ResponseWriter // Only used for Server-Sent Events.
+	incoming    chan []byte
+	outgoing    []json.RawMessage // Only used for long polling.
+	outgoingSet chan struct{}     // Signaled when messages are added to the long-poll queue.
+	expiryTimer *time.Timer
+	closed      chan struct{}
+	closeOnce   sync.Once
+	mutex       sync.Mutex
+}
+
+var fallbackSessions = make(map[string]*fallbackSession)
+var fallbackSessionsMutex sync.Mutex
+
+// Wraps the given handler so that each of its websocket endpoints is also served over Server-Sent Events at the
+// parallel path ending in "/events" and over long polling at the one ending in "/poll". The websocket handlers serve
+// these clients transparently through NewWebsocket, including the initial state bootstrap and pings. Also serves the
+// session endpoints through which fallback clients send messages and poll for them.
+func NewFallbackHandler(handler *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, FallbackSessionPath) {
+			handleFallbackSession(w, r, strings.TrimPrefix(r.URL.Path, FallbackSessionPath))
+			return
+		}
+
+		if r.Method == "GET" {
+			for suffix, transport := range map[string]fallbackTransport{
+				eventStreamPathSuffix: eventStreamTransport, longPollPathSuffix: longPollTransport,
+			} {
+				if !strings.HasSuffix(r.URL.Path, suffix) {
+					continue
+				}
+				websocketRequest := r.Clone(context.WithValue(r.Context(), transportContextKey{}, transport))
+				websocketRequest.URL.Path = strings.TrimSuffix(r.URL.Path, suffix) + websocketPathSuffix
+				websocketRequest.URL.RawPath = ""
+				if _, pattern := handler.Handler(websocketRequest); strings.HasSuffix(pattern, websocketPathSuffix) {
+					handler.ServeHTTP(w, websocketRequest)
+					return
+				}
+			}
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// Starts a fallback session of the given transport for the given request, which has been routed to a websocket handler.
+func newFallbackWebsocket(w http.ResponseWriter, r *http.Request, transport fallbackTransport) (*Websocket, error) {
+	var idBytes [16]byte
+	if _, err := rand.Read(idBytes[:]); err != nil {
+		return nil, err
+	}
+	session := &fallbackSession{
+		id:          hex.EncodeToString(idBytes[:]),
+		transport:   transport,
+		incoming:    make(chan []byte, fallbackIncomingBufferSize),
+		outgoingSet: make(chan struct{}, 1),
+		closed:      make(chan struct{}),
+	}
+
+	if transport == eventStreamTransport {
+		if _, ok := w.(http.Flusher); !ok {
+			return nil, fmt.Errorf("streaming is not supported by the response writer")
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no") // Prevent nginx from buffering the stream.
+		session.writer = w
+		if err := session.writeEvent("session", []byte(session.id)); err != nil {
+			return nil, err
+		}
+
+		// The stream lasts only as long as the request, so end the session when the client goes away.
+		go func() {
+			select {
+			case <-r.Context().Done():
+				session.Close()
+			case <-session.closed:
+			}
+		}()
+	} else {
+		// Complete the response with the session ID while the handler carries on serving the session. The connection
+		// can't be reused by the browser since it stays tied up until the handler returns.
+		body, _ := json.Marshal(map[string]string{"session": session.id})
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+		w.Header().Set("Connection", "close")
+		if _, err := w.Write(body); err != nil {
+			return nil, err
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		session.expiryTimer = time.AfterFunc(fallbackSessionTimeout, func() { _ = session.Close() })
+	}
+
+	fallbackSessionsMutex.Lock()
+	fallbackSessions[session.id] = session
+	fallbackSessionsMutex.Unlock()
+
+	return &Websocket{conn: session, writeMutex: new(sync.Mutex), dropCounter: new(DropCounter)}, nil
+}
+
+// Handles a request from a fallback client to send a message (POST), poll for messages (GET), or end its session
+// (DELETE).
+func handleFallbackSession(w http.ResponseWriter, r *http.Request, sessionId string) {
+	fallbackSessionsMutex.Lock()
+	session, ok := fallbackSessions[sessionId]
+	fallbackSessionsMutex.Unlock()
+	if !ok {
+		http.Error(w, "Session not found", http.StatusGone)
+		return
+	}
+	if session.expiryTimer != nil {
+		session.expiryTimer.Reset(fallbackSessionTimeout)
+	}
+
+	switch r.Method {
+	case "POST":
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxFallbackMessageBytes))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		select {
+		case session.incoming <- body:
+			w.WriteHeader(http.StatusNoContent)
+		case <-session.closed:
+			http.Error(w, "Session closed", http.StatusGone)
+		case <-time.After(longPollTimeout):
+			http.Error(w, "Session is not reading messages", http.StatusServiceUnavailable)
+		}
+	case "GET":
+		if session.transport != longPollTransport {
+			http.Error(w, "Session does not support polling", http.StatusBadRequest)
+			return
+		}
+		messages := session.poll(r.Context())
+		if messages == nil {
+			http.Error(w, "Session closed", http.StatusGone)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-cache")
+		_ = json.NewEncoder(w).Encode(messages)
+	case "DELETE":
+		_ = session.Close()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Waits until there are queued messages or the poll times out, and returns the messages. Returns nil if the session
+// has been closed with nothing left to deliver.
+func (session *fallbackSession) poll(ctx context.Context) []json.RawMessage {
+	select {
+	case <-session.outgoingSet:
+	case <-time.After(longPollTimeout):
+	case <-session.closed:
+	case <-ctx.Done():
+	}
+
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	messages := session.outgoing
+	session.outgoing = nil
+	if len(messages) == 0 {
+		select {
+		case <-session.closed:
+			return nil
+		default:
+			return []json.RawMessage{}
+		}
+	}
+	return messages
+}
+
+// Blocks until the client sends a message and decodes it into the given value. Returns a close error once the session
+// ends, so that Websocket.Read reports it the same way as a browser closing a websocket.
+func (session *fallbackSession) ReadJSON(v any) error {
+	select {
+	case data := <-session.incoming:
+		return json.Unmarshal(data, v)
+	case <-session.closed:
+		return &websocket.CloseError{Code: websocket.CloseGoingAway}
+	}
+}
+
+// Sends the given value to the client as JSON.
+func (session *fallbackSession) WriteJSON(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	select {
+	case <-session.closed:
+		return fmt.Errorf("session closed")
+	default:
+	}
+
+	if session.transport == eventStreamTransport {
+		if err = session.writeEvent("", data); err != nil {
+			go session.Close()
+		}
+		return err
+	}
+
+	if len(session.outgoing) >= maxQueuedLongPollMessages {
+		// The client has stopped polling; give up on it rather than buffering without bound.
+		go session.Close()
+		return fmt.Errorf("too many messages queued for long-poll session")
+	}
+	session.outgoing = append(session.outgoing, data)
+	select {
+	case session.outgoingSet <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Ends the session and releases it from the registry. Must not be called while holding the session mutex.
+func (session *fallbackSession) Close() error {
+	session.closeOnce.Do(func() {
+		session.mutex.Lock()
+		close(session.closed)
+		session.mutex.Unlock()
+		if session.expiryTimer != nil {
+			session.expiryTimer.Stop()
+		}
+
+		fallbackSessionsMutex.Lock()
+		delete(fallbackSessions, session.id)
+		fallbackSessionsMutex.Unlock()
+	})
+	return nil
+}
+
+// Writes a single Server-Sent Event with the given name (or the default if empty) and data, and flushes it.
+func (session *fallbackSession) writeEvent(eventName string, data []byte) error {
+	var event strings.Builder
+	if eventName != "" {
+		event.WriteString("event: " + eventName + "\n")
+	}
+	event.WriteString("data: ")
+	event.Write(data)
+	event.WriteString("\n\n")
+	if _, err := io.WriteString(session.writer, event.String()); err != nil {
+		return err
+	}
+	session.writer.(http.Flusher).Flush()
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+
+package websocket
+
+import (
+	"bufio"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Starts a server with a websocket handler that subscribes to the given notifier and echoes back any commands.
+func startFallbackTestServer(t *testing.T, notifier *Notifier) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /test/websocket", func(w http.ResponseWriter, r *http.Request) {
+		ws, err := NewWebsocket(w, r)
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer ws.Close()
+		go ws.HandleNotifiers(notifier)
+		for {
+			messageType, data, err := ws.Read()
+			if err != nil {
+				assert.Equal(t, io.EOF, err)
+				return
+			}
+			assert.Nil(t, ws.Write(messageType, data))
+		}
+	})
+	mux.HandleFunc("GET /test/other", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("other"))
+	})
+	return httptest.NewServer(NewFallbackHandler(mux))
+}
+
+func postFallbackMessage(t *testing.T, serverUrl, sessionId string, message string) {
+	resp, err := http.Post(serverUrl+FallbackSessionPath+sessionId, "application/json", strings.NewReader(message))
+	if assert.Nil(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+}
+
+func TestEventStreamFallback(t *testing.T) {
+	notifier := NewNotifier("messageType1", func() any { return "test message" })
+	notifier.Notify()
+	server := startFallbackTestServer(t, notifier)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/test/events")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	reader := bufio.NewReader(resp.Body)
+	readEvent := func() (string, string) {
+		var eventName, data string
+		for {
+			line, err := reader.ReadString('\n')
+			if !assert.Nil(t, err) {
+				return "", ""
+			}
+			line = strings.TrimSuffix(line, "\n")
+			if line == "" {
+				return eventName, data
+			}
+			if strings.HasPrefix(line, "event: ") {
+				eventName = strings.TrimPrefix(line, "event: ")
+			} else if strings.HasPrefix(line, "data: ") {
+				data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}
+
+	// The session ID should come first, followed by the same bootstrap as for a websocket.
+	eventName, sessionId := readEvent()
+	assert.Equal(t, "session", eventName)
+	assert.Equal(t, 32, len(sessionId))
+	_, data := readEvent()
+	assert.Equal(t, `{"type":"messageType1","data":"test message","seq":1}`, data)
+	notifier.NotifyWithMessage("test message 2")
+	_, data = readEvent()
+	assert.Equal(t, `{"type":"messageType1","data":"test message 2","seq":2}`, data)
+
+	// Messages posted to the session should be handled like those received over a websocket.
+	postFallbackMessage(t, server.URL, sessionId, `{"type":"echo","data":"hello"}`)
+	_, data = readEvent()
+	assert.Equal(t, `{"type":"echo","data":"hello"}`, data)
+
+	// Polling isn't supported for an event stream session.
+	resp2, err := http.Get(server.URL + FallbackSessionPath + sessionId)
+	if assert.Nil(t, err) {
+		resp2.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp2.StatusCode)
+	}
+
+	// Closing the stream should end the session.
+	resp.Body.Close()
+	assert.Eventually(t, func() bool {
+		fallbackSessionsMutex.Lock()
+		defer fallbackSessionsMutex.Unlock()
+		_, ok := fallbackSessions[sessionId]
+		return !ok
+	}, time.Second, time.Millisecond)
+}
+
+func TestLongPollFallback(t *testing.T) {
+	notifier := NewNotifier("messageType1", func() any { return "test message" })
+	server := startFallbackTestServer(t, notifier)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/test/poll")
+	if !assert.Nil(t, err) {
+		return
+	}
+	var sessionResponse map[string]string
+	assert.Nil(t, json.NewDecoder(resp.Body).Decode(&sessionResponse))
+	resp.Body.Close()
+	sessionId := sessionResponse["session"]
+	poll := func() []Message {
+		resp, err := http.Get(server.URL + FallbackSessionPath + sessionId)
+		if !assert.Nil(t, err) {
+			return nil
+		}
+		defer resp.Body.Close()
+		var messages []Message
+		assert.Nil(t, json.NewDecoder(resp.Body).Decode(&messages))
+		return messages
+	}
+
+	assert.Equal(t, []Message{{Type: "messageType1", Data: "test message"}}, poll())
+	notifier.NotifyWithMessage("test message 2")
+	notifier.NotifyWithMessage("test message 3")
+	fallbackSessionsMutex.Lock()
+	session := fallbackSessions[sessionId]
+	fallbackSessionsMutex.Unlock()
+	assert.Eventually(t, func() bool {
+		session.mutex.Lock()
+		defer session.mutex.Unlock()
+		return len(session.outgoing) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(
+		t,
+		[]Message{
+			{Type: "messageType1", Data: "test message 2", Sequence: 1},
+			{Type: "messageType1", Data: "test message 3", Sequence: 2},
+		},
+		poll(),
+	)
+	postFallbackMessage(t, server.URL, sessionId, `{"type":"echo","data":"hello"}`)
+	assert.Equal(t, []Message{{Type: "echo", Data: "hello"}}, poll())
+
+	// Ending the session should stop the handler, after which requests for it should be rejected.
+	request, _ := http.NewRequest("DELETE", server.URL+FallbackSessionPath+sessionId, nil)
+	resp, err = http.DefaultClient.Do(request)
+	if assert.Nil(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+	resp, err = http.Get(server.URL + FallbackSessionPath + sessionId)
+	if assert.Nil(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusGone, resp.StatusCode)
+	}
+}
+
+func TestFallbackHandlerPassthrough(t *testing.T) {
+	server := startFallbackTestServer(t, NewNotifier("messageType1", nil))
+	defer server.Close()
+
+	// Paths that don't correspond to a websocket endpoint should be passed through untouched.
+	resp, err := http.Get(server.URL + "/test/other")
+	if assert.Nil(t, err) {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, "other", string(body))
+	}
+	resp, err = http.Get(server.URL + "/other/events")
+	if assert.Nil(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+}
@@ -8,6 +8,7 @@ package websocket
 import (
 	"log"
 	"sync"
+	"sync/atomic"
 )
 
 // Allow the listeners to buffer a small number of notifications to streamline delivery.
@@ -16,18 +17,26 @@ const notifyBufferSize = 5
 type Notifier struct {
 	messageType     string
 	messageProducer func() any
-	listeners       map[chan messageEnvelope]struct{} // The map is essentially a set; the value is ignored.
+	listeners       map[chan messageEnvelope]*DropCounter // The value is the counter to charge for dropped messages.
+	sequence        int                                   // Incremented for each message so clients can detect gaps.
 	mutex           sync.Mutex
 }
 
 type messageEnvelope struct {
 	messageType string
 	messageBody any
+	sequence    int
+}
+
+// Counts the notifier messages that could not be delivered to a client because it wasn't reading them promptly. It is
+// safe for concurrent use.
+type DropCounter struct {
+	count atomic.Int64
 }
 
 func NewNotifier(messageType string, messageProducer func() any) *Notifier {
 	notifier := &Notifier{messageType: messageType, messageProducer: messageProducer}
-	notifier.listeners = make(map[chan messageEnvelope]struct{})
+	notifier.listeners = make(map[chan messageEnvelope]*DropCounter)
 	return notifier
 }
 
@@ -43,13 +52,16 @@ func (notifier *Notifier) NotifyWithMessage(messageBody any) {
 	notifier.mutex.Lock()
 	defer notifier.mutex.Unlock()
 
-	message := messageEnvelope{messageType: notifier.messageType, messageBody: messageBody}
-	for listener := range notifier.listeners {
-		notifier.notifyListener(listener, message)
+	notifier.sequence++
+	message := messageEnvelope{messageType: notifier.messageType, messageBody: messageBody, sequence: notifier.sequence}
+	for listener, dropCounter := range notifier.listeners {
+		notifier.notifyListener(listener, dropCounter, message)
 	}
 }
 
-func (notifier *Notifier) notifyListener(listener chan messageEnvelope, message messageEnvelope) {
+func (notifier *Notifier) notifyListener(
+	listener chan messageEnvelope, dropCounter *DropCounter, message messageEnvelope,
+) {
 	defer func() {
 		// If channel is closed sending to it will cause a panic; recover and remove it from the list.
 		if r := recover(); r != nil {
@@ -58,26 +70,36 @@ func (notifier *Notifier) notifyListener(listener chan messageEnvelope, message
 	}()
 
 	// Do a non-blocking send. This guarantees that sending notifications won't interrupt the main event loop,
-	// at the risk of clients missing some messages if they don't read them all promptly.
+	// at the risk of clients missing some messages if they don't read them all promptly. Clients detect the resulting
+	// gap in sequence numbers and resynchronize.
 	select {
 	case listener <- message:
 		// The notification was sent and received successfully.
 	default:
+		dropCounter.increment()
 		log.Printf("Failed to send a '%s' notification due to blocked listener.", notifier.messageType)
 	}
 }
 
-// Registers and returns a channel that can be read from to receive notification messages. The caller is
-// responsible for closing the channel, which will cause it to be reaped from the list of listeners.
-func (notifier *Notifier) listen() chan messageEnvelope {
+// Registers and returns a channel that can be read from to receive notification messages. Messages dropped because
+// the channel is full are charged to the given counter, if it is non-nil. The caller is responsible for closing the
+// channel, which will cause it to be reaped from the list of listeners.
+func (notifier *Notifier) listen(dropCounter *DropCounter) chan messageEnvelope {
 	notifier.mutex.Lock()
 	defer notifier.mutex.Unlock()
 
 	listener := make(chan messageEnvelope, notifyBufferSize)
-	notifier.listeners[listener] = struct{}{}
+	notifier.listeners[listener] = dropCounter
 	return listener
 }
 
+// Returns the sequence number of the most recent message sent by the notifier.
+func (notifier *Notifier) currentSequence() int {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+	return notifier.sequence
+}
+
 // Invokes the message producer to get the message, or returns nil if no producer is defined.
 func (notifier *Notifier) getMessageBody() any {
 	if notifier.messageProducer == nil {
@@ -86,3 +108,17 @@ func (notifier *Notifier) getMessageBody() any {
 		return notifier.messageProducer()
 	}
 }
+
+// Returns the number of messages that have been dropped.
+func (counter *DropCounter) Count() int64 {
+	if counter == nil {
+		return 0
+	}
+	return counter.count.Load()
+}
+
+func (counter *DropCounter) increment() {
+	if counter != nil {
+		counter.count.Add(1)
+	}
+}
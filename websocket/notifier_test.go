@@ -18,7 +18,7 @@ func TestNotifier(t *testing.T) {
 	notifier.NotifyWithMessage(12345)
 	notifier.NotifyWithMessage(struct{}{})
 
-	listener := notifier.listen()
+	listener := notifier.listen(nil)
 	notifier.Notify()
 	message := <-listener
 	assert.Equal(t, "testMessageType", message.messageType)
@@ -58,7 +58,7 @@ func TestNotifyMultipleListeners(t *testing.T) {
 	notifier := NewNotifier("testMessageType2", nil)
 	listeners := [50]chan messageEnvelope{}
 	for i := 0; i < len(listeners); i++ {
-		listeners[i] = notifier.listen()
+		listeners[i] = notifier.listen(nil)
 	}
 
 	notifier.Notify()
@@ -85,6 +85,34 @@ func TestNotifyMultipleListeners(t *testing.T) {
 	}
 }
 
+func TestNotifierSequenceAndDropCounter(t *testing.T) {
+	notifier := NewNotifier("testMessageType3", generateTestMessage)
+	notifier.Notify()
+	assert.Equal(t, 1, notifier.currentSequence())
+
+	var dropCounter DropCounter
+	listener := notifier.listen(&dropCounter)
+	notifier.Notify()
+	notifier.NotifyWithMessage(12345)
+	assert.Equal(t, 2, (<-listener).sequence)
+	assert.Equal(t, 3, (<-listener).sequence)
+	assert.Equal(t, int64(0), dropCounter.Count())
+
+	// Messages that don't fit in the buffer should be counted and leave a gap in the sequence.
+	log.SetOutput(ioutil.Discard) // Silence noisy log output.
+	for i := 0; i < notifyBufferSize+3; i++ {
+		notifier.NotifyWithMessage(i)
+	}
+	assert.Equal(t, int64(3), dropCounter.Count())
+	for i := 0; i < notifyBufferSize; i++ {
+		assert.Equal(t, 4+i, (<-listener).sequence)
+	}
+	notifier.Notify()
+	assert.Equal(t, 4+notifyBufferSize+3, (<-listener).sequence)
+	var nilCounter *DropCounter
+	assert.Equal(t, int64(0), nilCounter.Count())
+}
+
 func generateTestMessage() any {
 	return "test message"
 }
@@ -22,28 +22,52 @@ const pingInterval = time.Second * 10
 
 // Wraps the Gorilla Websocket module so that we can define additional functions on it.
 type Websocket struct {
-	conn       *websocket.Conn
-	writeMutex *sync.Mutex
+	conn        connection
+	writeMutex  *sync.Mutex
+	dropCounter *DropCounter
 }
 
 type Message struct {
-	Type string `json:"type"`
-	Data any    `json:"data"`
+	Type     string `json:"type"`
+	Data     any    `json:"data"`
+	Sequence int    `json:"seq,omitempty"` // Only set on notifier messages, which are numbered consecutively per type.
+}
+
+// The subset of the Gorilla connection methods used by Websocket, which the fallback transports also implement.
+type connection interface {
+	ReadJSON(v any) error
+	WriteJSON(v any) error
+	Close() error
 }
 
 var websocketUpgrader = websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 2014}
 
-// Upgrades the given HTTP request to a websocket connection.
+// Upgrades the given HTTP request to a websocket connection, or starts a fallback session if the request was routed
+// from one of the parallel endpoints set up by NewFallbackHandler.
 func NewWebsocket(w http.ResponseWriter, r *http.Request) (*Websocket, error) {
+	if transport, ok := r.Context().Value(transportContextKey{}).(fallbackTransport); ok {
+		return newFallbackWebsocket(w, r, transport)
+	}
 	conn, err := websocketUpgrader.Upgrade(w, r, nil)
 	if err != nil {
 		return nil, err
 	}
-	return &Websocket{conn, new(sync.Mutex)}, nil
+	return &Websocket{conn: conn, writeMutex: new(sync.Mutex), dropCounter: new(DropCounter)}, nil
 }
 
 func NewTestWebsocket(conn *websocket.Conn) *Websocket {
-	return &Websocket{conn, new(sync.Mutex)}
+	return &Websocket{conn: conn, writeMutex: new(sync.Mutex), dropCounter: new(DropCounter)}
+}
+
+// Replaces the counter that is charged for notifier messages dropped because this client isn't keeping up. Must be
+// called before HandleNotifiers. Sharing a counter allows the total to persist across reconnections of a display.
+func (ws *Websocket) SetDropCounter(dropCounter *DropCounter) {
+	ws.dropCounter = dropCounter
+}
+
+// Returns the number of notifier messages that have been dropped for this client.
+func (ws *Websocket) DroppedMessageCount() int64 {
+	return ws.dropCounter.Count()
 }
 
 func (ws *Websocket) Close() error {
@@ -89,20 +113,34 @@ func (ws *Websocket) ReadWithTimeout(timeout time.Duration) (string, any, error)
 }
 
 func (ws *Websocket) Write(messageType string, data any) error {
+	return ws.writeMessage(Message{Type: messageType, Data: data})
+}
+
+// Writes the given message verbatim, including its sequence number; used to pass through messages from another server.
+func (ws *Websocket) WriteMessage(message Message) error {
+	return ws.writeMessage(message)
+}
+
+func (ws *Websocket) writeMessage(message Message) error {
 	ws.writeMutex.Lock()
 	defer ws.writeMutex.Unlock()
-	err := ws.conn.WriteJSON(Message{messageType, data})
+	err := ws.conn.WriteJSON(message)
 	if err != nil {
 		// Include the caller of this method in the error message.
-		_, file, line, _ := runtime.Caller(1)
+		_, file, line, _ := runtime.Caller(2)
 		filePathParts := strings.Split(file, "/")
 		return fmt.Errorf("[%s:%d] Websocket write error: %v", filePathParts[len(filePathParts)-1], line, err)
 	}
 	return nil
 }
 
+// Writes the current state of the given notifier, tagged with its latest sequence number so that the client can
+// detect any messages it subsequently misses.
 func (ws *Websocket) WriteNotifier(notifier *Notifier) error {
-	return ws.Write(notifier.messageType, notifier.getMessageBody())
+	sequence := notifier.currentSequence()
+	return ws.writeMessage(
+		Message{Type: notifier.messageType, Data: notifier.getMessageBody(), Sequence: sequence},
+	)
 }
 
 func (ws *Websocket) WriteError(errorMessage string) error {
@@ -114,7 +152,7 @@ func (ws *Websocket) HandleNotifiers(notifiers ...*Notifier) {
 	// Use reflection to dynamically build a select/case structure for all the notifiers.
 	listeners := make([]reflect.SelectCase, len(notifiers))
 	for i, notifier := range notifiers {
-		listener := notifier.listen()
+		listener := notifier.listen(ws.dropCounter)
 		defer close(listener)
 		listeners[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(listener)}
 
@@ -133,9 +171,20 @@ func (ws *Websocket) HandleNotifiers(notifiers ...*Notifier) {
 	pingIndex := len(listeners)
 	listeners = append(listeners, pingCase)
 
+	// Fallback sessions know when the client has gone away, so bail out right away rather than waiting for a ping.
+	doneIndex := -1
+	if session, ok := ws.conn.(*fallbackSession); ok {
+		doneIndex = len(listeners)
+		doneCase := reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(session.closed)}
+		listeners = append(listeners, doneCase)
+	}
+
 	for {
 		// Block until a message is available on any of the channels.
 		chosenIndex, value, ok := reflect.Select(listeners)
+		if chosenIndex == doneIndex {
+			return
+		}
 		if ok && chosenIndex == pingIndex {
 			err := ws.Write("ping", nil)
 			if err != nil {
@@ -155,7 +204,9 @@ func (ws *Websocket) HandleNotifiers(notifiers ...*Notifier) {
 		}
 
 		// Forward the message verbatim on to the websocket.
-		err := ws.Write(message.messageType, message.messageBody)
+		err := ws.writeMessage(
+			Message{Type: message.messageType, Data: message.messageBody, Sequence: message.sequence},
+		)
 		if err != nil {
 			// The client has probably closed the connection; bail out of the loop.
 			return
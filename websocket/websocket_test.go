@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -107,6 +108,40 @@ func TestWebsocket(t *testing.T) {
 	assert.Equal(t, 0, len(notifier1.listeners))
 }
 
+func TestWebsocketSequenceNumbers(t *testing.T) {
+	notifier := NewNotifier("messageType1", func() any { return "test message" })
+	notifier.Notify()
+	notifier.Notify()
+	dropCounter := new(DropCounter)
+	testWebsocketHandler := func(w http.ResponseWriter, r *http.Request) {
+		ws, err := NewWebsocket(w, r)
+		assert.Nil(t, err)
+		defer ws.Close()
+		ws.SetDropCounter(dropCounter)
+		ws.HandleNotifiers(notifier)
+	}
+	server := httptest.NewServer(http.HandlerFunc(testWebsocketHandler))
+	defer server.Close()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+server.URL[len("http"):], nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	// The initial message should carry the latest sequence number, and subsequent ones should count up from there.
+	var message Message
+	assert.Nil(t, conn.ReadJSON(&message))
+	assert.Equal(t, Message{Type: "messageType1", Data: "test message", Sequence: 2}, message)
+	notifier.NotifyWithMessage("test message 2")
+	assert.Nil(t, conn.ReadJSON(&message))
+	assert.Equal(t, Message{Type: "messageType1", Data: "test message 2", Sequence: 3}, message)
+	assert.Equal(t, int64(0), dropCounter.Count())
+
+	// Messages to a client that isn't reading should be charged to its drop counter.
+	for i := 0; i < 1000 && dropCounter.Count() == 0; i++ {
+		notifier.NotifyWithMessage(strings.Repeat("x", 10000))
+	}
+	assert.Greater(t, dropCounter.Count(), int64(0))
+}
+
 func assertMessage(t *testing.T, ws *Websocket, expectedMessageType string, expectedMessageBody any) {
 	messageType, messageBody, err := ws.ReadWithTimeout(time.Second)
 	if assert.Nil(t, err) {